@@ -3,6 +3,7 @@ package beads
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
@@ -20,15 +21,118 @@ func FormatSlingContextDescription(fields *capacity.SlingContextFields) string {
 }
 
 // ParseSlingContextFields deserialises a context bead description.
-// Returns nil if the description is not valid JSON.
+// Returns nil if the description is not valid JSON. A missing version is
+// read as version 1, the first JSON schema.
 func ParseSlingContextFields(description string) *capacity.SlingContextFields {
 	var fields capacity.SlingContextFields
 	if err := json.Unmarshal([]byte(description), &fields); err != nil {
 		return nil
 	}
+	if fields.Version == 0 {
+		fields.Version = 1
+	}
 	return &fields
 }
 
+// LegacySchedulerDelimiter opens the text block that the legacy scheduler
+// embedded in work bead descriptions. Everything from the delimiter line to
+// the end of the description belongs to the block.
+const LegacySchedulerDelimiter = "---gt:scheduler:v1---"
+
+// ParseLegacySchedulerMetadata extracts the legacy "key: value" scheduling
+// block from a work bead description and converts it to current sling
+// context fields. Returns nil if the description has no legacy block.
+func ParseLegacySchedulerMetadata(workBeadID, description string) *capacity.SlingContextFields {
+	block, ok := legacySchedulerBlock(description)
+	if !ok {
+		return nil
+	}
+
+	fields := &capacity.SlingContextFields{
+		Version:    capacity.SlingContextVersion,
+		WorkBeadID: workBeadID,
+	}
+	var vars []string
+	for _, line := range strings.Split(block, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "target_rig":
+			fields.TargetRig = value
+		case "formula":
+			fields.Formula = value
+		case "args":
+			fields.Args = value
+		case "var":
+			vars = append(vars, value)
+		case "enqueued_at":
+			fields.EnqueuedAt = value
+		case "merge":
+			fields.Merge = value
+		case "convoy":
+			fields.Convoy = value
+		case "base_branch":
+			fields.BaseBranch = value
+		case "no_merge":
+			fields.NoMerge = value == "true"
+		case "account":
+			fields.Account = value
+		case "agent":
+			fields.Agent = value
+		case "hook_raw_bead":
+			fields.HookRawBead = value == "true"
+		case "owned":
+			fields.Owned = value == "true"
+		case "mode":
+			fields.Mode = value
+		case "dispatch_failures":
+			if n, err := strconv.Atoi(value); err == nil {
+				fields.DispatchFailures = n
+			}
+		case "last_failure":
+			fields.LastFailure = value
+		}
+	}
+	fields.Vars = strings.Join(vars, "\n")
+	return fields
+}
+
+// StripLegacySchedulerMetadata removes the whole legacy scheduling block,
+// delimiter included, from a work bead description. Descriptions without a
+// block are returned unchanged.
+func StripLegacySchedulerMetadata(description string) string {
+	idx := legacySchedulerDelimiterIndex(description)
+	if idx < 0 {
+		return description
+	}
+	return strings.TrimRight(description[:idx], " \t\n")
+}
+
+// legacySchedulerBlock returns the text following the legacy delimiter line.
+func legacySchedulerBlock(description string) (string, bool) {
+	idx := legacySchedulerDelimiterIndex(description)
+	if idx < 0 {
+		return "", false
+	}
+	return description[idx+len(LegacySchedulerDelimiter):], true
+}
+
+// legacySchedulerDelimiterIndex finds the delimiter only where it stands on
+// its own line, so user text that merely mentions it is not mistaken for a block.
+func legacySchedulerDelimiterIndex(description string) int {
+	offset := 0
+	for _, line := range strings.SplitAfter(description, "\n") {
+		if strings.TrimSpace(line) == LegacySchedulerDelimiter {
+			return offset + strings.Index(line, LegacySchedulerDelimiter)
+		}
+		offset += len(line)
+	}
+	return -1
+}
+
 // CreateSlingContext creates an ephemeral sling context bead that tracks
 // scheduling state for a work bead. The work bead is never modified.
 func (b *Beads) CreateSlingContext(workBeadTitle, workBeadID string, fields *capacity.SlingContextFields) (*Issue, error) {
@@ -111,6 +215,39 @@ func (b *Beads) CloseSlingContext(contextID, reason string) error {
 	return err
 }
 
+// MigrateLegacySchedulerMetadata moves a legacy scheduling block out of a
+// work bead into a sling context bead, then strips the block and the legacy
+// gt:queued label from the work bead. If the bead already has an open sling
+// context, only the cleanup is done. Returns the created context, or nil if
+// none was needed. Returns (nil, nil) if the bead has no legacy block.
+func (b *Beads) MigrateLegacySchedulerMetadata(workBead *Issue) (*Issue, error) {
+	fields := ParseLegacySchedulerMetadata(workBead.ID, workBead.Description)
+	if fields == nil {
+		return nil, nil
+	}
+
+	existing, _, err := b.FindOpenSlingContext(workBead.ID)
+	if err != nil {
+		return nil, fmt.Errorf("checking existing sling context: %w", err)
+	}
+	var created *Issue
+	if existing == nil {
+		created, err = b.CreateSlingContext(workBead.Title, workBead.ID, fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	description := StripLegacySchedulerMetadata(workBead.Description)
+	if err := b.Update(workBead.ID, UpdateOptions{
+		Description:  &description,
+		RemoveLabels: []string{capacity.LabelLegacyQueued},
+	}); err != nil {
+		return created, fmt.Errorf("stripping legacy metadata from %s: %w", workBead.ID, err)
+	}
+	return created, nil
+}
+
 // UpdateSlingContextFields updates the description (fields) of a sling context bead.
 func (b *Beads) UpdateSlingContextFields(contextID string, fields *capacity.SlingContextFields) error {
 	description := FormatSlingContextDescription(fields)
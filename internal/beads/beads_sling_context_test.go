@@ -154,3 +154,63 @@ func TestFormatSlingContextDescription_SpecialChars(t *testing.T) {
 		t.Errorf("LastFailure roundtrip failed:\ngot:  %q\nwant: %q", parsed.LastFailure, fields.LastFailure)
 	}
 }
+
+func TestParseSlingContextFields_MissingVersion(t *testing.T) {
+	parsed := ParseSlingContextFields(`{"work_bead_id":"gt-abc","target_rig":"myrig","future_field":"x"}`)
+	if parsed == nil {
+		t.Fatal("ParseSlingContextFields returned nil")
+	}
+	if parsed.Version != 1 {
+		t.Errorf("Version: got %d, want 1", parsed.Version)
+	}
+	if parsed.WorkBeadID != "gt-abc" {
+		t.Errorf("WorkBeadID: got %q, want %q", parsed.WorkBeadID, "gt-abc")
+	}
+}
+
+func TestParseLegacySchedulerMetadata(t *testing.T) {
+	description := "Fix the widget\n\nSome details.\n\n" + LegacySchedulerDelimiter + "\n" +
+		"target_rig: gastown\n" +
+		"formula: mol-polecat-work\n" +
+		"args: focus: tests\n" +
+		"var: a=1\n" +
+		"var: b=2\n" +
+		"enqueued_at: 2026-01-15T10:00:00Z\n" +
+		"no_merge: true\n" +
+		"dispatch_failures: 2\n"
+
+	fields := ParseLegacySchedulerMetadata("gt-abc", description)
+	if fields == nil {
+		t.Fatal("ParseLegacySchedulerMetadata returned nil")
+	}
+	if fields.Version != capacity.SlingContextVersion {
+		t.Errorf("Version: got %d, want %d", fields.Version, capacity.SlingContextVersion)
+	}
+	if fields.WorkBeadID != "gt-abc" || fields.TargetRig != "gastown" || fields.Formula != "mol-polecat-work" {
+		t.Errorf("unexpected fields: %+v", fields)
+	}
+	if fields.Args != "focus: tests" {
+		t.Errorf("Args: got %q, want %q", fields.Args, "focus: tests")
+	}
+	if fields.Vars != "a=1\nb=2" {
+		t.Errorf("Vars: got %q, want %q", fields.Vars, "a=1\nb=2")
+	}
+	if !fields.NoMerge || fields.DispatchFailures != 2 {
+		t.Errorf("NoMerge/DispatchFailures: got %v/%d", fields.NoMerge, fields.DispatchFailures)
+	}
+
+	if got := StripLegacySchedulerMetadata(description); got != "Fix the widget\n\nSome details." {
+		t.Errorf("StripLegacySchedulerMetadata: got %q", got)
+	}
+}
+
+func TestParseLegacySchedulerMetadata_NoBlock(t *testing.T) {
+	// A delimiter mentioned inline is user text, not a block.
+	description := "See " + LegacySchedulerDelimiter + " in the old docs"
+	if fields := ParseLegacySchedulerMetadata("gt-abc", description); fields != nil {
+		t.Errorf("expected nil, got %+v", fields)
+	}
+	if got := StripLegacySchedulerMetadata(description); got != description {
+		t.Errorf("StripLegacySchedulerMetadata changed description: %q", got)
+	}
+}
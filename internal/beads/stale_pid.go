@@ -1,12 +1,18 @@
 package beads
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 )
 
 // CleanStaleDoltServerPID removes the dolt-server.pid file inside a beads
@@ -17,30 +23,199 @@ import (
 //
 // This is a defensive measure — the Dolt server writes dolt-server.pid on
 // startup but does not always clean it up on crash or unclean shutdown.
+//
+// A live PID is not proof the file is current: after a crash the PID can be
+// reused by an unrelated process. When the co-located config.yaml names a
+// listener port and a different process is seen listening on it, the PID file
+// is treated as stale, removed, and a loud warning printed.
 func CleanStaleDoltServerPID(beadsDir string) {
+	pidPath := filepath.Join(beadsDir, "dolt", "dolt-server.pid")
+	pid, port, verdict := inspectDoltServerPID(beadsDir)
+	switch verdict {
+	case pidCorrupt:
+		_ = os.Remove(pidPath)
+	case pidDead:
+		_ = os.Remove(pidPath)
+		fmt.Fprintf(os.Stderr, "Cleaned stale dolt-server.pid (PID %d) from %s\n", pid, beadsDir)
+	case pidReused:
+		_ = os.Remove(pidPath)
+		fmt.Fprintf(os.Stderr, "WARNING: dolt-server.pid in %s names live PID %d, but port %d (from config.yaml) is held by a different process.\n"+
+			"  The PID was likely reused after a crash; removed the stale PID file.\n"+
+			"  bd would otherwise hang or read a different Dolt server's databases on port %d.\n",
+			beadsDir, pid, port, port)
+	}
+}
+
+// StaleDoltServerPIDReason reports why CleanStaleDoltServerPID would remove
+// the dolt-server.pid in beadsDir, or "" when it would leave it alone (no
+// file, or a live server). Nothing is changed.
+func StaleDoltServerPIDReason(beadsDir string) string {
+	pid, port, verdict := inspectDoltServerPID(beadsDir)
+	switch verdict {
+	case pidCorrupt:
+		return "PID file is corrupt"
+	case pidDead:
+		return fmt.Sprintf("PID %d is not running", pid)
+	case pidReused:
+		return fmt.Sprintf("PID %d is alive but port %d (from config.yaml) is held by a different process", pid, port)
+	}
+	return ""
+}
+
+// pidVerdict classifies a dolt-server.pid file.
+type pidVerdict int
+
+const (
+	pidKeep    pidVerdict = iota // No file, or nothing proves it stale
+	pidCorrupt                   // Unparseable or non-positive PID
+	pidDead                      // Process is gone
+	pidReused                    // Live PID, but another process holds the configured port
+)
+
+// inspectDoltServerPID reads the dolt-server.pid in beadsDir and decides
+// whether it is stale, returning the PID and, for pidReused, the configured
+// listener port.
+func inspectDoltServerPID(beadsDir string) (pid, port int, verdict pidVerdict) {
 	pidPath := filepath.Join(beadsDir, "dolt", "dolt-server.pid")
 	data, err := os.ReadFile(pidPath) //nolint:gosec // G304: path is constructed internally
 	if err != nil {
-		return // No PID file, nothing to clean
+		return 0, 0, pidKeep // No PID file, nothing to clean
 	}
 
-	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	pid, err = strconv.Atoi(strings.TrimSpace(string(data)))
 	if err != nil || pid <= 0 {
-		// Corrupt PID file — remove it
-		_ = os.Remove(pidPath)
-		return
+		return 0, 0, pidCorrupt
 	}
 
 	// Check if the process is alive using signal 0 (no-op probe)
 	proc, err := os.FindProcess(pid)
 	if err != nil {
-		_ = os.Remove(pidPath)
-		return
+		return pid, 0, pidDead
 	}
-
 	if err := proc.Signal(syscall.Signal(0)); err != nil {
-		// Process is dead — remove stale PID file
-		_ = os.Remove(pidPath)
-		fmt.Fprintf(os.Stderr, "Cleaned stale dolt-server.pid (PID %d) from %s\n", pid, beadsDir)
+		return pid, 0, pidDead
+	}
+
+	// Process is alive — verify it is the server the config describes.
+	port = doltConfigListenerPort(filepath.Join(beadsDir, "dolt", "config.yaml"))
+	if port <= 0 {
+		return pid, 0, pidKeep
+	}
+	listening, known := pidListensOnPort(pid, port)
+	if !known || listening {
+		return pid, port, pidKeep
+	}
+	return pid, port, pidReused
+}
+
+// doltConfigListenerPort returns listener.port from a Dolt sql-server
+// config.yaml, or 0 if the file is missing or names no port.
+func doltConfigListenerPort(configPath string) int {
+	data, err := os.ReadFile(configPath) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return 0
+	}
+	inListener := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inListener = trimmed == "listener:"
+			continue
+		}
+		if inListener && strings.HasPrefix(trimmed, "port:") {
+			port, err := strconv.Atoi(strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "port:")), `"'`))
+			if err != nil {
+				return 0
+			}
+			return port
+		}
+	}
+	return 0
+}
+
+// pidListensOnPort reports whether pid holds the TCP listener on port.
+// known is false unless some process is positively seen listening on the port:
+// without privileges lsof and ss hide other users' sockets, so "no listener
+// found" is not proof of a mismatch and the caller must not act on it.
+// Overridable for tests.
+var pidListensOnPort = func(pid, port int) (listening, known bool) {
+	owner := cachedPortListenerPID(port, time.Now())
+	if owner <= 0 {
+		return false, false
+	}
+	return owner == pid, true
+}
+
+// portListenerTTL is how long a seen port listener is trusted.
+// CleanStaleDoltServerPID runs before every bd call, and a healthy PID file
+// would otherwise cost an lsof or ss per call.
+const portListenerTTL = 30 * time.Second
+
+// portListenerLookup finds the PID listening on a port. Overridable for tests.
+var portListenerLookup = doltPortListenerPID
+
+// portListeners caches the listener seen on each port. Only positive
+// lookups are kept, so a server that has just started is found at once.
+var portListeners = struct {
+	sync.Mutex
+	byPort map[int]portListener
+}{byPort: make(map[int]portListener)}
+
+type portListener struct {
+	pid  int
+	seen time.Time
+}
+
+// cachedPortListenerPID returns the PID listening on port, reusing a lookup
+// made within portListenerTTL.
+func cachedPortListenerPID(port int, now time.Time) int {
+	portListeners.Lock()
+	defer portListeners.Unlock()
+	if l, ok := portListeners.byPort[port]; ok && now.Sub(l.seen) < portListenerTTL {
+		return l.pid
+	}
+	pid := portListenerLookup(port)
+	if pid > 0 {
+		portListeners.byPort[port] = portListener{pid: pid, seen: now}
+	} else {
+		delete(portListeners.byPort, port)
+	}
+	return pid
+}
+
+// doltPortListenerPID returns the PID listening on port, or 0 if none is
+// visible. Tries lsof first, then ss (iproute2) for Linux without lsof.
+func doltPortListenerPID(port int) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Without -sTCP:LISTEN, lsof returns client PIDs first.
+	cmd := exec.CommandContext(ctx, "lsof", "-i", fmt.Sprintf(":%d", port), "-sTCP:LISTEN", "-t") //nolint:gosec // G204: port is an integer
+	if out, err := cmd.Output(); err == nil {
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if pid, err := strconv.Atoi(lines[0]); err == nil && pid > 0 {
+			return pid
+		}
+	}
+
+	// Example output line: LISTEN 0 128 *:3307 *:* users:(("dolt",pid=12345,fd=7))
+	cmd = exec.CommandContext(ctx, "ss", "-tlnp", fmt.Sprintf("sport = :%d", port)) //nolint:gosec // G204: port is an integer
+	if out, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if idx := strings.Index(line, "pid="); idx >= 0 {
+				rest := line[idx+4:]
+				if end := strings.IndexAny(rest, ",)"); end > 0 {
+					if pid, err := strconv.Atoi(rest[:end]); err == nil && pid > 0 {
+						return pid
+					}
+				}
+			}
+		}
 	}
+	return 0
 }
@@ -0,0 +1,158 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeStalePIDFixture(t *testing.T, pid int, config string) (beadsDir, pidPath string) {
+	t.Helper()
+	beadsDir = t.TempDir()
+	doltDir := filepath.Join(beadsDir, "dolt")
+	if err := os.MkdirAll(doltDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pidPath = filepath.Join(doltDir, "dolt-server.pid")
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(pid)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(doltDir, "config.yaml"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return beadsDir, pidPath
+}
+
+func stubPidListensOnPort(t *testing.T, listening, known bool) *int {
+	t.Helper()
+	orig := pidListensOnPort
+	t.Cleanup(func() { pidListensOnPort = orig })
+	gotPort := new(int)
+	pidListensOnPort = func(_, port int) (bool, bool) {
+		*gotPort = port
+		return listening, known
+	}
+	return gotPort
+}
+
+func TestDoltConfigListenerPort(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		config string
+		want   int
+	}{
+		{"listener block", "log_level: info\n\nlistener:\n  host: 127.0.0.1\n  port: 3308\n\ndata_dir: x\n", 3308},
+		{"quoted port", "listener:\n  port: \"3310\"\n", 3310},
+		{"port outside listener ignored", "metrics:\n  port: 9000\n", 0},
+		{"no port", "log_level: info\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := doltConfigListenerPort(path); got != tt.want {
+				t.Errorf("doltConfigListenerPort() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := doltConfigListenerPort(filepath.Join(dir, "missing.yaml")); got != 0 {
+		t.Errorf("missing config = %d, want 0", got)
+	}
+}
+
+func TestCleanStaleDoltServerPID_LivePIDOnWrongPort(t *testing.T) {
+	beadsDir, pidPath := writeStalePIDFixture(t, os.Getpid(), "listener:\n  port: 3308\n")
+	gotPort := stubPidListensOnPort(t, false, true)
+
+	CleanStaleDoltServerPID(beadsDir)
+
+	if *gotPort != 3308 {
+		t.Errorf("checked port %d, want 3308 from config.yaml", *gotPort)
+	}
+	if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+		t.Error("expected PID file of live process on wrong port to be removed")
+	}
+}
+
+func TestCleanStaleDoltServerPID_KeepsLivePID(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		listening bool
+		known     bool
+	}{
+		{"listening on configured port", "listener:\n  port: 3308\n", true, true},
+		{"listener unknown", "listener:\n  port: 3308\n", false, false},
+		{"no config", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beadsDir, pidPath := writeStalePIDFixture(t, os.Getpid(), tt.config)
+			stubPidListensOnPort(t, tt.listening, tt.known)
+
+			CleanStaleDoltServerPID(beadsDir)
+
+			if _, err := os.Stat(pidPath); err != nil {
+				t.Errorf("expected PID file to be kept: %v", err)
+			}
+		})
+	}
+}
+
+func TestStaleDoltServerPIDReason(t *testing.T) {
+	beadsDir, pidPath := writeStalePIDFixture(t, os.Getpid(), "listener:\n  port: 3308\n")
+	stubPidListensOnPort(t, false, true)
+
+	if reason := StaleDoltServerPIDReason(beadsDir); !strings.Contains(reason, "port 3308") {
+		t.Errorf("reason = %q, want the reused-PID port", reason)
+	}
+	if _, err := os.Stat(pidPath); err != nil {
+		t.Errorf("StaleDoltServerPIDReason must not remove the PID file: %v", err)
+	}
+
+	stubPidListensOnPort(t, true, true)
+	if reason := StaleDoltServerPIDReason(beadsDir); reason != "" {
+		t.Errorf("reason for the listening server = %q, want none", reason)
+	}
+	if reason := StaleDoltServerPIDReason(t.TempDir()); reason != "" {
+		t.Errorf("reason without a PID file = %q, want none", reason)
+	}
+}
+
+func TestCachedPortListenerPID(t *testing.T) {
+	orig := portListenerLookup
+	t.Cleanup(func() { portListenerLookup = orig })
+	lookups, owner := 0, 0
+	portListenerLookup = func(int) int {
+		lookups++
+		return owner
+	}
+	now := time.Now()
+	const port = 45307
+
+	// Nothing listening is not cached: a server that starts is seen at once.
+	if pid := cachedPortListenerPID(port, now); pid != 0 {
+		t.Fatalf("pid = %d, want 0", pid)
+	}
+	owner = 4242
+	if pid := cachedPortListenerPID(port, now); pid != 4242 || lookups != 2 {
+		t.Fatalf("pid = %d after %d lookups, want 4242 after 2", pid, lookups)
+	}
+
+	// A seen listener is reused within the TTL, then looked up again.
+	owner = 5353
+	if pid := cachedPortListenerPID(port, now.Add(portListenerTTL/2)); pid != 4242 || lookups != 2 {
+		t.Errorf("within TTL: pid = %d after %d lookups, want cached 4242", pid, lookups)
+	}
+	if pid := cachedPortListenerPID(port, now.Add(portListenerTTL)); pid != 5353 || lookups != 3 {
+		t.Errorf("after TTL: pid = %d after %d lookups, want 5353 after 3", pid, lookups)
+	}
+}
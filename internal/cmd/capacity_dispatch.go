@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -73,9 +74,26 @@ var fireCrossRigEscalation = func(rig, prefix, beadID string) {
 // before a sling context is closed as circuit-broken.
 const maxDispatchFailures = 3
 
+// beadDispatcher starts the work for one scheduled bead. The dispatch loop
+// only reaches executeSling through it, so tests can record dispatches
+// without spawning polecats and other execution backends can plug in.
+type beadDispatcher interface {
+	Dispatch(b capacity.PendingBead, townRoot, actor string) (*SlingResult, error)
+}
+
+// slingDispatcher is the default beadDispatcher: it slings the bead to a
+// polecat in its target rig.
+type slingDispatcher struct{}
+
+func (slingDispatcher) Dispatch(b capacity.PendingBead, townRoot, actor string) (*SlingResult, error) {
+	return dispatchSingleBead(b, townRoot, actor)
+}
+
 // dispatchScheduledWork is the main dispatch loop for the capacity scheduler.
 // Called by both `gt scheduler run` and the daemon heartbeat.
-func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun bool) (int, error) {
+func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun bool, dispatcher beadDispatcher) (int, error) {
+	start := time.Now()
+
 	// Acquire exclusive lock to prevent concurrent dispatch
 	runtimeDir := filepath.Join(townRoot, ".runtime")
 	_ = os.MkdirAll(runtimeDir, 0755)
@@ -99,6 +117,9 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 	if state.Paused {
 		if !dryRun {
 			fmt.Printf("%s Scheduler is paused (by %s), skipping dispatch\n", style.Dim.Render("⏸"), state.PausedBy)
+			noteSchedulerStall(townRoot, actor, capacity.StallPaused, "paused by "+state.PausedBy, func() int {
+				return len(listAllSlingContextRecords(townRoot))
+			})
 		}
 		return 0, nil
 	}
@@ -140,13 +161,16 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 	// Clean up invalid/stale contexts before querying for ready beads.
 	// Skip during dry-run to avoid mutating state.
 	if !dryRun {
-		cleanupStaleContexts(townRoot)
+		cleanupStaleContexts(townRoot, actor)
 	}
 
 	// Wire up the DispatchCycle
 	successfulRigs := make(map[string]bool)
 	// Track polecat names from dispatch results, keyed by context bead ID.
 	polecatNames := make(map[string]string)
+	// Capacity refusals land in report.Failed; count them so a cycle that
+	// only hit full capacity is not recorded as a dispatch failure.
+	capacityRefused := 0
 	lastCapacitySnapshot := polecatCapacitySnapshot{Max: maxPolecats}
 	cycle := &capacity.DispatchCycle{
 		AvailableCapacity: func() (int, error) {
@@ -155,19 +179,22 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 				return 0, err
 			}
 			lastCapacitySnapshot = snapshot
-			if snapshot.Free <= 0 {
-				return 0, nil // No free slots — PlanDispatch treats <= 0 as no capacity
-			}
-			return snapshot.Free, nil
+			// Slots held back for interactive slings are not the scheduler's
+			// to fill. PlanDispatch treats <= 0 as no capacity.
+			return schedulerCfg.DispatchableCapacity(snapshot.Free), nil
 		},
 		QueryPending: func() ([]capacity.PendingBead, error) {
-			return getReadySlingContexts(townRoot)
+			pending, err := getReadySlingContexts(townRoot)
+			if err != nil || !schedulerCfg.GetRigAffinity() {
+				return pending, err
+			}
+			return capacity.ClusterByRig(pending), nil
 		},
 		Validate: func(b capacity.PendingBead) error {
 			return validatePendingBeadForDispatch(townRoot, b, true)
 		},
 		Execute: func(b capacity.PendingBead) error {
-			result, err := dispatchSingleBead(b, townRoot, actor)
+			result, err := dispatcher.Dispatch(b, townRoot, actor)
 			if err != nil {
 				return err
 			}
@@ -189,7 +216,6 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 		},
 		OnFailure: func(b capacity.PendingBead, err error) {
 			var onSuccessErr *capacity.ErrOnSuccessFailed
-			var admissionErr *polecatCapacityAdmissionError
 			if errors.As(err, &onSuccessErr) {
 				// Polecat launched but context close failed — not a true dispatch failure.
 				// Log a distinct warning so operators can distinguish from "polecat never launched".
@@ -209,18 +235,34 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 					// Skip recordDispatchFailure to avoid writing to a closed context.
 					return
 				}
-			} else if errors.As(err, &admissionErr) {
+			} else if isPolecatCapacityRefusal(err) {
+				// No room to spawn is not a failure of the bead: leave the
+				// context queued without counting toward the circuit breaker.
 				fmt.Fprintf(os.Stderr, "%s Capacity full while dispatching %s; leaving context queued: %v\n",
 					style.Dim.Render("○"), b.WorkBeadID, err)
+				capacityRefused++
 				return
 			} else {
 				_ = events.LogFeed(events.TypeSchedulerDispatchFailed, actor,
 					events.SchedulerDispatchFailedPayload(b.WorkBeadID, b.TargetRig, err.Error()))
 			}
-			recordDispatchFailure(beadsForPendingContext(townRoot, b), b, err)
+			recordDispatchFailure(beadsForPendingContext(townRoot, b), b, err, actor)
 		},
 		BatchSize:  batchSize,
 		SpawnDelay: spawnDelay,
+		SpawnDelayFor: func(b capacity.PendingBead) time.Duration {
+			return schedulerCfg.GetSpawnDelayForRig(b.TargetRig)
+		},
+	}
+	if schedulerRunMaxRuntime > 0 {
+		// Bound the whole invocation, so a long drain stops starting new
+		// spawns before it can overlap the next scheduled run.
+		cycle.Deadline = start.Add(schedulerRunMaxRuntime)
+	}
+	if threshold := schedulerCfg.GetDoltLatencyThreshold(); threshold > 0 {
+		cycle.UnderLoad = func() bool {
+			return doltUnderLoad(townRoot, threshold)
+		}
 	}
 
 	if dryRun {
@@ -229,7 +271,7 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 			return 0, fmt.Errorf("planning dispatch: %w", planErr)
 		}
 		plan = validateDryRunDispatchPlan(townRoot, plan)
-		printDryRunPlan(plan, lastCapacitySnapshot, batchSize)
+		printDryRunPlan(plan, lastCapacitySnapshot, batchSize, schedulerCfg.GetReserveForInteractive())
 		return 0, nil
 	}
 
@@ -256,23 +298,98 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 		}
 	}
 
+	if report.Reason == "load" {
+		fmt.Printf("%s Paused dispatch with %d bead(s) left this cycle: Dolt under load\n",
+			style.Dim.Render("○"), report.Skipped)
+	}
+	if report.Reason == "max-runtime" {
+		fmt.Printf("%s Stopped after --max-runtime %s: dispatched %d, %d bead(s) remaining\n",
+			style.Dim.Render("○"), schedulerRunMaxRuntime, report.Dispatched, report.Skipped)
+	}
+	failed := report.Failed - capacityRefused
 	if report.Dispatched > 0 || report.Failed > 0 {
-		fmt.Printf("\n%s Dispatched %d, failed %d (reason: %s)\n",
-			style.Bold.Render("✓"), report.Dispatched, report.Failed, report.Reason)
+		summary := fmt.Sprintf("Dispatched %d, failed %d", report.Dispatched, failed)
+		if capacityRefused > 0 {
+			summary += fmt.Sprintf(", %d left queued at capacity", capacityRefused)
+		}
+		fmt.Printf("\n%s %s (reason: %s)\n", style.Bold.Render("✓"), summary, report.Reason)
 	} else if report.Skipped > 0 {
 		snapshot, err := polecatCapacitySnapshotForTown(townRoot)
 		if err != nil {
 			snapshot = lastCapacitySnapshot
 		}
-		fmt.Printf("\n%s Skipped %d bead(s) — zero capacity (working: %d recovery_blocked: %d reservations: %d reusable_idle: %d pending_mr: %d)\n",
-			style.Dim.Render("○"), report.Skipped, snapshot.Working, snapshot.RecoveryBlocked, snapshot.Reservations, snapshot.ReusableIdle, snapshot.PendingMR)
+		fmt.Printf("\n%s Skipped %d bead(s) — zero capacity (working: %d recovery_blocked: %d reservations: %d reusable_idle: %d pending_mr: %d reserved_interactive: %d)\n",
+			style.Dim.Render("○"), report.Skipped, snapshot.Working, snapshot.RecoveryBlocked, snapshot.Reservations, snapshot.ReusableIdle, snapshot.PendingMR, schedulerCfg.GetReserveForInteractive())
+	}
+
+	// Record why a cycle with scheduled work moved nothing, for
+	// 'gt scheduler why-stalled'.
+	switch {
+	case report.Dispatched > 0:
+	case report.Reason == "max-runtime":
+		// Out of time, not stalled: the next run picks up the rest.
+	case report.Reason == "load":
+		noteSchedulerStall(townRoot, actor, capacity.StallDoltLoad,
+			fmt.Sprintf("Dolt query latency above scheduler.dolt_latency_threshold (%s)", schedulerCfg.GetDoltLatencyThreshold()),
+			func() int { return report.Skipped })
+	case failed > 0:
+		noteSchedulerStall(townRoot, actor, capacity.StallDispatchFailed,
+			fmt.Sprintf("%d dispatch attempt(s) failed", failed), func() int { return failed })
+	case report.Skipped > 0 || capacityRefused > 0:
+		detail := fmt.Sprintf("no free polecat slots (max %d)", maxPolecats)
+		if reserve := schedulerCfg.GetReserveForInteractive(); reserve > 0 {
+			detail = fmt.Sprintf("no free polecat slots (max %d, %d reserved for interactive slings)", maxPolecats, reserve)
+		}
+		noteSchedulerStall(townRoot, actor, capacity.StallCapacity, detail, func() int { return report.Skipped + capacityRefused })
+	default:
+		noteSchedulerStall(townRoot, actor, capacity.StallAllBlocked, "no scheduled bead is ready", func() int {
+			return len(listAllSlingContextRecords(townRoot))
+		})
 	}
 
 	return report.Dispatched, nil
 }
 
-// printDryRunPlan displays a dry-run dispatch plan.
-func printDryRunPlan(plan capacity.DispatchPlan, snapshot polecatCapacitySnapshot, batchSize int) {
+// doltUnderLoad samples Dolt query latency and reports whether it exceeds
+// threshold. A failed probe is treated as load: an unresponsive server is
+// the case backpressure exists for.
+func doltUnderLoad(townRoot string, threshold time.Duration) bool {
+	latency, err := doltserver.MeasureQueryLatency(townRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s dolt under load, throttling: latency probe failed: %v\n", style.Warning.Render("⚠"), err)
+		return true
+	}
+	if latency > threshold {
+		fmt.Fprintf(os.Stderr, "%s dolt under load, throttling: query latency %s > %s\n",
+			style.Warning.Render("⚠"), latency.Round(time.Millisecond), threshold)
+		return true
+	}
+	return false
+}
+
+// noteSchedulerStall records a stalled dispatch cycle in scheduler state and
+// emits a scheduler_stalled event the first time each cause is seen.
+// scheduled counts the waiting beads; it is only called for a new stall, and
+// an empty scheduler is not a stall.
+func noteSchedulerStall(townRoot, actor, reason, detail string, scheduled func() int) {
+	state, err := capacity.LoadState(townRoot)
+	if err != nil || state.StallReason == reason {
+		return
+	}
+	n := scheduled()
+	if n == 0 || !state.RecordStall(reason) {
+		return
+	}
+	if err := capacity.SaveState(townRoot, state); err != nil {
+		return
+	}
+	_ = events.LogFeed(events.TypeSchedulerStalled, actor,
+		events.SchedulerStalledPayload(reason, n, detail))
+}
+
+// printDryRunPlan displays a dry-run dispatch plan. reserve is the number of
+// free slots held back for interactive slings.
+func printDryRunPlan(plan capacity.DispatchPlan, snapshot polecatCapacitySnapshot, batchSize, reserve int) {
 	if plan.Reason == "none" {
 		fmt.Println("No ready beads scheduled for dispatch")
 		return
@@ -282,6 +399,9 @@ func printDryRunPlan(plan capacity.DispatchPlan, snapshot polecatCapacitySnapsho
 	if snapshot.Max > 0 {
 		capStr = fmt.Sprintf("%d free of %d (working: %d, recovery_blocked: %d, reservations: %d, reusable_idle: %d, pending_mr: %d)",
 			snapshot.Free, snapshot.Max, snapshot.Working, snapshot.RecoveryBlocked, snapshot.Reservations, snapshot.ReusableIdle, snapshot.PendingMR)
+		if reserve > 0 {
+			capStr += fmt.Sprintf(", %d reserved for interactive slings", reserve)
+		}
 	}
 
 	totalReady := len(plan.ToDispatch) + plan.Skipped
@@ -333,13 +453,18 @@ func beadsForContextRecord(rec slingContextRecord) *beads.Beads {
 	return beads.NewWithBeadsDir(rec.workDir, rec.beadsDir)
 }
 
-// cleanupStaleContexts closes invalid and stale sling context beads.
+// cleanupStaleContexts closes invalid and stale sling context beads, and
+// dead-letters contexts whose target rig is not registered.
 // Called explicitly before the dispatch cycle to separate cleanup from querying.
-func cleanupStaleContexts(townRoot string) {
-	contexts := listAllSlingContextRecords(townRoot)
+func cleanupStaleContexts(townRoot, actor string) {
+	contexts := deadLetterMisconfiguredContexts(listAllSlingContextRecords(townRoot), loadKnownRigSet(townRoot), actor, false,
+		func(rec slingContextRecord, fields *capacity.SlingContextFields, problem string, _ error) {
+			fmt.Fprintf(os.Stderr, "%s dispatch_dead_letter reason=unknown_rig bead=%s context=%s detail=%q\n",
+				style.Warning.Render("⚠"), fields.WorkBeadID, rec.issue.ID, problem)
+		})
 
-	// First pass: close invalid and circuit-broken contexts, collect work bead IDs
-	// that need status checks for stale detection.
+	// First pass: close invalid and circuit-broken contexts, collect work
+	// bead IDs that need status checks for stale detection.
 	var staleCheckContexts []slingContextRecord
 	var staleCheckFields []*capacity.SlingContextFields
 	for _, ctx := range contexts {
@@ -383,11 +508,12 @@ func cleanupStaleContexts(townRoot string) {
 	}
 }
 
-// beadStatusInfo holds batch-fetched bead status, title, and labels.
+// beadStatusInfo holds batch-fetched bead status, title, labels, and priority.
 type beadStatusInfo struct {
-	Status string
-	Title  string
-	Labels []string
+	Status   string
+	Title    string
+	Labels   []string
+	Priority int
 }
 
 // batchFetchBeadInfoByIDs returns a map of bead ID → status+title+labels for specific beads.
@@ -413,17 +539,19 @@ func batchFetchBeadInfoByIDs(townRoot string, ids []string) map[string]beadStatu
 			continue
 		}
 		var items []struct {
-			ID     string   `json:"id"`
-			Status string   `json:"status"`
-			Title  string   `json:"title"`
-			Labels []string `json:"labels"`
+			ID       string   `json:"id"`
+			Status   string   `json:"status"`
+			Title    string   `json:"title"`
+			Labels   []string `json:"labels"`
+			Priority int      `json:"priority"`
 		}
 		if err := json.Unmarshal(out, &items); err == nil {
 			for _, item := range items {
 				result[item.ID] = beadStatusInfo{
-					Status: item.Status,
-					Title:  item.Title,
-					Labels: item.Labels,
+					Status:   item.Status,
+					Title:    item.Title,
+					Labels:   item.Labels,
+					Priority: item.Priority,
 				}
 			}
 		}
@@ -458,7 +586,10 @@ func groupBeadIDsByResolvedBeadsDir(townRoot string, ids []string) map[string][]
 // is checked across all rig dirs since work beads live in rig-local DBs.
 func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 	// 1. List all open sling context beads from HQ (authoritative)
-	allContexts := listAllSlingContextRecords(townRoot)
+	allContexts, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(allContexts) == 0 {
 		return nil, nil
@@ -497,6 +628,7 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 		return allContexts[i].issue.ID < allContexts[j].issue.ID // deterministic tiebreaker
 	})
 
+	knownRigs := loadKnownRigSet(townRoot)
 	seenWork := make(map[string]bool)
 	var result []capacity.PendingBead
 	for _, ctx := range allContexts {
@@ -510,6 +642,15 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 			continue
 		}
 
+		// A context without a usable target rig would dispatch to nothing.
+		// cleanupStaleContexts dead-letters these before a real dispatch;
+		// dry-runs and status views only skip them.
+		if problem := scheduledRigProblem(fields.TargetRig, knownRigs); problem != "" {
+			fmt.Fprintf(os.Stderr, "%s dispatch_skip reason=misconfigured_rig bead=%s context=%s detail=%q\n",
+				style.Dim.Render("○"), fields.WorkBeadID, ctx.issue.ID, problem)
+			continue
+		}
+
 		// Only include open, unblocked work beads. This uses the fast blocked
 		// cache plus targeted show output instead of shelling out to bd ready for
 		// every rig, which is prohibitively expensive in large towns.
@@ -544,12 +685,26 @@ func getReadySlingContexts(townRoot string) ([]capacity.PendingBead, error) {
 			Context:         fields,
 			ContextWorkDir:  ctx.workDir,
 			ContextBeadsDir: ctx.beadsDir,
+			Priority:        effectiveDispatchPriority(fields, info),
 		})
 	}
 
+	// Dispatch higher-priority work first; the stable sort keeps FIFO order
+	// within a priority level.
+	capacity.SortByPriority(result)
 	return result, nil
 }
 
+// effectiveDispatchPriority returns the priority a scheduled bead dispatches
+// at: the override set at schedule time (gt sling --priority), otherwise the
+// work bead's own priority.
+func effectiveDispatchPriority(fields *capacity.SlingContextFields, info beadStatusInfo) int {
+	if fields != nil && fields.Priority != nil {
+		return *fields.Priority
+	}
+	return info.Priority
+}
+
 // dispatchSingleBead dispatches one scheduled bead via executeSling.
 // Context fields are already parsed (from PendingBead.Context).
 // Returns the SlingResult (including PolecatName) on success.
@@ -583,6 +738,11 @@ func dispatchSingleBead(b capacity.PendingBead, townRoot, _ string) (*SlingResul
 	}
 
 	fmt.Printf("  Dispatching %s → %s...\n", b.WorkBeadID, b.TargetRig)
+	if schedulerRunVerbose {
+		tracker := newDispatchStepTracker(os.Stdout, b.WorkBeadID, schedulerRunStepTimeout)
+		defer tracker.stop()
+		params.OnStep = tracker.step
+	}
 	result, err := executeSling(params)
 	if err != nil {
 		return nil, fmt.Errorf("sling failed: %w", err)
@@ -591,6 +751,69 @@ func dispatchSingleBead(b capacity.PendingBead, townRoot, _ string) (*SlingResul
 	return result, nil
 }
 
+// dispatchStepTracker streams executeSling sub-steps for one bead and warns
+// when a single sub-step runs longer than the stall timeout. The warning does
+// not abort the dispatch — executeSling holds bead and assignee locks and
+// rolls back on its own errors — it only names the step that is hanging.
+type dispatchStepTracker struct {
+	mu        sync.Mutex
+	out       io.Writer
+	beadID    string
+	timeout   time.Duration
+	start     time.Time
+	current   string
+	stepStart time.Time
+	timer     *time.Timer
+}
+
+func newDispatchStepTracker(out io.Writer, beadID string, timeout time.Duration) *dispatchStepTracker {
+	return &dispatchStepTracker{out: out, beadID: beadID, timeout: timeout, start: time.Now()}
+}
+
+// step records the start of a sub-step (or the outcome of the previous one)
+// and re-arms the stall timer.
+func (t *dispatchStepTracker) step(name, detail string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	elapsed := now.Sub(t.start).Round(100 * time.Millisecond)
+	if detail != "" {
+		fmt.Fprintf(t.out, "    %s [%s] %s: %s\n", style.Dim.Render("·"), elapsed, name, detail)
+	} else {
+		fmt.Fprintf(t.out, "    %s [%s] %s\n", style.Dim.Render("·"), elapsed, name)
+	}
+	t.current = name
+	t.stepStart = now
+	if t.timeout <= 0 {
+		return
+	}
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.timer = time.AfterFunc(t.timeout, t.warnStalled)
+}
+
+func (t *dispatchStepTracker) warnStalled() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == "" {
+		return
+	}
+	fmt.Fprintf(t.out, "    %s %s stalled in %s for %s (still waiting)\n",
+		style.Warning.Render("⚠"), t.beadID, t.current, time.Since(t.stepStart).Round(time.Second))
+}
+
+// stop disarms the stall timer. Safe to call more than once.
+func (t *dispatchStepTracker) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current = ""
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
 func validateDryRunDispatchPlan(townRoot string, plan capacity.DispatchPlan) capacity.DispatchPlan {
 	if len(plan.ToDispatch) == 0 {
 		return plan
@@ -654,7 +877,9 @@ func isDaemonDispatch() bool {
 }
 
 // recordDispatchFailure increments the dispatch failure counter on the sling context bead.
-func recordDispatchFailure(townBeads *beads.Beads, b capacity.PendingBead, dispatchErr error) {
+// A context still under the circuit breaker stays scheduled for the next cycle,
+// which is recorded as a scheduler_requeue event so bounce rates are visible.
+func recordDispatchFailure(townBeads *beads.Beads, b capacity.PendingBead, dispatchErr error, actor string) {
 	if b.Context == nil {
 		return
 	}
@@ -674,7 +899,10 @@ func recordDispatchFailure(townBeads *beads.Beads, b capacity.PendingBead, dispa
 		}
 		fmt.Printf("  %s Context %s (work: %s) failed %d times, circuit-broken\n",
 			style.Warning.Render("⚠"), b.ID, b.WorkBeadID, b.Context.DispatchFailures)
+		return
 	}
+	_ = events.LogFeed(events.TypeSchedulerRequeue, actor,
+		events.SchedulerRequeuePayload(b.WorkBeadID, b.TargetRig, b.Context.DispatchFailures, dispatchErr.Error()))
 }
 
 // listAllSlingContexts returns all open sling context beads across all rig
@@ -695,14 +923,32 @@ func listAllSlingContexts(townRoot string) []*beads.Issue {
 	return all
 }
 
+// listAllSlingContextRecords returns every open sling context record.
+// Convenience wrapper that ignores errors (an unreachable bd reads as no contexts).
 func listAllSlingContextRecords(townRoot string) []slingContextRecord {
+	records, _ := listAllSlingContextRecordsWithError(townRoot)
+	return records
+}
+
+// listAllSlingContextRecordsWithError lists open sling contexts across all
+// beads dirs. A missing bd binary is an error rather than an empty scheduler,
+// as is every dir failing; partial failure is acceptable.
+func listAllSlingContextRecordsWithError(townRoot string) ([]slingContextRecord, error) {
 	var records []slingContextRecord
 	seen := make(map[string]bool)
-	for _, dir := range beadsSearchDirs(townRoot) {
+	dirs := beadsSearchDirs(townRoot)
+	failCount := 0
+	var lastErr error
+	for _, dir := range dirs {
 		beadsDir := beads.ResolveBeadsDir(dir)
 		b := beads.NewWithBeadsDir(dir, beadsDir)
 		contexts, err := b.ListOpenSlingContexts()
 		if err != nil {
+			if errors.Is(err, beads.ErrNotInstalled) {
+				return nil, fmt.Errorf("listing sling contexts: %w", err)
+			}
+			failCount++
+			lastErr = err
 			continue // Partial failure is acceptable — skip unavailable dirs
 		}
 		for _, ctx := range contexts {
@@ -714,13 +960,32 @@ func listAllSlingContextRecords(townRoot string) []slingContextRecord {
 			records = append(records, slingContextRecord{issue: ctx, workDir: dir, beadsDir: beadsDir})
 		}
 	}
-	return records
+	if failCount == len(dirs) && failCount > 0 {
+		return nil, fmt.Errorf("bd not found or failed: all %d sling context queries failed (last: %w)", failCount, lastErr)
+	}
+	return records, nil
 }
 
 // listBlockedWorkBeadIDsWithError returns a set of work bead IDs that have active blockers.
 // Returns an error only when ALL dirs fail (partial success is acceptable).
 func listBlockedWorkBeadIDsWithError(townRoot string, workBeadIDs []string) (map[string]bool, error) {
-	blockedIDs := make(map[string]bool)
+	blockers, err := listWorkBeadBlockersWithError(townRoot, workBeadIDs)
+	if err != nil {
+		return nil, err
+	}
+	blockedIDs := make(map[string]bool, len(blockers))
+	for id := range blockers {
+		blockedIDs[id] = true
+	}
+	return blockedIDs, nil
+}
+
+// listWorkBeadBlockersWithError maps each blocked bead ID to the IDs of the
+// beads blocking it, as reported by bd blocked. Every blocked bead has an
+// entry, even when bd does not name its blockers.
+// Returns an error only when ALL dirs fail (partial success is acceptable).
+func listWorkBeadBlockersWithError(townRoot string, workBeadIDs []string) (map[string][]string, error) {
+	blockers := make(map[string][]string)
 	idsByBeadsDir := groupBeadIDsByResolvedBeadsDir(townRoot, workBeadIDs)
 	failCount := 0
 	var lastErr error
@@ -730,6 +995,9 @@ func listBlockedWorkBeadIDsWithError(townRoot string, workBeadIDs []string) (map
 		b := beads.NewWithBeadsDir(filepath.Dir(beadsDir), beadsDir)
 		blockedOut, err := b.Run("blocked", "--json")
 		if err != nil {
+			if errors.Is(err, beads.ErrNotInstalled) {
+				return nil, fmt.Errorf("bd blocked: %w", err)
+			}
 			failCount++
 			lastErr = err
 			fmt.Fprintf(os.Stderr, "%s Warning: bd blocked failed for %s: %v\n",
@@ -737,22 +1005,23 @@ func listBlockedWorkBeadIDsWithError(townRoot string, workBeadIDs []string) (map
 			continue
 		}
 		var blockedBeads []struct {
-			ID string `json:"id"`
+			ID        string   `json:"id"`
+			BlockedBy []string `json:"blocked_by"`
 		}
 		if err := json.Unmarshal(blockedOut, &blockedBeads); err == nil {
 			for _, b := range blockedBeads {
-				blockedIDs[b.ID] = true
+				blockers[b.ID] = append(blockers[b.ID], b.BlockedBy...)
 			}
 		}
 	}
 	if failCount == len(idsByBeadsDir) && failCount > 0 {
 		return nil, fmt.Errorf("all %d bd blocked queries failed (last: %w)", failCount, lastErr)
 	}
-	return blockedIDs, nil
+	return blockers, nil
 }
 
 // listBlockedWorkBeadIDs returns a set of work bead IDs that have active blockers.
-// Convenience wrapper that ignores errors (used by listScheduledBeads for display).
+// Convenience wrapper that ignores errors.
 func listBlockedWorkBeadIDs(townRoot string) map[string]bool {
 	ids, _ := listBlockedWorkBeadIDsWithError(townRoot, listAllScheduledBeadIDs(townRoot))
 	if ids == nil {
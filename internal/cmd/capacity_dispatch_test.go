@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 func TestShouldFireCrossRigEscalation_Debounces(t *testing.T) {
@@ -45,3 +53,84 @@ func TestShouldFireCrossRigEscalation_KeyedByRigAndPrefix(t *testing.T) {
 		t.Fatalf("walletui/hq repeat must not fire")
 	}
 }
+
+func TestDispatchStepTracker_StreamsSteps(t *testing.T) {
+	var buf bytes.Buffer
+	tracker := newDispatchStepTracker(&buf, "gt-abc", 0)
+	tracker.step("spawn-polecat", "gastown")
+	tracker.step("polecat-spawned", "Toast (branch polecat/Toast)")
+	tracker.stop()
+
+	out := buf.String()
+	for _, want := range []string{"spawn-polecat: gastown", "polecat-spawned: Toast (branch polecat/Toast)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "stalled") {
+		t.Errorf("zero timeout must never warn:\n%s", out)
+	}
+}
+
+func TestDispatchStepTracker_WarnsOnStalledStep(t *testing.T) {
+	var buf bytes.Buffer
+	tracker := newDispatchStepTracker(&buf, "gt-abc", 20*time.Millisecond)
+	tracker.step("start-session", "gt-gastown-p-Toast")
+	time.Sleep(100 * time.Millisecond)
+	tracker.stop()
+
+	if out := buf.String(); !strings.Contains(out, "gt-abc stalled in start-session") {
+		t.Errorf("expected stall warning naming start-session:\n%s", out)
+	}
+}
+
+func TestDispatchStepTracker_StopDisarmsTimer(t *testing.T) {
+	var buf bytes.Buffer
+	tracker := newDispatchStepTracker(&buf, "gt-abc", 20*time.Millisecond)
+	tracker.step("hook-bead", "")
+	tracker.stop()
+	time.Sleep(60 * time.Millisecond)
+
+	if out := buf.String(); strings.Contains(out, "stalled") {
+		t.Errorf("stopped tracker must not warn:\n%s", out)
+	}
+}
+
+func TestListAllSlingContextRecordsWithError_BdNotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	townRoot := t.TempDir()
+
+	_, err := listAllSlingContextRecordsWithError(townRoot)
+	if !errors.Is(err, beads.ErrNotInstalled) {
+		t.Fatalf("err = %v, want ErrNotInstalled", err)
+	}
+	if _, err := listScheduledBeads(townRoot); !errors.Is(err, beads.ErrNotInstalled) {
+		t.Fatalf("listScheduledBeads err = %v, want ErrNotInstalled", err)
+	}
+	if _, err := getReadySlingContexts(townRoot); !errors.Is(err, beads.ErrNotInstalled) {
+		t.Fatalf("getReadySlingContexts err = %v, want ErrNotInstalled", err)
+	}
+}
+
+func TestListAllSlingContextRecordsWithError_BdFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping fake bd test on Windows")
+	}
+	binDir := t.TempDir()
+	writeFailingBd(t, binDir, `echo "database unavailable" >&2; exit 1`)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	_, err := listAllSlingContextRecordsWithError(t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "bd not found or failed") {
+		t.Fatalf("err = %v, want bd failure rather than an empty scheduler", err)
+	}
+}
+
+// writeFailingBd installs a fake bd in binDir that runs body for every call.
+func writeFailingBd(t *testing.T, binDir, body string) {
+	t.Helper()
+	script := "#!/bin/sh\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+}
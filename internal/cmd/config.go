@@ -628,6 +628,17 @@ Supported keys:
   scheduler.max_polecats      Dispatch mode: -1 = direct (default), N > 0 = deferred
   scheduler.batch_size        Beads per heartbeat (default: 1)
   scheduler.spawn_delay       Delay between spawns (default: 0s)
+  scheduler.per_rig_spawn_delay.<rig>
+                              Delay after spawning into <rig>, overriding
+                              spawn_delay ("" removes the override)
+  scheduler.rig_affinity      Dispatch same-rig beads back-to-back (true/false,
+                              default: false)
+  scheduler.reserve_for_interactive
+                              Polecat slots the scheduler leaves free for
+                              direct gt sling (default: 0)
+  scheduler.dolt_latency_threshold
+                              Pause dispatch for the cycle when a Dolt probe
+                              query is slower than this (default: 0s, off)
   polecat.target_clean_policy When to delete <polecat>/target/ on reuse
                               ("per_bead", "every_n_beads:<N>", "never";
                               default: per_bead)
@@ -675,6 +686,13 @@ Supported keys:
   scheduler.max_polecats      Dispatch mode (-1 = direct, N > 0 = deferred)
   scheduler.batch_size        Beads per heartbeat
   scheduler.spawn_delay       Delay between spawns
+  scheduler.per_rig_spawn_delay.<rig>
+                              Delay after spawning into <rig>
+  scheduler.rig_affinity      Group dispatch batches by rig (true/false)
+  scheduler.reserve_for_interactive
+                              Polecat slots left free for direct gt sling
+  scheduler.dolt_latency_threshold
+                              Dolt latency that pauses dispatch
   polecat.target_clean_policy When to delete <polecat>/target/ on reuse
                               (per_bead, every_n_beads:<N>, never)
   maintenance.window          Maintenance window start time (HH:MM)
@@ -773,6 +791,39 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 		townSettings.Scheduler.SpawnDelay = value
 
+	case "scheduler.rig_affinity":
+		b, err := parseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w (expected true/false)", key, err)
+		}
+		if townSettings.Scheduler == nil {
+			townSettings.Scheduler = capacity.DefaultSchedulerConfig()
+		}
+		townSettings.Scheduler.RigAffinity = &b
+
+	case "scheduler.reserve_for_interactive":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: expected non-negative integer", key)
+		}
+		if townSettings.Scheduler == nil {
+			townSettings.Scheduler = capacity.DefaultSchedulerConfig()
+		}
+		if maxPolecats := townSettings.Scheduler.GetMaxPolecats(); maxPolecats > 0 && n >= maxPolecats {
+			fmt.Printf("%s reserve %d >= scheduler.max_polecats %d: the scheduler will never dispatch\n",
+				style.Warning.Render("⚠"), n, maxPolecats)
+		}
+		townSettings.Scheduler.ReserveForInteractive = &n
+
+	case "scheduler.dolt_latency_threshold":
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w (expected Go duration, e.g. 500ms; 0s disables)", key, err)
+		}
+		if townSettings.Scheduler == nil {
+			townSettings.Scheduler = capacity.DefaultSchedulerConfig()
+		}
+		townSettings.Scheduler.DoltLatencyThreshold = value
+
 	case "polecat.target_clean_policy":
 		// Validate the policy string parses cleanly. Storage form is the raw input
 		// (normalized via parsed.String() so e.g. "  per_bead  " becomes "per_bead").
@@ -812,7 +863,25 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return setLifecycleConfig(townRoot, key, value)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if rig, ok := strings.CutPrefix(key, "scheduler.per_rig_spawn_delay."); ok && rig != "" {
+			if townSettings.Scheduler == nil {
+				townSettings.Scheduler = capacity.DefaultSchedulerConfig()
+			}
+			if value == "" {
+				// Empty value removes the override; the rig uses spawn_delay again.
+				delete(townSettings.Scheduler.PerRigSpawnDelay, rig)
+				break
+			}
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid value for %s: %w (expected Go duration, e.g. 2s, 500ms)", key, err)
+			}
+			if townSettings.Scheduler.PerRigSpawnDelay == nil {
+				townSettings.Scheduler.PerRigSpawnDelay = make(map[string]string)
+			}
+			townSettings.Scheduler.PerRigSpawnDelay[rig] = value
+			break
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  scheduler.per_rig_spawn_delay.<rig>\n  scheduler.rig_affinity\n  scheduler.reserve_for_interactive\n  scheduler.dolt_latency_threshold\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
 	}
 
 	if err := config.SaveTownSettings(settingsPath, townSettings); err != nil {
@@ -879,6 +948,19 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		}
 		value = scfg.GetSpawnDelay().String()
 
+	case "scheduler.rig_affinity":
+		scfg := townSettings.Scheduler
+		if scfg == nil {
+			scfg = capacity.DefaultSchedulerConfig()
+		}
+		value = strconv.FormatBool(scfg.GetRigAffinity())
+
+	case "scheduler.reserve_for_interactive":
+		value = strconv.Itoa(townSettings.Scheduler.GetReserveForInteractive())
+
+	case "scheduler.dolt_latency_threshold":
+		value = townSettings.Scheduler.GetDoltLatencyThreshold().String()
+
 	case "polecat.target_clean_policy":
 		if townSettings.Polecat != nil && townSettings.Polecat.TargetCleanPolicy != "" {
 			value = townSettings.Polecat.TargetCleanPolicy
@@ -904,7 +986,11 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return getLifecycleConfig(townRoot, key)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if rig, ok := strings.CutPrefix(key, "scheduler.per_rig_spawn_delay."); ok && rig != "" {
+			value = townSettings.Scheduler.GetSpawnDelayForRig(rig).String()
+			break
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  scheduler.per_rig_spawn_delay.<rig>\n  scheduler.rig_affinity\n  scheduler.reserve_for_interactive\n  scheduler.dolt_latency_threshold\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
 	}
 
 	fmt.Println(value)
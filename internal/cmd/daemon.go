@@ -63,8 +63,16 @@ var daemonStatusCmd = &cobra.Command{
 Displays whether the daemon is running, its PID, uptime, heartbeat
 count, and whether the binary has been rebuilt since the daemon started.
 
+With --json, prints a stable machine-readable document for automation:
+whether the daemon is running, dolt_reachable, open_wisp_total (-1 when the
+Dolt server cannot be queried), and per patrol: enabled, interval_seconds,
+last_run, last_duration_ms, last_error, consecutive_failures and tripped. A
+patrol run counts as failed when any step of its dog molecule failed; a
+tripped patrol was disabled after repeated failures ('gt daemon patrol reset').
+
 Examples:
-  gt daemon status`,
+  gt daemon status
+  gt daemon status --json | jq '.patrols[] | select(.consecutive_failures > 0)'`,
 	RunE: runDaemonStatus,
 }
 
@@ -76,10 +84,14 @@ var daemonLogsCmd = &cobra.Command{
 Shows the most recent log entries from the daemon. Use -n to control
 how many lines to display, or -f to follow the log in real time.
 
+With --patrol, only lines from that patrol (those tagged "<patrol>:") are
+shown. A patrol configured with its own log_file is read from that file.
+
 Examples:
   gt daemon logs             # Show last 50 lines
   gt daemon logs -n 100      # Show last 100 lines
-  gt daemon logs -f           # Follow log output in real time`,
+  gt daemon logs -f           # Follow log output in real time
+  gt daemon logs --patrol wisp_reaper -f`,
 	RunE: runDaemonLogs,
 }
 
@@ -144,9 +156,30 @@ Examples:
 	RunE: runDaemonClearBackoff,
 }
 
+var daemonReloadCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Reload patrol config without restarting the daemon",
+	Long: `Re-read mayor/daemon.json in the running daemon.
+
+Validates the patrol config locally, then signals the daemon (SIGHUP) to
+re-read it. The daemon validates again and swaps in the new config, so the
+next patrol tick uses the new intervals, enabled flags, and reaper ages.
+In-flight patrols are not interrupted. If the new config is invalid, the
+daemon keeps its current config and logs why.
+
+Patrols that were disabled when the daemon started still need a restart
+to begin running.
+
+Examples:
+  gt daemon reload`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonReload,
+}
+
 var (
 	daemonLogLines  int
 	daemonLogFollow bool
+	daemonLogPatrol string
 )
 
 func init() {
@@ -158,10 +191,13 @@ func init() {
 	daemonCmd.AddCommand(daemonEnableSupervisorCmd)
 	daemonCmd.AddCommand(daemonClearBackoffCmd)
 	daemonCmd.AddCommand(daemonRotateLogsCmd)
+	daemonCmd.AddCommand(daemonReloadCmd)
 
 	daemonLogsCmd.Flags().IntVarP(&daemonLogLines, "lines", "n", 50, "Number of lines to show")
 	daemonLogsCmd.Flags().BoolVarP(&daemonLogFollow, "follow", "f", false, "Follow log output")
+	daemonLogsCmd.Flags().StringVar(&daemonLogPatrol, "patrol", "", "Only show lines from this patrol (e.g. wisp_reaper, dolt_backup)")
 	daemonRotateLogsCmd.Flags().BoolVar(&daemonRotateLogsForce, "force", false, "Rotate all logs regardless of size")
+	daemonStatusCmd.Flags().BoolVar(&daemonStatusJSON, "json", false, "Output daemon and patrol health as JSON")
 
 	rootCmd.AddCommand(daemonCmd)
 }
@@ -267,6 +303,10 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("checking daemon status: %w", err)
 	}
 
+	if daemonStatusJSON {
+		return printDaemonStatusJSON(townRoot, running, pid)
+	}
+
 	if running {
 		fmt.Printf("%s Daemon is %s (PID %d)\n",
 			style.Bold.Render("●"),
@@ -343,10 +383,20 @@ func runDaemonLogs(cmd *cobra.Command, args []string) error {
 
 	logFile := filepath.Join(townRoot, "daemon", "daemon.log")
 
+	if daemonLogPatrol != "" {
+		if patrolLog := daemon.PatrolLogFile(townRoot, daemonLogPatrol); patrolLog != "" {
+			logFile = patrolLog
+		}
+	}
+
 	if _, err := os.Stat(logFile); os.IsNotExist(err) {
 		return fmt.Errorf("no log file found at %s", logFile)
 	}
 
+	if daemonLogPatrol != "" {
+		return tailPatrolLog(logFile, daemonLogPatrol, daemonLogLines, daemonLogFollow)
+	}
+
 	if daemonLogFollow {
 		// Use tail -f for following
 		tailCmd := exec.Command("tail", "-f", logFile)
@@ -447,6 +497,45 @@ func runDaemonClearBackoff(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDaemonReload(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Validate up front so the user sees config errors immediately rather
+	// than only in the daemon log.
+	cfg, err := daemon.ReadPatrolConfig(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading patrol config: %w", err)
+	}
+	if err := daemon.ValidatePatrolConfig(cfg); err != nil {
+		return fmt.Errorf("invalid patrol config (daemon not signaled): %w", err)
+	}
+
+	running, pid, err := daemon.IsRunning(townRoot)
+	if err != nil {
+		return fmt.Errorf("checking daemon status: %w", err)
+	}
+	if !running {
+		fmt.Printf("%s Patrol config is valid (daemon not running, will take effect on next start)\n",
+			style.Bold.Render("✓"))
+		return nil
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("finding daemon process: %w", err)
+	}
+	if err := signalDaemonConfigReload(process); err != nil {
+		return fmt.Errorf("signaling daemon to reload config: %w", err)
+	}
+	fmt.Printf("%s Signaled daemon (PID %d) to reload %s\n",
+		style.Bold.Render("✓"), pid, daemon.PatrolConfigFile(townRoot))
+	fmt.Printf("  %s\n", style.Dim.Render("See 'gt daemon logs' for the applied changes"))
+	return nil
+}
+
 func runDaemonRotateLogs(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// daemonLogPollInterval is how often a followed patrol log is checked for
+// new lines.
+const daemonLogPollInterval = 500 * time.Millisecond
+
+// isPatrolLogLine reports whether a daemon log line was written by the given
+// patrol. Lines look like "2026/01/02 15:04:05 wisp_reaper: ...", so the tag
+// is matched right after the timestamp, not anywhere in the message.
+func isPatrolLogLine(line, patrol string) bool {
+	tag := patrol + ":"
+	if strings.HasPrefix(line, tag) {
+		return true
+	}
+	parts := strings.SplitN(line, " ", 3)
+	return len(parts) == 3 && strings.HasPrefix(parts[2], tag)
+}
+
+// lastPatrolLogLines returns the last n lines of r written by the patrol.
+func lastPatrolLogLines(r io.Reader, patrol string, n int) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isPatrolLogLine(line, patrol) {
+			continue
+		}
+		lines = append(lines, line)
+		if n >= 0 && len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// tailPatrolLog prints the last n lines of the patrol in logFile and, with
+// follow, keeps printing new ones. A log that is rotated or truncated while
+// followed is reopened from the start.
+func tailPatrolLog(logFile, patrol string, n int, follow bool) error {
+	f, err := os.Open(logFile) //nolint:gosec // G304: path constructed internally
+	if err != nil {
+		return fmt.Errorf("opening log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	lines, err := lastPatrolLogLines(f, patrol, n)
+	if err != nil {
+		return fmt.Errorf("reading log: %w", err)
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if !follow {
+		return nil
+	}
+
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("reading log: %w", err)
+	}
+	reader := bufio.NewReader(f)
+	var partial string
+	for {
+		chunk, err := reader.ReadString('\n')
+		offset += int64(len(chunk))
+		partial += chunk
+		if err == nil {
+			line := strings.TrimRight(partial, "\r\n")
+			partial = ""
+			if isPatrolLogLine(line, patrol) {
+				fmt.Println(line)
+			}
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading log: %w", err)
+		}
+
+		time.Sleep(daemonLogPollInterval)
+		current, statErr := os.Stat(logFile)
+		if statErr != nil {
+			continue // Mid-rotation; the new file appears shortly
+		}
+		opened, statErr := f.Stat()
+		if statErr == nil && os.SameFile(current, opened) && current.Size() >= offset {
+			continue
+		}
+		newFile, openErr := os.Open(logFile) //nolint:gosec // G304: path constructed internally
+		if openErr != nil {
+			continue
+		}
+		_ = f.Close()
+		f = newFile
+		reader = bufio.NewReader(f)
+		offset = 0
+		partial = ""
+	}
+}
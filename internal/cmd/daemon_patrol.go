@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	daemonPatrolRunForce   bool
+	daemonPatrolRunTimeout time.Duration
+)
+
+var daemonPatrolCmd = &cobra.Command{
+	Use:   "patrol",
+	Short: "Operate on individual daemon patrols",
+	RunE:  requireSubcommand,
+}
+
+var daemonPatrolRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Run one patrol now, without waiting for its ticker",
+	Long: `Ask the running daemon to run a single patrol once and report the outcome.
+
+The patrol runs inside the daemon, on its main loop, exactly as if its ticker
+had fired, so it never overlaps a scheduled run. A disabled patrol is skipped
+unless --force is given. Details of what the patrol did are in the daemon
+log ('gt daemon logs').
+
+Patrols: ` + strings.Join(daemon.PatrolRunNames(), ", ") + `
+
+Examples:
+  gt daemon patrol run wisp_reaper
+  gt daemon patrol run dolt_backup --force`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runDaemonPatrolRun,
+}
+
+var daemonPatrolResetCmd = &cobra.Command{
+	Use:   "reset <name>",
+	Short: "Re-enable a patrol disabled after repeated failures",
+	Long: `Clear a patrol's failure streak and re-enable it.
+
+The daemon stops running a patrol on its ticker once it fails
+patrols.failure_limit times in a row (default 5), so a persistently broken
+patrol does not alert every interval. Fix the cause, then reset it; the
+patrol runs again on its next interval. A daemon config reload
+('gt daemon reload') re-enables every such patrol.
+
+The daemon does not need to be running.
+
+Examples:
+  gt daemon patrol reset dolt_backup`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runDaemonPatrolReset,
+}
+
+func init() {
+	daemonPatrolRunCmd.Flags().BoolVar(&daemonPatrolRunForce, "force", false, "Run even if the patrol is disabled")
+	daemonPatrolRunCmd.Flags().DurationVar(&daemonPatrolRunTimeout, "timeout", 30*time.Minute, "How long to wait for the patrol to finish")
+
+	daemonPatrolCmd.AddCommand(daemonPatrolRunCmd)
+	daemonPatrolCmd.AddCommand(daemonPatrolResetCmd)
+	daemonCmd.AddCommand(daemonPatrolCmd)
+}
+
+func runDaemonPatrolRun(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !slices.Contains(daemon.PatrolRunNames(), name) {
+		return fmt.Errorf("unknown patrol %q (known: %s)", name, strings.Join(daemon.PatrolRunNames(), ", "))
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	running, pid, err := daemon.IsRunning(townRoot)
+	if err != nil {
+		return fmt.Errorf("checking daemon status: %w", err)
+	}
+	if !running {
+		return fmt.Errorf("daemon is not running (start it with 'gt daemon start')")
+	}
+
+	req := &daemon.PatrolRunRequest{Patrol: name, Force: daemonPatrolRunForce, RequestedAt: time.Now()}
+	if err := daemon.WritePatrolRunRequest(townRoot, req); err != nil {
+		return fmt.Errorf("writing patrol run request: %w", err)
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("finding daemon process: %w", err)
+	}
+	if err := signalDaemonPatrolRun(process); err != nil {
+		_ = os.Remove(daemon.PatrolRunRequestFile(townRoot))
+		return fmt.Errorf("signaling daemon: %w", err)
+	}
+	fmt.Printf("%s Asked daemon (PID %d) to run %s\n", style.Dim.Render("…"), pid, name)
+
+	result, err := waitForPatrolRunResult(townRoot, req, daemonPatrolRunTimeout)
+	if err != nil {
+		return err
+	}
+	if !result.Ran {
+		return fmt.Errorf("%s not run: %s", name, result.Skipped)
+	}
+	fmt.Printf("%s %s finished in %v\n", style.Bold.Render("✓"), name,
+		result.FinishedAt.Sub(result.StartedAt).Round(time.Millisecond))
+	fmt.Printf("  %s\n", style.Dim.Render("See 'gt daemon logs' for what it did"))
+	return nil
+}
+
+func runDaemonPatrolReset(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !slices.Contains(daemon.PatrolRunNames(), name) {
+		return fmt.Errorf("unknown patrol %q (known: %s)", name, strings.Join(daemon.PatrolRunNames(), ", "))
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	tripped, err := daemon.ResetPatrolHealth(townRoot, name)
+	if err != nil {
+		return fmt.Errorf("resetting %s: %w", name, err)
+	}
+	if !tripped {
+		fmt.Printf("%s %s was not disabled; failure streak cleared\n", style.Dim.Render("○"), name)
+		return nil
+	}
+	fmt.Printf("%s %s re-enabled; it runs again on its next interval\n", style.Bold.Render("✓"), name)
+	return nil
+}
+
+// waitForPatrolRunResult polls for the daemon's result for req. A patrol
+// already running when the request arrives delays it until the main loop is
+// free, so the timeout covers both.
+func waitForPatrolRunResult(townRoot string, req *daemon.PatrolRunRequest, timeout time.Duration) (*daemon.PatrolRunResult, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		result, err := daemon.LoadPatrolRunResult(townRoot)
+		if err == nil && result != nil && result.Patrol == req.Patrol && result.RequestedAt.Equal(req.RequestedAt) {
+			return result, nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return nil, fmt.Errorf("no result from daemon after %v; the patrol may still be running (see 'gt daemon logs')", timeout)
+}
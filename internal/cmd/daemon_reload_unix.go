@@ -11,3 +11,15 @@ import (
 func signalDaemonReload(process *os.Process) error {
 	return process.Signal(syscall.SIGUSR2)
 }
+
+// signalDaemonConfigReload sends SIGHUP to the daemon process to re-read
+// its patrol config (mayor/daemon.json).
+func signalDaemonConfigReload(process *os.Process) error {
+	return process.Signal(syscall.SIGHUP)
+}
+
+// signalDaemonPatrolRun sends SIGUSR1 to the daemon process so it picks up a
+// pending patrol run request.
+func signalDaemonPatrolRun(process *os.Process) error {
+	return process.Signal(syscall.SIGUSR1)
+}
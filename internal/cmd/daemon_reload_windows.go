@@ -11,3 +11,13 @@ import (
 func signalDaemonReload(process *os.Process) error {
 	return fmt.Errorf("daemon reload signal not supported on Windows")
 }
+
+// signalDaemonConfigReload is a no-op on Windows since SIGHUP is not available.
+func signalDaemonConfigReload(process *os.Process) error {
+	return fmt.Errorf("daemon config reload signal not supported on Windows")
+}
+
+// signalDaemonPatrolRun is a no-op on Windows since SIGUSR1 is not available.
+func signalDaemonPatrolRun(process *os.Process) error {
+	return fmt.Errorf("daemon patrol run signal not supported on Windows")
+}
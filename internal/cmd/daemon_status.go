@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+var daemonStatusJSON bool
+
+// daemonStatusDoc is the `gt daemon status --json` contract. Fields are only
+// ever added, so automation can rely on the existing ones.
+type daemonStatusDoc struct {
+	Running       bool                 `json:"running"`
+	PID           int                  `json:"pid,omitempty"`
+	StartedAt     *time.Time           `json:"started_at,omitempty"`
+	LastHeartbeat *time.Time           `json:"last_heartbeat,omitempty"`
+	DoltReachable bool                 `json:"dolt_reachable"`
+	OpenWispTotal int                  `json:"open_wisp_total"` // -1 when unknown
+	Patrols       []daemonPatrolStatus `json:"patrols"`
+}
+
+// daemonPatrolStatus is one patrol's entry in daemonStatusDoc.
+type daemonPatrolStatus struct {
+	Name                string     `json:"name"`
+	Enabled             bool       `json:"enabled"`
+	IntervalSeconds     int64      `json:"interval_seconds"`
+	LastRun             *time.Time `json:"last_run"` // null until the patrol first runs
+	LastDurationMS      int64      `json:"last_duration_ms"`
+	LastError           string     `json:"last_error"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Tripped             bool       `json:"tripped"` // disabled after repeated failures
+}
+
+func printDaemonStatusJSON(townRoot string, running bool, pid int) error {
+	doc := daemonStatusDoc{Running: running, OpenWispTotal: -1}
+	if running {
+		doc.PID = pid
+		if state, err := daemon.LoadState(townRoot); err == nil {
+			if !state.StartedAt.IsZero() {
+				doc.StartedAt = &state.StartedAt
+			}
+			if !state.LastHeartbeat.IsZero() {
+				doc.LastHeartbeat = &state.LastHeartbeat
+			}
+		}
+	}
+
+	// Health is best-effort: an unreadable record reports every patrol as
+	// never run rather than failing the whole status.
+	health, _ := daemon.LoadPatrolHealth(townRoot)
+	doc.Patrols = daemonPatrolStatuses(daemon.LoadPatrolConfig(townRoot), health)
+
+	doltCfg := doltserver.DefaultConfig(townRoot)
+	if doltserver.CheckServerReachable(townRoot) == nil {
+		doc.DoltReachable = true
+		// A bad schema mapping leaves the count unknown.
+		if ctx, err := reaperContext(); err == nil {
+			if total, ok := countOpenWispsOnServer(ctx, doltCfg.EffectiveHost(), doltCfg.Port); ok {
+				doc.OpenWispTotal = total
+			}
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// daemonPatrolStatuses builds the per-patrol entries, in PatrolRunNames order.
+func daemonPatrolStatuses(config *daemon.DaemonPatrolConfig, health map[string]daemon.PatrolHealth) []daemonPatrolStatus {
+	names := daemon.PatrolRunNames()
+	statuses := make([]daemonPatrolStatus, 0, len(names))
+	for _, name := range names {
+		s := daemonPatrolStatus{
+			Name:            name,
+			Enabled:         daemon.IsPatrolEnabled(config, name),
+			IntervalSeconds: int64(daemon.PatrolInterval(config, name) / time.Second),
+		}
+		if h, ok := health[name]; ok && !h.LastRun.IsZero() {
+			lastRun := h.LastRun
+			s.LastRun = &lastRun
+			s.LastDurationMS = h.LastDurationMS
+			s.LastError = h.LastError
+			s.ConsecutiveFailures = h.ConsecutiveFailures
+			s.Tripped = h.Tripped
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// countOpenWispsOnServer sums open wisps across the server's beads databases.
+// ok is false if any database with a reaper schema could not be counted, so
+// a partial total is never reported as the whole.
+func countOpenWispsOnServer(ctx context.Context, host string, port int) (total int, ok bool) {
+	for _, dbName := range reaper.DiscoverDatabases(ctx, host, port) {
+		if reaper.ValidateDBName(dbName) != nil {
+			continue
+		}
+		db, err := reaper.OpenDB(ctx, host, port, dbName, 10*time.Second, 10*time.Second)
+		if err != nil {
+			return 0, false
+		}
+		if hasSchema, err := reaper.HasReaperSchema(ctx, db); err != nil || !hasSchema {
+			db.Close()
+			if err != nil {
+				return 0, false
+			}
+			continue
+		}
+		n, err := reaper.CountOpenWisps(ctx, db)
+		db.Close()
+		if err != nil {
+			return 0, false
+		}
+		total += n
+	}
+	return total, true
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/daemon"
+)
+
+func TestDaemonPatrolStatuses(t *testing.T) {
+	config := &daemon.DaemonPatrolConfig{
+		Patrols: &daemon.PatrolsConfig{
+			WispReaper: &daemon.WispReaperConfig{Enabled: true, IntervalStr: "30m"},
+		},
+	}
+	lastRun := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	health := map[string]daemon.PatrolHealth{
+		"wisp_reaper": {LastRun: lastRun, LastDurationMS: 1500, LastError: "scan: no databases found", ConsecutiveFailures: 3},
+	}
+
+	statuses := daemonPatrolStatuses(config, health)
+	if len(statuses) != len(daemon.PatrolRunNames()) {
+		t.Fatalf("got %d patrols, want one per patrol (%d)", len(statuses), len(daemon.PatrolRunNames()))
+	}
+	byName := make(map[string]daemonPatrolStatus)
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+
+	reaper := byName["wisp_reaper"]
+	if !reaper.Enabled || reaper.IntervalSeconds != 1800 || reaper.LastRun == nil || !reaper.LastRun.Equal(lastRun) ||
+		reaper.LastDurationMS != 1500 || reaper.ConsecutiveFailures != 3 || reaper.LastError == "" {
+		t.Errorf("wisp_reaper status = %+v", reaper)
+	}
+
+	backup := byName["dolt_backup"]
+	if backup.Enabled || backup.LastRun != nil {
+		t.Errorf("unconfigured dolt_backup should be disabled and never run: %+v", backup)
+	}
+	data, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"last_run":null`) {
+		t.Errorf("never-run patrol should report last_run null: %s", data)
+	}
+}
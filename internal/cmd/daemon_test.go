@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -41,3 +42,24 @@ func TestReadDaemonStartupFailure_MissingPIDReturnsEmpty(t *testing.T) {
 		t.Fatalf("readDaemonStartupFailure() = %q, want empty string", got)
 	}
 }
+
+func TestLastPatrolLogLines(t *testing.T) {
+	logData := "" +
+		"2026/03/28 22:00:00 wisp_reaper: gt: reaped 1\n" +
+		"2026/03/28 22:00:01 dolt_backup: synced hq (mentions wisp_reaper: in passing)\n" +
+		"2026/03/28 22:00:02 wisp_reaper: gt: reaped 2\n" +
+		"2026/03/28 22:00:03 wisp_reaper_extra: not this patrol\n" +
+		"2026/03/28 22:00:04 wisp_reaper: gt: reaped 3\n"
+
+	got, err := lastPatrolLogLines(strings.NewReader(logData), "wisp_reaper", 2)
+	if err != nil {
+		t.Fatalf("lastPatrolLogLines: %v", err)
+	}
+	want := []string{
+		"2026/03/28 22:00:02 wisp_reaper: gt: reaped 2",
+		"2026/03/28 22:00:04 wisp_reaper: gt: reaped 3",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("lastPatrolLogLines() = %q, want %q", got, want)
+	}
+}
@@ -45,6 +45,7 @@ Town root protection:
 Infrastructure checks:
   - stale-binary             Check if gt binary is up to date with repo
   - beads-binary             Check that beads (bd) is installed and meets minimum version
+  - tmux-binary              Check that tmux is installed
   - daemon                   Check if daemon is running (fixable)
   - boot-health              Check Boot watchdog health (vet mode)
   - town-beads-config        Verify town .beads/config.yaml exists (fixable)
@@ -101,11 +102,14 @@ Session hook checks:
   - claude-settings          Check Claude settings.json match templates (fixable)
   - deprecated-merge-queue-keys  Detect stale deprecated keys in merge_queue config (fixable)
   - stale-task-dispatch      Detect stale task-dispatch guard in settings.json (fixable)
+  - accounts-config          Check accounts.json is valid and ~/.claude resolves
+  - commands-symlinks        Detect broken commands/skills symlinks in Claude config dirs (fixable)
 
 Dolt checks:
   - dolt-binary              Check that dolt is installed and meets minimum version
   - dolt-metadata            Check dolt metadata tables exist
   - dolt-server-reachable    Check dolt sql-server is reachable
+  - stale-dolt-server-pid    Detect dolt-server.pid files that do not name the running server (fixable)
   - dolt-orphaned-databases  Detect orphaned dolt databases
 
 Patrol checks:
@@ -167,12 +171,17 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// 1. gt binary freshness
 	// 2. bd binary exists
 	// 3. dolt binary exists
-	// 4. Dolt server is reachable (everything downstream depends on this)
+	// 4. No stale dolt-server.pid misleads bd about which server is running
+	// 5. Dolt server is reachable (everything downstream depends on this)
 	d.Register(doctor.NewStaleBinaryCheck())
 	d.Register(doctor.NewBeadsBinaryCheck())
 	d.Register(doctor.NewDoltBinaryCheck())
+	d.Register(doctor.NewTmuxBinaryCheck())
 	d.Register(doctor.NewClaudeBinaryCheck())
+	d.Register(doctor.NewAccountsConfigCheck())
+	d.Register(doctor.NewSharedCommandsSymlinkCheck())
 	d.Register(doctor.NewGroqCompoundCheck())
+	d.Register(doctor.NewStaleDoltServerPIDCheck())
 	d.Register(doctor.NewDoltServerReachableCheck())
 
 	d.Register(doctor.NewTownGitCheck())
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	doltBackupVerifyJSON    bool
+	doltBackupVerifyTimeout time.Duration
+	doltBackupSyncForce     bool
+	doltBackupOffsiteOnly   bool
+	doltBackupSyncDryRun    bool
+	doltBackupSyncSinceLast bool
+	doltBackupRemotesJSON   bool
+)
+
+var doltBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Inspect Dolt database backups",
+	RunE:  requireSubcommand,
+}
+
+var doltBackupVerifyCmd = &cobra.Command{
+	Use:   "verify <db>",
+	Short: "Verify a database's backup by restoring it to a temp dir",
+	Long: `Verify that a database's <db>-backup backup is restorable and sane.
+
+The backup is restored into a temporary directory and checked:
+  - restore:    dolt backup restore succeeds
+  - select-1:   SELECT 1 answers from the restored copy
+  - row-counts: the copy has at least one table and at least one row
+
+The temporary copy is removed afterwards; the live database is not touched.
+This is the same check the daemon's dolt_backup patrol runs after each sync.
+
+Exits non-zero if any check fails.
+
+Examples:
+  gt dolt backup verify hq
+  gt dolt backup verify gastown --json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runDoltBackupVerify,
+}
+
+var doltBackupSyncCmd = &cobra.Command{
+	Use:   "sync [db...]",
+	Short: "Sync databases to their backups now",
+	Long: `Run 'dolt backup sync <db>-backup' for each database, outside the
+daemon's dolt_backup patrol.
+
+Without arguments, every database with a <db>-backup backup is synced.
+Databases backed up within patrols.dolt_backup.min_interval (default 1m) —
+by the patrol or an earlier run — are skipped so the two do not contend on
+the same database. Use --force to sync them anyway.
+
+With --offsite-only, the local backups are left as they are and only the
+offsite half of the pipeline runs: each database's .dolt-backup copy is
+rsynced to iCloud Drive (macOS). Use it when local backups are current but
+the offsite mirror fell behind, e.g. while iCloud was offline.
+
+With --since-last, each database's HEAD is compared with the commit it was
+at when last backed up, and the number of new commits and the tables they
+changed are reported before it syncs. A database with no new commits is
+flagged, as its live copy is not advancing.
+
+With --dry-run, nothing is synced: each database is listed with the backup
+it would sync to, that backup's URL and the offsite path its copy would
+replicate to, after checking that the data dir exists and the offsite target
+resolves. Use it to check a new host before enabling the dolt_backup patrol.
+
+Examples:
+  gt dolt backup sync
+  gt dolt backup sync hq --force
+  gt dolt backup sync --offsite-only
+  gt dolt backup sync --since-last
+  gt dolt backup sync --dry-run`,
+	SilenceUsage: true,
+	RunE:         runDoltBackupSync,
+}
+
+var doltBackupRemotesCmd = &cobra.Command{
+	Use:   "remotes",
+	Short: "List the backups configured for each database",
+	Long: `List the backup remotes configured for each database in the Dolt data
+dir, as reported by 'dolt backup -v'.
+
+The dolt_backup patrol only syncs a database to a backup named <db>-backup.
+Databases without one are skipped silently; they are flagged here so the
+misconfiguration can be fixed.
+
+Examples:
+  gt dolt backup remotes
+  gt dolt backup remotes --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoltBackupRemotes,
+}
+
+func init() {
+	doltBackupSyncCmd.Flags().BoolVar(&doltBackupSyncForce, "force", false, "Sync even if recently backed up")
+	doltBackupSyncCmd.Flags().BoolVar(&doltBackupOffsiteOnly, "offsite-only", false, "Skip dolt backup sync; only replicate existing local backups offsite")
+	doltBackupSyncCmd.Flags().BoolVarP(&doltBackupSyncDryRun, "dry-run", "n", false, "Show what would sync and to where without syncing")
+	doltBackupSyncCmd.Flags().BoolVar(&doltBackupSyncSinceLast, "since-last", false, "Report new commits and changed tables since each database's last backup")
+	doltBackupCmd.AddCommand(doltBackupSyncCmd)
+
+	doltBackupVerifyCmd.Flags().BoolVar(&doltBackupVerifyJSON, "json", false, "Output as JSON")
+	doltBackupVerifyCmd.Flags().DurationVar(&doltBackupVerifyTimeout, "timeout", 10*time.Minute, "Give up after this long")
+
+	doltBackupCmd.AddCommand(doltBackupVerifyCmd)
+
+	doltBackupRemotesCmd.Flags().BoolVar(&doltBackupRemotesJSON, "json", false, "Output as JSON")
+	doltBackupCmd.AddCommand(doltBackupRemotesCmd)
+	doltCmd.AddCommand(doltBackupCmd)
+}
+
+func runDoltBackupVerify(cmd *cobra.Command, args []string) error {
+	db := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	config := doltserver.DefaultConfig(townRoot)
+
+	ctx, cancel := context.WithTimeout(context.Background(), doltBackupVerifyTimeout)
+	defer cancel()
+
+	result, err := doltserver.VerifyBackup(ctx, config.DataDir, db)
+	if err != nil {
+		return err
+	}
+
+	if doltBackupVerifyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		printBackupVerifyResult(result)
+	}
+
+	if !result.Passed() {
+		return fmt.Errorf("backup %s failed verification", result.BackupName)
+	}
+	return nil
+}
+
+func runDoltBackupSync(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if doltBackupOffsiteOnly {
+		return runDoltBackupOffsite(townRoot, args)
+	}
+	config := doltserver.DefaultConfig(townRoot)
+	if doltBackupSyncDryRun {
+		return runDoltBackupDryRun(townRoot, config.DataDir, args)
+	}
+
+	databases := args
+	if len(databases) == 0 {
+		all, err := doltserver.ListDatabases(townRoot)
+		if err != nil {
+			return fmt.Errorf("listing databases: %w", err)
+		}
+		for _, db := range all {
+			if maintainHasBackup(config.DataDir, db) {
+				databases = append(databases, db)
+			}
+		}
+		if len(databases) == 0 {
+			fmt.Println("No databases with a <db>-backup backup configured.")
+			return nil
+		}
+	}
+
+	minInterval := daemon.DoltBackupMinInterval(daemon.LoadPatrolConfig(townRoot))
+	var lastCommits map[string]string
+	if state, err := doltserver.LoadBackupState(townRoot); err == nil {
+		lastCommits = state.LastCommit
+	}
+	var failed int
+	for _, db := range databases {
+		if !doltBackupSyncForce {
+			if age, recent := doltserver.RecentBackupAge(townRoot, db, minInterval, time.Now()); recent {
+				fmt.Printf("  %s %s: backed up %d seconds ago, skipping\n", style.Dim.Render("○"), db, int(age.Seconds()))
+				continue
+			}
+		}
+		// Read HEAD before syncing: the backup holds at least this commit,
+		// which becomes the baseline for the next --since-last report.
+		head := doltBackupSyncReport(config.DataDir, db, lastCommits[db])
+		if err := maintainBackupSync(config.DataDir, db, db+"-backup"); err != nil {
+			fmt.Printf("  %s %s: backup failed: %v\n", style.Warning.Render("!"), db, err)
+			failed++
+			continue
+		}
+		_ = doltserver.RecordBackupSuccess(townRoot, db, time.Now())
+		if head != "" {
+			_ = doltserver.RecordBackupCommit(townRoot, db, head)
+		}
+		fmt.Printf("  %s %s backed up\n", style.Bold.Render("✓"), db)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d database(s) failed to back up", failed)
+	}
+	return nil
+}
+
+// doltBackupSyncReport returns db's HEAD commit and, with --since-last,
+// prints what changed since lastCommit. Failing to read HEAD never blocks the
+// sync; it only leaves the next report without a baseline.
+func doltBackupSyncReport(dataDir, db, lastCommit string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if !doltBackupSyncSinceLast {
+		head, _ := doltserver.BackupHead(ctx, dataDir, db)
+		return head
+	}
+	delta, err := doltserver.ComputeBackupDelta(ctx, dataDir, db, lastCommit)
+	if err != nil {
+		fmt.Printf("  %s %s: cannot compute delta: %v\n", style.Warning.Render("!"), db, err)
+		return ""
+	}
+	marker := style.Dim.Render("Δ")
+	if !delta.FirstBackup && delta.NewCommits == 0 {
+		marker = style.Warning.Render("!")
+	}
+	fmt.Printf("  %s %s: %s\n", marker, db, delta.Summary())
+	return delta.HeadCommit
+}
+
+func runDoltBackupRemotes(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	config := doltserver.DefaultConfig(townRoot)
+	databases, err := daemon.ListDoltBackupRemotes(config.DataDir)
+	if err != nil {
+		return fmt.Errorf("listing databases in %s: %w", config.DataDir, err)
+	}
+
+	if doltBackupRemotesJSON {
+		if databases == nil {
+			databases = []daemon.DoltBackupRemotes{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(databases)
+	}
+
+	if len(databases) == 0 {
+		fmt.Printf("No databases in %s.\n", config.DataDir)
+		return nil
+	}
+	missing := 0
+	for _, r := range databases {
+		switch {
+		case r.Error != "":
+			fmt.Printf("  %s %s: %s\n", style.Warning.Render("!"), r.Database, r.Error)
+		case r.Missing:
+			missing++
+			fmt.Printf("  %s %s: no %s backup, not backed up by the patrol\n", style.Warning.Render("!"), r.Database, r.Expected)
+		default:
+			fmt.Printf("  %s %s\n", style.Bold.Render("✓"), r.Database)
+		}
+		names := make([]string, 0, len(r.Remotes))
+		for name := range r.Remotes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("      %s %s\n", name, style.Dim.Render(r.Remotes[name]))
+		}
+	}
+	if missing > 0 {
+		fmt.Printf("\n%d database(s) without a <db>-backup backup. Add one with:\n  dolt backup add <db>-backup <url>   (in the database dir)\n", missing)
+	}
+	return nil
+}
+
+// runDoltBackupDryRun prints what a sync would do without syncing.
+func runDoltBackupDryRun(townRoot, dataDir string, databases []string) error {
+	minInterval := daemon.DoltBackupMinInterval(daemon.LoadPatrolConfig(townRoot))
+	if doltBackupSyncForce {
+		minInterval = 0
+	}
+	plan := daemon.PlanDoltBackups(townRoot, dataDir, databases, minInterval, time.Now())
+
+	fmt.Printf("%s\n", style.Bold.Render("Dolt backup dry run (nothing will be synced)"))
+	if !plan.DataDirExists {
+		return fmt.Errorf("data dir %s does not exist", plan.DataDir)
+	}
+	fmt.Printf("  Data dir: %s\n", plan.DataDir)
+	if plan.OffsiteProblem != "" {
+		fmt.Printf("  Offsite:  %s\n", style.Warning.Render(plan.OffsiteProblem))
+	} else {
+		fmt.Printf("  Offsite:  %s\n", plan.OffsiteDir)
+	}
+	fmt.Println()
+
+	if len(plan.Databases) == 0 {
+		fmt.Println("No databases with a <db>-backup backup configured.")
+		return nil
+	}
+	for _, e := range plan.Databases {
+		if e.Skip != "" {
+			fmt.Printf("  %s %s: %s, would skip\n", style.Dim.Render("○"), e.Database, e.Skip)
+			continue
+		}
+		fmt.Printf("  %s %s → %s %s\n", style.Bold.Render("→"), e.Database, e.Backup, style.Dim.Render(e.Remotes[e.Backup]))
+		if e.Offsite != "" {
+			fmt.Printf("      offsite: %s\n", e.Offsite)
+		}
+	}
+	return nil
+}
+
+// runDoltBackupOffsite replicates the existing local backups offsite without
+// syncing them first.
+func runDoltBackupOffsite(townRoot string, databases []string) error {
+	if runtime.GOOS != "darwin" {
+		return fmt.Errorf("offsite backup replicates to iCloud Drive and is only available on macOS")
+	}
+	if len(databases) == 0 {
+		databases = nil
+	}
+	result, err := daemon.SyncOffsiteBackup(townRoot, databases, func(string, ...interface{}) {})
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No local backups to replicate offsite.")
+		return nil
+	}
+	for _, db := range result.Replicated {
+		fmt.Printf("  %s %s replicated\n", style.Bold.Render("✓"), db)
+	}
+	for _, failure := range result.Failed {
+		fmt.Printf("  %s %s\n", style.Warning.Render("!"), failure)
+	}
+	fmt.Printf("%s %s\n", style.Dim.Render(result.Dir+":"), result.Summary())
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d database(s) failed to replicate offsite", len(result.Failed))
+	}
+	return nil
+}
+
+func printBackupVerifyResult(result *doltserver.BackupVerifyResult) {
+	fmt.Printf("%s %s\n", style.Bold.Render(result.BackupName), style.Dim.Render(result.URL))
+	for _, c := range result.Checks {
+		mark := style.Bold.Render("✓")
+		if !c.OK {
+			mark = style.Error.Render("✗")
+		}
+		if c.Detail != "" {
+			fmt.Printf("  %s %s: %s\n", mark, c.Name, c.Detail)
+		} else {
+			fmt.Printf("  %s %s\n", mark, c.Name)
+		}
+	}
+
+	tables := make([]string, 0, len(result.TableRows))
+	for table := range result.TableRows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		fmt.Printf("    %s %d\n", style.Dim.Render(table+":"), result.TableRows[table])
+	}
+
+	if result.Passed() {
+		fmt.Printf("%s Backup verified\n", style.Bold.Render("✓"))
+	} else {
+		fmt.Printf("%s Backup verification FAILED\n", style.Error.Render("✗"))
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var doltDatabasesJSON bool
+
+var doltDatabasesCmd = &cobra.Command{
+	Use:   "databases",
+	Short: "List every database on the Dolt server with basic health",
+	Long: `Inventory the databases the daemon operates on.
+
+Queries the Dolt server for all non-system databases (the same discovery the
+wisp reaper uses) and reports, per database:
+  - whether the issues and wisps tables exist
+  - open (open, hooked, in_progress), closed and total rows in each
+  - whether the <db>-backup backup the dolt_backup patrol syncs is configured
+
+Backups can only be checked on a local server; on a remote server they are
+reported as unknown. Table and column names follow the reaper's schema
+mapping in daemon.json.
+
+  gt dolt databases
+  gt dolt databases --json`,
+	RunE: runDoltDatabases,
+}
+
+func init() {
+	doltDatabasesCmd.Flags().BoolVar(&doltDatabasesJSON, "json", false, "Output as JSON")
+	doltCmd.AddCommand(doltDatabasesCmd)
+}
+
+// Backup states reported by `gt dolt databases`.
+const (
+	doltDatabaseBackupConfigured = "configured"
+	doltDatabaseBackupMissing    = "missing"
+	doltDatabaseBackupUnknown    = "unknown"
+)
+
+// doltDatabaseReport is one row of `gt dolt databases`.
+type doltDatabaseReport struct {
+	Database string                `json:"database"`
+	Issues   reaper.TableInventory `json:"issues"`
+	Wisps    reaper.TableInventory `json:"wisps"`
+	Backup   string                `json:"backup"`
+	Error    string                `json:"error,omitempty"`
+}
+
+func runDoltDatabases(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	ctx, err := reaperContext()
+	if err != nil {
+		return err
+	}
+
+	config := doltserver.DefaultConfig(townRoot)
+	host := config.EffectiveHost()
+	databases, err := reaper.ListServerDatabases(ctx, host, config.Port)
+	if err != nil {
+		return fmt.Errorf("listing databases on %s: %w", config.HostPort(), err)
+	}
+
+	backups := map[string]string{}
+	if !config.IsRemote() {
+		remotes, err := daemon.ListDoltBackupRemotes(config.DataDir)
+		if err == nil {
+			for _, r := range remotes {
+				switch {
+				case r.Error != "":
+				case r.Missing:
+					backups[r.Database] = doltDatabaseBackupMissing
+				default:
+					backups[r.Database] = doltDatabaseBackupConfigured
+				}
+			}
+		}
+	}
+
+	reports := make([]doltDatabaseReport, 0, len(databases))
+	for _, name := range databases {
+		report := doltDatabaseReport{Database: name, Backup: doltDatabaseBackupUnknown}
+		if state, ok := backups[name]; ok {
+			report.Backup = state
+		}
+		if inv, err := inspectDoltDatabase(ctx, host, config.Port, name); err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Issues, report.Wisps = inv.Issues, inv.Wisps
+		}
+		reports = append(reports, report)
+	}
+
+	if doltDatabasesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+	printDoltDatabases(config.HostPort(), reports)
+	return nil
+}
+
+func inspectDoltDatabase(ctx context.Context, host string, port int, name string) (*reaper.DatabaseInventory, error) {
+	if err := reaper.ValidateDBName(name); err != nil {
+		return nil, err
+	}
+	db, err := reaper.OpenDB(ctx, host, port, name, 30*time.Second, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return reaper.InspectDatabase(ctx, db)
+}
+
+// formatTableInventory renders one table's counts for the text view.
+func formatTableInventory(inv reaper.TableInventory) string {
+	if !inv.Present {
+		return style.Dim.Render("no table")
+	}
+	return fmt.Sprintf("%d open, %d closed, %d total", inv.Open, inv.Closed, inv.Total)
+}
+
+func printDoltDatabases(server string, reports []doltDatabaseReport) {
+	if len(reports) == 0 {
+		fmt.Printf("No databases on %s.\n", server)
+		return
+	}
+	fmt.Printf("%s\n\n", style.Bold.Render(fmt.Sprintf("Dolt databases on %s", server)))
+	problems := 0
+	for _, r := range reports {
+		ok := r.Error == "" && r.Issues.Present && r.Wisps.Present && r.Backup != doltDatabaseBackupMissing
+		mark := style.Bold.Render("✓")
+		if !ok {
+			mark = style.Warning.Render("!")
+			problems++
+		}
+		fmt.Printf("  %s %s\n", mark, r.Database)
+		if r.Error != "" {
+			fmt.Printf("      error:  %s\n", r.Error)
+			continue
+		}
+		fmt.Printf("      issues: %s\n", formatTableInventory(r.Issues))
+		fmt.Printf("      wisps:  %s\n", formatTableInventory(r.Wisps))
+		backup := r.Backup
+		if backup == doltDatabaseBackupMissing {
+			backup = style.Warning.Render(fmt.Sprintf("missing (no %s-backup)", r.Database))
+		}
+		fmt.Printf("      backup: %s\n", backup)
+	}
+	fmt.Printf("\n%d database(s), %d need attention\n", len(reports), problems)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var doltMaintenanceReason string
+
+var doltMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Pause the wisp reaper on databases under maintenance",
+	Long: `Mark databases as under maintenance so the wisp reaper leaves them alone.
+
+While a database is marked, the daemon's wisp_reaper patrol and the 'gt
+reaper' commands skip it and log the skip, so a schema migration is not
+interrupted by reaper UPDATEs and DELETEs. Migration tooling sets the marker
+before it starts and clears it when done.
+
+Without a subcommand, lists the databases currently under maintenance.
+
+Examples:
+  gt dolt maintenance on hq --reason "v3 schema migration"
+  gt dolt maintenance off hq
+  gt dolt maintenance`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoltMaintenanceList,
+}
+
+var doltMaintenanceOnCmd = &cobra.Command{
+	Use:          "on <db>",
+	Short:        "Mark a database as under maintenance",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runDoltMaintenanceOn,
+}
+
+var doltMaintenanceOffCmd = &cobra.Command{
+	Use:          "off <db>",
+	Short:        "Clear a database's maintenance marker",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runDoltMaintenanceOff,
+}
+
+func init() {
+	doltMaintenanceOnCmd.Flags().StringVar(&doltMaintenanceReason, "reason", "", "Why the database is under maintenance")
+
+	doltMaintenanceCmd.AddCommand(doltMaintenanceOnCmd)
+	doltMaintenanceCmd.AddCommand(doltMaintenanceOffCmd)
+	doltCmd.AddCommand(doltMaintenanceCmd)
+}
+
+func runDoltMaintenanceOn(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	marker := &doltserver.MaintenanceMarker{
+		Database: args[0],
+		Reason:   doltMaintenanceReason,
+		SetBy:    detectActor(),
+		SetAt:    time.Now().UTC(),
+	}
+	if err := doltserver.SetMaintenance(townRoot, marker); err != nil {
+		return fmt.Errorf("setting maintenance marker: %w", err)
+	}
+	fmt.Printf("%s %s is under maintenance; the wisp reaper will skip it\n", style.Bold.Render("⏸"), marker.Database)
+	return nil
+}
+
+func runDoltMaintenanceOff(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cleared, err := doltserver.ClearMaintenance(townRoot, args[0])
+	if err != nil {
+		return fmt.Errorf("clearing maintenance marker: %w", err)
+	}
+	if !cleared {
+		fmt.Printf("%s %s was not under maintenance\n", style.Dim.Render("○"), args[0])
+		return nil
+	}
+	fmt.Printf("%s %s is back in service; the wisp reaper resumes on its next cycle\n", style.Bold.Render("▶"), args[0])
+	return nil
+}
+
+func runDoltMaintenanceList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	markers, err := doltserver.ListMaintenance(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing maintenance markers: %w", err)
+	}
+	if len(markers) == 0 {
+		fmt.Println("No databases under maintenance.")
+		return nil
+	}
+	for _, m := range markers {
+		line := fmt.Sprintf("  %s %s", style.Bold.Render("⏸"), m.Database)
+		if !m.SetAt.IsZero() {
+			line += style.Dim.Render(fmt.Sprintf(" since %s", m.SetAt.Local().Format("2006-01-02 15:04")))
+		}
+		if m.SetBy != "" {
+			line += style.Dim.Render(" by " + m.SetBy)
+		}
+		if m.Reason != "" {
+			line += ": " + m.Reason
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
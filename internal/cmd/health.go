@@ -13,8 +13,10 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/health"
+	"github.com/steveyegge/gastown/internal/reaper"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -32,6 +34,7 @@ type HealthReport struct {
 	Backups   *BackupHealth       `json:"backups"`
 	Processes *ProcessHealth      `json:"processes"`
 	Orphans   []OrphanDB          `json:"orphans,omitempty"`
+	Config    []daemon.PatrolConfigIssue `json:"config_issues,omitempty"`
 }
 
 type ServerHealth struct {
@@ -45,6 +48,7 @@ type ServerHealth struct {
 	DiskUsageHuman     string  `json:"disk_usage_human,omitempty"`
 	LastCommitAgeSec   float64 `json:"last_commit_age_seconds,omitempty"`
 	LastCommitDB       string  `json:"last_commit_db,omitempty"`
+	ClockSkewSec       float64 `json:"clock_skew_seconds,omitempty"`
 }
 
 type DatabaseHealth struct {
@@ -94,6 +98,7 @@ Sections:
   4. Backups: Dolt filesystem and JSONL git freshness
   5. Processes: zombie dolt servers
   6. Orphan DBs: databases not referenced by any rig
+  7. Patrol Config: unparseable or non-positive durations in mayor/daemon.json
 
 Use --json for machine-readable output.`,
 	RunE: runHealth,
@@ -136,6 +141,9 @@ func runHealth(cmd *cobra.Command, args []string) error {
 	// 6. Orphans
 	report.Orphans = checkOrphanDBs(townRoot)
 
+	// 7. Patrol config
+	report.Config = checkPatrolConfig(townRoot)
+
 	if healthJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -173,6 +181,7 @@ func checkServerHealth(townRoot string) *ServerHealth {
 		sh.LastCommitAgeSec = metrics.LastCommitAge.Seconds()
 		sh.LastCommitDB = metrics.LastCommitDB
 	}
+	sh.ClockSkewSec = metrics.ClockSkew.Seconds()
 
 	return sh
 }
@@ -343,6 +352,12 @@ func checkOrphanDBs(townRoot string) []OrphanDB {
 	return results
 }
 
+// checkPatrolConfig reports daemon.json durations that the daemon would
+// silently replace with defaults.
+func checkPatrolConfig(townRoot string) []daemon.PatrolConfigIssue {
+	return daemon.PatrolConfigDurationIssues(daemon.LoadPatrolConfig(townRoot))
+}
+
 func printHealthReport(r *HealthReport) {
 	// 1. Server
 	fmt.Printf("\n%s Dolt Server\n", style.Bold.Render("●"))
@@ -356,6 +371,10 @@ func printHealthReport(r *HealthReport) {
 		if r.Server.DiskUsageHuman != "" {
 			fmt.Printf("  Disk: %s\n", r.Server.DiskUsageHuman)
 		}
+		if skew := time.Duration(r.Server.ClockSkewSec * float64(time.Second)); reaper.ClockSkewExceeded(skew) {
+			fmt.Printf("  %s Clock skew: server is %v off from this host — age-based reaping is skewed\n",
+				style.Bold.Render("!"), skew.Round(time.Second))
+		}
 	} else {
 		fmt.Printf("  Status: %s\n", style.Dim.Render("not running"))
 	}
@@ -421,5 +440,15 @@ func printHealthReport(r *HealthReport) {
 		}
 	}
 
+	// 7. Patrol config
+	fmt.Printf("\n%s Patrol Config\n", style.Bold.Render("●"))
+	if len(r.Config) == 0 {
+		fmt.Printf("  %s No issues\n", style.Bold.Render("✓"))
+	} else {
+		for _, issue := range r.Config {
+			fmt.Printf("  %s %s: %q %s\n", style.Bold.Render("!"), issue.Field, issue.Value, issue.Message)
+		}
+	}
+
 	fmt.Println()
 }
@@ -13,6 +13,7 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -159,14 +160,22 @@ func runMaintain(cmd *cobra.Command, args []string) error {
 	// Phase 2: Backup.
 	if backupCount > 0 {
 		fmt.Printf("\n%s Backing up databases...\n", style.Bold.Render("●"))
+		backupMinInterval := daemon.DoltBackupMinInterval(daemon.LoadPatrolConfig(townRoot))
 		for _, db := range dbInfos {
 			if !db.hasBackup {
 				continue
 			}
+			// A backup within patrols.dolt_backup.min_interval is fresh
+			// enough; skip rather than contend with the dolt_backup patrol.
+			if age, recent := doltserver.RecentBackupAge(townRoot, db.name, backupMinInterval, time.Now()); recent {
+				fmt.Printf("  %s %s backed up %d seconds ago, skipping\n", style.Dim.Render("○"), db.name, int(age.Seconds()))
+				continue
+			}
 			backupName := db.name + "-backup"
 			if err := maintainBackupSync(config.DataDir, db.name, backupName); err != nil {
 				fmt.Printf("  %s %s: backup failed: %v\n", style.Warning.Render("!"), db.name, err)
 			} else {
+				_ = doltserver.RecordBackupSuccess(townRoot, db.name, time.Now())
 				fmt.Printf("  %s %s backed up\n", style.Bold.Render("✓"), db.name)
 			}
 		}
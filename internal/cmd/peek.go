@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/session"
@@ -12,15 +13,40 @@ import (
 )
 
 // Peek command flags
-var peekLines int
+var (
+	peekLines       int
+	peekDiffFlag    bool
+	peekWaitFlag    bool
+	peekWaitTimeout time.Duration
+	peekFormat      string
+	peekRig         string
+	peekCrew        bool
+	peekSave        string
+	peekAppend      bool
+	peekPane        int
+	peekAllPanes    bool
+)
 
 func init() {
 	rootCmd.AddCommand(peekCmd)
 	peekCmd.Flags().IntVarP(&peekLines, "lines", "n", 100, "Number of lines to capture")
+	peekCmd.Flags().BoolVar(&peekDiffFlag, "diff", false, "Show only output added since the last --diff peek of this session")
+	peekCmd.Flags().BoolVar(&peekWaitFlag, "wait", false, "Block until the session prints new output or exits")
+	peekCmd.Flags().DurationVar(&peekWaitTimeout, "timeout", 0, "With --wait, give up after this long (0 = wait indefinitely)")
+	peekCmd.Flags().StringVar(&peekFormat, "format", "", "Prefix template for each line: {rig} {polecat} {session} {ts} {line} (default: raw output)")
+	peekCmd.Flags().StringVar(&peekRig, "rig", "", "With --crew, the rig whose crew sessions to capture")
+	peekCmd.Flags().BoolVar(&peekCrew, "crew", false, "Capture every crew session in --rig, each under a header")
+	peekCmd.Flags().StringVar(&peekSave, "save", "", "Write the capture to this file instead of printing it (with --crew, a directory)")
+	peekCmd.Flags().BoolVar(&peekAppend, "append", false, "With --save, append the capture under a timestamped header")
+	peekCmd.Flags().IntVar(&peekPane, "pane", -1, "Capture this pane index of the session's current window (default: the active pane)")
+	peekCmd.Flags().BoolVar(&peekAllPanes, "all-panes", false, "Capture every pane of the session's current window, each under a header")
+	peekCmd.MarkFlagsMutuallyExclusive("diff", "wait")
+	peekCmd.MarkFlagsMutuallyExclusive("pane", "all-panes")
+	peekCmd.MarkFlagsRequiredTogether("rig", "crew")
 }
 
 var peekCmd = &cobra.Command{
-	Use:     "peek <rig/polecat> [count]",
+	Use:     "peek <rig/polecat> [count] | --rig <rig> --crew [count]",
 	GroupID: GroupComm,
 	Short:   "View recent output from a polecat or crew session",
 	Long: `Capture and display recent terminal output from an agent session.
@@ -36,6 +62,44 @@ Supports polecats, crew workers, and town-level agents:
   - Polecats: rig/name format (e.g., greenplace/furiosa)
   - Crew: rig/crew/name format (e.g., beads/crew/dave)
   - Town-level: mayor, deacon, boot (or hq/mayor, hq/deacon, hq/boot)
+  - Town-level crew: hq/crew/name (e.g., hq/crew/max)
+  - Dogs (deacon's workers): hq/dog/name (e.g., hq/dog/alpha)
+
+With --diff, only lines added since the previous 'gt peek --diff' of the same
+address are shown. The position is kept under <town>/.peek-state. If the old
+position has scrolled out of the captured lines, the full capture is shown
+with a "buffer rolled" notice.
+
+With --wait, peek takes a capture and then polls the session until something
+happens, for scripts that orchestrate around agent activity:
+  - new output appears: the new lines are printed (exit 0)
+  - the session goes away: "session ended" is reported (exit 1)
+  - --timeout elapses first: a timeout is reported (exit 2)
+
+With --format, each printed line is rewritten from a template so output from
+several peeks can be interleaved and still attributed and grepped.
+Placeholders: {rig}, {polecat} (the agent part of the address), {session}
+(tmux session), {ts} (capture time, RFC 3339 UTC) and {line}. A template
+without {line} is used as a prefix. Town-level agents report rig "hq".
+
+With --rig <rig> --crew, every running crew session in the rig is captured
+in turn under a "=== rig/crew/name (session) ===" header, for a quick read of
+a rig's whole crew during triage. --format applies to each capture; --diff and
+--wait are single-session only.
+
+With --save <file>, the capture is written to the file (parent directories
+are created) instead of being printed, for attaching agent output to a bug
+report or bead. --append adds each capture under a "=== address (session)
+time ===" header instead of overwriting, to accumulate successive captures.
+With --crew, --save names a directory and each session is written to its own
+file, e.g. <dir>/beads__crew__dave.txt. --save does not combine with --diff
+or --wait.
+
+By default the session's active pane is captured. When a session has several
+panes (e.g. the agent plus a log tail), --pane <n> captures pane n of the
+current window instead (see 'tmux display -p "#{pane_index}"'), and
+--all-panes captures every pane under a "--- pane N (command) ---" header.
+Neither combines with --diff, --wait or --crew.
 
 Examples:
   gt peek greenplace/furiosa         # Polecat: last 100 lines (default)
@@ -43,12 +107,46 @@ Examples:
   gt peek beads/crew/dave            # Crew: last 100 lines
   gt peek beads/crew/dave -n 200     # Crew: last 200 lines
   gt peek mayor                      # Mayor: last 100 lines
-  gt peek deacon -n 50               # Deacon: last 50 lines`,
-	Args: cobra.RangeArgs(1, 2),
+  gt peek deacon -n 50               # Deacon: last 50 lines
+  gt peek hq/crew/max                # Town-level crew: last 100 lines
+  gt peek greenplace/furiosa --diff  # Only what's new since the last --diff
+  gt peek greenplace/furiosa --wait --timeout 5m   # Block until furiosa prints something
+  gt peek greenplace/furiosa --format "[{rig}/{polecat} {ts}] {line}"
+  gt peek --rig beads --crew -n 30   # Every crew worker in beads: last 30 lines
+  gt peek greenplace/furiosa --save triage/furiosa.txt
+  gt peek greenplace/furiosa --save furiosa.log --append
+  gt peek --rig beads --crew --save triage/beads-crew
+  gt peek greenplace/furiosa --pane 1          # Second pane (e.g. a log tail)
+  gt peek greenplace/furiosa --all-panes -n 20`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: runPeek,
 }
 
 func runPeek(cmd *cobra.Command, args []string) error {
+	if err := checkPeekSaveFlags(); err != nil {
+		return err
+	}
+	if err := checkPeekPaneFlags(); err != nil {
+		return err
+	}
+	if peekCrew {
+		// All-crew mode takes only the optional count positionally.
+		lines := peekLines
+		if len(args) > 1 {
+			return fmt.Errorf("--rig --crew takes at most a line count argument")
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid line count: %s", args[0])
+			}
+			lines = n
+		}
+		return runPeekRigCrew(peekRig, lines)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires an address: gt peek <rig>/<polecat> (or --rig <rig> --crew)")
+	}
 	address := args[0]
 
 	// Handle optional positional count argument
@@ -61,28 +159,31 @@ func runPeek(cmd *cobra.Command, args []string) error {
 		lines = n
 	}
 
-	// Handle town-level agents: mayor, deacon, boot
-	// These use session names like "hq-mayor", "hq-deacon" but have no rig.
-	townAgentSessions := map[string]string{
-		"mayor":     "hq-mayor",
-		"hq/mayor":  "hq-mayor",
-		"deacon":    "hq-deacon",
-		"hq/deacon": "hq-deacon",
-		"boot":      "hq-boot",
-		"hq/boot":   "hq-boot",
-	}
-	if sessionName, ok := townAgentSessions[address]; ok {
+	// Handle town-level agents (mayor, deacon, boot, town crew, dogs).
+	// These use "hq-" session names but have no rig.
+	if sessionName, ok := townPeekSession(address); ok {
 		_, err := workspace.FindFromCwdOrError()
 		if err != nil {
 			return fmt.Errorf("not in a Gas Town workspace: %w", err)
 		}
 		t := tmux.NewTmux()
-		output, err := t.CapturePane(sessionName, lines)
+		var output string
+		if peekPaneMode() {
+			output, err = capturePeekPanes(t, sessionName, lines)
+		} else {
+			output, err = t.CapturePane(sessionName, lines)
+		}
 		if err != nil {
 			return fmt.Errorf("capturing %s: %w", address, err)
 		}
-		fmt.Print(output)
-		return nil
+		format := newPeekFormatter(peekFormat, address, sessionName)
+		if peekWaitFlag {
+			return runPeekWait(t, sessionName, lines, output, format)
+		}
+		if peekDiffFlag {
+			return printPeekDiff(address, output, format)
+		}
+		return emitPeek(address, output, format)
 	}
 
 	rigName, polecatName, err := parseAddress(address)
@@ -101,22 +202,74 @@ func runPeek(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var output string
+	var output, sessionName string
 
 	// Handle crew/ prefix for cross-rig crew workers
 	// e.g., "beads/crew/dave" -> session name "gt-beads-crew-dave"
 	if strings.HasPrefix(polecatName, "crew/") {
 		crewName := strings.TrimPrefix(polecatName, "crew/")
-		sessionID := session.CrewSessionName(session.PrefixFor(rigName), crewName)
-		output, err = mgr.CaptureSession(sessionID, lines)
+		sessionName = crewPeekSession(rigName, crewName)
+		output, err = mgr.CaptureSession(sessionName, lines)
 	} else {
+		sessionName = mgr.SessionName(polecatName)
 		output, err = mgr.Capture(polecatName, lines)
 	}
+	if err == nil && peekPaneMode() {
+		// The capture above confirmed the session exists (reporting a
+		// missing one the usual way); now take the requested panes.
+		output, err = capturePeekPanes(tmux.NewTmux(), sessionName, lines)
+	}
 
 	if err != nil {
 		return fmt.Errorf("capturing output: %w", err)
 	}
 
-	fmt.Print(output)
-	return nil
+	format := newPeekFormatter(peekFormat, address, sessionName)
+	if peekWaitFlag {
+		return runPeekWait(tmux.NewTmux(), sessionName, lines, output, format)
+	}
+
+	if peekDiffFlag {
+		return printPeekDiff(address, output, format)
+	}
+	return emitPeek(address, output, format)
+}
+
+// townPeekSession resolves a town-level address to its tmux session name.
+// Town agents may be addressed bare ("mayor") or under hq/ ("hq/mayor").
+// Town-level crew and dogs mirror the rig-level "<rig>/crew/<name>" form:
+// "hq/crew/<name>" and "hq/dog/<name>". Returns false for rig addresses.
+func townPeekSession(address string) (string, bool) {
+	rest := strings.TrimPrefix(address, "hq/")
+	switch rest {
+	case "mayor":
+		return session.MayorSessionName(), true
+	case "deacon":
+		return session.DeaconSessionName(), true
+	case "boot":
+		return session.BootSessionName(), true
+	}
+	if rest == address {
+		return "", false // not hq/-qualified
+	}
+	kind, name, ok := strings.Cut(rest, "/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	switch kind {
+	case "crew":
+		return crewPeekSession("hq", name), true
+	case "dog", "dogs":
+		return session.DogSessionName(name), true
+	}
+	return "", false
+}
+
+// crewPeekSession returns the session name for a crew worker. Rig crew use the
+// rig's beads prefix; town-level crew ("hq") use the hq- prefix.
+func crewPeekSession(rigName, crewName string) string {
+	if rigName == "hq" {
+		return session.CrewSessionName(strings.TrimSuffix(session.HQPrefix, "-"), crewName)
+	}
+	return session.CrewSessionName(session.PrefixFor(rigName), crewName)
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// rigCrewSessions picks the crew sessions for rigName out of a tmux session
+// list, keyed by crew name. Crew sessions share the "<prefix>-crew-" prefix
+// that crewPeekSession builds.
+func rigCrewSessions(sessions []string, rigName string) map[string]string {
+	prefix := crewPeekSession(rigName, "")
+	crew := make(map[string]string)
+	for _, s := range sessions {
+		if name := strings.TrimPrefix(s, prefix); name != s && name != "" {
+			crew[name] = s
+		}
+	}
+	return crew
+}
+
+// runPeekRigCrew captures every crew session in a rig, each under a header.
+func runPeekRigCrew(rigName string, lines int) error {
+	if peekDiffFlag || peekWaitFlag {
+		return fmt.Errorf("--diff and --wait peek a single session and cannot be combined with --crew")
+	}
+	if _, err := workspace.FindFromCwdOrError(); err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	crew := rigCrewSessions(sessions, rigName)
+	if len(crew) == 0 {
+		fmt.Printf("%s No crew sessions running in %s\n", style.Dim.Render("○"), rigName)
+		return nil
+	}
+
+	names := make([]string, 0, len(crew))
+	for name := range crew {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		if i > 0 {
+			fmt.Println()
+		}
+		address := rigName + "/crew/" + name
+		sessionName := crew[name]
+		fmt.Printf("%s\n", style.Bold.Render(fmt.Sprintf("=== %s (%s) ===", address, sessionName)))
+		output, err := t.CapturePane(sessionName, lines)
+		if err != nil {
+			fmt.Printf("%s capturing %s: %v\n", style.Warning.Render("⚠"), address, err)
+			continue
+		}
+		format := newPeekFormatter(peekFormat, address, sessionName)
+		if peekSave == "" {
+			format.print(output, time.Now())
+			continue
+		}
+		path := filepath.Join(peekSave, peekFileName(address)+".txt")
+		if err := savePeekOutput(path, address, output, format, time.Now(), peekAppend); err != nil {
+			fmt.Printf("%s %v\n", style.Warning.Render("⚠"), err)
+			continue
+		}
+		fmt.Printf("%s Saved to %s\n", style.Bold.Render("✓"), path)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// peekAnchorLines is how many trailing lines of a capture are remembered to
+// find where the previous peek left off. Several lines, so a repeated prompt
+// line alone does not match the wrong place.
+const peekAnchorLines = 5
+
+// peekCursor is what --diff remembers about the previous peek of a session.
+type peekCursor struct {
+	// Anchor holds hashes of the last peekAnchorLines lines of the previous
+	// capture, oldest first, after trailing blank lines are trimmed. Blank
+	// lines inside that window are kept. A blank capture leaves it empty, and
+	// an empty anchor is treated as no cursor at all.
+	Anchor []string `json:"anchor"`
+}
+
+// peekStatePath returns the cursor file for a session address.
+func peekStatePath(townRoot, address string) string {
+	return filepath.Join(townRoot, ".peek-state", peekFileName(address)+".json")
+}
+
+func loadPeekCursor(path string) *peekCursor {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cursor peekCursor
+	if json.Unmarshal(data, &cursor) != nil || len(cursor.Anchor) == 0 {
+		return nil
+	}
+	return &cursor
+}
+
+func savePeekCursor(path string, cursor *peekCursor) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func hashPeekLine(line string) string {
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:8])
+}
+
+// peekDiff returns the lines of output after the point the previous peek
+// ended, and the cursor to save for next time. rolled is true when there was
+// a previous cursor but its anchor is no longer in the buffer, in which case
+// the full output is returned. A nil prev or one with an empty anchor returns
+// the full output.
+func peekDiff(prev *peekCursor, output string) (diff []string, rolled bool, next *peekCursor) {
+	lines := strings.Split(strings.TrimRight(output, "\n "), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		lines = nil
+	}
+	hashes := make([]string, len(lines))
+	for i, line := range lines {
+		hashes[i] = hashPeekLine(strings.TrimRight(line, " "))
+	}
+
+	next = &peekCursor{}
+	start := len(hashes) - peekAnchorLines
+	if start < 0 {
+		start = 0
+	}
+	next.Anchor = append(next.Anchor, hashes[start:]...)
+
+	if prev == nil || len(prev.Anchor) == 0 {
+		return lines, false, next
+	}
+	// Find the latest occurrence of the previous anchor.
+	n := len(prev.Anchor)
+	for end := len(hashes); end >= n; end-- {
+		if slices.Equal(hashes[end-n:end], prev.Anchor) {
+			return lines[end:], false, next
+		}
+	}
+	return lines, true, next
+}
+
+// printPeekDiff prints only what is new in output since the last --diff peek
+// of address, then records the new cursor.
+func printPeekDiff(address, output string, format peekFormatter) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	path := peekStatePath(townRoot, address)
+
+	diff, rolled, next := peekDiff(loadPeekCursor(path), output)
+	if rolled {
+		fmt.Fprintln(os.Stderr, style.Dim.Render("(buffer rolled, showing full capture)"))
+	}
+	if len(diff) == 0 {
+		fmt.Fprintln(os.Stderr, style.Dim.Render("(no new output since last peek)"))
+	} else {
+		fmt.Println(strings.Join(format.lines(diff, time.Now()), "\n"))
+	}
+	return savePeekCursor(path, next)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// peekFormatter prefixes captured lines per --format. The zero value (no
+// template) passes lines through untouched.
+type peekFormatter struct {
+	template string
+	rig      string
+	agent    string
+	session  string
+}
+
+// newPeekFormatter builds the formatter for one peeked session. Town-level
+// addresses ("mayor", "hq/crew/max") report "hq" as their rig.
+func newPeekFormatter(template, address, sessionName string) peekFormatter {
+	rig, agent, ok := strings.Cut(address, "/")
+	if !ok {
+		rig, agent = "hq", address
+	}
+	return peekFormatter{template: template, rig: rig, agent: agent, session: sessionName}
+}
+
+// lines applies the template to each line, stamping {ts} with at.
+func (f peekFormatter) lines(lines []string, at time.Time) []string {
+	if f.template == "" {
+		return lines
+	}
+	r := strings.NewReplacer(
+		"{rig}", f.rig,
+		"{polecat}", f.agent,
+		"{session}", f.session,
+		"{ts}", at.UTC().Format(time.RFC3339),
+	)
+	// Expand everything but {line} once, then splice each line in, so text
+	// in the captured output is never itself treated as a placeholder.
+	prefix, suffix, hasLine := strings.Cut(r.Replace(f.template), "{line}")
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if hasLine {
+			out[i] = prefix + line + suffix
+		} else {
+			out[i] = prefix + line
+		}
+	}
+	return out
+}
+
+// render returns a raw capture with each line formatted when a template is
+// set.
+func (f peekFormatter) render(output string, at time.Time) string {
+	if f.template == "" {
+		return output
+	}
+	if output = strings.TrimSuffix(output, "\n"); output == "" {
+		return ""
+	}
+	return strings.Join(f.lines(strings.Split(output, "\n"), at), "\n") + "\n"
+}
+
+// print writes a raw capture, formatting each line when a template is set.
+func (f peekFormatter) print(output string, at time.Time) {
+	fmt.Print(f.render(output, at))
+}
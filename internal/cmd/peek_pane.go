@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// peekPaneCapture is one pane's output in an --all-panes peek.
+type peekPaneCapture struct {
+	pane   tmux.PaneSummary
+	output string
+	err    error
+}
+
+// checkPeekPaneFlags rejects --pane/--all-panes combinations that have no
+// single pane to follow.
+func checkPeekPaneFlags() error {
+	if peekPane < -1 {
+		return fmt.Errorf("invalid --pane %d: pane indexes start at 0", peekPane)
+	}
+	if peekPane < 0 && !peekAllPanes {
+		return nil
+	}
+	if peekCrew {
+		return fmt.Errorf("--pane and --all-panes peek one session and cannot be combined with --crew")
+	}
+	if peekDiffFlag || peekWaitFlag {
+		return fmt.Errorf("--diff and --wait follow the session's default pane and cannot be combined with --pane or --all-panes")
+	}
+	return nil
+}
+
+// peekPaneMode reports whether --pane or --all-panes picks the panes to capture.
+func peekPaneMode() bool {
+	return peekPane >= 0 || peekAllPanes
+}
+
+// capturePeekPanes captures the --pane pane of a session, or with --all-panes
+// every pane of its current window under per-pane headers.
+func capturePeekPanes(t *tmux.Tmux, sessionName string, lines int) (string, error) {
+	if !peekAllPanes {
+		output, err := t.CapturePaneAt(sessionName, peekPane, lines)
+		if err != nil {
+			return "", fmt.Errorf("pane %d of %s: %w", peekPane, sessionName, err)
+		}
+		return output, nil
+	}
+
+	panes, err := t.ListPanes(sessionName)
+	if err != nil {
+		return "", fmt.Errorf("listing panes of %s: %w", sessionName, err)
+	}
+	captures := make([]peekPaneCapture, 0, len(panes))
+	for _, pane := range panes {
+		output, err := t.CapturePaneAt(sessionName, pane.Index, lines)
+		captures = append(captures, peekPaneCapture{pane: pane, output: output, err: err})
+	}
+	return formatPeekPanes(captures), nil
+}
+
+// formatPeekPanes joins per-pane captures, each under a
+// "--- pane N (command) ---" header. A pane that failed to capture shows the
+// error in place of its output.
+func formatPeekPanes(captures []peekPaneCapture) string {
+	var b strings.Builder
+	for i, c := range captures {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "--- pane %d (%s) ---\n", c.pane.Index, c.pane.Command)
+		if c.err != nil {
+			fmt.Fprintf(&b, "(capture failed: %v)\n", c.err)
+			continue
+		}
+		if out := strings.TrimRight(c.output, "\n"); out != "" {
+			b.WriteString(out)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// peekFileName turns a session address into a flat file name stem, e.g.
+// "beads/crew/dave" -> "beads__crew__dave".
+func peekFileName(address string) string {
+	return strings.NewReplacer("/", "__", "\\", "__", ":", "_").Replace(address)
+}
+
+// peekSaveHeader marks where one capture starts in an --append file.
+func peekSaveHeader(address, sessionName string, at time.Time) string {
+	return fmt.Sprintf("=== %s (%s) %s ===\n", address, sessionName, at.UTC().Format(time.RFC3339))
+}
+
+// savePeekOutput writes a capture to path, creating parent directories. With
+// appendMode the capture is added under a timestamped header instead of
+// replacing the file, so successive peeks accumulate.
+func savePeekOutput(path, address, output string, format peekFormatter, at time.Time, appendMode bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0644) //nolint:gosec // G304: path is the user's --save target
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	content := format.render(output, at)
+	if appendMode {
+		content = peekSaveHeader(address, format.session, at) + content
+	}
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// emitPeek prints a capture, or saves it to --save when set.
+func emitPeek(address, output string, format peekFormatter) error {
+	now := time.Now()
+	if peekSave == "" {
+		format.print(output, now)
+		return nil
+	}
+	if err := savePeekOutput(peekSave, address, output, format, now, peekAppend); err != nil {
+		return err
+	}
+	verb := "Saved"
+	if peekAppend {
+		verb = "Appended"
+	}
+	fmt.Printf("%s %s %s to %s\n", style.Bold.Render("✓"), verb, address, peekSave)
+	return nil
+}
+
+// checkPeekSaveFlags rejects --save combinations that have no single capture
+// to write.
+func checkPeekSaveFlags() error {
+	if peekAppend && peekSave == "" {
+		return fmt.Errorf("--append requires --save")
+	}
+	if peekSave != "" && (peekDiffFlag || peekWaitFlag) {
+		return fmt.Errorf("--save cannot be combined with --diff or --wait")
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestTownPeekSession(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+		ok      bool
+	}{
+		{"mayor", "hq-mayor", true},
+		{"hq/mayor", "hq-mayor", true},
+		{"deacon", "hq-deacon", true},
+		{"hq/deacon", "hq-deacon", true},
+		{"boot", "hq-boot", true},
+		{"hq/boot", "hq-boot", true},
+		{"hq/crew/max", "hq-crew-max", true},
+		{"hq/dog/alpha", "hq-dog-alpha", true},
+		{"hq/crew/", "", false},
+		{"hq/crew/a/b", "", false},
+		{"hq/witness", "", false},
+		{"crew/max", "", false},
+		{"beads/crew/dave", "", false},
+		{"greenplace/furiosa", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, ok := townPeekSession(tt.address)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("townPeekSession(%q) = (%q, %v), want (%q, %v)", tt.address, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCrewPeekSession(t *testing.T) {
+	originalRegistry := session.DefaultRegistry()
+	t.Cleanup(func() { session.SetDefaultRegistry(originalRegistry) })
+
+	testRegistry := session.NewPrefixRegistry()
+	testRegistry.Register("bd", "beads")
+	session.SetDefaultRegistry(testRegistry)
+
+	if got := crewPeekSession("beads", "dave"); got != "bd-crew-dave" {
+		t.Errorf("rig crew session = %q, want bd-crew-dave", got)
+	}
+	if got := crewPeekSession("hq", "max"); got != "hq-crew-max" {
+		t.Errorf("town crew session = %q, want hq-crew-max", got)
+	}
+}
+
+func TestRigCrewSessions(t *testing.T) {
+	originalRegistry := session.DefaultRegistry()
+	t.Cleanup(func() { session.SetDefaultRegistry(originalRegistry) })
+
+	testRegistry := session.NewPrefixRegistry()
+	testRegistry.Register("bd", "beads")
+	testRegistry.Register("gt", "gastown")
+	session.SetDefaultRegistry(testRegistry)
+
+	sessions := []string{"bd-crew-dave", "bd-witness", "bd-crew-max", "gt-crew-joe", "bd-furiosa", "bd-crew-"}
+	got := rigCrewSessions(sessions, "beads")
+	want := map[string]string{"dave": "bd-crew-dave", "max": "bd-crew-max"}
+	if len(got) != len(want) {
+		t.Fatalf("rigCrewSessions = %v, want %v", got, want)
+	}
+	for name, s := range want {
+		if got[name] != s {
+			t.Errorf("crew %s session = %q, want %q", name, got[name], s)
+		}
+	}
+}
+
+func TestPeekDiff(t *testing.T) {
+	first := "line 1\nline 2\nline 3\n\n\n"
+	diff, rolled, cursor := peekDiff(nil, first)
+	if rolled || strings.Join(diff, "|") != "line 1|line 2|line 3" {
+		t.Fatalf("first peek: diff=%q rolled=%v", diff, rolled)
+	}
+
+	// New lines appended: only they are shown.
+	second := "line 2\nline 3\nline 4\nline 5\n"
+	diff, rolled, cursor = peekDiff(cursor, second)
+	if rolled || strings.Join(diff, "|") != "line 4|line 5" {
+		t.Fatalf("appended: diff=%q rolled=%v", diff, rolled)
+	}
+
+	// Nothing new.
+	diff, rolled, cursor = peekDiff(cursor, second)
+	if rolled || len(diff) != 0 {
+		t.Fatalf("unchanged: diff=%q rolled=%v", diff, rolled)
+	}
+
+	// The old position scrolled away: full capture, flagged as rolled.
+	third := "line 9\nline 10\n"
+	diff, rolled, _ = peekDiff(cursor, third)
+	if !rolled || strings.Join(diff, "|") != "line 9|line 10" {
+		t.Fatalf("rolled: diff=%q rolled=%v", diff, rolled)
+	}
+
+	// A blank capture leaves no anchor: the next peek shows everything.
+	_, _, cursor = peekDiff(nil, "\n  \n")
+	if len(cursor.Anchor) != 0 {
+		t.Fatalf("blank capture anchor = %q, want empty", cursor.Anchor)
+	}
+	diff, rolled, _ = peekDiff(cursor, third)
+	if rolled || strings.Join(diff, "|") != "line 9|line 10" {
+		t.Fatalf("after blank: diff=%q rolled=%v", diff, rolled)
+	}
+}
+
+func TestPeekCursorRoundTrip(t *testing.T) {
+	path := peekStatePath(t.TempDir(), "greenplace/furiosa")
+	if !strings.HasSuffix(path, "greenplace__furiosa.json") {
+		t.Errorf("peekStatePath = %s", path)
+	}
+	if loadPeekCursor(path) != nil {
+		t.Fatal("missing cursor should load as nil")
+	}
+	_, _, cursor := peekDiff(nil, "a\nb\n")
+	if err := savePeekCursor(path, cursor); err != nil {
+		t.Fatalf("savePeekCursor: %v", err)
+	}
+	if got := loadPeekCursor(path); got == nil || len(got.Anchor) != 2 {
+		t.Errorf("loadPeekCursor = %+v, want 2 anchor lines", got)
+	}
+}
+
+func TestWaitForPeekOutput(t *testing.T) {
+	// scripted returns each capture in turn, then repeats the last one.
+	scripted := func(captures ...string) func() (string, bool, error) {
+		i := 0
+		return func() (string, bool, error) {
+			c := captures[i]
+			if i < len(captures)-1 {
+				i++
+			}
+			if c == "<gone>" {
+				return "", false, nil
+			}
+			return c, true, nil
+		}
+	}
+
+	baseline := "line 1\nline 2\n"
+	lines, outcome, err := waitForPeekOutput(baseline, scripted(baseline, baseline, "line 1\nline 2\nline 3\n"), time.Millisecond, 0)
+	if err != nil || outcome != peekWaitNewOutput || strings.Join(lines, "|") != "line 3" {
+		t.Errorf("new output: lines=%q outcome=%v err=%v", lines, outcome, err)
+	}
+
+	lines, outcome, err = waitForPeekOutput("", scripted("", "hello\n"), time.Millisecond, 0)
+	if err != nil || outcome != peekWaitNewOutput || strings.Join(lines, "|") != "hello" {
+		t.Errorf("empty baseline: lines=%q outcome=%v err=%v", lines, outcome, err)
+	}
+
+	if _, outcome, err = waitForPeekOutput(baseline, scripted(baseline, "<gone>"), time.Millisecond, 0); err != nil || outcome != peekWaitEnded {
+		t.Errorf("session exit: outcome=%v err=%v", outcome, err)
+	}
+
+	if _, outcome, err = waitForPeekOutput(baseline, scripted(baseline), time.Millisecond, 20*time.Millisecond); err != nil || outcome != peekWaitTimedOut {
+		t.Errorf("timeout: outcome=%v err=%v", outcome, err)
+	}
+}
+
+func TestPeekFormatter(t *testing.T) {
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	lines := []string{"building...", "literal {rig} stays"}
+
+	if got := newPeekFormatter("", "greenplace/furiosa", "gp-furiosa").lines(lines, at); !reflect.DeepEqual(got, lines) {
+		t.Errorf("empty template changed lines: %q", got)
+	}
+
+	got := newPeekFormatter("[{rig}/{polecat} {ts}] {line}", "greenplace/furiosa", "gp-furiosa").lines(lines, at)
+	want := []string{
+		"[greenplace/furiosa 2026-05-01T12:00:00Z] building...",
+		"[greenplace/furiosa 2026-05-01T12:00:00Z] literal {rig} stays",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+
+	got = newPeekFormatter("{rig}/{polecat} {session}| ", "mayor", "hq-mayor").lines(lines[:1], at)
+	if want := []string{"hq/mayor hq-mayor| building..."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prefix-only template = %q, want %q", got, want)
+	}
+
+	got = newPeekFormatter("{polecat}: {line}", "beads/crew/dave", "bd-crew-dave").lines(lines[:1], at)
+	if want := []string{"crew/dave: building..."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("crew address = %q, want %q", got, want)
+	}
+}
+
+func TestSavePeekOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "triage", "furiosa.txt")
+	format := newPeekFormatter("", "greenplace/furiosa", "gp-furiosa")
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	if err := savePeekOutput(path, "greenplace/furiosa", "old\n", format, at, false); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := savePeekOutput(path, "greenplace/furiosa", "first\n", format, at, false); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "first\n" {
+		t.Errorf("after overwrite = %q, want %q", got, "first\n")
+	}
+
+	if err := savePeekOutput(path, "greenplace/furiosa", "second\n", format, at, true); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	want := "first\n=== greenplace/furiosa (gp-furiosa) 2026-03-04T05:06:07Z ===\nsecond\n"
+	if got, _ := os.ReadFile(path); string(got) != want {
+		t.Errorf("after append = %q, want %q", got, want)
+	}
+}
+
+func TestPeekFileName(t *testing.T) {
+	if got := peekFileName("beads/crew/dave"); got != "beads__crew__dave" {
+		t.Errorf("peekFileName = %q, want beads__crew__dave", got)
+	}
+}
+
+func TestFormatPeekPanes(t *testing.T) {
+	got := formatPeekPanes([]peekPaneCapture{
+		{pane: tmux.PaneSummary{Index: 0, Command: "claude"}, output: "working\n\n"},
+		{pane: tmux.PaneSummary{Index: 1, Command: "tail"}, output: "log line\n"},
+		{pane: tmux.PaneSummary{Index: 2, Command: "bash"}, err: os.ErrNotExist},
+	})
+	want := "--- pane 0 (claude) ---\nworking\n\n--- pane 1 (tail) ---\nlog line\n\n--- pane 2 (bash) ---\n(capture failed: file does not exist)\n"
+	if got != want {
+		t.Errorf("formatPeekPanes =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCheckPeekPaneFlags(t *testing.T) {
+	defer func() { peekPane, peekAllPanes, peekCrew, peekDiffFlag = -1, false, false, false }()
+
+	tests := []struct {
+		name     string
+		pane     int
+		allPanes bool
+		crew     bool
+		diff     bool
+		wantErr  bool
+	}{
+		{name: "default", pane: -1},
+		{name: "pane", pane: 1},
+		{name: "all panes", pane: -1, allPanes: true},
+		{name: "negative pane", pane: -2, wantErr: true},
+		{name: "pane with crew", pane: 0, crew: true, wantErr: true},
+		{name: "all panes with diff", pane: -1, allPanes: true, diff: true, wantErr: true},
+		{name: "diff alone", pane: -1, diff: true},
+	}
+	for _, tt := range tests {
+		peekPane, peekAllPanes, peekCrew, peekDiffFlag = tt.pane, tt.allPanes, tt.crew, tt.diff
+		if err := checkPeekPaneFlags(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkPeekPaneFlags() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// peekWaitInterval is how often --wait re-captures the session.
+const peekWaitInterval = time.Second
+
+// peekWaitOutcome is how a --wait peek finished.
+type peekWaitOutcome int
+
+const (
+	peekWaitNewOutput peekWaitOutcome = iota
+	peekWaitEnded
+	peekWaitTimedOut
+)
+
+// runPeekWait blocks until sessionName prints past baseline, the session goes
+// away, or --timeout elapses, and reports which happened.
+func runPeekWait(t *tmux.Tmux, sessionName string, lines int, baseline string, format peekFormatter) error {
+	capture := func() (string, bool, error) {
+		running, err := t.HasSession(sessionName)
+		if err != nil || !running {
+			return "", false, err
+		}
+		output, err := t.CapturePane(sessionName, lines)
+		if err != nil {
+			// The session can exit between the check and the capture.
+			if running, _ := t.HasSession(sessionName); !running {
+				return "", false, nil
+			}
+			return "", true, err
+		}
+		return output, true, nil
+	}
+
+	newLines, outcome, err := waitForPeekOutput(baseline, capture, peekWaitInterval, peekWaitTimeout)
+	if err != nil {
+		return fmt.Errorf("waiting on %s: %w", sessionName, err)
+	}
+	switch outcome {
+	case peekWaitEnded:
+		fmt.Fprintln(os.Stderr, style.Dim.Render("session ended"))
+		return NewSilentExit(1)
+	case peekWaitTimedOut:
+		fmt.Fprintln(os.Stderr, style.Dim.Render(fmt.Sprintf("(no new output after %s)", peekWaitTimeout)))
+		return NewSilentExit(2)
+	}
+	fmt.Println(strings.Join(format.lines(newLines, time.Now()), "\n"))
+	return nil
+}
+
+// waitForPeekOutput polls capture every interval until it returns lines past
+// the end of baseline, reports the session gone, or timeout (if > 0)
+// elapses. capture returns the pane contents and whether the session still
+// exists.
+func waitForPeekOutput(baseline string, capture func() (string, bool, error), interval, timeout time.Duration) ([]string, peekWaitOutcome, error) {
+	// An empty pane leaves an empty anchor, so any output is new.
+	_, _, cursor := peekDiff(nil, baseline)
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-deadline:
+			return nil, peekWaitTimedOut, nil
+		case <-ticker.C:
+		}
+		output, alive, err := capture()
+		if err != nil {
+			return nil, 0, err
+		}
+		if !alive {
+			return nil, peekWaitEnded, nil
+		}
+		if diff, _, _ := peekDiff(cursor, output); len(diff) > 0 {
+			return diff, peekWaitNewOutput, nil
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -89,6 +90,33 @@ func (e *polecatCapacityAdmissionError) Error() string {
 	)
 }
 
+// polecatDirCapError reports that a rig already holds its max_polecats worth
+// of polecat directories, so no new slot can be allocated there.
+type polecatDirCapError struct {
+	Rig      string
+	DirCount int
+	Max      int
+}
+
+func (e *polecatDirCapError) Error() string {
+	return fmt.Sprintf("rig %s has %d polecat directories (max %d). "+
+		"Resolve recovery-needed polecats before allocating more slots: gt polecat list %s",
+		e.Rig, e.DirCount, e.Max, e.Rig)
+}
+
+// isPolecatCapacityRefusal reports whether a spawn failed only because there
+// was no room: the town's scheduler.max_polecats is full, the rig is at its
+// polecat directory cap, or the Dolt server is at connection capacity. These
+// say nothing about the bead itself, so the scheduler leaves the context
+// queued instead of counting a dispatch failure.
+func isPolecatCapacityRefusal(err error) bool {
+	var admissionErr *polecatCapacityAdmissionError
+	var dirCapErr *polecatDirCapError
+	return errors.As(err, &admissionErr) ||
+		errors.As(err, &dirCapErr) ||
+		errors.Is(err, polecat.ErrDoltAtCapacity)
+}
+
 func acquirePolecatAdmission(townRoot, rigName, beadID, operation string) (*polecatAdmissionHandle, polecatCapacitySnapshot, error) {
 	max, err := configuredSchedulerMaxPolecats(townRoot)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 )
 
@@ -285,6 +287,27 @@ func TestConcurrentPolecatAdmissionReservationsDoNotExceedCap(t *testing.T) {
 	}
 }
 
+func TestIsPolecatCapacityRefusal(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"town admission", fmt.Errorf("sling failed: %w", &polecatCapacityAdmissionError{Rig: "gastown", Reason: "full"}), true},
+		{"rig dir cap", fmt.Errorf("failed to spawn polecat: %w", &polecatDirCapError{Rig: "gastown", DirCount: 10, Max: 10}), true},
+		{"dolt at capacity", fmt.Errorf("admission control: %w", fmt.Errorf("%w: 190 active connections", polecat.ErrDoltAtCapacity)), true},
+		{"dolt unhealthy", fmt.Errorf("pre-spawn health check failed: %w", polecat.ErrDoltUnhealthy), false},
+		{"other", errors.New("rig 'nope' not found"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPolecatCapacityRefusal(tt.err); got != tt.want {
+				t.Errorf("isPolecatCapacityRefusal(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplyAgentFieldsToCapacitySnapshotSeparatesPendingMR(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -333,7 +356,7 @@ func TestPrintDryRunPlanUsesCapacitySnapshot(t *testing.T) {
 			ReusableIdle:    3,
 			PendingMR:       2,
 			Free:            0,
-		}, 5)
+		}, 5, 0)
 	})
 	for _, want := range []string{"0 free of 2", "working: 1", "recovery_blocked: 1", "reusable_idle: 3", "pending_mr: 2"} {
 		if !strings.Contains(out, want) {
@@ -342,6 +365,18 @@ func TestPrintDryRunPlanUsesCapacitySnapshot(t *testing.T) {
 	}
 }
 
+func TestPrintDryRunPlanShowsInteractiveReserve(t *testing.T) {
+	out := captureStdout(t, func() {
+		printDryRunPlan(capacity.DispatchPlan{Skipped: 1, Reason: "capacity"},
+			polecatCapacitySnapshot{Max: 4, Working: 3, Free: 1}, 1, 1)
+	})
+	for _, want := range []string{"No capacity", "1 free of 4", "1 reserved for interactive slings"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("dry-run output %q missing %q", out, want)
+		}
+	}
+}
+
 func TestResolveTargetRigPassesHeldAdmissionToSpawn(t *testing.T) {
 	townRoot := setupPolecatCapacityRig(t, 1)
 	oldSpawn := spawnPolecatForSling
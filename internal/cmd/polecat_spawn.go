@@ -254,9 +254,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 			}
 		}
 		if dirCount >= maxPolecatDirsPerRig {
-			return nil, fmt.Errorf("rig %s has %d polecat directories (max %d). "+
-				"Resolve recovery-needed polecats before allocating more slots: gt polecat list %s",
-				rigName, dirCount, maxPolecatDirsPerRig, rigName)
+			return nil, &polecatDirCapError{Rig: rigName, DirCount: dirCount, Max: maxPolecatDirsPerRig}
 		}
 	}
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -8,36 +9,153 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
-	reaperDB       string
-	reaperHost     string
-	reaperPort     int
-	reaperMaxAge   string
-	reaperPurgeAge string
-	reaperMailAge  string
-	reaperStaleAge string
-	reaperDBDelay  string
-	reaperDryRun   bool
-	reaperJSON     bool
+	reaperDB           string
+	reaperHost         string
+	reaperPort         int
+	reaperMaxAge       string
+	reaperPurgeAge     string
+	reaperMailAge      string
+	reaperStaleMailAge string
+	reaperStaleAge     string
+	reaperSince        string
+	reaperUntil        string
+	reaperDBDelay      string
+	reaperDryRun       bool
+	reaperTombstone    bool
+	reaperKeepRecent   int
+	reaperExcludeTypes []string
+	reaperDeferEvents  string
+	reaperConcurrency  int
+	reaperBusinessDays bool
+	reaperHolidays     []string
+	reaperJSON         bool
 )
 
-func reaperDatabaseNames() []string {
+func reaperDatabaseNames(ctx context.Context) []string {
+	var databases []string
 	if reaperDB == "" {
-		return reaper.DiscoverDatabases(reaperHost, reaperPort)
+		databases = reaper.DiscoverDatabases(ctx, reaperHost, reaperPort)
+	} else {
+		for _, part := range strings.Split(reaperDB, ",") {
+			name := strings.TrimSpace(part)
+			if name != "" {
+				databases = append(databases, name)
+			}
+		}
 	}
-	parts := strings.Split(reaperDB, ",")
-	databases := make([]string, 0, len(parts))
-	for _, part := range parts {
-		name := strings.TrimSpace(part)
-		if name != "" {
-			databases = append(databases, name)
+	return skipMaintenanceDatabases(databases)
+}
+
+// reaperPurgeOptions builds the purge options from the command's flags.
+func reaperPurgeOptions() (reaper.PurgeOptions, error) {
+	opts := reaper.PurgeOptions{Tombstone: reaperTombstone, KeepRecentPerType: reaperKeepRecent, ExcludeTypes: reaperExcludeTypes}
+	if reaperConcurrency < 1 {
+		return reaper.PurgeOptions{}, fmt.Errorf("invalid --batch-concurrency %d: want at least 1", reaperConcurrency)
+	}
+	opts.BatchConcurrency = reaperConcurrency
+	if reaperDeferEvents != "" {
+		d, err := time.ParseDuration(reaperDeferEvents)
+		if err != nil || d <= 0 {
+			return reaper.PurgeOptions{}, fmt.Errorf("invalid --defer-recent-events %q: want a positive duration", reaperDeferEvents)
 		}
+		opts.RecentEventWindow = d
 	}
-	return databases
+	return opts, nil
+}
+
+// skipMaintenanceDatabases drops databases marked as under maintenance
+// ('gt dolt maintenance on'), noting each skip on stderr. Outside a town
+// there are no markers and nothing is dropped.
+func skipMaintenanceDatabases(databases []string) []string {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return databases
+	}
+	kept := make([]string, 0, len(databases))
+	for _, name := range databases {
+		if marker, _ := doltserver.LoadMaintenance(townRoot, name); marker != nil {
+			fmt.Fprintf(os.Stderr, "%s: skipped, under maintenance\n", name)
+			continue
+		}
+		kept = append(kept, name)
+	}
+	return kept
+}
+
+// reaperAutoCloseOptions parses --since/--until, --business-days and
+// --holidays into auto-close options.
+func reaperAutoCloseOptions() (reaper.AutoCloseOptions, error) {
+	window, err := reaper.ParseAutoCloseWindow(reaperSince, reaperUntil)
+	if err != nil {
+		return reaper.AutoCloseOptions{}, fmt.Errorf("invalid --since/--until: %w", err)
+	}
+	holidays, err := reaper.ParseHolidays(reaperHolidays)
+	if err != nil {
+		return reaper.AutoCloseOptions{}, fmt.Errorf("invalid --holidays: %w", err)
+	}
+	return reaper.AutoCloseOptions{Window: window, BusinessDaysOnly: reaperBusinessDays, Holidays: holidays}, nil
+}
+
+// reaperContext returns the context reaper calls run under, carrying the
+// schema mapping and connection settings configured in the town's
+// daemon.json (patrols.wisp_reaper.schema and .connection), so Dog-run
+// commands and the daemon's inline reaper agree on table and column names
+// and connect the same way. Outside a town, or with nothing configured, the
+// defaults are used.
+func reaperContext() (context.Context, error) {
+	ctx := context.Background()
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return ctx, nil
+	}
+	config := daemon.LoadPatrolConfig(townRoot)
+	if config == nil || config.Patrols == nil || config.Patrols.WispReaper == nil {
+		return ctx, nil
+	}
+	schema, err := reaper.NewSchema(config.Patrols.WispReaper.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wisp_reaper.schema in %s: %w", daemon.PatrolConfigFile(townRoot), err)
+	}
+	if conn := config.Patrols.WispReaper.Connection; conn != nil {
+		if err := conn.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid wisp_reaper.connection in %s: %w", daemon.PatrolConfigFile(townRoot), err)
+		}
+		ctx = reaper.WithConnConfig(ctx, *conn)
+	}
+	return reaper.WithSchema(ctx, schema), nil
+}
+
+// reaperStaleMailAgeFlag parses --stale-mail-age. Zero means stale mail
+// closing is off.
+func reaperStaleMailAgeFlag() (time.Duration, error) {
+	if reaperStaleMailAge == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(reaperStaleMailAge)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --stale-mail-age %q: want a positive duration", reaperStaleMailAge)
+	}
+	return d, nil
+}
+
+// checkReaperExcludeTypes rejects --exclude-type values that are not plain
+// wisp type names.
+func checkReaperExcludeTypes() error {
+	for _, wtype := range reaperExcludeTypes {
+		if err := reaper.ValidateWispType(wtype); err != nil {
+			return fmt.Errorf("invalid --exclude-type: %w", err)
+		}
+	}
+	return nil
 }
 
 func waitBeforeReaperDatabase(index int) error {
@@ -76,7 +194,11 @@ var reaperDatabasesCmd = &cobra.Command{
 	Use:   "databases",
 	Short: "List databases available for reaping",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dbs := reaper.DiscoverDatabases(reaperHost, reaperPort)
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
+		dbs := reaper.DiscoverDatabases(ctx, reaperHost, reaperPort)
 		if reaperJSON {
 			fmt.Println(reaper.FormatJSON(dbs))
 		} else {
@@ -99,6 +221,10 @@ all databases on the Dolt server and scans each one, printing a summary.
 Returns counts and anomaly detection results without modifying any data.
 The Dog uses this to understand the state before deciding what to reap.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
 		maxAge, err := time.ParseDuration(reaperMaxAge)
 		if err != nil {
 			return fmt.Errorf("invalid --max-age: %w", err)
@@ -116,7 +242,7 @@ The Dog uses this to understand the state before deciding what to reap.`,
 			return fmt.Errorf("invalid --stale-age: %w", err)
 		}
 
-		databases := reaperDatabaseNames()
+		databases := reaperDatabaseNames(ctx)
 
 		var results []*reaper.ScanResult
 		for i, dbName := range databases {
@@ -128,13 +254,13 @@ The Dog uses this to understand the state before deciding what to reap.`,
 				continue
 			}
 
-			db, err := reaper.OpenDB(reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
+			db, err := reaper.OpenDB(ctx, reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: connect error: %v\n", dbName, err)
 				continue
 			}
 
-			if ok, err := reaper.HasReaperSchema(db); err != nil {
+			if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
 				fmt.Fprintf(os.Stderr, "%s: schema check error: %v\n", dbName, err)
 				db.Close()
 				continue
@@ -143,7 +269,7 @@ The Dog uses this to understand the state before deciding what to reap.`,
 				continue
 			}
 
-			result, err := reaper.Scan(db, dbName, maxAge, purgeAge, mailAge, staleAge)
+			result, err := reaper.Scan(ctx, db, dbName, maxAge, purgeAge, mailAge, staleAge)
 			db.Close()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: scan error: %v\n", dbName, err)
@@ -203,13 +329,18 @@ all databases on the Dolt server and reaps each one.
 
 Returns the count of reaped wisps. Use --dry-run to preview.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
 		maxAge, err := time.ParseDuration(reaperMaxAge)
 		if err != nil {
 			return fmt.Errorf("invalid --max-age: %w", err)
 		}
 
-		databases := reaperDatabaseNames()
+		databases := reaperDatabaseNames(ctx)
 
+		liveOwners := session.LivePolecatAddresses()
 		var results []*reaper.ReapResult
 		for i, dbName := range databases {
 			if err := waitBeforeReaperDatabase(i); err != nil {
@@ -220,13 +351,13 @@ Returns the count of reaped wisps. Use --dry-run to preview.`,
 				continue
 			}
 
-			db, err := reaper.OpenDB(reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
+			db, err := reaper.OpenDB(ctx, reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: connect error: %v\n", dbName, err)
 				continue
 			}
 
-			if ok, err := reaper.HasReaperSchema(db); err != nil {
+			if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
 				fmt.Fprintf(os.Stderr, "%s: schema check error: %v\n", dbName, err)
 				db.Close()
 				continue
@@ -235,7 +366,7 @@ Returns the count of reaped wisps. Use --dry-run to preview.`,
 				continue
 			}
 
-			result, err := reaper.Reap(db, dbName, maxAge, reaperDryRun)
+			result, err := reaper.ReapWithOptions(ctx, db, dbName, maxAge, reaperDryRun, reaper.ReapOptions{LiveOwners: liveOwners})
 			db.Close()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: reap error: %v\n", dbName, err)
@@ -259,6 +390,12 @@ Returns the count of reaped wisps. Use --dry-run to preview.`,
 				}
 				fmt.Printf("%s: %sreaped %d wisps%s, %d open remain\n",
 					r.Database, prefix, r.Reaped, extra, r.OpenRemain)
+				if r.SparedLive > 0 {
+					fmt.Printf("  spared %d stale wisps owned by a live polecat\n", r.SparedLive)
+				}
+				for _, a := range r.Anomalies {
+					fmt.Printf("  %s %s\n", style.Warning.Render("ANOMALY:"), a.Message)
+				}
 				totalReaped += r.Reaped
 				totalMoleculeSteps += r.MoleculeStepsClosed
 				totalOpen += r.OpenRemain
@@ -290,11 +427,38 @@ var reaperPurgeCmd = &cobra.Command{
 	Long: `Delete closed wisps past the purge-age threshold and closed mail
 past the mail-age threshold. Irreversible operation.
 
+With --stale-mail-age, open mail with no updates for that long is first
+closed (reason "mail:stale-unread"), so unread messages to agents that are
+gone age into a later mail purge. Off unless the flag is given.
+
+With --tombstone, purged wisps and mail are kept as stub rows (status
+"purged", description emptied, labels/comments/events/dependencies removed)
+instead of being deleted, so external references to their IDs still resolve.
+
+With --exclude-type, closed wisps of the named wisp types are never purged,
+whatever their age ("unknown" names wisps with no type). They are reported
+as retained (excluded type).
+
+With --defer-recent-events <duration>, closed wisps with any wisp_events row
+newer than that (e.g. reopened and closed again) are left for a later purge,
+since recent activity may still reference them. They are reported as
+deferred (recent events).
+
+With --batch-concurrency N, up to N closed-wisp delete batches run at once
+within each database, each in its own transaction over distinct IDs. The
+default of 1 deletes batch by batch. Databases are still purged one after
+another (see --db-delay). The output shows the batches, the time they took
+and the speedup over running them back to back.
+
 When --db is provided, purges a single database. When omitted, auto-discovers
 all databases on the Dolt server and purges each one.
 
 Returns counts of purged rows. Use --dry-run to preview.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
 		purgeAge, err := time.ParseDuration(reaperPurgeAge)
 		if err != nil {
 			return fmt.Errorf("invalid --purge-age: %w", err)
@@ -303,8 +467,19 @@ Returns counts of purged rows. Use --dry-run to preview.`,
 		if err != nil {
 			return fmt.Errorf("invalid --mail-age: %w", err)
 		}
+		staleMailAge, err := reaperStaleMailAgeFlag()
+		if err != nil {
+			return err
+		}
+		if err := checkReaperExcludeTypes(); err != nil {
+			return err
+		}
+		purgeOpts, err := reaperPurgeOptions()
+		if err != nil {
+			return err
+		}
 
-		databases := reaperDatabaseNames()
+		databases := reaperDatabaseNames(ctx)
 
 		var results []*reaper.PurgeResult
 		for i, dbName := range databases {
@@ -316,13 +491,13 @@ Returns counts of purged rows. Use --dry-run to preview.`,
 				continue
 			}
 
-			db, err := reaper.OpenDB(reaperHost, reaperPort, dbName, 30*time.Second, 30*time.Second)
+			db, err := reaper.OpenDB(ctx, reaperHost, reaperPort, dbName, 30*time.Second, 30*time.Second)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: connect error: %v\n", dbName, err)
 				continue
 			}
 
-			if ok, err := reaper.HasReaperSchema(db); err != nil {
+			if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
 				fmt.Fprintf(os.Stderr, "%s: schema check error: %v\n", dbName, err)
 				db.Close()
 				continue
@@ -331,12 +506,23 @@ Returns counts of purged rows. Use --dry-run to preview.`,
 				continue
 			}
 
-			result, err := reaper.Purge(db, dbName, purgeAge, mailAge, reaperDryRun)
+			var staleMailClosed int
+			if staleMailAge > 0 {
+				closeResult, err := reaper.CloseStaleMail(ctx, db, dbName, staleMailAge, reaperDryRun)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: stale mail close error: %v\n", dbName, err)
+				} else {
+					staleMailClosed = closeResult.Closed
+				}
+			}
+
+			result, err := reaper.PurgeWithOptions(ctx, db, dbName, purgeAge, mailAge, reaperDryRun, purgeOpts)
 			db.Close()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: purge error: %v\n", dbName, err)
 				continue
 			}
+			result.StaleMailClosed = staleMailClosed
 			results = append(results, result)
 		}
 
@@ -349,8 +535,30 @@ Returns counts of purged rows. Use --dry-run to preview.`,
 				if r.DryRun {
 					prefix = "[DRY RUN] would "
 				}
-				fmt.Printf("%s: %spurged %d wisps, %d mail\n",
-					r.Database, prefix, r.WispsPurged, r.MailPurged)
+				verb := "purged"
+				if r.Tombstoned {
+					verb = "tombstoned"
+				}
+				fmt.Printf("%s: %s%s %d wisps, %d mail\n",
+					r.Database, prefix, verb, r.WispsPurged, r.MailPurged)
+				if r.StaleMailClosed > 0 {
+					fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%sclosed %d stale unread mail", prefix, r.StaleMailClosed)))
+				}
+				if b := r.ReopenBuckets; b != nil {
+					fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%d aged out, %d reclosed after reopen", b.AgedOut, b.Reclosed)))
+				}
+				if len(r.RetainedRecent) > 0 {
+					fmt.Printf("  %s\n", style.Dim.Render("kept as most recent of type: "+formatCounts(r.RetainedRecent)))
+				}
+				if len(r.RetainedExcluded) > 0 {
+					fmt.Printf("  %s\n", style.Dim.Render("retained (excluded type): "+formatCounts(r.RetainedExcluded)))
+				}
+				if r.DeferredRecentEvents > 0 {
+					fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("deferred (recent events): %d", r.DeferredRecentEvents)))
+				}
+				if r.WispDelete != nil {
+					fmt.Printf("  %s\n", style.Dim.Render("deleted in "+r.WispDelete.String()))
+				}
 				for _, a := range r.Anomalies {
 					fmt.Printf("  %s %s\n", style.Warning.Render("ANOMALY:"), a.Message)
 				}
@@ -379,14 +587,33 @@ Excludes P0/P1 priority, epics, and issues with active dependencies.
 When --db is provided, auto-closes in a single database. When omitted,
 auto-discovers all databases on the Dolt server and auto-closes in each one.
 
+--since and --until restrict candidates to issues last updated in that range,
+so a large stale backlog can be closed out a window at a time:
+
+  gt reaper auto-close --until 2025-01-01 --dry-run
+  gt reaper auto-close --since 2025-01-01 --until 2025-04-01
+
+--business-days ages issues by weekday time only, so weekends (and any
+--holidays dates) do not count toward --stale-age:
+
+  gt reaper auto-close --business-days --holidays 2025-12-25,2026-01-01
+
 Returns the count of closed issues. Use --dry-run to preview.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
 		staleAge, err := time.ParseDuration(reaperStaleAge)
 		if err != nil {
 			return fmt.Errorf("invalid --stale-age: %w", err)
 		}
+		closeOpts, err := reaperAutoCloseOptions()
+		if err != nil {
+			return err
+		}
 
-		databases := reaperDatabaseNames()
+		databases := reaperDatabaseNames(ctx)
 
 		var results []*reaper.AutoCloseResult
 		for i, dbName := range databases {
@@ -398,13 +625,13 @@ Returns the count of closed issues. Use --dry-run to preview.`,
 				continue
 			}
 
-			db, err := reaper.OpenDB(reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
+			db, err := reaper.OpenDB(ctx, reaperHost, reaperPort, dbName, 10*time.Second, 10*time.Second)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: connect error: %v\n", dbName, err)
 				continue
 			}
 
-			if ok, err := reaper.HasReaperSchema(db); err != nil {
+			if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
 				fmt.Fprintf(os.Stderr, "%s: schema check error: %v\n", dbName, err)
 				db.Close()
 				continue
@@ -413,7 +640,7 @@ Returns the count of closed issues. Use --dry-run to preview.`,
 				continue
 			}
 
-			result, err := reaper.AutoClose(db, dbName, staleAge, reaperDryRun)
+			result, err := reaper.AutoCloseWithOptions(ctx, db, dbName, staleAge, reaperDryRun, closeOpts)
 			db.Close()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%s: auto-close error: %v\n", dbName, err)
@@ -426,18 +653,22 @@ Returns the count of closed issues. Use --dry-run to preview.`,
 			fmt.Println(reaper.FormatJSON(results))
 		} else {
 			var totalClosed int
+			var totalExempted reaper.AutoCloseExemptCounts
 			for _, r := range results {
 				prefix := ""
 				if r.DryRun {
 					prefix = "[DRY RUN] would "
 				}
 				for _, entry := range r.ClosedEntries {
-					fmt.Printf("  %s %s (%dd stale, db:%s)\n",
-						entry.ID, entry.Title, entry.AgeDays, entry.Database)
+					fmt.Printf("  %s\n", entry)
 				}
 				fmt.Printf("%s: %sauto-closed %d stale issues\n",
 					r.Database, prefix, r.Closed)
+				if r.Exempted.Total() > 0 {
+					fmt.Printf("%s: exempted stale issues: %s\n", r.Database, r.Exempted)
+				}
 				totalClosed += r.Closed
+				totalExempted.Add(r.Exempted)
 			}
 			if len(results) > 1 {
 				prefix := ""
@@ -446,6 +677,9 @@ Returns the count of closed issues. Use --dry-run to preview.`,
 				}
 				fmt.Printf("\n%sAuto-close summary (%d databases): auto-closed %d stale issues\n",
 					prefix, len(results), totalClosed)
+				if totalExempted.Total() > 0 {
+					fmt.Printf("Exempted stale issues: %s\n", totalExempted)
+				}
 			}
 		}
 		return nil
@@ -460,7 +694,18 @@ var reaperRunCmd = &cobra.Command{
 This is the inline fallback for when Dog dispatch is unavailable.
 Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		databases := reaperDatabaseNames()
+		ctx, err := reaperContext()
+		if err != nil {
+			return err
+		}
+		if err := checkReaperExcludeTypes(); err != nil {
+			return err
+		}
+		purgeOpts, err := reaperPurgeOptions()
+		if err != nil {
+			return err
+		}
+		databases := reaperDatabaseNames(ctx)
 
 		maxAge, err := time.ParseDuration(reaperMaxAge)
 		if err != nil {
@@ -478,8 +723,18 @@ Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 		if err != nil {
 			return fmt.Errorf("invalid --stale-age: %w", err)
 		}
+		staleMailAge, err := reaperStaleMailAgeFlag()
+		if err != nil {
+			return err
+		}
+		closeOpts, err := reaperAutoCloseOptions()
+		if err != nil {
+			return err
+		}
 
-		var totalReaped, totalMoleculeSteps, totalPurged, totalMailPurged, totalClosed, totalOpen int
+		var totalReaped, totalMoleculeSteps, totalPurged, totalMailPurged, totalStaleMail, totalClosed, totalOpen, totalSparedLive int
+		var totalExempted reaper.AutoCloseExemptCounts
+		liveOwners := session.LivePolecatAddresses()
 
 		for i, dbName := range databases {
 			if err := waitBeforeReaperDatabase(i); err != nil {
@@ -490,13 +745,13 @@ Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 				continue
 			}
 
-			db, err := reaper.OpenDB(reaperHost, reaperPort, dbName, 30*time.Second, 30*time.Second)
+			db, err := reaper.OpenDB(ctx, reaperHost, reaperPort, dbName, 30*time.Second, 30*time.Second)
 			if err != nil {
 				fmt.Printf("%s: connect error: %v\n", dbName, err)
 				continue
 			}
 
-			if ok, err := reaper.HasReaperSchema(db); err != nil {
+			if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
 				fmt.Printf("%s: schema check error: %v\n", dbName, err)
 				db.Close()
 				continue
@@ -507,7 +762,7 @@ Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 			}
 
 			// Scan
-			scanResult, err := reaper.Scan(db, dbName, maxAge, purgeAge, mailAge, staleAge)
+			scanResult, err := reaper.Scan(ctx, db, dbName, maxAge, purgeAge, mailAge, staleAge)
 			if err != nil {
 				fmt.Printf("%s: scan error: %v\n", dbName, err)
 				db.Close()
@@ -518,34 +773,48 @@ Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 			}
 
 			// Reap
-			reapResult, err := reaper.Reap(db, dbName, maxAge, reaperDryRun)
+			reapResult, err := reaper.ReapWithOptions(ctx, db, dbName, maxAge, reaperDryRun, reaper.ReapOptions{LiveOwners: liveOwners})
 			if err != nil {
 				fmt.Printf("%s: reap error: %v\n", dbName, err)
 			} else {
 				totalReaped += reapResult.Reaped
 				totalMoleculeSteps += reapResult.MoleculeStepsClosed
 				totalOpen += reapResult.OpenRemain
+				totalSparedLive += reapResult.SparedLive
+			}
+
+			// Close stale unread mail so a later purge can reclaim it
+			if staleMailAge > 0 {
+				mailResult, err := reaper.CloseStaleMail(ctx, db, dbName, staleMailAge, reaperDryRun)
+				if err != nil {
+					fmt.Printf("%s: stale mail close error: %v\n", dbName, err)
+				} else {
+					totalStaleMail += mailResult.Closed
+				}
 			}
 
 			// Purge
-			purgeResult, err := reaper.Purge(db, dbName, purgeAge, mailAge, reaperDryRun)
+			purgeResult, err := reaper.PurgeWithOptions(ctx, db, dbName, purgeAge, mailAge, reaperDryRun, purgeOpts)
 			if err != nil {
 				fmt.Printf("%s: purge error: %v\n", dbName, err)
 			} else {
 				totalPurged += purgeResult.WispsPurged
 				totalMailPurged += purgeResult.MailPurged
+				if purgeResult.WispDelete != nil {
+					fmt.Printf("%s: purge deleted in %s\n", dbName, purgeResult.WispDelete)
+				}
 			}
 
 			// Auto-close
-			closeResult, err := reaper.AutoClose(db, dbName, staleAge, reaperDryRun)
+			closeResult, err := reaper.AutoCloseWithOptions(ctx, db, dbName, staleAge, reaperDryRun, closeOpts)
 			if err != nil {
 				fmt.Printf("%s: auto-close error: %v\n", dbName, err)
 			} else {
 				for _, entry := range closeResult.ClosedEntries {
-					fmt.Printf("  %s %s (%dd stale, db:%s)\n",
-						entry.ID, entry.Title, entry.AgeDays, entry.Database)
+					fmt.Printf("  %s\n", entry)
 				}
 				totalClosed += closeResult.Closed
+				totalExempted.Add(closeResult.Exempted)
 			}
 
 			db.Close()
@@ -563,17 +832,27 @@ Normally the daemon dispatches a Dog to execute the mol-dog-reaper formula.`,
 			fmt.Printf(" (+%d closed-molecule steps)", totalMoleculeSteps)
 		}
 		fmt.Println()
+		if totalSparedLive > 0 {
+			fmt.Printf("  Spared:    %d stale wisps owned by a live polecat\n", totalSparedLive)
+		}
 		fmt.Printf("  Purged:    %d wisps, %d mail\n", totalPurged, totalMailPurged)
 		fmt.Printf("  Closed:    %d stale issues\n", totalClosed)
+		if totalExempted.Total() > 0 {
+			fmt.Printf("  Exempted:  %s\n", totalExempted)
+		}
+		if staleMailAge > 0 {
+			fmt.Printf("  Mail:      %d stale unread closed\n", totalStaleMail)
+		}
 		fmt.Printf("  Open:      %d wisps remain\n", totalOpen)
 
 		return nil
 	},
 }
 
-func init() {
-	// Shared flags
-	// GH#2601: Default host/port from env vars for non-localhost setups.
+// reaperDefaultHostPort returns the Dolt server to connect to when --host and
+// --port are not given.
+// GH#2601: Default host/port from env vars for non-localhost setups.
+func reaperDefaultHostPort() (string, int) {
 	defaultHost := "127.0.0.1"
 	if h := os.Getenv("GT_DOLT_HOST"); h != "" {
 		defaultHost = h
@@ -590,7 +869,12 @@ func init() {
 			defaultPort = v
 		}
 	}
+	return defaultHost, defaultPort
+}
 
+func init() {
+	// Shared flags
+	defaultHost, defaultPort := reaperDefaultHostPort()
 	for _, cmd := range []*cobra.Command{reaperScanCmd, reaperReapCmd, reaperPurgeCmd, reaperAutoCloseCmd, reaperRunCmd, reaperDatabasesCmd} {
 		cmd.Flags().StringVar(&reaperDB, "db", "", "Database name (required for single-db commands)")
 		cmd.Flags().StringVar(&reaperHost, "host", defaultHost, "Dolt server host (env: GT_DOLT_HOST)")
@@ -614,9 +898,23 @@ func init() {
 		cmd.Flags().StringVar(&reaperPurgeAge, "purge-age", "168h", "Max closed wisp age before purging (7d)")
 		cmd.Flags().StringVar(&reaperMailAge, "mail-age", "168h", "Max closed mail age before purging (7d)")
 	}
+	for _, cmd := range []*cobra.Command{reaperPurgeCmd, reaperRunCmd} {
+		cmd.Flags().StringVar(&reaperStaleMailAge, "stale-mail-age", "", "Close open mail with no updates for this long (empty = leave open mail alone)")
+		cmd.Flags().BoolVar(&reaperTombstone, "tombstone", false, "Keep purged rows as stubs (status \"purged\") instead of deleting them")
+		cmd.Flags().IntVar(&reaperKeepRecent, "keep-recent-per-type", 0, "Never purge the N most recently closed wisps of each wisp type (0 = keep none)")
+		cmd.Flags().StringSliceVar(&reaperExcludeTypes, "exclude-type", nil, "Never purge closed wisps of these wisp types (comma-separated or repeated)")
+		cmd.Flags().StringVar(&reaperDeferEvents, "defer-recent-events", "", "Defer purging closed wisps with wisp_events activity within this long (e.g. 72h; empty = off)")
+		cmd.Flags().IntVar(&reaperConcurrency, "batch-concurrency", 1, "Closed-wisp delete batches to run at once within each database")
+	}
 	for _, cmd := range []*cobra.Command{reaperScanCmd, reaperAutoCloseCmd, reaperRunCmd} {
 		cmd.Flags().StringVar(&reaperStaleAge, "stale-age", "720h", "Max issue staleness before auto-close (30d)")
 	}
+	for _, cmd := range []*cobra.Command{reaperAutoCloseCmd, reaperRunCmd} {
+		cmd.Flags().StringVar(&reaperSince, "since", "", "Only auto-close issues last updated at or after this date (YYYY-MM-DD or RFC 3339)")
+		cmd.Flags().StringVar(&reaperUntil, "until", "", "Only auto-close issues last updated before this date (YYYY-MM-DD or RFC 3339)")
+		cmd.Flags().BoolVar(&reaperBusinessDays, "business-days", false, "Count only weekdays toward --stale-age")
+		cmd.Flags().StringSliceVar(&reaperHolidays, "holidays", nil, "With --business-days, extra non-working days (YYYY-MM-DD, comma-separated)")
+	}
 
 	reaperCmd.AddCommand(reaperDatabasesCmd)
 	reaperCmd.AddCommand(reaperScanCmd)
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"reflect"
 	"testing"
 )
@@ -10,7 +11,7 @@ func TestReaperDatabaseNamesTrimsConfiguredList(t *testing.T) {
 	t.Cleanup(func() { reaperDB = oldDB })
 
 	reaperDB = " hq, gastown ,, beads "
-	got := reaperDatabaseNames()
+	got := reaperDatabaseNames(context.Background())
 	want := []string{"hq", "gastown", "beads"}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("reaperDatabaseNames() = %#v, want %#v", got, want)
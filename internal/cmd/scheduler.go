@@ -1,27 +1,45 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 )
 
 var (
-	schedulerStatusJSON bool
-	schedulerListJSON   bool
-	schedulerClearBead  string
-	schedulerRunBatch   int
-	schedulerRunDryRun  bool
+	schedulerStatusJSON     bool
+	schedulerStatusWatch    bool
+	schedulerStatusInterval time.Duration
+	schedulerListJSON       bool
+	schedulerListBlocked    bool
+	schedulerListReady      bool
+	schedulerClearBead      string
+	schedulerRunBatch       int
+	schedulerRunDryRun      bool
+
+	schedulerRunVerbose     bool
+	schedulerRunStepTimeout time.Duration
+	schedulerRunMaxRuntime  time.Duration
+
+	schedulerResumeRun bool
 )
 
 var schedulerCmd = &cobra.Command{
@@ -33,10 +51,13 @@ var schedulerCmd = &cobra.Command{
 Subcommands:
   gt scheduler status    # Show scheduler state
   gt scheduler list      # List all scheduled beads
+  gt scheduler top       # Live table, longest-waiting first
   gt scheduler run       # Manual dispatch trigger
   gt scheduler pause     # Pause dispatch
   gt scheduler resume    # Resume dispatch
   gt scheduler clear     # Remove beads from scheduler
+  gt scheduler fix       # Remove beads with a missing/unknown target rig
+  gt scheduler state     # Show or reset the runtime state file
 
 Config:
   gt config set scheduler.max_polecats 5    # Enable deferred dispatch
@@ -65,7 +86,14 @@ var schedulerPauseCmd = &cobra.Command{
 var schedulerResumeCmd = &cobra.Command{
 	Use:   "resume",
 	Short: "Resume scheduler dispatch",
-	RunE:  runSchedulerResume,
+	Long: `Resume scheduler dispatch after 'gt scheduler pause'.
+
+With --run, scheduled work is dispatched once right away, within the usual
+capacity and batch limits, instead of waiting for the next daemon heartbeat.
+
+  gt scheduler resume
+  gt scheduler resume --run`,
+	RunE: runSchedulerResume,
 }
 
 var schedulerClearCmd = &cobra.Command{
@@ -74,7 +102,10 @@ var schedulerClearCmd = &cobra.Command{
 	Long: `Remove beads from the scheduler by closing sling context beads.
 
 Without --bead, removes ALL beads from the scheduler.
-With --bead, removes only the specified bead.`,
+With --bead, removes only the specified bead.
+
+This cannot be undone. Run 'gt scheduler export <file>' first to keep a copy
+that 'gt scheduler import <file>' can restore.`,
 	RunE: runSchedulerClear,
 }
 
@@ -88,16 +119,31 @@ but can be run ad-hoc. Useful for testing or when the daemon is not running.
 
   gt scheduler run                  # Dispatch using config defaults
   gt scheduler run --batch 5        # Dispatch up to 5
-  gt scheduler run --dry-run        # Preview what would dispatch`,
+  gt scheduler run --dry-run        # Preview what would dispatch
+  gt scheduler run --verbose        # Stream each bead's startup sub-steps
+  gt scheduler run --max-runtime 5m # Stop starting new spawns after 5 minutes
+
+With --verbose, each dispatch prints its sub-steps (polecat spawn and branch,
+formula, hook, session start) as they happen. If one sub-step runs longer than
+--step-timeout, a warning names the stalled step; dispatch keeps waiting.
+
+With --max-runtime, no new spawn starts once the run has taken longer than the
+bound; a spawn already in flight finishes. The remaining beads stay scheduled
+for the next run. Use it from cron so one run cannot overlap the next.`,
 	RunE: runSchedulerRun,
 }
 
 func init() {
 	// Status flags
 	schedulerStatusCmd.Flags().BoolVar(&schedulerStatusJSON, "json", false, "Output as JSON")
+	schedulerStatusCmd.Flags().BoolVarP(&schedulerStatusWatch, "watch", "w", false, "Watch mode: redraw status continuously")
+	schedulerStatusCmd.Flags().DurationVarP(&schedulerStatusInterval, "interval", "n", 2*time.Second, "Refresh interval for --watch")
 
 	// List flags
 	schedulerListCmd.Flags().BoolVar(&schedulerListJSON, "json", false, "Output as JSON")
+	schedulerListCmd.Flags().BoolVar(&schedulerListBlocked, "blocked", false, "Show only blocked beads")
+	schedulerListCmd.Flags().BoolVar(&schedulerListReady, "ready", false, "Show only beads ready to dispatch")
+	schedulerListCmd.MarkFlagsMutuallyExclusive("blocked", "ready")
 
 	// Clear flags
 	schedulerClearCmd.Flags().StringVar(&schedulerClearBead, "bead", "", "Remove specific bead from scheduler")
@@ -105,6 +151,12 @@ func init() {
 	// Run flags
 	schedulerRunCmd.Flags().IntVar(&schedulerRunBatch, "batch", 0, "Override batch size (0 = use config)")
 	schedulerRunCmd.Flags().BoolVar(&schedulerRunDryRun, "dry-run", false, "Preview what would dispatch")
+	schedulerRunCmd.Flags().BoolVarP(&schedulerRunVerbose, "verbose", "v", false, "Stream per-bead dispatch sub-steps as they happen")
+	schedulerRunCmd.Flags().DurationVar(&schedulerRunStepTimeout, "step-timeout", 2*time.Minute, "With --verbose, warn when one dispatch sub-step runs longer than this (0 = never)")
+	schedulerRunCmd.Flags().DurationVar(&schedulerRunMaxRuntime, "max-runtime", 0, "Stop starting new spawns once the run has taken this long (0 = no limit)")
+
+	// Resume flags
+	schedulerResumeCmd.Flags().BoolVar(&schedulerResumeRun, "run", false, "Dispatch scheduled work once after resuming")
 
 	// Build command tree (flat — no intermediary "capacity" level)
 	schedulerCmd.AddCommand(schedulerStatusCmd)
@@ -124,6 +176,74 @@ type scheduledBeadInfo struct {
 	Status    string `json:"status"`
 	TargetRig string `json:"target_rig"`
 	Blocked   bool   `json:"blocked,omitempty"`
+	// Misconfigured says why the bead cannot dispatch (e.g. no target rig);
+	// such beads are never dispatched. See 'gt scheduler fix'.
+	Misconfigured string `json:"misconfigured,omitempty"`
+	EnqueuedAt    string `json:"enqueued_at,omitempty"`
+	// Priority is the effective dispatch priority: the gt sling --priority
+	// override if set, otherwise the work bead's own priority.
+	Priority int `json:"priority"`
+	// BlockedBy lists the beads blocking this one, when bd names them.
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// schedulerStatusSnapshot is the data shown by `gt scheduler status`.
+type schedulerStatusSnapshot struct {
+	Paused         bool                    `json:"paused"`
+	PausedBy       string                  `json:"paused_by,omitempty"`
+	ScheduledTotal int                     `json:"queued_total"`
+	ScheduledReady int                     `json:"queued_ready"`
+	ActivePolecats int                     `json:"active_polecats"`
+	Capacity       polecatCapacitySnapshot `json:"capacity"`
+	LastDispatchAt string                  `json:"last_dispatch_at,omitempty"`
+	LastDispatchN  int                     `json:"-"`
+	Beads          []scheduledBeadInfo     `json:"beads"`
+	Misconfigured  []scheduledBeadInfo     `json:"misconfigured,omitempty"`
+	Reserved       int                     `json:"reserved_for_interactive"` // free slots left for interactive slings
+}
+
+// gatherSchedulerStatus collects scheduler state, scheduled beads, and
+// polecat capacity for display.
+func gatherSchedulerStatus(townRoot string) (*schedulerStatusSnapshot, error) {
+	state, err := capacity.LoadState(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("loading scheduler state: %w", err)
+	}
+
+	scheduled, err := listScheduledBeads(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled beads: %w", err)
+	}
+
+	capacitySnapshot, err := polecatCapacitySnapshotForTown(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("loading polecat capacity: %w", err)
+	}
+
+	var reserved int
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		reserved = settings.Scheduler.GetReserveForInteractive()
+	}
+
+	snap := &schedulerStatusSnapshot{
+		Reserved:       reserved,
+		Paused:         state.Paused,
+		PausedBy:       state.PausedBy,
+		ScheduledTotal: len(scheduled),
+		ActivePolecats: capacitySnapshot.ActiveSessions,
+		Capacity:       capacitySnapshot,
+		LastDispatchAt: state.LastDispatchAt,
+		LastDispatchN:  state.LastDispatchCount,
+		Beads:          scheduled,
+	}
+	for _, b := range scheduled {
+		if b.Misconfigured != "" {
+			snap.Misconfigured = append(snap.Misconfigured, b)
+		} else if !b.Blocked {
+			snap.ScheduledReady++
+		}
+	}
+	return snap, nil
 }
 
 func runSchedulerStatus(cmd *cobra.Command, args []string) error {
@@ -132,64 +252,37 @@ func runSchedulerStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	state, err := capacity.LoadState(townRoot)
-	if err != nil {
-		return fmt.Errorf("loading scheduler state: %w", err)
+	if schedulerStatusWatch {
+		return runSchedulerStatusWatch(townRoot)
 	}
 
-	scheduled := listScheduledBeads(townRoot)
-
-	capacitySnapshot, err := polecatCapacitySnapshotForTown(townRoot)
+	snap, err := gatherSchedulerStatus(townRoot)
 	if err != nil {
-		return fmt.Errorf("loading polecat capacity: %w", err)
+		return err
 	}
 
 	if schedulerStatusJSON {
-		out := struct {
-			Paused         bool                    `json:"paused"`
-			PausedBy       string                  `json:"paused_by,omitempty"`
-			ScheduledTotal int                     `json:"queued_total"`
-			ScheduledReady int                     `json:"queued_ready"`
-			ActivePolecats int                     `json:"active_polecats"`
-			Capacity       polecatCapacitySnapshot `json:"capacity"`
-			LastDispatchAt string                  `json:"last_dispatch_at,omitempty"`
-			Beads          []scheduledBeadInfo     `json:"beads"`
-		}{
-			Paused:         state.Paused,
-			PausedBy:       state.PausedBy,
-			ScheduledTotal: len(scheduled),
-			ActivePolecats: capacitySnapshot.ActiveSessions,
-			Capacity:       capacitySnapshot,
-			LastDispatchAt: state.LastDispatchAt,
-			Beads:          scheduled,
-		}
-		for _, b := range scheduled {
-			if !b.Blocked {
-				out.ScheduledReady++
-			}
-		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(out)
+		return enc.Encode(snap)
 	}
 
-	readyCount := 0
-	for _, b := range scheduled {
-		if !b.Blocked {
-			readyCount++
-		}
-	}
+	printSchedulerStatus(os.Stdout, snap)
+	return nil
+}
 
-	fmt.Printf("%s\n\n", style.Bold.Render("Scheduler Status"))
-	if state.Paused {
-		fmt.Printf("  State:    %s (by %s)\n", style.Warning.Render("PAUSED"), state.PausedBy)
+func printSchedulerStatus(w io.Writer, snap *schedulerStatusSnapshot) {
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render("Scheduler Status"))
+	if snap.Paused {
+		fmt.Fprintf(w, "  State:    %s (by %s)\n", style.Warning.Render("PAUSED"), snap.PausedBy)
 	} else {
-		fmt.Printf("  State:    active\n")
+		fmt.Fprintf(w, "  State:    active\n")
 	}
-	fmt.Printf("  Scheduled: %d total, %d ready\n", len(scheduled), readyCount)
-	fmt.Printf("  Active:    %d polecats\n", capacitySnapshot.ActiveSessions)
+	fmt.Fprintf(w, "  Scheduled: %d total, %d ready\n", snap.ScheduledTotal, snap.ScheduledReady)
+	fmt.Fprintf(w, "  Active:    %d polecats\n", snap.ActivePolecats)
+	capacitySnapshot := snap.Capacity
 	if capacitySnapshot.Max > 0 {
-		fmt.Printf("  Capacity:  %d free of %d (working: %d, recovery: %d, reservations: %d, reusable idle: %d, pending MR: %d)\n",
+		fmt.Fprintf(w, "  Capacity:  %d free of %d (working: %d, recovery: %d, reservations: %d, reusable idle: %d, pending MR: %d)\n",
 			capacitySnapshot.Free,
 			capacitySnapshot.Max,
 			capacitySnapshot.Working,
@@ -198,14 +291,86 @@ func runSchedulerStatus(cmd *cobra.Command, args []string) error {
 			capacitySnapshot.ReusableIdle,
 			capacitySnapshot.PendingMR,
 		)
+		if snap.Reserved > 0 {
+			usable := capacitySnapshot.Free - snap.Reserved
+			if usable < 0 {
+				usable = 0
+			}
+			fmt.Fprintf(w, "  Reserved:  %d slot(s) for interactive slings; scheduler may use %d\n", snap.Reserved, usable)
+		}
 	} else {
-		fmt.Printf("  Capacity:  direct dispatch (scheduler.max_polecats=%d)\n", capacitySnapshot.Max)
+		fmt.Fprintf(w, "  Capacity:  direct dispatch (scheduler.max_polecats=%d)\n", capacitySnapshot.Max)
+	}
+	if snap.LastDispatchAt != "" {
+		fmt.Fprintf(w, "  Last dispatch: %s (%d beads)\n", snap.LastDispatchAt, snap.LastDispatchN)
 	}
-	if state.LastDispatchAt != "" {
-		fmt.Printf("  Last dispatch: %s (%d beads)\n", state.LastDispatchAt, state.LastDispatchCount)
+	if len(snap.Misconfigured) > 0 {
+		fmt.Fprintf(w, "\n  %s %d (will not dispatch)\n", style.Warning.Render("Misconfigured:"), len(snap.Misconfigured))
+		for _, b := range snap.Misconfigured {
+			fmt.Fprintf(w, "    %s: %s\n", b.ID, b.Misconfigured)
+		}
+		fmt.Fprintf(w, "  %s\n", style.Dim.Render("Fix: gt scheduler fix, then re-schedule with gt sling <bead> <rig>"))
 	}
+}
 
-	return nil
+// runSchedulerStatusWatch redraws scheduler status every --interval until
+// interrupted. A failed refresh keeps showing the last good snapshot with a
+// warning instead of ending the loop.
+func runSchedulerStatusWatch(townRoot string) error {
+	if schedulerStatusJSON {
+		return fmt.Errorf("--json and --watch cannot be used together")
+	}
+	if schedulerStatusInterval <= 0 {
+		return fmt.Errorf("interval must be positive, got %v", schedulerStatusInterval)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(schedulerStatusInterval)
+	defer ticker.Stop()
+
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+
+	var lastGood *schedulerStatusSnapshot
+	var lastGoodAt time.Time
+	for {
+		var buf bytes.Buffer
+		if isTTY {
+			buf.WriteString("\033[H\033[2J") // ANSI: cursor home + clear screen
+		}
+		header := fmt.Sprintf("[%s] gt scheduler status --watch (every %v, Ctrl+C to stop)",
+			time.Now().Format("15:04:05"), schedulerStatusInterval)
+		fmt.Fprintf(&buf, "%s\n\n", style.Dim.Render(header))
+
+		snap, err := gatherSchedulerStatus(townRoot)
+		if err == nil {
+			lastGood, lastGoodAt = snap, time.Now()
+		} else if lastGood != nil {
+			fmt.Fprintf(&buf, "%s Refresh failed: %v (showing data from %s)\n\n",
+				style.Warning.Render("⚠"), err, lastGoodAt.Format("15:04:05"))
+			snap = lastGood
+		}
+
+		if snap != nil {
+			printSchedulerStatus(&buf, snap)
+		} else {
+			fmt.Fprintf(&buf, "Error: %v\n", err)
+		}
+
+		// Write the entire frame at once so the terminal never shows a blank screen.
+		_, _ = os.Stdout.Write(buf.Bytes())
+
+		select {
+		case <-sigChan:
+			if isTTY {
+				fmt.Println("\nStopped.")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
 func runSchedulerList(cmd *cobra.Command, args []string) error {
@@ -214,7 +379,11 @@ func runSchedulerList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	scheduled := listScheduledBeads(townRoot)
+	all, err := listScheduledBeads(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing scheduled beads: %w", err)
+	}
+	scheduled := filterScheduledBeads(all, schedulerListBlocked, schedulerListReady)
 
 	if schedulerListJSON {
 		enc := json.NewEncoder(os.Stdout)
@@ -222,6 +391,10 @@ func runSchedulerList(cmd *cobra.Command, args []string) error {
 		return enc.Encode(scheduled)
 	}
 
+	if len(scheduled) == 0 && (schedulerListBlocked || schedulerListReady) {
+		fmt.Println("No matching beads scheduled.")
+		return nil
+	}
 	if len(scheduled) == 0 {
 		fmt.Println("No beads scheduled.")
 		fmt.Println("Enable deferred dispatch with: gt config set scheduler.max_polecats <N>")
@@ -235,13 +408,23 @@ func runSchedulerList(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s (%d beads)\n\n", style.Bold.Render("Scheduled Work"), len(scheduled))
 	for rig, beads := range byRig {
+		// Show beads in the order they dispatch: priority, then FIFO.
+		sort.SliceStable(beads, func(i, j int) bool { return beads[i].Priority < beads[j].Priority })
 		fmt.Printf("  %s (%d):\n", style.Bold.Render(rig), len(beads))
 		for _, b := range beads {
 			indicator := "○"
-			if b.Blocked {
+			if b.Misconfigured != "" {
+				indicator = "✗"
+			} else if b.Blocked {
 				indicator = "⏸"
 			}
-			fmt.Printf("    %s %s: %s\n", indicator, b.ID, b.Title)
+			fmt.Printf("    %s %s %s: %s\n", indicator, style.Dim.Render(fmt.Sprintf("P%d", b.Priority)), b.ID, b.Title)
+			if b.Misconfigured != "" {
+				fmt.Printf("      %s\n", style.Warning.Render(b.Misconfigured+" — see 'gt scheduler fix'"))
+			}
+			if len(b.BlockedBy) > 0 {
+				fmt.Printf("      %s\n", style.Dim.Render("blocked by: "+strings.Join(b.BlockedBy, ", ")))
+			}
 		}
 		fmt.Println()
 	}
@@ -288,16 +471,19 @@ func runSchedulerResume(cmd *cobra.Command, args []string) error {
 
 	if !state.Paused {
 		fmt.Printf("%s Scheduler is not paused\n", style.Dim.Render("○"))
-		return nil
+	} else {
+		state.SetResumed()
+		if err := capacity.SaveState(townRoot, state); err != nil {
+			return fmt.Errorf("saving scheduler state: %w", err)
+		}
+		fmt.Printf("%s Scheduler resumed\n", style.Bold.Render("▶"))
 	}
 
-	state.SetResumed()
-	if err := capacity.SaveState(townRoot, state); err != nil {
-		return fmt.Errorf("saving scheduler state: %w", err)
+	if !schedulerResumeRun {
+		return nil
 	}
-
-	fmt.Printf("%s Scheduler resumed\n", style.Bold.Render("▶"))
-	return nil
+	_, err = dispatchScheduledWork(townRoot, detectActor(), 0, false, slingDispatcher{})
+	return err
 }
 
 func runSchedulerClear(cmd *cobra.Command, args []string) error {
@@ -360,18 +546,44 @@ func runSchedulerRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	_, err = dispatchScheduledWork(townRoot, detectActor(), schedulerRunBatch, schedulerRunDryRun)
+	_, err = dispatchScheduledWork(townRoot, detectActor(), schedulerRunBatch, schedulerRunDryRun, slingDispatcher{})
 	return err
 }
 
+// filterScheduledBeads keeps only blocked beads (blocked) or only beads ready
+// to dispatch (ready). Misconfigured beads never dispatch, so they are not
+// ready. With neither set, all beads are kept.
+func filterScheduledBeads(scheduled []scheduledBeadInfo, blocked, ready bool) []scheduledBeadInfo {
+	if !blocked && !ready {
+		return scheduled
+	}
+	var result []scheduledBeadInfo
+	for _, b := range scheduled {
+		if blocked && b.Blocked {
+			result = append(result, b)
+		} else if ready && !b.Blocked && b.Misconfigured == "" {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
 // listScheduledBeads returns info about all scheduled beads for display.
 // Reconciles sling context beads with work bead readiness to mark blocked status.
 // Uses batch fetch for work bead info to avoid N+1 subprocess spawns.
-func listScheduledBeads(townRoot string) []scheduledBeadInfo {
-	allContexts := listAllSlingContexts(townRoot)
+// A missing or failing bd is an error, not an empty scheduler.
+func listScheduledBeads(townRoot string) ([]scheduledBeadInfo, error) {
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	allContexts := make([]*beads.Issue, 0, len(records))
+	for _, rec := range records {
+		allContexts = append(allContexts, rec.issue)
+	}
 
 	if len(allContexts) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Collect work bead IDs from contexts for targeted fetch
@@ -386,9 +598,14 @@ func listScheduledBeads(townRoot string) []scheduledBeadInfo {
 	// Build blockedIDs set and batch-fetch work bead info for specific IDs.
 	// bd blocked is fast because it reads the cached blocked set; bd ready walks
 	// the full ready graph and is too slow for scheduler display paths.
-	blockedWorkIDs, _ := listBlockedWorkBeadIDsWithError(townRoot, workBeadIDs)
+	blockers, _ := listWorkBeadBlockersWithError(townRoot, workBeadIDs)
+	blockedWorkIDs := make(map[string]bool, len(blockers))
+	for id := range blockers {
+		blockedWorkIDs[id] = true
+	}
 	workBeadInfo := batchFetchBeadInfoByIDs(townRoot, workBeadIDs)
 
+	knownRigs := loadKnownRigSet(townRoot)
 	seenWork := make(map[string]bool)
 	var result []scheduledBeadInfo
 	for _, ctx := range allContexts {
@@ -422,15 +639,19 @@ func listScheduledBeads(townRoot string) []scheduledBeadInfo {
 		}
 
 		result = append(result, scheduledBeadInfo{
-			ID:        fields.WorkBeadID,
-			Title:     title,
-			Status:    status,
-			TargetRig: fields.TargetRig,
-			Blocked:   !isScheduledWorkBeadReady(fields.WorkBeadID, info, found, blockedWorkIDs),
+			ID:            fields.WorkBeadID,
+			Title:         title,
+			Status:        status,
+			TargetRig:     fields.TargetRig,
+			Blocked:       !isScheduledWorkBeadReady(fields.WorkBeadID, info, found, blockedWorkIDs),
+			Misconfigured: scheduledRigProblem(fields.TargetRig, knownRigs),
+			EnqueuedAt:    fields.EnqueuedAt,
+			Priority:      effectiveDispatchPriority(fields, info),
+			BlockedBy:     blockers[fields.WorkBeadID],
 		})
 	}
 
-	return result
+	return result, nil
 }
 
 // listAllScheduledBeadIDs returns the work bead IDs of all scheduled beads.
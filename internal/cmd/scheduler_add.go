@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	schedulerAddPriority int
+	schedulerAddArgs     string
+	schedulerAddNoConvoy bool
+	schedulerAddDryRun   bool
+)
+
+var schedulerAddCmd = &cobra.Command{
+	Use:   "add <bead>... <rig>",
+	Short: "Schedule existing beads for a rig without slinging",
+	Long: `Schedule one or more existing beads for deferred dispatch to a rig.
+
+This is the scheduling half of 'gt sling' on its own, for bulk-scheduling
+beads from scripts: each bead gets a sling context targeting the rig, with
+the rig's default formula, and is dispatched by the scheduler as capacity
+frees up. The rig must be registered, and beads that are already scheduled
+are skipped and reported.
+
+  gt scheduler add gt-abc gt-def gastown
+  gt scheduler add gt-abc gastown --priority 1 --args "focus on tests"`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE:         runSchedulerAdd,
+}
+
+func init() {
+	schedulerAddCmd.Flags().IntVar(&schedulerAddPriority, "priority", -1, "Dispatch priority override, 0-4 (0=highest; default: the bead's own priority)")
+	schedulerAddCmd.Flags().StringVar(&schedulerAddArgs, "args", "", "Natural language instructions for the executor")
+	schedulerAddCmd.Flags().BoolVar(&schedulerAddNoConvoy, "no-convoy", false, "Skip auto-convoy creation")
+	schedulerAddCmd.Flags().BoolVar(&schedulerAddDryRun, "dry-run", false, "Show what would be scheduled without scheduling")
+	schedulerCmd.AddCommand(schedulerAddCmd)
+}
+
+// schedulerAddTargets splits `gt scheduler add` arguments into the bead IDs,
+// deduplicated in order, and the trailing rig.
+func schedulerAddTargets(args []string) ([]string, string) {
+	rigName := args[len(args)-1]
+	seen := make(map[string]bool)
+	var beadIDs []string
+	for _, id := range args[:len(args)-1] {
+		if !seen[id] {
+			seen[id] = true
+			beadIDs = append(beadIDs, id)
+		}
+	}
+	return beadIDs, rigName
+}
+
+func runSchedulerAdd(cmd *cobra.Command, args []string) error {
+	beadIDs, rigName := schedulerAddTargets(args)
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	if problem := scheduledRigProblem(rigName, loadKnownRigSet(townRoot)); problem != "" {
+		return fmt.Errorf("cannot schedule to %s: %s", rigName, problem)
+	}
+	if err := validateSlingPriority(schedulerAddPriority, true); err != nil {
+		return err
+	}
+	var priority *int
+	if schedulerAddPriority >= 0 {
+		p := schedulerAddPriority
+		priority = &p
+	}
+
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return err
+	}
+	formula := resolveFormula("", false, townRoot, rigName)
+	added, skipped, failed := 0, 0, 0
+	for _, beadID := range beadIDs {
+		if rec, fields := findScheduledContext(records, beadID); rec != nil {
+			fmt.Printf("  %s %s: already scheduled to %s (context: %s)\n", style.Dim.Render("○"), beadID, fields.TargetRig, rec.issue.ID)
+			skipped++
+			continue
+		}
+		err := scheduleBead(beadID, rigName, ScheduleOptions{
+			Formula:  formula,
+			Args:     schedulerAddArgs,
+			NoConvoy: schedulerAddNoConvoy,
+			DryRun:   schedulerAddDryRun,
+			Priority: priority,
+		})
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), beadID, err)
+			failed++
+			continue
+		}
+		added++
+	}
+
+	verb := "Added"
+	if schedulerAddDryRun {
+		verb = "Would add"
+	}
+	summary := fmt.Sprintf("%s %d/%d beads to %s", verb, added, len(beadIDs), rigName)
+	if skipped > 0 {
+		summary += fmt.Sprintf(" (%d already scheduled)", skipped)
+	}
+	fmt.Printf("\n%s %s\n", style.Bold.Render("📊"), summary)
+	if failed > 0 && added == 0 {
+		return fmt.Errorf("all %d schedule attempts failed", failed)
+	}
+	return nil
+}
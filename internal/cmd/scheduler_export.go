@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// schedulerExportVersion is bumped if the export file format changes.
+const schedulerExportVersion = 1
+
+// slingContextTitlePrefix is what CreateSlingContext puts before the work
+// bead's title in a sling context bead's title.
+const slingContextTitlePrefix = "sling-context: "
+
+var schedulerImportDryRun bool
+
+var schedulerExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Save scheduled beads to a JSON file",
+	Long: `Write every scheduled bead to a JSON file so it can be restored later.
+
+Each entry records the work bead, its target rig, whether it is currently
+blocked, and the full sling context (formula, vars, merge strategy, etc.).
+Use "-" to write to stdout.
+
+Run this before 'gt scheduler clear' — clear cannot be undone otherwise.
+
+  gt scheduler export scheduled.json
+  gt scheduler import scheduled.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSchedulerExport,
+}
+
+var schedulerImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Re-schedule beads from a JSON export",
+	Long: `Re-create sling contexts from a file written by 'gt scheduler export'.
+
+Each entry gets a new sling context in its target rig with the exported
+fields, including the original enqueue time, so dispatch order is preserved.
+Beads that are already scheduled, or whose work bead is now closed or
+hooked, are skipped.
+
+  gt scheduler import scheduled.json
+  gt scheduler import scheduled.json --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSchedulerImport,
+}
+
+func init() {
+	schedulerImportCmd.Flags().BoolVar(&schedulerImportDryRun, "dry-run", false, "Show what would be re-scheduled without changing anything")
+
+	schedulerCmd.AddCommand(schedulerExportCmd)
+	schedulerCmd.AddCommand(schedulerImportCmd)
+}
+
+// schedulerExport is the file format written by `gt scheduler export`.
+type schedulerExport struct {
+	Version    int                    `json:"version"`
+	ExportedAt string                 `json:"exported_at"`
+	Entries    []schedulerExportEntry `json:"entries"`
+}
+
+// schedulerExportEntry is one scheduled work bead.
+type schedulerExportEntry struct {
+	WorkBeadID string                       `json:"work_bead_id"`
+	Title      string                       `json:"title"`
+	TargetRig  string                       `json:"target_rig"`
+	Blocked    bool                         `json:"blocked,omitempty"`
+	ContextID  string                       `json:"context_id"`
+	Context    *capacity.SlingContextFields `json:"context"`
+}
+
+func runSchedulerExport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return err
+	}
+	var workBeadIDs []string
+	for _, rec := range records {
+		if fields := beads.ParseSlingContextFields(rec.issue.Description); fields != nil {
+			workBeadIDs = append(workBeadIDs, fields.WorkBeadID)
+		}
+	}
+	blocked, err := listBlockedWorkBeadIDsWithError(townRoot, workBeadIDs)
+	if err != nil {
+		return fmt.Errorf("checking blocked work beads: %w", err)
+	}
+	info := batchFetchBeadInfoByIDs(townRoot, workBeadIDs)
+
+	export := buildSchedulerExport(records, blocked, info, time.Now())
+
+	if args[0] == "-" {
+		return writeSchedulerExport(os.Stdout, export)
+	}
+	f, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+	if err := writeSchedulerExport(f, export); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing export file: %w", err)
+	}
+	fmt.Printf("%s Exported %d scheduled bead(s) to %s\n", style.Bold.Render("✓"), len(export.Entries), args[0])
+	return nil
+}
+
+// writeSchedulerExport encodes export as indented JSON to w.
+func writeSchedulerExport(w io.Writer, export *schedulerExport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(export); err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	return nil
+}
+
+// buildSchedulerExport converts open sling contexts into export entries,
+// one per work bead (oldest context wins, as in dispatch), in enqueue order.
+func buildSchedulerExport(records []slingContextRecord, blocked map[string]bool, info map[string]beadStatusInfo, now time.Time) *schedulerExport {
+	type parsed struct {
+		rec    slingContextRecord
+		fields *capacity.SlingContextFields
+	}
+	var contexts []parsed
+	for _, rec := range records {
+		if fields := beads.ParseSlingContextFields(rec.issue.Description); fields != nil {
+			contexts = append(contexts, parsed{rec, fields})
+		}
+	}
+	sort.SliceStable(contexts, func(i, j int) bool {
+		if contexts[i].fields.EnqueuedAt != contexts[j].fields.EnqueuedAt {
+			return contexts[i].fields.EnqueuedAt < contexts[j].fields.EnqueuedAt
+		}
+		return contexts[i].rec.issue.ID < contexts[j].rec.issue.ID
+	})
+
+	export := &schedulerExport{
+		Version:    schedulerExportVersion,
+		ExportedAt: now.UTC().Format(time.RFC3339),
+		Entries:    []schedulerExportEntry{},
+	}
+	seen := make(map[string]bool)
+	for _, c := range contexts {
+		if seen[c.fields.WorkBeadID] {
+			continue
+		}
+		seen[c.fields.WorkBeadID] = true
+
+		// The context bead's title is "sling-context: <work title>";
+		// CreateSlingContext adds the prefix back on import.
+		title := strings.TrimPrefix(c.rec.issue.Title, slingContextTitlePrefix)
+		if bi, ok := info[c.fields.WorkBeadID]; ok && bi.Title != "" {
+			title = bi.Title
+		}
+		export.Entries = append(export.Entries, schedulerExportEntry{
+			WorkBeadID: c.fields.WorkBeadID,
+			Title:      title,
+			TargetRig:  c.fields.TargetRig,
+			Blocked:    blocked[c.fields.WorkBeadID],
+			ContextID:  c.rec.issue.ID,
+			Context:    c.fields,
+		})
+	}
+	return export
+}
+
+func runSchedulerImport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	export, err := readSchedulerExport(args[0])
+	if err != nil {
+		return err
+	}
+
+	var workBeadIDs []string
+	for _, e := range export.Entries {
+		workBeadIDs = append(workBeadIDs, e.WorkBeadID)
+	}
+	info := batchFetchBeadInfoByIDs(townRoot, workBeadIDs)
+
+	var restored, skipped, failed int
+	for _, e := range export.Entries {
+		if bi, ok := info[e.WorkBeadID]; !ok {
+			fmt.Printf("  %s %s: work bead not found\n", style.Dim.Render("skip"), e.WorkBeadID)
+			skipped++
+			continue
+		} else if bi.Status == "closed" || bi.Status == "tombstone" || bi.Status == "hooked" {
+			fmt.Printf("  %s %s: work bead is %s\n", style.Dim.Render("skip"), e.WorkBeadID, bi.Status)
+			skipped++
+			continue
+		}
+
+		rigBeads := beads.NewWithBeadsDir(townRoot, doltserver.FindRigBeadsDir(townRoot, e.TargetRig))
+		existing, _, err := rigBeads.FindOpenSlingContext(e.WorkBeadID)
+		if err != nil {
+			fmt.Printf("  %s %s: checking for existing context: %v\n", style.Warning.Render("⚠"), e.WorkBeadID, err)
+			failed++
+			continue
+		}
+		if existing != nil {
+			fmt.Printf("  %s %s: already scheduled (context: %s)\n", style.Dim.Render("skip"), e.WorkBeadID, existing.ID)
+			skipped++
+			continue
+		}
+
+		if schedulerImportDryRun {
+			fmt.Printf("  %s %s → %s\n", style.Dim.Render("would schedule"), e.WorkBeadID, e.TargetRig)
+			restored++
+			continue
+		}
+		// Files from older exports may carry the context bead's prefix.
+		title := strings.TrimPrefix(e.Title, slingContextTitlePrefix)
+		ctxBead, err := rigBeads.CreateSlingContext(title, e.WorkBeadID, e.Context)
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), e.WorkBeadID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s %s → %s (context: %s)\n", style.Bold.Render("✓"), e.WorkBeadID, e.TargetRig, ctxBead.ID)
+		restored++
+	}
+
+	verb := "Re-scheduled"
+	if schedulerImportDryRun {
+		verb = "Would re-schedule"
+	}
+	fmt.Printf("%s %d bead(s), skipped %d, failed %d\n", verb, restored, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries could not be re-scheduled", failed, len(export.Entries))
+	}
+	return nil
+}
+
+// readSchedulerExport loads and sanity-checks an export file.
+func readSchedulerExport(path string) (*schedulerExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading export file: %w", err)
+	}
+	var export schedulerExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("parsing export file: %w", err)
+	}
+	if export.Version != schedulerExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d (want %d)", export.Version, schedulerExportVersion)
+	}
+	for i, e := range export.Entries {
+		if e.WorkBeadID == "" || e.TargetRig == "" || e.Context == nil {
+			return nil, fmt.Errorf("entry %d: missing work_bead_id, target_rig or context", i)
+		}
+		if e.Context.WorkBeadID != e.WorkBeadID {
+			return nil, fmt.Errorf("entry %d: context is for %s, not %s", i, e.Context.WorkBeadID, e.WorkBeadID)
+		}
+	}
+	return &export, nil
+}
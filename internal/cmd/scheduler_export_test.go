@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func slingContextRecordForTest(id, workBeadID, rig, enqueuedAt string) slingContextRecord {
+	fields := &capacity.SlingContextFields{
+		Version:    1,
+		WorkBeadID: workBeadID,
+		TargetRig:  rig,
+		EnqueuedAt: enqueuedAt,
+		Formula:    "mol-polecat-work",
+	}
+	return slingContextRecord{issue: &beads.Issue{
+		ID:          id,
+		Title:       "sling-context: " + workBeadID,
+		Description: beads.FormatSlingContextDescription(fields),
+	}}
+}
+
+func TestBuildSchedulerExport(t *testing.T) {
+	records := []slingContextRecord{
+		slingContextRecordForTest("hq-ctx3", "gt-b", "beads", "2026-05-01T10:02:00Z"),
+		slingContextRecordForTest("hq-ctx1", "gt-a", "gastown", "2026-05-01T10:00:00Z"),
+		slingContextRecordForTest("hq-ctx2", "gt-a", "gastown", "2026-05-01T10:01:00Z"), // duplicate, newer
+		{issue: &beads.Issue{ID: "hq-bad", Description: "not json"}},
+	}
+	blocked := map[string]bool{"gt-b": true}
+	info := map[string]beadStatusInfo{"gt-a": {Status: "open", Title: "Fix the thing"}}
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	export := buildSchedulerExport(records, blocked, info, now)
+
+	if export.Version != schedulerExportVersion || export.ExportedAt != "2026-05-01T12:00:00Z" {
+		t.Errorf("header = %d/%s", export.Version, export.ExportedAt)
+	}
+	if len(export.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(export.Entries), export.Entries)
+	}
+	a, b := export.Entries[0], export.Entries[1]
+	if a.WorkBeadID != "gt-a" || a.ContextID != "hq-ctx1" || a.Title != "Fix the thing" || a.Blocked {
+		t.Errorf("first entry = %+v, want oldest gt-a context with work bead title", a)
+	}
+	if a.Context == nil || a.Context.Formula != "mol-polecat-work" {
+		t.Errorf("first entry lost sling context: %+v", a.Context)
+	}
+	if b.WorkBeadID != "gt-b" || b.TargetRig != "beads" || !b.Blocked || b.Title != "gt-b" {
+		t.Errorf("second entry = %+v, want the context title without its sling-context prefix", b)
+	}
+}
+
+func TestReadSchedulerExport(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, v interface{}) string {
+		t.Helper()
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	export := buildSchedulerExport([]slingContextRecord{
+		slingContextRecordForTest("hq-ctx1", "gt-a", "gastown", "2026-05-01T10:00:00Z"),
+	}, nil, nil, time.Now())
+	got, err := readSchedulerExport(write("good.json", export))
+	if err != nil {
+		t.Fatalf("readSchedulerExport: %v", err)
+	}
+	if len(got.Entries) != 1 || got.Entries[0].Context.EnqueuedAt != "2026-05-01T10:00:00Z" {
+		t.Errorf("round trip lost data: %+v", got.Entries)
+	}
+
+	wrongVersion := *export
+	wrongVersion.Version = 99
+	if _, err := readSchedulerExport(write("version.json", wrongVersion)); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("expected version error, got %v", err)
+	}
+
+	mismatched := *export
+	mismatched.Entries = []schedulerExportEntry{export.Entries[0]}
+	mismatched.Entries[0].WorkBeadID = "gt-other"
+	if _, err := readSchedulerExport(write("mismatch.json", mismatched)); err == nil {
+		t.Error("expected error for entry whose context names a different bead")
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var schedulerFixDryRun bool
+
+var schedulerFixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Remove scheduled beads whose target rig is missing or unknown",
+	Long: `Close sling contexts that can never dispatch because their target rig is
+empty or not registered in mayor/rigs.json (e.g. a partially failed
+reschedule, or a rig that was removed).
+
+Dispatch ('gt scheduler run' and the daemon) dead-letters these contexts
+the same way before each cycle; fix does it now without dispatching.
+
+The work beads themselves are not touched. Re-schedule them with:
+  gt sling <bead> <rig>
+
+  gt scheduler fix --dry-run    # Show what would be removed
+  gt scheduler fix`,
+	RunE: runSchedulerFix,
+}
+
+func init() {
+	schedulerFixCmd.Flags().BoolVar(&schedulerFixDryRun, "dry-run", false, "Show misconfigured beads without removing them")
+	schedulerCmd.AddCommand(schedulerFixCmd)
+}
+
+// loadKnownRigSet returns the rigs registered in mayor/rigs.json, or nil if
+// the registry cannot be read.
+func loadKnownRigSet(townRoot string) map[string]bool {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil
+	}
+	known := make(map[string]bool, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		known[name] = true
+	}
+	return known
+}
+
+// scheduledRigProblem returns why a scheduled bead's target rig cannot be
+// dispatched to, or "" if it can. With a nil knownRigs (registry unreadable)
+// only an empty rig is flagged, so a missing rigs.json never strands work.
+func scheduledRigProblem(targetRig string, knownRigs map[string]bool) string {
+	if targetRig == "" {
+		return "no target rig"
+	}
+	if knownRigs != nil && !knownRigs[targetRig] {
+		return fmt.Sprintf("target rig %q is not registered", targetRig)
+	}
+	return ""
+}
+
+// deadLetterUnknownRigError prefixes the dispatch-failed event error of a
+// context dead-lettered because its target rig is not registered.
+const deadLetterUnknownRigError = "unknown rig: "
+
+// deadLetterMisconfiguredContexts closes the sling contexts among records
+// whose target rig cannot be dispatched to, recording a dispatch-failed feed
+// event for each, and returns the other records. A renamed or removed rig
+// never comes back on its own: dispatching would fail deep in polecat spawn
+// and requeue every cycle, so the work bead is freed to be re-slung to a
+// real rig instead. With dryRun nothing is closed. report, if non-nil, is
+// called for each misconfigured context with the close error.
+func deadLetterMisconfiguredContexts(records []slingContextRecord, knownRigs map[string]bool, actor string, dryRun bool,
+	report func(rec slingContextRecord, fields *capacity.SlingContextFields, problem string, err error)) []slingContextRecord {
+	var rest []slingContextRecord
+	for _, rec := range records {
+		fields := beads.ParseSlingContextFields(rec.issue.Description)
+		if fields == nil {
+			rest = append(rest, rec) // invalid contexts are closed by dispatch cleanup
+			continue
+		}
+		problem := scheduledRigProblem(fields.TargetRig, knownRigs)
+		if problem == "" {
+			rest = append(rest, rec)
+			continue
+		}
+
+		var err error
+		if !dryRun {
+			_ = events.LogFeed(events.TypeSchedulerDispatchFailed, actor,
+				events.SchedulerDispatchFailedPayload(fields.WorkBeadID, fields.TargetRig, deadLetterUnknownRigError+problem))
+			err = beadsForContextRecord(rec).CloseSlingContext(rec.issue.ID, schedulerIssueUnknownRig)
+		}
+		if report != nil {
+			report(rec, fields, problem, err)
+		}
+	}
+	return rest
+}
+
+func runSchedulerFix(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return err
+	}
+
+	var fixed, failed int
+	deadLetterMisconfiguredContexts(records, loadKnownRigSet(townRoot), detectActor(), schedulerFixDryRun,
+		func(rec slingContextRecord, fields *capacity.SlingContextFields, problem string, err error) {
+			switch {
+			case schedulerFixDryRun:
+				fmt.Printf("  %s %s (context: %s): %s\n", style.Dim.Render("would remove"), fields.WorkBeadID, rec.issue.ID, problem)
+				fixed++
+			case err != nil:
+				fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), fields.WorkBeadID, err)
+				failed++
+			default:
+				fmt.Printf("  %s Removed %s (context: %s): %s\n", style.Bold.Render("✓"), fields.WorkBeadID, rec.issue.ID, problem)
+				fixed++
+			}
+		})
+	switch {
+	case fixed == 0 && failed == 0:
+		fmt.Println("No misconfigured scheduled beads.")
+	case schedulerFixDryRun:
+		fmt.Printf("Would remove %d misconfigured bead(s)\n", fixed)
+	default:
+		fmt.Printf("Removed %d misconfigured bead(s); re-schedule with: gt sling <bead> <rig>\n", fixed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d context(s) could not be closed", failed)
+	}
+	return nil
+}
@@ -1258,6 +1258,35 @@ func TestSchedulerInvalidJSONContextCleanup(t *testing.T) {
 	}
 }
 
+// TestSchedulerUnknownRigContextDeadLettered verifies that a sling context
+// targeting a rig missing from mayor/rigs.json is closed as "unknown-rig"
+// before dispatch instead of failing and requeueing every cycle.
+func TestSchedulerUnknownRigContextDeadLettered(t *testing.T) {
+	hqPath, rigPath, gtBinary, env := setupSchedulerIntegrationTown(t)
+
+	beadID := createTestBead(t, rigPath, "Unknown rig dead-letter test")
+	ctxID := createSlingContext(t, hqPath, &capacity.SlingContextFields{
+		Version:    1,
+		WorkBeadID: beadID,
+		TargetRig:  "removed-rig",
+		EnqueuedAt: "2026-01-01T00:00:00Z",
+	})
+
+	out := runGTCmdOutput(t, gtBinary, hqPath, env, "scheduler", "run")
+	t.Logf("scheduler run output:\n%s", out)
+
+	townBeads := beads.NewWithBeadsDir(hqPath, filepath.Join(hqPath, ".beads"))
+	contexts, err := townBeads.ListOpenSlingContexts()
+	if err != nil {
+		t.Fatalf("ListOpenSlingContexts failed: %v", err)
+	}
+	for _, ctx := range contexts {
+		if ctx.ID == ctxID {
+			t.Errorf("Unknown-rig context %s should have been dead-lettered, but is still open", ctxID)
+		}
+	}
+}
+
 // TestSchedulerActualDispatchRoutesPollutedEnvToTargetRig verifies the non-dry-run
 // scheduler path uses the same env-routing boundary as direct sling. The parent
 // process is poisoned with HQ BEADS_* selectors; dispatch must still hook and
@@ -1296,7 +1325,7 @@ func TestSchedulerActualDispatchRoutesPollutedEnvToTargetRig(t *testing.T) {
 	t.Setenv("BEADS_DOLT_SERVER_DATABASE", beads.DatabaseNameFromMetadata(filepath.Join(hqPath, ".beads")))
 	t.Setenv("BEADS_DOLT_DATA_DIR", filepath.Join(hqPath, ".wrong-dolt-data"))
 
-	dispatched, err := dispatchScheduledWork(hqPath, "test", 1, false)
+	dispatched, err := dispatchScheduledWork(hqPath, "test", 1, false, slingDispatcher{})
 	if err != nil {
 		t.Fatalf("dispatchScheduledWork: %v", err)
 	}
@@ -1323,6 +1352,63 @@ func TestSchedulerActualDispatchRoutesPollutedEnvToTargetRig(t *testing.T) {
 	}
 }
 
+// recordingDispatcher is a beadDispatcher that records what it was asked to
+// dispatch instead of slinging.
+type recordingDispatcher struct {
+	dispatched []capacity.PendingBead
+}
+
+func (d *recordingDispatcher) Dispatch(b capacity.PendingBead, _, _ string) (*SlingResult, error) {
+	d.dispatched = append(d.dispatched, b)
+	return &SlingResult{PolecatName: "fake"}, nil
+}
+
+// TestSchedulerDispatchUsesInjectedDispatcher verifies the dispatch loop
+// hands ready beads to its dispatcher and closes their contexts on success,
+// without slinging anything itself.
+func TestSchedulerDispatchUsesInjectedDispatcher(t *testing.T) {
+	hqPath, rigPath, _, _ := setupSchedulerIntegrationTown(t)
+
+	beadID := createTestBead(t, rigPath, "Injected dispatcher")
+	ctxID := createSlingContext(t, hqPath, &capacity.SlingContextFields{
+		Version:     1,
+		WorkBeadID:  beadID,
+		TargetRig:   "testrig",
+		HookRawBead: true,
+		EnqueuedAt:  "2026-01-01T00:00:00Z",
+	})
+
+	prevSpawn := spawnPolecatForSling
+	t.Cleanup(func() { spawnPolecatForSling = prevSpawn })
+	spawnPolecatForSling = func(string, SlingSpawnOptions) (*SpawnedPolecatInfo, error) {
+		t.Fatal("dispatch with an injected dispatcher must not spawn a polecat")
+		return nil, nil
+	}
+
+	fake := &recordingDispatcher{}
+	dispatched, err := dispatchScheduledWork(hqPath, "test", 1, false, fake)
+	if err != nil {
+		t.Fatalf("dispatchScheduledWork: %v", err)
+	}
+	if dispatched != 1 || len(fake.dispatched) != 1 {
+		t.Fatalf("dispatched = %d, dispatcher saw %d, want 1 and 1", dispatched, len(fake.dispatched))
+	}
+	if got := fake.dispatched[0]; got.WorkBeadID != beadID || got.TargetRig != "testrig" {
+		t.Errorf("dispatcher got %s → %s, want %s → testrig", got.WorkBeadID, got.TargetRig, beadID)
+	}
+
+	townBeads := beads.NewWithBeadsDir(hqPath, filepath.Join(hqPath, ".beads"))
+	contexts, err := townBeads.ListOpenSlingContexts()
+	if err != nil {
+		t.Fatalf("ListOpenSlingContexts: %v", err)
+	}
+	for _, ctx := range contexts {
+		if ctx.ID == ctxID {
+			t.Errorf("context %s still open after dispatch", ctxID)
+		}
+	}
+}
+
 func TestSchedulerDispatchFailureRecordedInContextSourceDB(t *testing.T) {
 	hqPath, rigPath, _, _ := setupSchedulerIntegrationTown(t)
 
@@ -1344,7 +1430,7 @@ func TestSchedulerDispatchFailureRecordedInContextSourceDB(t *testing.T) {
 		return nil, fmt.Errorf("forced spawn failure")
 	}
 
-	dispatched, err := dispatchScheduledWork(hqPath, "test", 1, false)
+	dispatched, err := dispatchScheduledWork(hqPath, "test", 1, false, slingDispatcher{})
 	if err != nil {
 		t.Fatalf("dispatchScheduledWork: %v", err)
 	}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var schedulerMigrateDryRun bool
+
+var schedulerMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Convert legacy scheduling metadata to sling contexts",
+	Long: `Find work beads scheduled under the legacy format, where scheduling
+metadata was embedded in the work bead's description as a text block
+starting with ` + beads.LegacySchedulerDelimiter + ` and the bead carried
+the ` + capacity.LabelLegacyQueued + ` label.
+
+Each block is converted to a versioned JSON sling context bead, then the
+whole block and the legacy label are removed from the work bead. Beads that
+already have an open sling context are only cleaned up.
+
+  gt scheduler migrate --dry-run    # Show what would be migrated
+  gt scheduler migrate`,
+	SilenceUsage: true,
+	RunE:         runSchedulerMigrate,
+}
+
+func init() {
+	schedulerMigrateCmd.Flags().BoolVar(&schedulerMigrateDryRun, "dry-run", false, "Show legacy beads without migrating them")
+	schedulerCmd.AddCommand(schedulerMigrateCmd)
+}
+
+func runSchedulerMigrate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	var migrated, failed int
+	seen := make(map[string]bool)
+	for _, dir := range beadsSearchDirs(townRoot) {
+		beadsDir := beads.ResolveBeadsDir(dir)
+		b := beads.NewWithBeadsDir(dir, beadsDir)
+		issues, err := b.List(beads.ListOptions{
+			Status:   "open",
+			Label:    capacity.LabelLegacyQueued,
+			Priority: -1,
+		})
+		if err != nil {
+			continue // Unavailable dirs hold nothing we can migrate
+		}
+		for _, issue := range issues {
+			key := beadsDir + "\x00" + issue.ID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			fields := beads.ParseLegacySchedulerMetadata(issue.ID, issue.Description)
+			if fields == nil {
+				continue
+			}
+			if schedulerMigrateDryRun {
+				fmt.Printf("  %s %s → %s\n", style.Dim.Render("would migrate"), issue.ID, fields.TargetRig)
+				migrated++
+				continue
+			}
+			ctx, err := b.MigrateLegacySchedulerMetadata(issue)
+			if err != nil {
+				fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), issue.ID, err)
+				failed++
+				continue
+			}
+			if ctx != nil {
+				fmt.Printf("  %s Migrated %s → %s (context: %s)\n", style.Bold.Render("✓"), issue.ID, fields.TargetRig, ctx.ID)
+			} else {
+				fmt.Printf("  %s Cleaned %s (already has a sling context)\n", style.Bold.Render("✓"), issue.ID)
+			}
+			migrated++
+		}
+	}
+
+	switch {
+	case migrated == 0 && failed == 0:
+		fmt.Println("No legacy scheduled beads.")
+	case schedulerMigrateDryRun:
+		fmt.Printf("Would migrate %d legacy bead(s)\n", migrated)
+	default:
+		fmt.Printf("Migrated %d legacy bead(s)\n", migrated)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d bead(s) could not be migrated", failed)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var schedulerMoveCmd = &cobra.Command{
+	Use:   "move <bead> <rig>",
+	Short: "Retarget a scheduled bead to a different rig",
+	Long: `Change the target rig of a scheduled bead without losing its place.
+
+The sling context keeps its enqueue time, formula, vars and other dispatch
+options; only the target rig changes, and the dispatch failure count is reset
+since earlier failures were against the old rig. The new rig must be
+registered in mayor/rigs.json and accept the bead's ID prefix.
+
+  gt scheduler move gt-abc123 gastown`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSchedulerMove,
+}
+
+func init() {
+	schedulerCmd.AddCommand(schedulerMoveCmd)
+}
+
+func runSchedulerMove(cmd *cobra.Command, args []string) error {
+	workBeadID, newRig := args[0], args[1]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	if problem := scheduledRigProblem(newRig, loadKnownRigSet(townRoot)); problem != "" {
+		return fmt.Errorf("cannot move %s: %s", workBeadID, problem)
+	}
+	rigPrefix := rigBeadsPrefix(townRoot, filepath.Join(townRoot, newRig), newRig)
+	if !capacity.AcceptsPrefix(rigPrefix, workBeadID) {
+		return fmt.Errorf("cannot move %s: rig %s uses prefix %q, dispatch would refuse it", workBeadID, newRig, rigPrefix)
+	}
+
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return err
+	}
+	rec, fields := findScheduledContext(records, workBeadID)
+	if rec == nil {
+		return fmt.Errorf("%s is not scheduled", workBeadID)
+	}
+	oldRig := fields.TargetRig
+	if oldRig == newRig {
+		fmt.Printf("%s %s is already scheduled to %s\n", style.Dim.Render("○"), workBeadID, newRig)
+		return nil
+	}
+
+	moved := retargetSlingContext(fields, newRig)
+	oldBeads := beadsForContextRecord(*rec)
+	newBeadsDir := doltserver.FindRigBeadsDir(townRoot, newRig)
+	if newBeadsDir == rec.beadsDir {
+		if err := oldBeads.UpdateSlingContextFields(rec.issue.ID, moved); err != nil {
+			return fmt.Errorf("updating sling context %s: %w", rec.issue.ID, err)
+		}
+		fmt.Printf("%s Moved %s: %s → %s (context: %s)\n", style.Bold.Render("✓"), workBeadID, oldRig, newRig, rec.issue.ID)
+		return nil
+	}
+
+	// Sling contexts live in their target rig's beads dir (GH#3468), so a
+	// cross-database move creates the new context before closing the old one.
+	// Closing the new one again if that fails keeps exactly one context open.
+	newBeads := beads.NewWithBeadsDir(townRoot, newBeadsDir)
+	title := strings.TrimPrefix(rec.issue.Title, slingContextTitlePrefix)
+	ctxBead, err := newBeads.CreateSlingContext(title, workBeadID, moved)
+	if err != nil {
+		return fmt.Errorf("creating sling context in %s: %w", newRig, err)
+	}
+	if err := oldBeads.CloseSlingContext(rec.issue.ID, "moved"); err != nil {
+		_ = newBeads.CloseSlingContext(ctxBead.ID, "move-failed")
+		return fmt.Errorf("closing old sling context %s: %w", rec.issue.ID, err)
+	}
+	fmt.Printf("%s Moved %s: %s → %s (context: %s)\n", style.Bold.Render("✓"), workBeadID, oldRig, newRig, ctxBead.ID)
+	return nil
+}
+
+// findScheduledContext returns the open sling context that dispatch would use
+// for workBeadID (oldest enqueue time, then lowest ID), or nil.
+func findScheduledContext(records []slingContextRecord, workBeadID string) (*slingContextRecord, *capacity.SlingContextFields) {
+	var best *slingContextRecord
+	var bestFields *capacity.SlingContextFields
+	for i := range records {
+		fields := beads.ParseSlingContextFields(records[i].issue.Description)
+		if fields == nil || fields.WorkBeadID != workBeadID {
+			continue
+		}
+		if best == nil || fields.EnqueuedAt < bestFields.EnqueuedAt ||
+			(fields.EnqueuedAt == bestFields.EnqueuedAt && records[i].issue.ID < best.issue.ID) {
+			best, bestFields = &records[i], fields
+		}
+	}
+	return best, bestFields
+}
+
+// retargetSlingContext returns a copy of fields scheduled to rig. Dispatch
+// failures are cleared: they were recorded against the old rig.
+func retargetSlingContext(fields *capacity.SlingContextFields, rig string) *capacity.SlingContextFields {
+	moved := *fields
+	moved.TargetRig = rig
+	moved.DispatchFailures = 0
+	moved.LastFailure = ""
+	return &moved
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func TestFindScheduledContext(t *testing.T) {
+	records := []slingContextRecord{
+		slingContextRecordForTest("hq-ctx3", "gt-a", "gastown", "2026-05-01T10:02:00Z"),
+		slingContextRecordForTest("hq-ctx2", "gt-a", "gastown", "2026-05-01T10:00:00Z"),
+		slingContextRecordForTest("hq-ctx1", "gt-a", "gastown", "2026-05-01T10:00:00Z"),
+		slingContextRecordForTest("hq-ctx4", "gt-b", "beads", "2026-05-01T09:00:00Z"),
+	}
+
+	rec, fields := findScheduledContext(records, "gt-a")
+	if rec == nil || rec.issue.ID != "hq-ctx1" || fields.WorkBeadID != "gt-a" {
+		t.Fatalf("findScheduledContext(gt-a) = %v, want hq-ctx1", rec)
+	}
+	if rec, _ := findScheduledContext(records, "gt-missing"); rec != nil {
+		t.Errorf("findScheduledContext(gt-missing) = %s, want nil", rec.issue.ID)
+	}
+}
+
+func TestRetargetSlingContext(t *testing.T) {
+	fields := &capacity.SlingContextFields{
+		Version:          1,
+		WorkBeadID:       "gt-a",
+		TargetRig:        "beads",
+		Formula:          "mol-polecat-work",
+		Vars:             "k=v",
+		EnqueuedAt:       "2026-05-01T10:00:00Z",
+		DispatchFailures: 2,
+		LastFailure:      "rig not found",
+	}
+
+	moved := retargetSlingContext(fields, "gastown")
+	if moved.TargetRig != "gastown" || moved.DispatchFailures != 0 || moved.LastFailure != "" {
+		t.Errorf("moved = %+v, want gastown with failures cleared", moved)
+	}
+	if moved.EnqueuedAt != fields.EnqueuedAt || moved.Formula != fields.Formula || moved.Vars != fields.Vars {
+		t.Errorf("moved = %+v, want enqueue time and dispatch options preserved", moved)
+	}
+	if fields.TargetRig != "beads" || fields.DispatchFailures != 2 {
+		t.Errorf("original fields modified: %+v", fields)
+	}
+}
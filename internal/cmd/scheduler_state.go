@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var schedulerStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Show the raw scheduler runtime state file",
+	Long: `Print the scheduler runtime state file (.runtime/scheduler-state.json, or
+the legacy queue-state.json) exactly as stored, and whether it parses.
+
+The state file records pause, last dispatch and stall information. If it is
+corrupt, every dispatch fails to load it and nothing is dispatched; reset it
+with 'gt scheduler state reset'.
+
+  gt scheduler state
+  gt scheduler state reset`,
+	Args: cobra.NoArgs,
+	RunE: runSchedulerState,
+}
+
+var schedulerStateResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Reinitialize the scheduler runtime state file",
+	Long: `Replace the scheduler runtime state with a clean default: not paused, no
+last dispatch and no stall recorded. Use it when the state file is corrupt or
+stale and blocking dispatch.
+
+The old file is kept next to it as <name>.bak-<timestamp>. Scheduled beads
+are not touched. A paused scheduler is resumed by the reset; run
+'gt scheduler pause' afterwards to keep it paused.`,
+	Args: cobra.NoArgs,
+	RunE: runSchedulerStateReset,
+}
+
+func init() {
+	schedulerStateCmd.AddCommand(schedulerStateResetCmd)
+	schedulerCmd.AddCommand(schedulerStateCmd)
+}
+
+func runSchedulerState(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	path, data, err := capacity.ReadStateFile(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if data == nil {
+		fmt.Printf("%s No state file at %s (defaults: not paused, never dispatched)\n",
+			style.Dim.Render("○"), path)
+		return nil
+	}
+
+	fmt.Printf("%s\n", style.Bold.Render(path))
+	fmt.Println(strings.TrimRight(string(data), "\n"))
+	fmt.Println()
+	if _, err := capacity.LoadState(townRoot); err != nil {
+		fmt.Printf("%s State file does not parse: %v\n", style.Warning.Render("⚠"), err)
+		fmt.Printf("  %s\n", style.Dim.Render("Dispatch cannot run until it is fixed; 'gt scheduler state reset' reinitializes it"))
+		return nil
+	}
+	fmt.Printf("%s State file parses\n", style.Bold.Render("✓"))
+	return nil
+}
+
+func runSchedulerStateReset(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	wasPaused := false
+	if state, err := capacity.LoadState(townRoot); err == nil {
+		wasPaused = state.Paused
+	}
+	backup, err := capacity.ResetState(townRoot)
+	if err != nil {
+		return fmt.Errorf("resetting scheduler state: %w", err)
+	}
+
+	if backup != "" {
+		fmt.Printf("%s Backed up old state to %s\n", style.Dim.Render("○"), backup)
+	}
+	fmt.Printf("%s Scheduler state reset (not paused, counters cleared)\n", style.Bold.Render("✓"))
+	if wasPaused {
+		fmt.Fprintf(os.Stderr, "%s The scheduler was paused and is now resumed; run 'gt scheduler pause' to pause it again\n",
+			style.Warning.Render("⚠"))
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var schedulerStatsJSON bool
+
+var schedulerStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show scheduler throughput, wait times and backlog trend",
+	Long: `Summarize scheduler behavior from the activity log.
+
+Reports beads dispatched in the last hour and day, the average and p95 time
+a bead waited between being scheduled and dispatched, the current backlog
+and whether it is growing or shrinking (beads scheduled vs dispatched in the
+last hour), and dispatch failures, re-queues and dead-lettered beads in the
+last day. The bounce rate is the share of dispatch outcomes in the last day
+that sent the bead back to the scheduler instead of launching it.
+
+Wait times need both the scheduler_enqueue and scheduler_dispatch events for
+a bead; beads scheduled before the log was rotated are not counted.
+
+  gt scheduler stats
+  gt scheduler stats --json`,
+	RunE: runSchedulerStats,
+}
+
+func init() {
+	schedulerStatsCmd.Flags().BoolVar(&schedulerStatsJSON, "json", false, "Output as JSON")
+	schedulerCmd.AddCommand(schedulerStatsCmd)
+}
+
+// schedulerStats is the output of `gt scheduler stats`.
+type schedulerStats struct {
+	DispatchedHour int     `json:"dispatched_last_hour"`
+	DispatchedDay  int     `json:"dispatched_last_day"`
+	WaitSamples    int     `json:"wait_samples"`
+	AvgWaitSec     float64 `json:"avg_wait_seconds"`
+	P95WaitSec     float64 `json:"p95_wait_seconds"`
+	Backlog        int     `json:"backlog"`
+	EnqueuedHour   int     `json:"enqueued_last_hour"`
+	Trend          string  `json:"trend"` // growing, shrinking or steady
+	FailuresDay    int     `json:"dispatch_failures_last_day"`
+	DeadLettered   int     `json:"dead_lettered_last_day"`
+	RequeuedDay    int     `json:"requeued_last_day"`
+	BounceRate     float64 `json:"bounce_rate"` // requeued / (dispatched + requeued), last day
+}
+
+func runSchedulerStats(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	snap, err := gatherSchedulerStatus(townRoot)
+	if err != nil {
+		return err
+	}
+
+	var stats schedulerStats
+	f, err := events.OpenHistory(townRoot)
+	switch {
+	case err == nil:
+		stats, err = computeSchedulerStats(f, time.Now())
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading events file: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("reading events file: %w", err)
+	}
+	stats.Backlog = snap.ScheduledTotal
+
+	if schedulerStatsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	printSchedulerStats(os.Stdout, stats)
+	return nil
+}
+
+// computeSchedulerStats reads scheduler events from an events log and
+// summarizes the day before now. A bead's wait runs from its latest
+// enqueue to its dispatch. Backlog is left for the caller to fill in.
+func computeSchedulerStats(r io.Reader, now time.Time) (schedulerStats, error) {
+	var stats schedulerStats
+	hourAgo, dayAgo := now.Add(-time.Hour), now.Add(-24*time.Hour)
+	enqueuedAt := make(map[string]time.Time)
+	var waits []time.Duration
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case events.TypeSchedulerEnqueue, events.TypeSchedulerDispatch, events.TypeSchedulerDispatchFailed, events.TypeSchedulerRequeue:
+		default:
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil {
+			continue
+		}
+		bead, _ := event.Payload["bead"].(string)
+
+		switch event.Type {
+		case events.TypeSchedulerEnqueue:
+			enqueuedAt[bead] = ts
+			if !ts.Before(hourAgo) {
+				stats.EnqueuedHour++
+			}
+		case events.TypeSchedulerDispatch:
+			queued, ok := enqueuedAt[bead]
+			delete(enqueuedAt, bead)
+			if ts.Before(dayAgo) {
+				continue
+			}
+			stats.DispatchedDay++
+			if !ts.Before(hourAgo) {
+				stats.DispatchedHour++
+			}
+			if ok && !ts.Before(queued) {
+				waits = append(waits, ts.Sub(queued))
+			}
+		case events.TypeSchedulerDispatchFailed:
+			if ts.Before(dayAgo) {
+				continue
+			}
+			stats.FailuresDay++
+			if msg, _ := event.Payload["error"].(string); strings.HasPrefix(msg, deadLetterUnknownRigError) {
+				stats.DeadLettered++
+			}
+		case events.TypeSchedulerRequeue:
+			if !ts.Before(dayAgo) {
+				stats.RequeuedDay++
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, err
+	}
+
+	if len(waits) > 0 {
+		sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+		var total time.Duration
+		for _, w := range waits {
+			total += w
+		}
+		stats.WaitSamples = len(waits)
+		stats.AvgWaitSec = (total / time.Duration(len(waits))).Seconds()
+		// Nearest-rank p95.
+		rank := (len(waits)*95 + 99) / 100
+		stats.P95WaitSec = waits[rank-1].Seconds()
+	}
+
+	if outcomes := stats.DispatchedDay + stats.RequeuedDay; outcomes > 0 {
+		stats.BounceRate = float64(stats.RequeuedDay) / float64(outcomes)
+	}
+
+	switch {
+	case stats.EnqueuedHour > stats.DispatchedHour:
+		stats.Trend = "growing"
+	case stats.EnqueuedHour < stats.DispatchedHour:
+		stats.Trend = "shrinking"
+	default:
+		stats.Trend = "steady"
+	}
+	return stats, nil
+}
+
+func printSchedulerStats(w io.Writer, s schedulerStats) {
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render("Scheduler Stats"))
+	fmt.Fprintf(w, "  Dispatched: %d last hour, %d last 24h\n", s.DispatchedHour, s.DispatchedDay)
+	if s.WaitSamples > 0 {
+		fmt.Fprintf(w, "  Wait:       avg %s, p95 %s (%d dispatch(es))\n",
+			formatDuration(time.Duration(s.AvgWaitSec*float64(time.Second))),
+			formatDuration(time.Duration(s.P95WaitSec*float64(time.Second))),
+			s.WaitSamples)
+	} else {
+		fmt.Fprintf(w, "  Wait:       %s\n", style.Dim.Render("no dispatches with a known schedule time"))
+	}
+	trend := s.Trend
+	if trend == "growing" {
+		trend = style.Warning.Render(trend)
+	}
+	fmt.Fprintf(w, "  Backlog:    %d scheduled, %s (%d scheduled, %d dispatched last hour)\n",
+		s.Backlog, trend, s.EnqueuedHour, s.DispatchedHour)
+	fmt.Fprintf(w, "  Failures:   %d last 24h", s.FailuresDay)
+	if s.DeadLettered > 0 {
+		fmt.Fprintf(w, " (%d dead-lettered)", s.DeadLettered)
+	}
+	fmt.Fprintln(w)
+	if s.RequeuedDay > 0 {
+		fmt.Fprintf(w, "  Requeued:   %d last 24h (bounce rate %.0f%%)\n", s.RequeuedDay, s.BounceRate*100)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeSchedulerStats(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	log := strings.Join([]string{
+		// Dispatched two days ago: outside every window.
+		`{"ts":"2026-04-29T10:00:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-ancient"}}`,
+		`{"ts":"2026-04-29T10:05:00Z","type":"scheduler_dispatch","payload":{"bead":"gt-ancient"}}`,
+		// Waited 10m, dispatched this morning.
+		`{"ts":"2026-05-01T08:00:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-a"}}`,
+		`{"ts":"2026-05-01T08:10:00Z","type":"scheduler_dispatch","payload":{"bead":"gt-a"}}`,
+		// Waited 2m, dispatched in the last hour.
+		`{"ts":"2026-05-01T11:28:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-b"}}`,
+		`{"ts":"2026-05-01T11:30:00Z","type":"scheduler_dispatch","payload":{"bead":"gt-b"}}`,
+		// Enqueued before the log starts: counted, but no wait sample.
+		`{"ts":"2026-05-01T11:40:00Z","type":"scheduler_dispatch","payload":{"bead":"gt-c"}}`,
+		// Still waiting.
+		`{"ts":"2026-05-01T11:45:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-d"}}`,
+		`{"ts":"2026-05-01T11:50:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-e"}}`,
+		`{"ts":"2026-05-01T11:55:00Z","type":"scheduler_enqueue","payload":{"bead":"gt-f"}}`,
+		`{"ts":"2026-05-01T09:00:00Z","type":"scheduler_dispatch_failed","payload":{"bead":"gt-x","error":"spawn failed"}}`,
+		`{"ts":"2026-05-01T09:01:00Z","type":"scheduler_dispatch_failed","payload":{"bead":"gt-y","error":"unknown rig: gone"}}`,
+		`{"ts":"2026-05-01T09:00:00Z","type":"scheduler_requeue","payload":{"bead":"gt-x","attempt":1,"reason":"spawn failed"}}`,
+		`{"ts":"2026-04-29T09:00:00Z","type":"scheduler_requeue","payload":{"bead":"gt-old","attempt":1,"reason":"spawn failed"}}`,
+		`{"ts":"2026-05-01T09:02:00Z","type":"sling","payload":{"bead":"gt-z"}}`,
+		`not json`,
+	}, "\n")
+
+	stats, err := computeSchedulerStats(strings.NewReader(log), now)
+	if err != nil {
+		t.Fatalf("computeSchedulerStats: %v", err)
+	}
+	if stats.DispatchedHour != 2 || stats.DispatchedDay != 3 {
+		t.Errorf("dispatched = %d/hour %d/day, want 2 and 3", stats.DispatchedHour, stats.DispatchedDay)
+	}
+	if stats.WaitSamples != 2 || stats.AvgWaitSec != 360 || stats.P95WaitSec != 600 {
+		t.Errorf("wait = %d samples avg %v p95 %v, want 2, 360, 600", stats.WaitSamples, stats.AvgWaitSec, stats.P95WaitSec)
+	}
+	if stats.EnqueuedHour != 4 || stats.Trend != "growing" {
+		t.Errorf("enqueued %d, trend %q; want 4, growing", stats.EnqueuedHour, stats.Trend)
+	}
+	if stats.FailuresDay != 2 || stats.DeadLettered != 1 {
+		t.Errorf("failures %d, dead-lettered %d; want 2 and 1", stats.FailuresDay, stats.DeadLettered)
+	}
+	if stats.RequeuedDay != 1 || stats.BounceRate != 0.25 {
+		t.Errorf("requeued %d, bounce rate %v; want 1 and 0.25", stats.RequeuedDay, stats.BounceRate)
+	}
+
+	empty, err := computeSchedulerStats(strings.NewReader(""), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Trend != "steady" || empty.WaitSamples != 0 {
+		t.Errorf("empty stats = %+v", empty)
+	}
+}
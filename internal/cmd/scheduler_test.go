@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintSchedulerStatus(t *testing.T) {
+	snap := &schedulerStatusSnapshot{
+		Paused:         true,
+		PausedBy:       "mayor",
+		ScheduledTotal: 5,
+		ScheduledReady: 3,
+		ActivePolecats: 2,
+		Capacity:       polecatCapacitySnapshot{Max: 4, Free: 2, Working: 2},
+		LastDispatchAt: "2026-01-02T03:04:05Z",
+		LastDispatchN:  1,
+		Reserved:       1,
+	}
+
+	var buf bytes.Buffer
+	printSchedulerStatus(&buf, snap)
+	out := buf.String()
+	for _, want := range []string{
+		"PAUSED",
+		"(by mayor)",
+		"Scheduled: 5 total, 3 ready",
+		"Active:    2 polecats",
+		"2 free of 4",
+		"Last dispatch: 2026-01-02T03:04:05Z (1 beads)",
+		"Reserved:  1 slot(s) for interactive slings; scheduler may use 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	printSchedulerStatus(&buf, &schedulerStatusSnapshot{Capacity: polecatCapacitySnapshot{Max: -1}})
+	if out := buf.String(); !strings.Contains(out, "State:    active") || !strings.Contains(out, "direct dispatch") || strings.Contains(out, "Last dispatch") {
+		t.Errorf("unexpected idle output:\n%s", out)
+	}
+}
+
+func TestPrintSchedulerStatusMisconfigured(t *testing.T) {
+	snap := &schedulerStatusSnapshot{
+		ScheduledTotal: 2,
+		Capacity:       polecatCapacitySnapshot{Max: -1},
+		Misconfigured: []scheduledBeadInfo{
+			{ID: "gt-abc", Misconfigured: "no target rig"},
+		},
+	}
+	var buf bytes.Buffer
+	printSchedulerStatus(&buf, snap)
+	out := buf.String()
+	for _, want := range []string{"Misconfigured:", "1 (will not dispatch)", "gt-abc: no target rig", "gt scheduler fix"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestScheduledRigProblem(t *testing.T) {
+	known := map[string]bool{"gastown": true}
+	tests := []struct {
+		rig   string
+		known map[string]bool
+		want  string
+	}{
+		{"gastown", known, ""},
+		{"", known, "no target rig"},
+		{"oldrig", known, `target rig "oldrig" is not registered`},
+		// Registry unreadable: only an empty rig is flagged.
+		{"oldrig", nil, ""},
+		{"", nil, "no target rig"},
+	}
+	for _, tt := range tests {
+		if got := scheduledRigProblem(tt.rig, tt.known); got != tt.want {
+			t.Errorf("scheduledRigProblem(%q, %v) = %q, want %q", tt.rig, tt.known, got, tt.want)
+		}
+	}
+}
+
+func TestFilterScheduledBeads(t *testing.T) {
+	scheduled := []scheduledBeadInfo{
+		{ID: "gt-ready"},
+		{ID: "gt-blocked", Blocked: true, BlockedBy: []string{"gt-dep"}},
+		{ID: "gt-norig", Misconfigured: "no target rig"},
+	}
+	ids := func(beads []scheduledBeadInfo) string {
+		var out []string
+		for _, b := range beads {
+			out = append(out, b.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	tests := []struct {
+		blocked, ready bool
+		want           string
+	}{
+		{false, false, "gt-ready,gt-blocked,gt-norig"},
+		{true, false, "gt-blocked"},
+		{false, true, "gt-ready"},
+	}
+	for _, tt := range tests {
+		if got := ids(filterScheduledBeads(scheduled, tt.blocked, tt.ready)); got != tt.want {
+			t.Errorf("filterScheduledBeads(blocked=%v, ready=%v) = %q, want %q", tt.blocked, tt.ready, got, tt.want)
+		}
+	}
+}
+
+func TestSchedulerAddTargets(t *testing.T) {
+	beadIDs, rigName := schedulerAddTargets([]string{"gt-a", "gt-b", "gt-a", "gastown"})
+	if rigName != "gastown" {
+		t.Errorf("rig = %q, want gastown", rigName)
+	}
+	if got := strings.Join(beadIDs, ","); got != "gt-a,gt-b" {
+		t.Errorf("beads = %q, want gt-a,gt-b", got)
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+var schedulerTopInterval time.Duration
+
+var schedulerTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live table of scheduled beads, longest-waiting first",
+	Long: `Show a live, refreshing table of scheduled beads sorted by how long they
+have been waiting (oldest first), for babysitting a busy scheduler.
+
+The header shows scheduler state, active polecats and capacity. Each row shows
+the work bead ID, target rig, whether it is ready or blocked, how long it has
+been scheduled, and its effective dispatch priority. Press Ctrl+C to stop.
+
+Examples:
+  gt scheduler top
+  gt scheduler top -n 5s`,
+	Args: cobra.NoArgs,
+	RunE: runSchedulerTop,
+}
+
+func init() {
+	schedulerTopCmd.Flags().DurationVarP(&schedulerTopInterval, "interval", "n", 2*time.Second, "Refresh interval")
+	schedulerCmd.AddCommand(schedulerTopCmd)
+}
+
+func runSchedulerTop(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	if schedulerTopInterval <= 0 {
+		return fmt.Errorf("interval must be positive, got %v", schedulerTopInterval)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(schedulerTopInterval)
+	defer ticker.Stop()
+
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+
+	for {
+		var buf bytes.Buffer
+		if isTTY {
+			buf.WriteString("\033[H\033[2J") // ANSI: cursor home + clear screen
+		}
+		now := time.Now()
+		header := fmt.Sprintf("[%s] gt scheduler top (every %v, Ctrl+C to stop)",
+			now.Format("15:04:05"), schedulerTopInterval)
+		fmt.Fprintf(&buf, "%s\n\n", style.Dim.Render(header))
+
+		if snap, err := gatherSchedulerStatus(townRoot); err != nil {
+			fmt.Fprintf(&buf, "Error: %v\n", err)
+		} else {
+			printSchedulerTop(&buf, snap, now)
+		}
+
+		// Write the entire frame at once so the terminal never shows a blank screen.
+		_, _ = os.Stdout.Write(buf.Bytes())
+
+		select {
+		case <-sigChan:
+			if isTTY {
+				fmt.Println("\nStopped.")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printSchedulerTop renders one frame of `gt scheduler top`.
+func printSchedulerTop(w io.Writer, snap *schedulerStatusSnapshot, now time.Time) {
+	state := "active"
+	if snap.Paused {
+		state = style.Warning.Render("PAUSED")
+	}
+	capacityLine := "direct dispatch"
+	if snap.Capacity.Max > 0 {
+		capacityLine = fmt.Sprintf("%d free of %d", snap.Capacity.Free, snap.Capacity.Max)
+	}
+	fmt.Fprintf(w, "State: %s   Active: %d   Capacity: %s   Scheduled: %d (%d ready)\n\n",
+		state, snap.ActivePolecats, capacityLine, snap.ScheduledTotal, snap.ScheduledReady)
+
+	if len(snap.Beads) == 0 {
+		fmt.Fprintln(w, "No beads scheduled.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tRIG\tSTATE\tWAIT\tPRI")
+	for _, b := range sortScheduledByWait(snap.Beads) {
+		wait := "-"
+		if d, ok := scheduledWait(b, now); ok {
+			wait = formatDuration(d)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\tP%d\n", b.ID, b.TargetRig, scheduledBeadState(b), wait, b.Priority)
+	}
+	_ = tw.Flush()
+}
+
+// sortScheduledByWait returns a copy of beads ordered by enqueue time, oldest
+// first. Beads without a parseable enqueue time sort last, by ID.
+func sortScheduledByWait(beads []scheduledBeadInfo) []scheduledBeadInfo {
+	sorted := append([]scheduledBeadInfo(nil), beads...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, iok := parseEnqueuedAt(sorted[i].EnqueuedAt)
+		tj, jok := parseEnqueuedAt(sorted[j].EnqueuedAt)
+		if iok != jok {
+			return iok
+		}
+		if iok && !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// scheduledWait reports how long b has been scheduled as of now.
+func scheduledWait(b scheduledBeadInfo, now time.Time) (time.Duration, bool) {
+	t, ok := parseEnqueuedAt(b.EnqueuedAt)
+	if !ok {
+		return 0, false
+	}
+	if d := now.Sub(t); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+func parseEnqueuedAt(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// scheduledBeadState is the STATE column: misconfigured, blocked or ready.
+func scheduledBeadState(b scheduledBeadInfo) string {
+	switch {
+	case b.Misconfigured != "":
+		return "misconfigured"
+	case b.Blocked:
+		return "blocked"
+	default:
+		return "ready"
+	}
+}
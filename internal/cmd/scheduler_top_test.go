@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintSchedulerTopSortsByWait(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	snap := &schedulerStatusSnapshot{
+		ActivePolecats: 3,
+		Capacity:       polecatCapacitySnapshot{Max: 5, Free: 2},
+		ScheduledTotal: 4,
+		ScheduledReady: 2,
+		Beads: []scheduledBeadInfo{
+			{ID: "gt-new", TargetRig: "gastown", EnqueuedAt: "2026-05-01T11:55:00Z", Priority: 1},
+			{ID: "gt-unknown", TargetRig: "gastown", Priority: 2},
+			{ID: "gt-old", TargetRig: "beads", EnqueuedAt: "2026-05-01T09:00:00Z", Priority: 2, Blocked: true},
+			{ID: "gt-mid", TargetRig: "gastown", EnqueuedAt: "2026-05-01T11:00:00Z", Priority: 0},
+		},
+	}
+
+	var buf bytes.Buffer
+	printSchedulerTop(&buf, snap, now)
+	out := buf.String()
+
+	if !strings.Contains(out, "Active: 3") || !strings.Contains(out, "Capacity: 2 free of 5") {
+		t.Errorf("header missing capacity/active:\n%s", out)
+	}
+	order := []string{"gt-old", "gt-mid", "gt-new", "gt-unknown"}
+	last := -1
+	for _, id := range order {
+		i := strings.Index(out, id)
+		if i < 0 || i < last {
+			t.Fatalf("rows not ordered oldest first %v:\n%s", order, out)
+		}
+		last = i
+	}
+	for _, want := range []string{"3h 0m", "5m 0s", "blocked", "ready", "P0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintSchedulerTopEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	printSchedulerTop(&buf, &schedulerStatusSnapshot{Paused: true}, time.Now())
+	out := buf.String()
+	if !strings.Contains(out, "PAUSED") || !strings.Contains(out, "direct dispatch") || !strings.Contains(out, "No beads scheduled.") {
+		t.Errorf("unexpected empty frame:\n%s", out)
+	}
+}
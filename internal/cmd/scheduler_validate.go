@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	schedulerValidateFix  bool
+	schedulerValidateJSON bool
+)
+
+var schedulerValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check scheduler state for inconsistencies",
+	Long: `Scan sling contexts and legacy queue labels for state that has drifted.
+
+Reported classes:
+  invalid-context    Sling context description is not valid JSON
+  circuit-broken     Context reached the dispatch failure limit but is still open
+  unknown-rig        Target rig is empty or not registered
+  stale-work-bead    Work bead is already hooked, closed or tombstoned
+  duplicate-context  Work bead has more than one open context (oldest is kept)
+  legacy-label       Work bead has the legacy ` + capacity.LabelLegacyQueued + ` label but nothing to migrate
+  legacy-metadata    Work bead still carries a legacy scheduling block
+
+With --fix, contexts are closed with the class as the reason, legacy labels
+are removed, and legacy blocks are migrated as by 'gt scheduler migrate'.
+
+  gt scheduler validate
+  gt scheduler validate --fix
+  gt scheduler validate --json`,
+	SilenceUsage: true,
+	RunE:         runSchedulerValidate,
+}
+
+func init() {
+	schedulerValidateCmd.Flags().BoolVar(&schedulerValidateFix, "fix", false, "Normalize the inconsistencies found")
+	schedulerValidateCmd.Flags().BoolVar(&schedulerValidateJSON, "json", false, "Output as JSON")
+	schedulerCmd.AddCommand(schedulerValidateCmd)
+}
+
+// Scheduler inconsistency classes reported by `gt scheduler validate`.
+const (
+	schedulerIssueInvalidContext   = "invalid-context"
+	schedulerIssueCircuitBroken    = "circuit-broken"
+	schedulerIssueUnknownRig       = "unknown-rig"
+	schedulerIssueStaleWorkBead    = "stale-work-bead"
+	schedulerIssueDuplicateContext = "duplicate-context"
+	schedulerIssueLegacyLabel      = "legacy-label"
+	schedulerIssueLegacyMetadata   = "legacy-metadata"
+)
+
+// schedulerIssue is one inconsistency found by `gt scheduler validate`.
+type schedulerIssue struct {
+	Class     string `json:"class"`
+	BeadID    string `json:"bead_id,omitempty"`
+	ContextID string `json:"context_id,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Fixed     bool   `json:"fixed,omitempty"`
+
+	rec    *slingContextRecord
+	legacy *beads.Issue
+	dir    string
+}
+
+// validateSlingContexts classifies open sling contexts. Each context gets at
+// most one issue, checked in the order dispatch cleanup would close it.
+func validateSlingContexts(records []slingContextRecord, knownRigs map[string]bool, info map[string]beadStatusInfo) []schedulerIssue {
+	type parsed struct {
+		rec    *slingContextRecord
+		fields *capacity.SlingContextFields
+	}
+	var issues []schedulerIssue
+	var healthy []parsed
+	for i := range records {
+		rec := &records[i]
+		fields := beads.ParseSlingContextFields(rec.issue.Description)
+		if fields == nil {
+			issues = append(issues, schedulerIssue{Class: schedulerIssueInvalidContext, ContextID: rec.issue.ID, rec: rec})
+			continue
+		}
+		issue := schedulerIssue{BeadID: fields.WorkBeadID, ContextID: rec.issue.ID, rec: rec}
+		switch {
+		case fields.DispatchFailures >= maxDispatchFailures:
+			issue.Class = schedulerIssueCircuitBroken
+			issue.Detail = fmt.Sprintf("%d dispatch failures", fields.DispatchFailures)
+		case scheduledRigProblem(fields.TargetRig, knownRigs) != "":
+			issue.Class = schedulerIssueUnknownRig
+			issue.Detail = scheduledRigProblem(fields.TargetRig, knownRigs)
+		default:
+			if bi, ok := info[fields.WorkBeadID]; ok && (bi.Status == "hooked" || bi.Status == "closed" || bi.Status == "tombstone") {
+				issue.Class = schedulerIssueStaleWorkBead
+				issue.Detail = "work bead is " + bi.Status
+			}
+		}
+		if issue.Class != "" {
+			issues = append(issues, issue)
+			continue
+		}
+		healthy = append(healthy, parsed{rec, fields})
+	}
+
+	// Dispatch takes the oldest context per work bead; the rest are leftovers.
+	sort.SliceStable(healthy, func(i, j int) bool {
+		if healthy[i].fields.EnqueuedAt != healthy[j].fields.EnqueuedAt {
+			return healthy[i].fields.EnqueuedAt < healthy[j].fields.EnqueuedAt
+		}
+		return healthy[i].rec.issue.ID < healthy[j].rec.issue.ID
+	})
+	kept := make(map[string]string)
+	for _, c := range healthy {
+		if first, ok := kept[c.fields.WorkBeadID]; ok {
+			issues = append(issues, schedulerIssue{
+				Class:     schedulerIssueDuplicateContext,
+				BeadID:    c.fields.WorkBeadID,
+				ContextID: c.rec.issue.ID,
+				Detail:    "kept " + first,
+				rec:       c.rec,
+			})
+			continue
+		}
+		kept[c.fields.WorkBeadID] = c.rec.issue.ID
+	}
+	return issues
+}
+
+// validateLegacyQueuedBead classifies a bead carrying the legacy gt:queued
+// label. Open beads with a legacy block still need migrating; anything else
+// only needs the label removed.
+func validateLegacyQueuedBead(issue *beads.Issue) string {
+	if issue.Status != "closed" && issue.Status != "tombstone" &&
+		beads.ParseLegacySchedulerMetadata(issue.ID, issue.Description) != nil {
+		return schedulerIssueLegacyMetadata
+	}
+	return schedulerIssueLegacyLabel
+}
+
+func runSchedulerValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	records, err := listAllSlingContextRecordsWithError(townRoot)
+	if err != nil {
+		return err
+	}
+	workBeadIDs := make([]string, 0, len(records))
+	for _, rec := range records {
+		if fields := beads.ParseSlingContextFields(rec.issue.Description); fields != nil {
+			workBeadIDs = append(workBeadIDs, fields.WorkBeadID)
+		}
+	}
+	issues := validateSlingContexts(records, loadKnownRigSet(townRoot), batchFetchBeadInfoByIDs(townRoot, workBeadIDs))
+
+	seen := make(map[string]bool)
+	for _, dir := range beadsSearchDirs(townRoot) {
+		beadsDir := beads.ResolveBeadsDir(dir)
+		legacy, err := beads.NewWithBeadsDir(dir, beadsDir).List(beads.ListOptions{
+			Status:   "all",
+			Label:    capacity.LabelLegacyQueued,
+			Priority: -1,
+		})
+		if err != nil {
+			continue // Partial failure is acceptable — skip unavailable dirs
+		}
+		for _, issue := range legacy {
+			key := beadsDir + "\x00" + issue.ID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			issues = append(issues, schedulerIssue{
+				Class:  validateLegacyQueuedBead(issue),
+				BeadID: issue.ID,
+				Detail: "status " + issue.Status,
+				legacy: issue,
+				dir:    dir,
+			})
+		}
+	}
+
+	failed := 0
+	if schedulerValidateFix {
+		for i := range issues {
+			if err := fixSchedulerIssue(&issues[i]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s %s: %v\n", style.Warning.Render("⚠"), issues[i].Class, schedulerIssueSubject(issues[i]), err)
+				failed++
+				continue
+			}
+			issues[i].Fixed = true
+		}
+	}
+
+	if schedulerValidateJSON {
+		if issues == nil {
+			issues = []schedulerIssue{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(issues); err != nil {
+			return err
+		}
+	} else {
+		printSchedulerIssues(issues)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d inconsistencies could not be fixed", failed)
+	}
+	return nil
+}
+
+// fixSchedulerIssue normalizes one inconsistency.
+func fixSchedulerIssue(issue *schedulerIssue) error {
+	if issue.rec != nil {
+		return beadsForContextRecord(*issue.rec).CloseSlingContext(issue.ContextID, issue.Class)
+	}
+	b := beads.NewWithBeadsDir(issue.dir, beads.ResolveBeadsDir(issue.dir))
+	if issue.Class == schedulerIssueLegacyMetadata {
+		_, err := b.MigrateLegacySchedulerMetadata(issue.legacy)
+		return err
+	}
+	return b.Update(issue.BeadID, beads.UpdateOptions{RemoveLabels: []string{capacity.LabelLegacyQueued}})
+}
+
+func schedulerIssueSubject(issue schedulerIssue) string {
+	switch {
+	case issue.BeadID == "":
+		return "context " + issue.ContextID
+	case issue.ContextID == "":
+		return issue.BeadID
+	default:
+		return fmt.Sprintf("%s (context: %s)", issue.BeadID, issue.ContextID)
+	}
+}
+
+func printSchedulerIssues(issues []schedulerIssue) {
+	if len(issues) == 0 {
+		fmt.Printf("%s Scheduler state is consistent\n", style.Bold.Render("✓"))
+		return
+	}
+
+	byClass := make(map[string][]schedulerIssue)
+	var classes []string
+	for _, issue := range issues {
+		if _, ok := byClass[issue.Class]; !ok {
+			classes = append(classes, issue.Class)
+		}
+		byClass[issue.Class] = append(byClass[issue.Class], issue)
+	}
+	sort.Strings(classes)
+
+	fixed := 0
+	for _, class := range classes {
+		fmt.Printf("%s (%d)\n", style.Bold.Render(class), len(byClass[class]))
+		for _, issue := range byClass[class] {
+			line := "  " + schedulerIssueSubject(issue)
+			if issue.Detail != "" {
+				line += style.Dim.Render(" — " + issue.Detail)
+			}
+			if issue.Fixed {
+				line += " " + style.Success.Render("fixed")
+				fixed++
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+	if schedulerValidateFix {
+		fmt.Printf("Fixed %d of %d inconsistencies\n", fixed, len(issues))
+	} else {
+		fmt.Printf("%d inconsistencies; normalize with: gt scheduler validate --fix\n", len(issues))
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func TestValidateSlingContexts(t *testing.T) {
+	broken := slingContextRecordForTest("hq-ctx-broken", "gt-broken", "gastown", "2026-05-01T10:00:00Z")
+	broken.issue.Description = beads.FormatSlingContextDescription(&capacity.SlingContextFields{
+		WorkBeadID: "gt-broken", TargetRig: "gastown", DispatchFailures: maxDispatchFailures,
+	})
+	records := []slingContextRecord{
+		{issue: &beads.Issue{ID: "hq-bad", Description: "not json"}},
+		broken,
+		slingContextRecordForTest("hq-ctx-rig", "gt-rig", "nowhere", "2026-05-01T10:00:00Z"),
+		slingContextRecordForTest("hq-ctx-done", "gt-done", "gastown", "2026-05-01T10:00:00Z"),
+		slingContextRecordForTest("hq-ctx-new", "gt-dup", "gastown", "2026-05-01T10:05:00Z"),
+		slingContextRecordForTest("hq-ctx-old", "gt-dup", "gastown", "2026-05-01T10:01:00Z"),
+		slingContextRecordForTest("hq-ctx-ok", "gt-ok", "gastown", "2026-05-01T10:00:00Z"),
+	}
+	knownRigs := map[string]bool{"gastown": true}
+	info := map[string]beadStatusInfo{
+		"gt-done": {Status: "closed"},
+		"gt-ok":   {Status: "open"},
+	}
+
+	got := make(map[string]string)
+	for _, issue := range validateSlingContexts(records, knownRigs, info) {
+		got[issue.ContextID] = issue.Class
+	}
+	want := map[string]string{
+		"hq-bad":        schedulerIssueInvalidContext,
+		"hq-ctx-broken": schedulerIssueCircuitBroken,
+		"hq-ctx-rig":    schedulerIssueUnknownRig,
+		"hq-ctx-done":   schedulerIssueStaleWorkBead,
+		"hq-ctx-new":    schedulerIssueDuplicateContext,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %v, want %v", got, want)
+	}
+	for id, class := range want {
+		if got[id] != class {
+			t.Errorf("%s: class = %q, want %q", id, got[id], class)
+		}
+	}
+}
+
+func TestValidateLegacyQueuedBead(t *testing.T) {
+	block := "Fix it\n\n" + beads.LegacySchedulerDelimiter + "\ntarget_rig: gastown\n"
+	tests := []struct {
+		name  string
+		issue *beads.Issue
+		want  string
+	}{
+		{"open with block", &beads.Issue{ID: "gt-a", Status: "open", Description: block}, schedulerIssueLegacyMetadata},
+		{"closed with block", &beads.Issue{ID: "gt-b", Status: "closed", Description: block}, schedulerIssueLegacyLabel},
+		{"open without block", &beads.Issue{ID: "gt-c", Status: "open", Description: "Fix it"}, schedulerIssueLegacyLabel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateLegacyQueuedBead(tt.issue); got != tt.want {
+				t.Errorf("validateLegacyQueuedBead() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
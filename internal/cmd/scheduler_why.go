@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// schedulerEventWindow is how far back why-stalled reads scheduler events.
+const schedulerEventWindow = 24 * time.Hour
+
+// schedulerWaitingShown caps the longest-waiting beads listed.
+const schedulerWaitingShown = 5
+
+var schedulerWhyStalledJSON bool
+
+var schedulerWhyStalledCmd = &cobra.Command{
+	Use:   "why-stalled",
+	Short: "Explain why scheduled beads are not dispatching",
+	Long: `Answer "why isn't my scheduler moving?" in one place.
+
+Combines scheduler state (pause, last dispatch), polecat capacity, which
+scheduled beads are ready or blocked, and the last 24h of scheduler events
+from the activity log (stalls and dispatch failures) into a single
+explanation, and lists the ready beads that have waited longest.
+
+  gt scheduler why-stalled
+  gt scheduler why-stalled --json`,
+	RunE: runSchedulerWhyStalled,
+}
+
+func init() {
+	schedulerWhyStalledCmd.Flags().BoolVar(&schedulerWhyStalledJSON, "json", false, "Output as JSON")
+	schedulerCmd.AddCommand(schedulerWhyStalledCmd)
+}
+
+// schedulerEventSummary is what why-stalled needs from the events log.
+type schedulerEventSummary struct {
+	LastDispatch   time.Time
+	LastStall      *schedulerStallEvent
+	RecentFailures int
+}
+
+// schedulerStallEvent is one scheduler_stalled event.
+type schedulerStallEvent struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// schedulerWaitingBead is a ready bead that has not been dispatched.
+type schedulerWaitingBead struct {
+	ID         string `json:"id"`
+	TargetRig  string `json:"target_rig"`
+	EnqueuedAt string `json:"enqueued_at"`
+	WaitingSec int    `json:"waiting_seconds"`
+}
+
+// schedulerStallReport is the output of `gt scheduler why-stalled`.
+type schedulerStallReport struct {
+	Stalled        bool                   `json:"stalled"`
+	Reason         string                 `json:"reason"`
+	Explanation    string                 `json:"explanation"`
+	Hint           string                 `json:"hint,omitempty"`
+	LastDispatchAt string                 `json:"last_dispatch_at,omitempty"`
+	StalledSince   string                 `json:"stalled_since,omitempty"`
+	LastStall      *schedulerStallEvent   `json:"last_stall_event,omitempty"`
+	RecentFailures int                    `json:"recent_dispatch_failures"`
+	Waiting        []schedulerWaitingBead `json:"longest_waiting,omitempty"`
+}
+
+func runSchedulerWhyStalled(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	snap, err := gatherSchedulerStatus(townRoot)
+	if err != nil {
+		return err
+	}
+	state, err := capacity.LoadState(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading scheduler state: %w", err)
+	}
+	now := time.Now()
+	summary, err := readSchedulerEvents(townRoot, now.Add(-schedulerEventWindow))
+	if err != nil {
+		return err
+	}
+
+	report := diagnoseSchedulerStall(snap, state, summary, now)
+	if schedulerWhyStalledJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printSchedulerStallReport(os.Stdout, report, now)
+	return nil
+}
+
+// readSchedulerEvents scans the events log in townRoot, including rotated
+// rolls, for scheduler events at or after since. A missing log is not an
+// error: the town may never have dispatched.
+func readSchedulerEvents(townRoot string, since time.Time) (schedulerEventSummary, error) {
+	var summary schedulerEventSummary
+	f, err := events.OpenHistory(townRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return summary, nil
+		}
+		return summary, fmt.Errorf("reading events file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case events.TypeSchedulerDispatch, events.TypeSchedulerStalled, events.TypeSchedulerDispatchFailed:
+		default:
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		switch event.Type {
+		case events.TypeSchedulerDispatch:
+			summary.LastDispatch = ts
+		case events.TypeSchedulerDispatchFailed:
+			summary.RecentFailures++
+		case events.TypeSchedulerStalled:
+			reason, _ := event.Payload["reason"].(string)
+			detail, _ := event.Payload["detail"].(string)
+			summary.LastStall = &schedulerStallEvent{Time: ts, Reason: reason, Detail: detail}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return summary, fmt.Errorf("reading events file: %w", err)
+	}
+	return summary, nil
+}
+
+// diagnoseSchedulerStall explains why scheduled work is not dispatching,
+// checking causes in the order dispatch itself would hit them.
+func diagnoseSchedulerStall(snap *schedulerStatusSnapshot, state *capacity.SchedulerState, ev schedulerEventSummary, now time.Time) *schedulerStallReport {
+	report := &schedulerStallReport{
+		Stalled:        true,
+		LastDispatchAt: state.LastDispatchAt,
+		StalledSince:   state.StalledSince,
+		LastStall:      ev.LastStall,
+		RecentFailures: ev.RecentFailures,
+	}
+	if report.LastDispatchAt == "" && !ev.LastDispatch.IsZero() {
+		report.LastDispatchAt = ev.LastDispatch.UTC().Format(time.RFC3339)
+	}
+
+	var ready []scheduledBeadInfo
+	for _, b := range snap.Beads {
+		if !b.Blocked && b.Misconfigured == "" {
+			ready = append(ready, b)
+		}
+	}
+	sort.SliceStable(ready, func(i, j int) bool { return ready[i].EnqueuedAt < ready[j].EnqueuedAt })
+	for _, b := range ready {
+		if len(report.Waiting) == schedulerWaitingShown {
+			break
+		}
+		w := schedulerWaitingBead{ID: b.ID, TargetRig: b.TargetRig, EnqueuedAt: b.EnqueuedAt}
+		if t, err := time.Parse(time.RFC3339, b.EnqueuedAt); err == nil {
+			w.WaitingSec = int(now.Sub(t).Seconds())
+		}
+		report.Waiting = append(report.Waiting, w)
+	}
+
+	c := snap.Capacity
+	switch {
+	case snap.ScheduledTotal == 0:
+		report.Stalled = false
+		report.Reason = "empty"
+		report.Explanation = "Nothing is scheduled."
+	case snap.Paused:
+		report.Reason = capacity.StallPaused
+		report.Explanation = fmt.Sprintf("The scheduler is paused (by %s); %d scheduled bead(s) are waiting.", snap.PausedBy, snap.ScheduledTotal)
+		report.Hint = "gt scheduler resume"
+	case c.Max <= 0:
+		report.Reason = "direct_dispatch"
+		report.Explanation = fmt.Sprintf("scheduler.max_polecats=%d, so the scheduler never dispatches; %d context(s) are left over from deferred mode.", c.Max, snap.ScheduledTotal)
+		report.Hint = "gt config set scheduler.max_polecats N, or gt scheduler clear"
+	case len(ready) == 0 && len(snap.Misconfigured) == snap.ScheduledTotal:
+		report.Reason = "misconfigured"
+		report.Explanation = fmt.Sprintf("All %d scheduled bead(s) target a missing or unknown rig.", snap.ScheduledTotal)
+		report.Hint = "gt scheduler fix, then gt sling <bead> <rig>"
+	case len(ready) == 0:
+		report.Reason = capacity.StallAllBlocked
+		report.Explanation = fmt.Sprintf("None of the %d scheduled bead(s) is ready: they are blocked by open dependencies.", snap.ScheduledTotal)
+		report.Hint = "gt scheduler list shows what each bead is waiting on"
+	case c.Free <= 0:
+		report.Reason = capacity.StallCapacity
+		report.Explanation = fmt.Sprintf("%d bead(s) are ready but all %d polecat slots are in use (working: %d, recovery: %d, reservations: %d, pending MR: %d).",
+			len(ready), c.Max, c.Working, c.RecoveryBlocked, c.Reservations, c.PendingMR)
+		report.Hint = "wait for polecats to finish, or raise scheduler.max_polecats"
+	case c.Free <= snap.Reserved:
+		report.Reason = capacity.StallCapacity
+		report.Explanation = fmt.Sprintf("%d bead(s) are ready; the %d free slot(s) are held back for interactive slings (scheduler.reserve_for_interactive=%d).",
+			len(ready), c.Free, snap.Reserved)
+		report.Hint = "wait for polecats to finish, or lower scheduler.reserve_for_interactive"
+	case state.StallReason == capacity.StallDoltLoad:
+		report.Reason = capacity.StallDoltLoad
+		report.Explanation = fmt.Sprintf("%d bead(s) are ready and %d slot(s) free, but dispatch is paused because Dolt query latency is above scheduler.dolt_latency_threshold.",
+			len(ready), c.Free)
+		report.Hint = "gt dolt status, or raise scheduler.dolt_latency_threshold"
+	case state.StallReason == capacity.StallDispatchFailed:
+		report.Reason = capacity.StallDispatchFailed
+		report.Explanation = fmt.Sprintf("%d bead(s) are ready and %d slot(s) free, but the last dispatch attempts failed (%d failure(s) in the last 24h).",
+			len(ready), c.Free, ev.RecentFailures)
+		report.Hint = "gt feed, and the daemon log, show the dispatch errors"
+	default:
+		report.Stalled = false
+		report.Reason = "pending_cycle"
+		report.Explanation = fmt.Sprintf("%d bead(s) are ready and %d slot(s) free; the next dispatch cycle should pick them up.", len(ready), c.Free)
+		report.Hint = "if nothing moves, check gt daemon status or run gt scheduler run"
+	}
+	return report
+}
+
+func printSchedulerStallReport(w io.Writer, r *schedulerStallReport, now time.Time) {
+	headline := style.Bold.Render("Scheduler is moving")
+	if r.Stalled {
+		headline = style.Warning.Render("Scheduler is stalled: " + r.Reason)
+	}
+	fmt.Fprintf(w, "%s\n\n", headline)
+	fmt.Fprintf(w, "  %s\n", r.Explanation)
+	if r.Hint != "" {
+		fmt.Fprintf(w, "  %s\n", style.Dim.Render("Next: "+r.Hint))
+	}
+
+	fmt.Fprintln(w)
+	if t, err := time.Parse(time.RFC3339, r.LastDispatchAt); err == nil {
+		fmt.Fprintf(w, "  Last dispatch:    %s (%s ago)\n", r.LastDispatchAt, formatDuration(now.Sub(t)))
+	} else {
+		fmt.Fprintf(w, "  Last dispatch:    never\n")
+	}
+	if r.StalledSince != "" {
+		fmt.Fprintf(w, "  Stalled since:    %s\n", r.StalledSince)
+	}
+	if r.LastStall != nil {
+		fmt.Fprintf(w, "  Last stall event: %s %s", r.LastStall.Time.UTC().Format(time.RFC3339), r.LastStall.Reason)
+		if r.LastStall.Detail != "" {
+			fmt.Fprintf(w, " (%s)", r.LastStall.Detail)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "  Dispatch failures (24h): %d\n", r.RecentFailures)
+
+	if len(r.Waiting) > 0 {
+		fmt.Fprintf(w, "\n  %s\n", style.Bold.Render("Longest-waiting ready beads:"))
+		for _, b := range r.Waiting {
+			fmt.Fprintf(w, "    %s → %s  waiting %s\n", b.ID, b.TargetRig, formatDuration(time.Duration(b.WaitingSec)*time.Second))
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func TestDiagnoseSchedulerStall(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	readyBeads := []scheduledBeadInfo{
+		{ID: "gt-new", TargetRig: "gastown", EnqueuedAt: "2026-05-01T11:00:00Z"},
+		{ID: "gt-old", TargetRig: "gastown", EnqueuedAt: "2026-05-01T09:00:00Z"},
+		{ID: "gt-blocked", TargetRig: "gastown", EnqueuedAt: "2026-05-01T08:00:00Z", Blocked: true},
+	}
+
+	tests := []struct {
+		name        string
+		snap        schedulerStatusSnapshot
+		state       capacity.SchedulerState
+		wantReason  string
+		wantStalled bool
+	}{
+		{
+			name:       "empty",
+			snap:       schedulerStatusSnapshot{Capacity: polecatCapacitySnapshot{Max: 4, Free: 4}},
+			wantReason: "empty",
+		},
+		{
+			name:        "paused",
+			snap:        schedulerStatusSnapshot{Paused: true, PausedBy: "mayor", ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: 4, Free: 4}},
+			wantReason:  capacity.StallPaused,
+			wantStalled: true,
+		},
+		{
+			name:        "direct dispatch",
+			snap:        schedulerStatusSnapshot{ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: -1}},
+			wantReason:  "direct_dispatch",
+			wantStalled: true,
+		},
+		{
+			name:        "all blocked",
+			snap:        schedulerStatusSnapshot{ScheduledTotal: 1, Beads: readyBeads[2:], Capacity: polecatCapacitySnapshot{Max: 4, Free: 4}},
+			wantReason:  capacity.StallAllBlocked,
+			wantStalled: true,
+		},
+		{
+			name: "misconfigured",
+			snap: schedulerStatusSnapshot{
+				ScheduledTotal: 1,
+				Beads:          []scheduledBeadInfo{{ID: "gt-x", Misconfigured: "no target rig"}},
+				Misconfigured:  []scheduledBeadInfo{{ID: "gt-x", Misconfigured: "no target rig"}},
+				Capacity:       polecatCapacitySnapshot{Max: 4, Free: 4},
+			},
+			wantReason:  "misconfigured",
+			wantStalled: true,
+		},
+		{
+			name:        "capacity",
+			snap:        schedulerStatusSnapshot{ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: 4, Free: 0, Working: 4}},
+			wantReason:  capacity.StallCapacity,
+			wantStalled: true,
+		},
+		{
+			name:        "capacity held for interactive",
+			snap:        schedulerStatusSnapshot{ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: 4, Free: 2, Working: 2}, Reserved: 2},
+			wantReason:  capacity.StallCapacity,
+			wantStalled: true,
+		},
+		{
+			name:        "dispatch failed",
+			snap:        schedulerStatusSnapshot{ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: 4, Free: 2}},
+			state:       capacity.SchedulerState{StallReason: capacity.StallDispatchFailed},
+			wantReason:  capacity.StallDispatchFailed,
+			wantStalled: true,
+		},
+		{
+			name:       "waiting for next cycle",
+			snap:       schedulerStatusSnapshot{ScheduledTotal: 3, Beads: readyBeads, Capacity: polecatCapacitySnapshot{Max: 4, Free: 2}},
+			wantReason: "pending_cycle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := diagnoseSchedulerStall(&tt.snap, &tt.state, schedulerEventSummary{}, now)
+			if r.Reason != tt.wantReason || r.Stalled != tt.wantStalled {
+				t.Errorf("reason = %q stalled = %v, want %q %v (%s)", r.Reason, r.Stalled, tt.wantReason, tt.wantStalled, r.Explanation)
+			}
+		})
+	}
+
+	r := diagnoseSchedulerStall(&tests[5].snap, &capacity.SchedulerState{}, schedulerEventSummary{}, now)
+	if len(r.Waiting) != 2 || r.Waiting[0].ID != "gt-old" || r.Waiting[0].WaitingSec != 3*3600 {
+		t.Errorf("Waiting = %+v, want gt-old (3h) first and blocked beads excluded", r.Waiting)
+	}
+}
+
+func TestReadSchedulerEvents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".events.jsonl")
+	lines := []string{
+		`{"ts":"2026-04-29T10:00:00Z","type":"scheduler_dispatch_failed","payload":{"bead":"gt-a"}}`,
+		`{"ts":"2026-05-01T09:00:00Z","type":"scheduler_dispatch","payload":{"bead":"gt-a"}}`,
+		`{"ts":"2026-05-01T10:00:00Z","type":"scheduler_dispatch_failed","payload":{"bead":"gt-b"}}`,
+		`not json`,
+		`{"ts":"2026-05-01T10:30:00Z","type":"sling","payload":{"bead":"gt-c"}}`,
+		`{"ts":"2026-05-01T11:00:00Z","type":"scheduler_stalled","payload":{"reason":"capacity","detail":"no free polecat slots (max 4)"}}`,
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := readSchedulerEvents(dir, time.Date(2026, 4, 30, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("readSchedulerEvents: %v", err)
+	}
+	if want := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC); !summary.LastDispatch.Equal(want) {
+		t.Errorf("LastDispatch = %v, want %v", summary.LastDispatch, want)
+	}
+	if summary.RecentFailures != 1 {
+		t.Errorf("RecentFailures = %d, want 1 (older failure is outside the window)", summary.RecentFailures)
+	}
+	if summary.LastStall == nil || summary.LastStall.Reason != "capacity" || summary.LastStall.Detail == "" {
+		t.Errorf("LastStall = %+v, want capacity stall", summary.LastStall)
+	}
+
+	if _, err := readSchedulerEvents(t.TempDir(), time.Time{}); err != nil {
+		t.Errorf("missing events file: %v", err)
+	}
+}
@@ -7,13 +7,17 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gofrs/flock"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/atomicfile"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
@@ -22,12 +26,14 @@ import (
 )
 
 var (
-	seanceRole   string
-	seanceRig    string
-	seanceRecent int
-	seanceTalk   string
-	seancePrompt string
-	seanceJSON   bool
+	seanceRole    string
+	seanceRig     string
+	seanceRecent  int
+	seanceTalk    string
+	seancePrompt  string
+	seanceJSON    bool
+	seanceProject string
+	seanceGrace   time.Duration
 )
 
 var seanceCmd = &cobra.Command{
@@ -51,12 +57,22 @@ DISCOVERY:
   gt seance --recent 10         # Last N sessions
 
 THE SEANCE (talk to predecessor):
+  gt seance open <session-id>                # Resolve, link and resume in one step
   gt seance --talk <session-id>              # Interactive conversation
   gt seance --talk <id> -p "Where is X?"     # One-shot question
+  gt seance --talk <id> --project <dir>      # Symlink into a specific project dir
 
 The --talk flag spawns: claude --fork-session --resume <id>
 This loads the predecessor's full context without modifying their session.
 
+Sessions from other accounts or project directories are temporarily symlinked
+into the current account. By default the symlink lands in the project dir
+derived from the current working directory; --project overrides this so
+seance can be run from a scratch directory. Symlinks left behind by an
+interrupted seance are removed on the next --talk once their target is gone
+and they are older than --symlink-grace, so a symlink another seance is
+still setting up is not mistaken for one.
+
 Sessions are discovered from:
   1. Events emitted by SessionStart hooks (~/gt/.events.jsonl)
   2. The [GAS TOWN] beacon makes sessions searchable in /resume`,
@@ -70,6 +86,8 @@ func init() {
 	seanceCmd.Flags().StringVarP(&seanceTalk, "talk", "t", "", "Session ID to commune with")
 	seanceCmd.Flags().StringVarP(&seancePrompt, "prompt", "p", "", "One-shot prompt (with --talk)")
 	seanceCmd.Flags().BoolVar(&seanceJSON, "json", false, "Output as JSON")
+	seanceCmd.Flags().StringVar(&seanceProject, "project", "", "Target project dir for the session symlink (default: derived from cwd)")
+	seanceCmd.Flags().DurationVar(&seanceGrace, "symlink-grace", defaultSessionSymlinkGrace, "Keep dangling session symlinks younger than this")
 
 	rootCmd.AddCommand(seanceCmd)
 }
@@ -85,7 +103,7 @@ type sessionEvent struct {
 func runSeance(cmd *cobra.Command, args []string) error {
 	// If --talk is provided, spawn a seance
 	if seanceTalk != "" {
-		return runSeanceTalk(seanceTalk, seancePrompt)
+		return runSeanceTalk(seanceTalk, seancePrompt, seanceProject)
 	}
 
 	// Otherwise, list discoverable sessions
@@ -205,7 +223,7 @@ func resolveSeanceCommand() (string, error) {
 	return "", fmt.Errorf("no agent supports fork session (seance requires --fork-session)")
 }
 
-func runSeanceTalk(sessionID, prompt string) error {
+func runSeanceTalk(sessionID, prompt, projectDir string) error {
 	// Resolve the agent command that supports fork session
 	agentCmd, err := resolveSeanceCommand()
 	if err != nil {
@@ -213,8 +231,22 @@ func runSeanceTalk(sessionID, prompt string) error {
 	}
 
 	// Clean up any orphaned symlinks from previous interrupted sessions
-	cleanupOrphanedSessionSymlinks()
+	cleanupOrphanedSessionSymlinks(seanceGrace)
+
+	sessionID, cleanup, err := summonSession(sessionID, projectDir)
+	if err != nil {
+		return err
+	}
+	stop := cleanupOnExit(cleanup)
+	defer stop()
+
+	return launchSeance(agentCmd, sessionID, prompt)
+}
 
+// summonSession resolves a session ID prefix to the full ID and symlinks the
+// session into the current account if it lives elsewhere. The returned
+// cleanup (nil if nothing was linked) removes the symlink again.
+func summonSession(sessionID, projectDir string) (string, func(), error) {
 	// Find workspace root (needed for both prefix resolution and session symlinks)
 	townRoot, _ := workspace.FindFromCwd()
 
@@ -225,22 +257,60 @@ func runSeanceTalk(sessionID, prompt string) error {
 		if townRoot != "" {
 			resolved, err := resolveSessionPrefix(townRoot, sessionID)
 			if err != nil {
-				return fmt.Errorf("resolving session ID: %w", err)
+				return "", nil, fmt.Errorf("resolving session ID: %w", err)
 			}
 			sessionID = resolved
 		}
 	}
 
 	fmt.Printf("%s Summoning session %s...\n\n", style.Bold.Render("🔮"), sessionID)
-	cleanup, err := symlinkSessionToCurrentAccount(townRoot, sessionID)
+	cleanup, err := symlinkSessionToCurrentAccount(townRoot, sessionID, projectDir)
 	if err != nil {
 		// Not fatal - session might already be in current account
 		fmt.Printf("%s\n", style.Dim.Render("Note: "+err.Error()))
 	}
-	if cleanup != nil {
-		defer cleanup()
+	return sessionID, cleanup, nil
+}
+
+// cleanupOnExit arranges for cleanup to run exactly once: when the returned
+// stop function is called, or when gt is terminated by SIGTERM or SIGHUP
+// (e.g. the terminal closes) before that. SIGINT is left to the agent
+// subprocess, which handles Ctrl+C itself; gt cleans up once it exits.
+func cleanupOnExit(cleanup func()) (stop func()) {
+	if cleanup == nil {
+		return func() {}
+	}
+	var once sync.Once
+	sigChan := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case sig := <-sigChan:
+				if sig == os.Interrupt {
+					continue
+				}
+				once.Do(cleanup)
+				if s, ok := sig.(syscall.Signal); ok {
+					os.Exit(128 + int(s))
+				}
+				os.Exit(1)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigChan)
+		close(done)
+		once.Do(cleanup)
 	}
+}
 
+// launchSeance resumes sessionID in a forked agent session: one-shot when
+// prompt is set, otherwise interactively on the current terminal.
+func launchSeance(agentCmd, sessionID, prompt string) error {
 	// Build the command
 	args := []string{"--fork-session", "--resume", sessionID}
 
@@ -303,10 +373,29 @@ func clearClaudeCodeEnv(environ []string) []string {
 	return filtered
 }
 
-// discoverSessions reads session_start events from our event stream.
+// discoverSessions reads session_start events from our event stream,
+// including rolled events files, so older sessions stay resolvable.
 func discoverSessions(townRoot string) ([]sessionEvent, error) {
-	eventsPath := filepath.Join(townRoot, events.EventsFile)
+	var sessions []sessionEvent
+	for _, eventsPath := range events.LogFiles(townRoot) {
+		found, err := readSessionStarts(eventsPath)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, found...)
+	}
+
+	// Sort by timestamp descending (most recent first)
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Timestamp > sessions[j].Timestamp
+	})
+
+	return sessions, nil
+}
 
+// readSessionStarts returns the session_start events in one events file.
+// A missing file holds no sessions.
+func readSessionStarts(eventsPath string) ([]sessionEvent, error) {
 	file, err := os.Open(eventsPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -334,11 +423,6 @@ func discoverSessions(townRoot string) ([]sessionEvent, error) {
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].Timestamp > sessions[j].Timestamp
-	})
-
 	return sessions, scanner.Err()
 }
 
@@ -439,6 +523,58 @@ func lockSessionsIndex(indexPath string) (*flock.Flock, error) {
 	return lock, nil
 }
 
+// updateSessionsIndex read-modifies-writes the sessions index at indexPath
+// under its lock. modify gets the current index (version 1 with no entries
+// if the file does not exist yet) and reports whether it changed it. Changes
+// are written to a temp file and renamed into place, so concurrent seance
+// operations neither lose each other's updates nor see a partial index.
+// An index that exists but cannot be parsed is left alone.
+func updateSessionsIndex(indexPath string, modify func(*sessionsIndex) (bool, error)) error {
+	lock, err := lockSessionsIndex(indexPath)
+	if err != nil {
+		return fmt.Errorf("locking sessions index: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	index := sessionsIndex{Version: 1}
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := json.Unmarshal(data, &index); err != nil {
+			return fmt.Errorf("parsing sessions index: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading sessions index: %w", err)
+	}
+
+	changed, err := modify(&index)
+	if err != nil || !changed {
+		return err
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding sessions index: %w", err)
+	}
+	if err := atomicfile.WriteFile(indexPath, data, 0600); err != nil {
+		return fmt.Errorf("writing sessions index: %w", err)
+	}
+	return nil
+}
+
+// removeSessionsIndexEntries drops the entries for the given session IDs and
+// returns how many were removed.
+func removeSessionsIndexEntries(index *sessionsIndex, remove map[string]bool) int {
+	kept := make([]json.RawMessage, 0, len(index.Entries))
+	for _, rawEntry := range index.Entries {
+		var e sessionsIndexEntry
+		if json.Unmarshal(rawEntry, &e) == nil && remove[e.SessionID] {
+			continue
+		}
+		kept = append(kept, rawEntry)
+	}
+	removed := len(index.Entries) - len(kept)
+	index.Entries = kept
+	return removed
+}
+
 // findSessionLocation searches all account config directories for a session.
 // Returns the config directory and project directory that contain the session.
 func findSessionLocation(townRoot, sessionID string) *sessionLocation {
@@ -539,10 +675,23 @@ func findSessionLocation(townRoot, sessionID string) *sessionLocation {
 	return nil
 }
 
+// seanceProjectDirName converts a --project value into a Claude project dir name.
+// Absolute paths are encoded the same way Claude derives project dirs from cwd;
+// anything else is taken as an existing project dir name.
+func seanceProjectDirName(project string) string {
+	if filepath.IsAbs(project) {
+		return strings.ReplaceAll(filepath.Clean(project), "/", "-")
+	}
+	return project
+}
+
 // symlinkSessionToCurrentAccount finds a session in any account and symlinks
 // it to the current account so Claude can access it.
+// If projectDir is non-empty, the symlink is placed in that project dir instead
+// of the default (cwd-based for same-account sessions, the source project dir
+// for cross-account sessions).
 // Returns a cleanup function to remove the symlink after use.
-func symlinkSessionToCurrentAccount(townRoot, sessionID string) (cleanup func(), err error) {
+func symlinkSessionToCurrentAccount(townRoot, sessionID, projectDir string) (cleanup func(), err error) {
 	// Get current account's config directory (resolve ~/.claude symlink)
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -556,35 +705,44 @@ func symlinkSessionToCurrentAccount(townRoot, sessionID string) (cleanup func(),
 		currentConfigDir = claudeDir
 	}
 
-	return symlinkSessionToConfigDir(townRoot, sessionID, currentConfigDir)
+	return symlinkSessionToConfigDir(townRoot, sessionID, currentConfigDir, projectDir)
 }
 
 // symlinkSessionToConfigDir symlinks a session file from its source account into the
 // given target config directory, updating the sessions-index.json so Claude can find it.
+// An empty projectDir selects the default target project dir.
 // Returns a cleanup function (may be nil if no work was needed) and any error.
-func symlinkSessionToConfigDir(townRoot, sessionID, targetConfigDir string) (cleanup func(), err error) {
+func symlinkSessionToConfigDir(townRoot, sessionID, targetConfigDir, projectDir string) (cleanup func(), err error) {
 	// Find where the session lives
 	loc := findSessionLocation(townRoot, sessionID)
 	if loc == nil {
 		return nil, fmt.Errorf("session not found in any account")
 	}
 
+	if projectDir != "" {
+		projectDir = seanceProjectDirName(projectDir)
+	}
+
 	// Session in same account but possibly different project dir.
-	// Symlink into cwd-based project dir so Claude can find it via --resume.
+	// Symlink into the target (default: cwd-based) project dir so Claude can
+	// find it via --resume.
 	// Resolve both paths to handle macOS /var → /private/var symlink differences.
 	resolvedLocDir, _ := filepath.EvalSymlinks(loc.configDir)
 	resolvedTargetDir, _ := filepath.EvalSymlinks(targetConfigDir)
 	if resolvedLocDir == resolvedTargetDir {
-		cwd, cwdErr := os.Getwd()
-		if cwdErr != nil {
-			return nil, nil
+		targetProjectDir := projectDir
+		if targetProjectDir == "" {
+			cwd, cwdErr := os.Getwd()
+			if cwdErr != nil {
+				return nil, nil
+			}
+			targetProjectDir = strings.ReplaceAll(cwd, "/", "-")
 		}
-		cwdProjectDir := strings.ReplaceAll(cwd, "/", "-")
-		if cwdProjectDir == loc.projectDir {
+		if targetProjectDir == loc.projectDir {
 			return nil, nil // Already in correct project dir
 		}
 		sourceFile := filepath.Join(targetConfigDir, "projects", loc.projectDir, sessionID+".jsonl")
-		targetDir := filepath.Join(targetConfigDir, "projects", cwdProjectDir)
+		targetDir := filepath.Join(targetConfigDir, "projects", targetProjectDir)
 		if mkErr := os.MkdirAll(targetDir, 0755); mkErr != nil {
 			return nil, nil
 		}
@@ -606,8 +764,13 @@ func symlinkSessionToConfigDir(townRoot, sessionID, targetConfigDir string) (cle
 		return nil, fmt.Errorf("session file not found: %s", sourceSessionFile)
 	}
 
-	// Target: the project directory in the target account
-	currentProjectDir := filepath.Join(targetConfigDir, "projects", loc.projectDir)
+	// Target: the project directory in the target account. Mirror the source
+	// project dir unless the caller asked for a specific one.
+	targetProjectDir := loc.projectDir
+	if projectDir != "" {
+		targetProjectDir = projectDir
+	}
+	currentProjectDir := filepath.Join(targetConfigDir, "projects", targetProjectDir)
 
 	// Create project directory if it doesn't exist
 	if err := os.MkdirAll(currentProjectDir, 0755); err != nil {
@@ -670,92 +833,52 @@ func symlinkSessionToConfigDir(townRoot, sessionID, targetConfigDir string) (cle
 		return nil, fmt.Errorf("session not found in source index")
 	}
 
-	// Read or create target index (with file locking to prevent race conditions)
+	// Add the session to the target index so Claude can find it.
 	targetIndexPath := filepath.Join(currentProjectDir, "sessions-index.json")
-
-	// Acquire lock for read-modify-write operation
-	lock, err := lockSessionsIndex(targetIndexPath)
-	if err != nil {
-		_ = os.Remove(targetSessionFile)
-		return nil, fmt.Errorf("locking sessions index: %w", err)
-	}
-	defer func() { _ = lock.Unlock() }()
-
-	var targetIndex sessionsIndex
-	if targetIndexData, err := os.ReadFile(targetIndexPath); err == nil {
-		_ = json.Unmarshal(targetIndexData, &targetIndex)
-	} else {
-		targetIndex.Version = 1
-	}
-
-	// Check if session already in target index
-	sessionInIndex := false
-	for _, rawEntry := range targetIndex.Entries {
-		var e sessionsIndexEntry
-		if json.Unmarshal(rawEntry, &e) == nil && e.SessionID == sessionID {
-			sessionInIndex = true
-			break
-		}
-	}
-
-	// Add session to target index if not present
 	indexModified := false
-	if !sessionInIndex {
-		targetIndex.Entries = append(targetIndex.Entries, sessionEntry)
-		indexModified = true
-
-		// Write updated index
-		targetIndexData, err := json.MarshalIndent(targetIndex, "", "  ")
-		if err != nil {
-			_ = os.Remove(targetSessionFile)
-			return nil, fmt.Errorf("encoding target sessions index: %w", err)
-		}
-		if err := os.WriteFile(targetIndexPath, targetIndexData, 0600); err != nil {
-			_ = os.Remove(targetSessionFile)
-			return nil, fmt.Errorf("writing target sessions index: %w", err)
+	err = updateSessionsIndex(targetIndexPath, func(index *sessionsIndex) (bool, error) {
+		for _, rawEntry := range index.Entries {
+			var e sessionsIndexEntry
+			if json.Unmarshal(rawEntry, &e) == nil && e.SessionID == sessionID {
+				return false, nil
+			}
 		}
+		index.Entries = append(index.Entries, sessionEntry)
+		indexModified = true
+		return true, nil
+	})
+	if err != nil {
+		_ = os.Remove(targetSessionFile)
+		return nil, fmt.Errorf("updating target sessions index: %w", err)
 	}
 
 	// Return cleanup function
 	cleanup = func() {
 		_ = os.Remove(targetSessionFile)
-		// If we modified the index, remove the entry we added
+		// If we added the index entry, remove it again. If the lock can't be
+		// taken the entry is left behind; gt session prune-index drops it.
 		if indexModified {
-			// Acquire lock for read-modify-write operation
-			cleanupLock, lockErr := lockSessionsIndex(targetIndexPath)
-			if lockErr == nil {
-				defer func() { _ = cleanupLock.Unlock() }()
-			}
-			// Proceed with best-effort cleanup even without lock,
-			// to avoid leaving stale entries in sessions-index.json
-
-			// Re-read index, remove our entry, write it back
-			if data, err := os.ReadFile(targetIndexPath); err == nil {
-				var idx sessionsIndex
-				if json.Unmarshal(data, &idx) == nil {
-					newEntries := make([]json.RawMessage, 0, len(idx.Entries))
-					for _, rawEntry := range idx.Entries {
-						var e sessionsIndexEntry
-						if json.Unmarshal(rawEntry, &e) == nil && e.SessionID != sessionID {
-							newEntries = append(newEntries, rawEntry)
-						}
-					}
-					idx.Entries = newEntries
-					if newData, err := json.MarshalIndent(idx, "", "  "); err == nil {
-						_ = os.WriteFile(targetIndexPath, newData, 0600)
-					}
-				}
-			}
+			_ = updateSessionsIndex(targetIndexPath, func(index *sessionsIndex) (bool, error) {
+				return removeSessionsIndexEntries(index, map[string]bool{sessionID: true}) > 0, nil
+			})
 		}
 	}
 
 	return cleanup, nil
 }
 
+// defaultSessionSymlinkGrace is how old a dangling session symlink must be
+// before cleanupOrphanedSessionSymlinks removes it.
+const defaultSessionSymlinkGrace = 5 * time.Minute
+
 // cleanupOrphanedSessionSymlinks removes stale session symlinks from the current account.
 // This handles cases where a previous seance was interrupted (e.g., SIGKILL) and
 // couldn't run its cleanup function. Call this at the start of seance operations.
-func cleanupOrphanedSessionSymlinks() {
+// Symlinks created less than grace ago are kept even if their target is missing:
+// another seance may be creating the session they point at right now.
+func cleanupOrphanedSessionSymlinks(grace time.Duration) {
+	now := time.Now()
+
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return
@@ -802,8 +925,8 @@ func cleanupOrphanedSessionSymlinks() {
 				continue
 			}
 
-			// Only check symlinks
-			if info.Mode()&os.ModeSymlink == 0 {
+			// Only check symlinks, and only once past the grace period
+			if info.Mode()&os.ModeSymlink == 0 || now.Sub(info.ModTime()) < grace {
 				continue
 			}
 
@@ -821,52 +944,19 @@ func cleanupOrphanedSessionSymlinks() {
 			}
 		}
 
-		// Clean up orphaned entries from sessions-index.json
+		// Clean up orphaned entries from sessions-index.json (best effort)
 		if len(orphanedSessionIDs) > 0 {
-			indexPath := filepath.Join(projPath, "sessions-index.json")
-
-			// Acquire lock for read-modify-write operation
-			lock, lockErr := lockSessionsIndex(indexPath)
-			if lockErr != nil {
-				// Best effort cleanup - skip this project if lock fails
-				continue
-			}
-
-			data, err := os.ReadFile(indexPath)
-			if err != nil {
-				_ = lock.Unlock()
-				continue
-			}
-
-			var index sessionsIndex
-			if err := json.Unmarshal(data, &index); err != nil {
-				_ = lock.Unlock()
-				continue
-			}
-
-			// Build a set of orphaned IDs for fast lookup
-			orphanedSet := make(map[string]bool)
+			orphanedSet := make(map[string]bool, len(orphanedSessionIDs))
 			for _, id := range orphanedSessionIDs {
 				orphanedSet[id] = true
 			}
-
-			// Filter out orphaned entries
-			newEntries := make([]json.RawMessage, 0, len(index.Entries))
-			for _, rawEntry := range index.Entries {
-				var e sessionsIndexEntry
-				if json.Unmarshal(rawEntry, &e) == nil && !orphanedSet[e.SessionID] {
-					newEntries = append(newEntries, rawEntry)
-				}
-			}
-
-			if len(newEntries) != len(index.Entries) {
-				index.Entries = newEntries
-				if newData, err := json.MarshalIndent(index, "", "  "); err == nil {
-					_ = os.WriteFile(indexPath, newData, 0600)
-				}
+			indexPath := filepath.Join(projPath, "sessions-index.json")
+			if _, err := os.Stat(indexPath); err != nil {
+				continue
 			}
-
-			_ = lock.Unlock()
+			_ = updateSessionsIndex(indexPath, func(index *sessionsIndex) (bool, error) {
+				return removeSessionsIndexEntries(index, orphanedSet) > 0, nil
+			})
 		}
 	}
 }
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var seanceAccountsJSON bool
+
+var seanceAccountsCmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "List account config dirs and which one is current",
+	Long: `List the Claude accounts seance searches for sessions.
+
+Reads mayor/accounts.json and prints each account's handle, email and config
+dir, marking the default account and the current one: the account whose
+config dir ~/.claude resolves to. Sessions from any other account are
+symlinked into the current one when you seance them.
+
+Examples:
+  gt seance accounts
+  gt seance accounts --json`,
+	Args: cobra.NoArgs,
+	RunE: runSeanceAccounts,
+}
+
+func init() {
+	seanceAccountsCmd.Flags().BoolVar(&seanceAccountsJSON, "json", false, "Output as JSON")
+	seanceCmd.AddCommand(seanceAccountsCmd)
+}
+
+// seanceAccount is one accounts.json entry as seance sees it.
+type seanceAccount struct {
+	Handle    string `json:"handle"`
+	Email     string `json:"email,omitempty"`
+	ConfigDir string `json:"config_dir"`
+	Default   bool   `json:"default,omitempty"`
+	Current   bool   `json:"current,omitempty"`
+}
+
+// seanceAccountsReport is the output of `gt seance accounts`.
+type seanceAccountsReport struct {
+	// CurrentConfigDir is where ~/.claude resolves to.
+	CurrentConfigDir string `json:"current_config_dir"`
+	// CurrentAccount is the handle of the account at CurrentConfigDir, or
+	// empty when that dir is not registered in accounts.json.
+	CurrentAccount string          `json:"current_account,omitempty"`
+	Accounts       []seanceAccount `json:"accounts"`
+}
+
+func runSeanceAccounts(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return fmt.Errorf("not in a Gas Town workspace")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("getting home directory: %w", err)
+	}
+
+	report, err := listSeanceAccounts(constants.MayorAccountsPath(townRoot), filepath.Join(home, ".claude"))
+	if err != nil {
+		return err
+	}
+
+	if seanceAccountsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	if len(report.Accounts) == 0 {
+		fmt.Println("No accounts configured in mayor/accounts.json.")
+	}
+	for _, a := range report.Accounts {
+		marker := "  "
+		if a.Current {
+			marker = style.Bold.Render("→ ")
+		}
+		fmt.Printf("%s%s", marker, style.Bold.Render(a.Handle))
+		if a.Email != "" {
+			fmt.Printf("  %s", a.Email)
+		}
+		if a.Default {
+			fmt.Printf("  %s", style.Dim.Render("(default)"))
+		}
+		if a.Current {
+			fmt.Printf("  %s", style.Dim.Render("(current)"))
+		}
+		fmt.Println()
+		fmt.Printf("    %s\n", style.Dim.Render(a.ConfigDir))
+	}
+
+	fmt.Println()
+	current := report.CurrentAccount
+	if current == "" {
+		current = style.Warning.Render("not in accounts.json")
+	}
+	fmt.Printf("%s %s %s\n", style.Bold.Render("~/.claude:"), report.CurrentConfigDir, style.Dim.Render("("+current+")"))
+	return nil
+}
+
+// listSeanceAccounts reads the accounts config at accountsPath and marks the
+// default account and the one claudeDir (normally ~/.claude) resolves to. A
+// missing accounts file yields no accounts rather than an error, as seance
+// then falls back to ~/.claude alone.
+func listSeanceAccounts(accountsPath, claudeDir string) (*seanceAccountsReport, error) {
+	report := &seanceAccountsReport{
+		CurrentConfigDir: resolveConfigDir(claudeDir),
+		Accounts:         []seanceAccount{},
+	}
+
+	cfg, err := config.LoadAccountsConfig(accountsPath)
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return report, nil
+		}
+		return nil, fmt.Errorf("loading %s: %w", accountsPath, err)
+	}
+
+	for handle, acct := range cfg.Accounts {
+		configDir := util.ExpandHome(acct.ConfigDir)
+		a := seanceAccount{
+			Handle:    handle,
+			Email:     acct.Email,
+			ConfigDir: configDir,
+			Default:   handle == cfg.Default,
+		}
+		if configDir != "" && resolveConfigDir(configDir) == report.CurrentConfigDir {
+			a.Current = true
+			report.CurrentAccount = handle
+		}
+		report.Accounts = append(report.Accounts, a)
+	}
+	sort.Slice(report.Accounts, func(i, j int) bool {
+		return report.Accounts[i].Handle < report.Accounts[j].Handle
+	})
+	return report, nil
+}
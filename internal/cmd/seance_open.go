@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	seanceOpenPrompt  string
+	seanceOpenProject string
+)
+
+var seanceOpenCmd = &cobra.Command{
+	Use:   "open <session-prefix>",
+	Short: "Resolve, link and resume a predecessor session in one step",
+	Long: `Open a predecessor session, wherever it lives.
+
+Resolves the session ID prefix, symlinks the session into the current account
+if it belongs to another account or project dir, and resumes it with
+--fork-session. The symlink is removed when the session ends, including when
+gt is terminated or its terminal closes. Symlinks left dangling by a killed
+seance are cleared by the next seance once past the symlink grace period.
+
+Examples:
+  gt seance open 3f2a
+  gt seance open 3f2a -p "Where did you put the migration notes?"
+  gt seance open 3f2a --project ~/gt/gastown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSeanceOpen,
+}
+
+func init() {
+	seanceOpenCmd.Flags().StringVarP(&seanceOpenPrompt, "prompt", "p", "", "One-shot prompt instead of an interactive session")
+	seanceOpenCmd.Flags().StringVar(&seanceOpenProject, "project", "", "Target project dir for the session symlink (default: derived from cwd)")
+	seanceCmd.AddCommand(seanceOpenCmd)
+}
+
+func runSeanceOpen(cmd *cobra.Command, args []string) error {
+	return runSeanceTalk(args[0], seanceOpenPrompt, seanceOpenProject)
+}
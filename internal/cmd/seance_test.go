@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
@@ -86,29 +88,64 @@ func createTestSession(t *testing.T, configDir, projectName, sessionID string) {
 	}
 
 	// Create or update sessions-index.json
-	indexPath := filepath.Join(projectDir, "sessions-index.json")
-	var index sessionsIndex
-	if data, err := os.ReadFile(indexPath); err == nil {
-		_ = json.Unmarshal(data, &index)
-	} else {
-		index.Version = 1
-	}
-
-	// Add session entry
 	entry := map[string]interface{}{
 		"sessionId":    sessionID,
 		"name":         "Test Session",
 		"lastAccessed": "2026-01-22T00:00:00Z",
 	}
 	entryJSON, _ := json.Marshal(entry)
-	index.Entries = append(index.Entries, entryJSON)
-
-	indexData, _ := json.MarshalIndent(index, "", "  ")
-	if err := os.WriteFile(indexPath, indexData, 0600); err != nil {
+	indexPath := filepath.Join(projectDir, "sessions-index.json")
+	err := updateSessionsIndex(indexPath, func(index *sessionsIndex) (bool, error) {
+		index.Entries = append(index.Entries, entryJSON)
+		return true, nil
+	})
+	if err != nil {
 		t.Fatalf("write sessions-index.json: %v", err)
 	}
 }
 
+func TestListSeanceAccounts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink tests require elevated privileges on Windows")
+	}
+	townRoot, fakeHome, cleanup := setupSeanceTestEnv(t)
+	defer cleanup()
+
+	accountsPath := filepath.Join(townRoot, "mayor", "accounts.json")
+	report, err := listSeanceAccounts(accountsPath, filepath.Join(fakeHome, ".claude"))
+	if err != nil {
+		t.Fatalf("listSeanceAccounts: %v", err)
+	}
+	if report.CurrentAccount != "account1" {
+		t.Errorf("CurrentAccount = %q, want account1", report.CurrentAccount)
+	}
+	if len(report.Accounts) != 2 || report.Accounts[0].Handle != "account1" || report.Accounts[1].Handle != "account2" {
+		t.Fatalf("Accounts = %+v, want account1, account2", report.Accounts)
+	}
+	first, second := report.Accounts[0], report.Accounts[1]
+	if !first.Default || !first.Current || first.Email != "test1@example.com" {
+		t.Errorf("account1 = %+v, want default and current", first)
+	}
+	if second.Default || second.Current {
+		t.Errorf("account2 = %+v, want neither default nor current", second)
+	}
+
+	// ~/.claude that is no registered account: listed, but nothing current.
+	report, err = listSeanceAccounts(accountsPath, filepath.Join(fakeHome, "elsewhere"))
+	if err != nil {
+		t.Fatalf("listSeanceAccounts(elsewhere): %v", err)
+	}
+	if report.CurrentAccount != "" || report.Accounts[0].Current {
+		t.Errorf("unregistered ~/.claude: report = %+v, want no current account", report)
+	}
+
+	// No accounts.json: an empty list, not an error.
+	report, err = listSeanceAccounts(filepath.Join(t.TempDir(), "accounts.json"), filepath.Join(fakeHome, ".claude"))
+	if err != nil || len(report.Accounts) != 0 {
+		t.Errorf("missing accounts.json: report = %+v, err = %v", report, err)
+	}
+}
+
 func TestFindSessionLocation(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("symlink tests require elevated privileges on Windows")
@@ -184,7 +221,7 @@ func TestSymlinkSessionToCurrentAccount(t *testing.T) {
 		createTestSession(t, account2Dir, "cross-project", "session-cross123")
 
 		// Call symlinkSessionToCurrentAccount
-		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-cross123")
+		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-cross123", "")
 		if err != nil {
 			t.Fatalf("symlinkSessionToCurrentAccount failed: %v", err)
 		}
@@ -257,7 +294,7 @@ func TestSymlinkSessionToCurrentAccount(t *testing.T) {
 		account1Dir := filepath.Join(fakeHome, "claude-config-account1")
 		createTestSession(t, account1Dir, cwdProjectDir, "session-local456")
 
-		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-local456")
+		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-local456", "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -274,7 +311,7 @@ func TestSymlinkSessionToCurrentAccount(t *testing.T) {
 		account1Dir := filepath.Join(fakeHome, "claude-config-account1")
 		createTestSession(t, account1Dir, "other-project", "session-crossproj789")
 
-		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-crossproj789")
+		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-crossproj789", "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -292,17 +329,161 @@ func TestSymlinkSessionToCurrentAccount(t *testing.T) {
 		}
 	})
 
+	t.Run("symlinks into explicit project dir in same account", func(t *testing.T) {
+		townRoot, fakeHome, cleanup := setupSeanceTestEnv(t)
+		defer cleanup()
+
+		account1Dir := filepath.Join(fakeHome, "claude-config-account1")
+		createTestSession(t, account1Dir, "other-project", "session-override111")
+
+		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-override111", "chosen-project")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cleanupFn == nil {
+			t.Fatal("expected non-nil cleanup for explicit project dir")
+		}
+
+		symlinkPath := filepath.Join(account1Dir, "projects", "chosen-project", "session-override111.jsonl")
+		if _, err := os.Lstat(symlinkPath); err != nil {
+			t.Fatalf("expected symlink at %s: %v", symlinkPath, err)
+		}
+
+		cleanupFn()
+		if _, err := os.Lstat(symlinkPath); !os.IsNotExist(err) {
+			t.Error("symlink should have been removed from chosen project dir after cleanup")
+		}
+	})
+
+	t.Run("symlinks into explicit project dir from other account", func(t *testing.T) {
+		townRoot, fakeHome, cleanup := setupSeanceTestEnv(t)
+		defer cleanup()
+
+		account2Dir := filepath.Join(fakeHome, "claude-config-account2")
+		createTestSession(t, account2Dir, "cross-project", "session-override222")
+
+		cleanupFn, err := symlinkSessionToCurrentAccount(townRoot, "session-override222", "/scratch/work")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cleanupFn == nil {
+			t.Fatal("expected cleanup function, got nil")
+		}
+
+		account1Dir := filepath.Join(fakeHome, "claude-config-account1")
+		projectDir := filepath.Join(account1Dir, "projects", "-scratch-work")
+		symlinkPath := filepath.Join(projectDir, "session-override222.jsonl")
+		if _, err := os.Lstat(symlinkPath); err != nil {
+			t.Fatalf("expected symlink at %s: %v", symlinkPath, err)
+		}
+		if _, err := os.Stat(filepath.Join(account1Dir, "projects", "cross-project")); !os.IsNotExist(err) {
+			t.Error("default project dir should not be created when an override is given")
+		}
+
+		indexPath := filepath.Join(projectDir, "sessions-index.json")
+		data, err := os.ReadFile(indexPath)
+		if err != nil {
+			t.Fatalf("reading index: %v", err)
+		}
+		if !strings.Contains(string(data), "session-override222") {
+			t.Error("session not found in chosen project's index")
+		}
+
+		cleanupFn()
+		if _, err := os.Lstat(symlinkPath); !os.IsNotExist(err) {
+			t.Error("symlink should have been removed after cleanup")
+		}
+		data, _ = os.ReadFile(indexPath)
+		if strings.Contains(string(data), "session-override222") {
+			t.Error("session should have been removed from chosen project's index after cleanup")
+		}
+	})
+
 	t.Run("returns error for nonexistent session", func(t *testing.T) {
 		townRoot, _, cleanup := setupSeanceTestEnv(t)
 		defer cleanup()
 
-		_, err := symlinkSessionToCurrentAccount(townRoot, "session-notfound")
+		_, err := symlinkSessionToCurrentAccount(townRoot, "session-notfound", "")
 		if err == nil {
 			t.Error("expected error for nonexistent session")
 		}
 	})
 }
 
+func TestSymlinkSessionsConcurrently(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink tests require elevated privileges on Windows")
+	}
+
+	townRoot, fakeHome, cleanup := setupSeanceTestEnv(t)
+	defer cleanup()
+
+	// Concurrent seance operations symlinking into the same project must not
+	// lose each other's sessions-index.json updates.
+	account2Dir := filepath.Join(fakeHome, "claude-config-account2")
+	ids := []string{"session-race-a", "session-race-b", "session-race-c", "session-race-d"}
+	for _, id := range ids {
+		createTestSession(t, account2Dir, "shared-project", id)
+	}
+
+	cleanups := make([]func(), len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			cleanups[i], errs[i] = symlinkSessionToCurrentAccount(townRoot, id, "")
+		}(i, id)
+	}
+	wg.Wait()
+
+	indexedIDs := func() map[string]bool {
+		t.Helper()
+		indexPath := filepath.Join(fakeHome, "claude-config-account1", "projects", "shared-project", "sessions-index.json")
+		data, err := os.ReadFile(indexPath)
+		if err != nil {
+			t.Fatalf("reading index: %v", err)
+		}
+		var index sessionsIndex
+		if err := json.Unmarshal(data, &index); err != nil {
+			t.Fatalf("index corrupted: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, entry := range index.Entries {
+			var e sessionsIndexEntry
+			if json.Unmarshal(entry, &e) == nil {
+				got[e.SessionID] = true
+			}
+		}
+		return got
+	}
+
+	for i, id := range ids {
+		if errs[i] != nil || cleanups[i] == nil {
+			t.Fatalf("symlink %s: cleanup=%v err=%v", id, cleanups[i] != nil, errs[i])
+		}
+	}
+	got := indexedIDs()
+	for _, id := range ids {
+		if !got[id] {
+			t.Errorf("index lost concurrent entry %s (have %v)", id, got)
+		}
+	}
+
+	for _, fn := range cleanups {
+		wg.Add(1)
+		go func(fn func()) {
+			defer wg.Done()
+			fn()
+		}(fn)
+	}
+	wg.Wait()
+	if got := indexedIDs(); len(got) != 0 {
+		t.Errorf("index after concurrent cleanup = %v, want empty", got)
+	}
+}
+
 func TestCleanupOrphanedSessionSymlinks(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("symlink tests require elevated privileges on Windows")
@@ -339,7 +520,7 @@ func TestCleanupOrphanedSessionSymlinks(t *testing.T) {
 		}
 
 		// Run cleanup
-		cleanupOrphanedSessionSymlinks()
+		cleanupOrphanedSessionSymlinks(0)
 
 		// Verify orphan symlink was removed
 		if _, err := os.Lstat(orphanSymlink); !os.IsNotExist(err) {
@@ -355,6 +536,26 @@ func TestCleanupOrphanedSessionSymlinks(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps orphans younger than the grace period", func(t *testing.T) {
+		_, fakeHome, cleanup := setupSeanceTestEnv(t)
+		defer cleanup()
+
+		projectDir := filepath.Join(fakeHome, "claude-config-account1", "projects", "fresh-project")
+		if err := os.MkdirAll(projectDir, 0755); err != nil {
+			t.Fatalf("mkdir project: %v", err)
+		}
+		freshSymlink := filepath.Join(projectDir, "fresh-session.jsonl")
+		if err := os.Symlink(filepath.Join(fakeHome, "not-yet", "session.jsonl"), freshSymlink); err != nil {
+			t.Fatalf("create symlink: %v", err)
+		}
+
+		cleanupOrphanedSessionSymlinks(time.Hour)
+
+		if _, err := os.Lstat(freshSymlink); err != nil {
+			t.Error("symlink inside the grace period should have been kept")
+		}
+	})
+
 	t.Run("preserves valid symlinks", func(t *testing.T) {
 		_, fakeHome, cleanup := setupSeanceTestEnv(t)
 		defer cleanup()
@@ -392,7 +593,7 @@ func TestCleanupOrphanedSessionSymlinks(t *testing.T) {
 		}
 
 		// Run cleanup
-		cleanupOrphanedSessionSymlinks()
+		cleanupOrphanedSessionSymlinks(0)
 
 		// Verify valid symlink was preserved
 		if _, err := os.Lstat(validSymlink); err != nil {
@@ -491,6 +692,25 @@ func TestResolveSessionPrefix(t *testing.T) {
 		}
 	})
 
+	t.Run("resolves sessions in rolled events files", func(t *testing.T) {
+		townRoot := t.TempDir()
+		oldID := "0ld5e551-3caa-4bbb-8ccc-123456789abc"
+		writeTestEvents(t, townRoot, []string{oldID})
+		eventsPath := filepath.Join(townRoot, events.EventsFile)
+		if err := os.Rename(eventsPath, eventsPath+".1"); err != nil {
+			t.Fatal(err)
+		}
+		writeTestEvents(t, townRoot, []string{"46621448-3caa-4bbb-8ccc-123456789abc"})
+
+		resolved, err := resolveSessionPrefix(townRoot, "0ld5e551")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resolved != oldID {
+			t.Errorf("expected %s, got %s", oldID, resolved)
+		}
+	})
+
 	t.Run("deduplicates repeated session IDs", func(t *testing.T) {
 		townRoot := t.TempDir()
 		fullID := "46621448-3caa-4bbb-8ccc-123456789abc"
@@ -506,3 +726,64 @@ func TestResolveSessionPrefix(t *testing.T) {
 		}
 	})
 }
+
+func TestDescribeSessionLocation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink tests require elevated privileges on Windows")
+	}
+
+	townRoot, fakeHome, cleanup := setupSeanceTestEnv(t)
+	defer cleanup()
+
+	account1Dir := filepath.Join(fakeHome, "claude-config-account1")
+	account2Dir := filepath.Join(fakeHome, "claude-config-account2")
+	createTestSession(t, account1Dir, "home-project", "session-local")
+	createTestSession(t, account2Dir, "other-project", "session-remote")
+
+	local, err := describeSessionLocation(townRoot, "session-local")
+	if err != nil {
+		t.Fatalf("describeSessionLocation(local): %v", err)
+	}
+	if local.Account != "account1" || !local.Accessible {
+		t.Errorf("local session: account=%q accessible=%v, want account1/true", local.Account, local.Accessible)
+	}
+	if want := filepath.Join(account1Dir, "projects", "home-project"); local.ProjectDir != want {
+		t.Errorf("local project dir = %q, want %q", local.ProjectDir, want)
+	}
+
+	remote, err := describeSessionLocation(townRoot, "session-remote")
+	if err != nil {
+		t.Fatalf("describeSessionLocation(remote): %v", err)
+	}
+	if remote.Account != "account2" || remote.Accessible {
+		t.Errorf("remote session: account=%q accessible=%v, want account2/false", remote.Account, remote.Accessible)
+	}
+
+	// Once symlinked into the current account, the remote session is accessible.
+	if _, err := symlinkSessionToCurrentAccount(townRoot, "session-remote", ""); err != nil {
+		t.Fatalf("symlinkSessionToCurrentAccount: %v", err)
+	}
+	remote, err = describeSessionLocation(townRoot, "session-remote")
+	if err != nil {
+		t.Fatalf("describeSessionLocation(remote after symlink): %v", err)
+	}
+	if !remote.Accessible {
+		t.Error("symlinked session should be accessible from the current account")
+	}
+
+	if _, err := describeSessionLocation(townRoot, "session-missing"); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
+
+func TestCleanupOnExit(t *testing.T) {
+	calls := 0
+	stop := cleanupOnExit(func() { calls++ })
+	stop()
+	if calls != 1 {
+		t.Errorf("cleanup ran %d times after stop, want 1", calls)
+	}
+
+	// A nil cleanup (nothing was symlinked) yields a no-op stop.
+	cleanupOnExit(nil)()
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var seanceWhichJSON bool
+
+var seanceWhichCmd = &cobra.Command{
+	Use:   "which <session-prefix>",
+	Short: "Show which account and project a session lives in",
+	Long: `Resolve a session ID prefix and report where the session lives.
+
+Prints the full session ID, the owning account (from mayor/accounts.json),
+the project directory holding the transcript, and whether the current account
+can already see it. Read-only: nothing is symlinked or moved.
+
+Examples:
+  gt seance which 3f2a
+  gt seance which 3f2a --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSeanceWhich,
+}
+
+func init() {
+	seanceWhichCmd.Flags().BoolVar(&seanceWhichJSON, "json", false, "Output as JSON")
+	seanceCmd.AddCommand(seanceWhichCmd)
+}
+
+// seanceWhichInfo describes where a session's transcript lives.
+type seanceWhichInfo struct {
+	SessionID  string `json:"session_id"`
+	Account    string `json:"account,omitempty"` // accounts.json handle; empty if unregistered
+	ConfigDir  string `json:"config_dir"`
+	ProjectDir string `json:"project_dir"`
+	Accessible bool   `json:"accessible"` // visible from the current account without symlinking
+}
+
+func runSeanceWhich(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return fmt.Errorf("not in a Gas Town workspace")
+	}
+
+	sessionID, err := resolveSessionPrefix(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+	info, err := describeSessionLocation(townRoot, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if seanceWhichJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	account := info.Account
+	if account == "" {
+		account = style.Dim.Render("(not in accounts.json)")
+	}
+	accessible := "yes"
+	if !info.Accessible {
+		accessible = "no (gt seance --talk will symlink it)"
+	}
+	fmt.Printf("%s %s\n", style.Bold.Render("Session:"), info.SessionID)
+	fmt.Printf("%s %s %s\n", style.Bold.Render("Account:"), account, style.Dim.Render(info.ConfigDir))
+	fmt.Printf("%s %s\n", style.Bold.Render("Project:"), info.ProjectDir)
+	fmt.Printf("%s %s\n", style.Bold.Render("Accessible from current account:"), accessible)
+	return nil
+}
+
+// describeSessionLocation finds sessionID via findSessionLocation and works
+// out which account owns it and whether the current account (~/.claude)
+// already has the transcript in any of its project dirs.
+func describeSessionLocation(townRoot, sessionID string) (*seanceWhichInfo, error) {
+	loc := findSessionLocation(townRoot, sessionID)
+	if loc == nil {
+		return nil, fmt.Errorf("session %s not found in any account", sessionID)
+	}
+
+	info := &seanceWhichInfo{
+		SessionID:  sessionID,
+		ConfigDir:  loc.configDir,
+		ProjectDir: filepath.Join(loc.configDir, "projects", loc.projectDir),
+	}
+
+	ownerDir := resolveConfigDir(loc.configDir)
+	if cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot)); err == nil {
+		for handle, acct := range cfg.Accounts {
+			if acct.ConfigDir != "" && resolveConfigDir(util.ExpandHome(acct.ConfigDir)) == ownerDir {
+				info.Account = handle
+				break
+			}
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return info, nil
+	}
+	currentDir := resolveConfigDir(filepath.Join(home, ".claude"))
+	if currentDir == ownerDir {
+		info.Accessible = true
+	} else if matches, _ := filepath.Glob(filepath.Join(currentDir, "projects", "*", sessionID+".jsonl")); len(matches) > 0 {
+		info.Accessible = true
+	}
+	return info, nil
+}
+
+// resolveConfigDir resolves symlinks in a config dir so that ~/.claude and
+// the account dir it points at compare equal.
+func resolveConfigDir(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return dir
+}
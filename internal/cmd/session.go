@@ -38,7 +38,7 @@ var (
 
 var sessionCmd = &cobra.Command{
 	Use:     "session",
-	Aliases: []string{"sess"},
+	Aliases: []string{"sess", "sessions"},
 	GroupID: GroupAgents,
 	Short:   "Manage polecat sessions",
 	RunE:    requireSubcommand,
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	sessionGCDryRun bool
+	sessionGCYes    bool
+	sessionGCMinAge time.Duration
+)
+
+var sessionGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove orphaned Claude session transcripts",
+	Long: `Garbage-collect Claude session transcript files across all accounts.
+
+For every account config dir (from mayor/accounts.json, plus ~/.claude) and
+every project under it, a <session>.jsonl file is an orphan when:
+  - no sessions-index.json entry in that project references it, and
+  - no session_start event for it exists in the town events file.
+
+Orphans older than --min-age are removed, along with sessions-index.json
+entries whose transcript no longer resolves (deleted, or a symlink whose
+target was deleted), as 'gt session prune-index' does. Symlinked transcripts
+themselves are left alone (seance cleans up its own symlinks).
+
+Examples:
+  gt session gc --dry-run        # Show what would be removed
+  gt session gc                  # Remove after confirmation
+  gt session gc --yes            # Remove without prompting
+  gt session gc --min-age 72h    # Only consider files untouched for 3 days`,
+	RunE: runSessionGC,
+}
+
+func init() {
+	sessionGCCmd.Flags().BoolVar(&sessionGCDryRun, "dry-run", false, "Show what would be removed without removing anything")
+	sessionGCCmd.Flags().BoolVarP(&sessionGCYes, "yes", "y", false, "Remove without confirmation")
+	sessionGCCmd.Flags().DurationVar(&sessionGCMinAge, "min-age", 24*time.Hour, "Skip transcripts modified more recently than this")
+
+	sessionCmd.AddCommand(sessionGCCmd)
+}
+
+// sessionGCProject is the garbage found in one account's project directory.
+type sessionGCProject struct {
+	dir          string   // <configDir>/projects/<project>
+	orphans      []string // session IDs whose .jsonl is unreferenced
+	staleEntries []string // session IDs indexed whose .jsonl does not resolve
+}
+
+func runSessionGC(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessions, err := discoverSessions(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading session events: %w", err)
+	}
+	knownIDs := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		if id := getPayloadString(s.Payload, "session_id"); id != "" {
+			knownIDs[id] = true
+		}
+	}
+
+	plan := planSessionGC(sessionGCConfigDirs(townRoot), knownIDs, sessionGCMinAge, time.Now())
+	if len(plan) == 0 {
+		fmt.Println("No orphaned session files found")
+		return nil
+	}
+
+	var orphanCount, staleCount int
+	for _, p := range plan {
+		fmt.Printf("%s\n", style.Bold.Render(p.dir))
+		for _, id := range p.orphans {
+			fmt.Printf("  %s %s.jsonl\n", style.Dim.Render("rm"), id)
+		}
+		for _, id := range p.staleEntries {
+			fmt.Printf("  %s index entry %s\n", style.Dim.Render("rm"), id)
+		}
+		orphanCount += len(p.orphans)
+		staleCount += len(p.staleEntries)
+	}
+	fmt.Printf("\n%d orphaned file(s), %d stale index entr(ies)\n", orphanCount, staleCount)
+
+	if sessionGCDryRun {
+		fmt.Println(style.Dim.Render("Dry run: nothing removed"))
+		return nil
+	}
+	if !sessionGCYes && !promptYesNo("Remove these?") {
+		fmt.Println("Aborted")
+		return nil
+	}
+
+	var removedFiles, removedEntries int
+	for _, p := range plan {
+		files, entries, err := applySessionGC(p)
+		removedFiles += files
+		removedEntries += entries
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), p.dir, err)
+		}
+	}
+	fmt.Printf("%s Removed %d file(s) and %d index entr(ies)\n", style.Bold.Render("✓"), removedFiles, removedEntries)
+	return nil
+}
+
+// sessionGCConfigDirs returns every account config dir plus the current
+// ~/.claude, resolved and deduplicated.
+func sessionGCConfigDirs(townRoot string) []string {
+	var candidates []string
+	if cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot)); err == nil {
+		for _, acct := range cfg.Accounts {
+			if acct.ConfigDir != "" {
+				candidates = append(candidates, util.ExpandHome(acct.ConfigDir))
+			}
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".claude"))
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, dir := range candidates {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// planSessionGC scans each config dir's projects for orphaned transcripts and
+// stale index entries. knownIDs holds session IDs with a session_start event.
+// Transcripts modified within minAge of now are never orphans, so a session
+// that has not been indexed yet is not collected out from under its writer.
+func planSessionGC(configDirs []string, knownIDs map[string]bool, minAge time.Duration, now time.Time) []sessionGCProject {
+	var plan []sessionGCProject
+	for _, configDir := range configDirs {
+		projectsDir := filepath.Join(configDir, "projects")
+		projects, err := os.ReadDir(projectsDir)
+		if err != nil {
+			continue
+		}
+		for _, proj := range projects {
+			if !proj.IsDir() {
+				continue
+			}
+			p := planSessionGCProject(filepath.Join(projectsDir, proj.Name()), knownIDs, minAge, now)
+			if len(p.orphans) > 0 || len(p.staleEntries) > 0 {
+				plan = append(plan, p)
+			}
+		}
+	}
+	return plan
+}
+
+func planSessionGCProject(dir string, knownIDs map[string]bool, minAge time.Duration, now time.Time) sessionGCProject {
+	p := sessionGCProject{dir: dir}
+
+	indexed := make(map[string]bool)
+	if data, err := os.ReadFile(filepath.Join(dir, "sessions-index.json")); err == nil {
+		var index sessionsIndex
+		if json.Unmarshal(data, &index) == nil {
+			for _, rawEntry := range index.Entries {
+				var e sessionsIndexEntry
+				if json.Unmarshal(rawEntry, &e) == nil && e.SessionID != "" {
+					indexed[e.SessionID] = true
+				}
+			}
+			p.staleEntries = danglingSessionIndexEntries(dir, &index)
+		}
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return p
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".jsonl") {
+			continue
+		}
+		id := strings.TrimSuffix(f.Name(), ".jsonl")
+		if indexed[id] || knownIDs[id] {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(dir, f.Name()))
+		if err != nil || !info.Mode().IsRegular() {
+			continue // symlinks belong to cleanupOrphanedSessionSymlinks
+		}
+		if now.Sub(info.ModTime()) < minAge {
+			continue
+		}
+		p.orphans = append(p.orphans, id)
+	}
+	sort.Strings(p.orphans)
+	return p
+}
+
+// applySessionGC removes a project's orphaned transcripts and rewrites its
+// sessions-index.json without the stale entries.
+func applySessionGC(p sessionGCProject) (removedFiles, removedEntries int, err error) {
+	for _, id := range p.orphans {
+		if rmErr := os.Remove(filepath.Join(p.dir, id+".jsonl")); rmErr == nil || os.IsNotExist(rmErr) {
+			removedFiles++
+		}
+	}
+	if len(p.staleEntries) == 0 {
+		return removedFiles, 0, nil
+	}
+
+	// Re-check each entry against the filesystem: the index may have changed
+	// since planning, and a transcript may have reappeared.
+	stale := make(map[string]bool, len(p.staleEntries))
+	for _, id := range p.staleEntries {
+		if sessionTranscriptMissing(p.dir, id) {
+			stale[id] = true
+		}
+	}
+	err = updateSessionsIndex(filepath.Join(p.dir, "sessions-index.json"), func(index *sessionsIndex) (bool, error) {
+		removedEntries = removeSessionsIndexEntries(index, stale)
+		return removedEntries > 0, nil
+	})
+	if err != nil {
+		return removedFiles, 0, err
+	}
+	return removedFiles, removedEntries, nil
+}
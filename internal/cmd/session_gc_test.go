@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeSessionGCFixture(t *testing.T, projDir string, indexed []string, files map[string]time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(projDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var index sessionsIndex
+	index.Version = 1
+	for _, id := range indexed {
+		raw, _ := json.Marshal(map[string]string{"sessionId": id, "summary": "s-" + id})
+		index.Entries = append(index.Entries, raw)
+	}
+	data, _ := json.Marshal(index)
+	if err := os.WriteFile(filepath.Join(projDir, "sessions-index.json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	for id, mtime := range files {
+		path := filepath.Join(projDir, id+".jsonl")
+		if err := os.WriteFile(path, []byte("{}\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPlanSessionGC(t *testing.T) {
+	configDir := t.TempDir()
+	projDir := filepath.Join(configDir, "projects", "-home-gt-rig")
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	writeSessionGCFixture(t, projDir, []string{"indexed", "gone"}, map[string]time.Time{
+		"indexed":    old,
+		"from-event": old,
+		"orphan":     old,
+		"fresh":      now,
+	})
+	// Symlinks are left to cleanupOrphanedSessionSymlinks.
+	if err := os.Symlink(filepath.Join(configDir, "nowhere.jsonl"), filepath.Join(projDir, "linked.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := planSessionGC([]string{configDir}, map[string]bool{"from-event": true}, 24*time.Hour, now)
+	if len(plan) != 1 {
+		t.Fatalf("plan = %+v, want one project", plan)
+	}
+	if !reflect.DeepEqual(plan[0].orphans, []string{"orphan"}) {
+		t.Errorf("orphans = %v, want [orphan]", plan[0].orphans)
+	}
+	if !reflect.DeepEqual(plan[0].staleEntries, []string{"gone"}) {
+		t.Errorf("staleEntries = %v, want [gone]", plan[0].staleEntries)
+	}
+}
+
+func TestApplySessionGC(t *testing.T) {
+	configDir := t.TempDir()
+	projDir := filepath.Join(configDir, "projects", "-home-gt-rig")
+	old := time.Now().Add(-48 * time.Hour)
+	writeSessionGCFixture(t, projDir, []string{"indexed", "gone"}, map[string]time.Time{
+		"indexed": old,
+		"orphan":  old,
+	})
+
+	plan := planSessionGC([]string{configDir}, nil, 24*time.Hour, time.Now())
+	if len(plan) != 1 {
+		t.Fatalf("plan = %+v, want one project", plan)
+	}
+	files, entries, err := applySessionGC(plan[0])
+	if err != nil {
+		t.Fatalf("applySessionGC: %v", err)
+	}
+	if files != 1 || entries != 1 {
+		t.Errorf("removed files=%d entries=%d, want 1 and 1", files, entries)
+	}
+	if _, err := os.Stat(filepath.Join(projDir, "orphan.jsonl")); !os.IsNotExist(err) {
+		t.Error("orphan.jsonl should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(projDir, "indexed.jsonl")); err != nil {
+		t.Errorf("indexed.jsonl should be kept: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projDir, "sessions-index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index sessionsIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Entries) != 1 {
+		t.Fatalf("index entries = %d, want 1", len(index.Entries))
+	}
+	var kept map[string]string
+	_ = json.Unmarshal(index.Entries[0], &kept)
+	if kept["sessionId"] != "indexed" || kept["summary"] != "s-indexed" {
+		t.Errorf("kept entry = %v, want indexed entry with fields preserved", kept)
+	}
+
+	if again := planSessionGC([]string{configDir}, nil, 24*time.Hour, time.Now()); len(again) != 0 {
+		t.Errorf("second plan = %+v, want nothing left", again)
+	}
+}
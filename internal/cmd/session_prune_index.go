@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/atomicfile"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var sessionPruneIndexDryRun bool
+
+var sessionPruneIndexCmd = &cobra.Command{
+	Use:   "prune-index",
+	Short: "Drop sessions-index.json entries whose transcript is gone",
+	Long: `Remove sessions-index.json entries that point at deleted transcripts.
+
+For every account config dir (from mayor/accounts.json, plus ~/.claude) and
+every project under it, an index entry is dropped when <session>.jsonl in
+that project no longer resolves: the file was deleted, or it is a symlink
+whose target was deleted. Such entries show up as ghosts in 'gt seance' and
+break session resolution.
+
+Only the index is changed; transcripts and symlinks are left alone. Each
+index is copied to sessions-index.json.bak before it is rewritten.
+
+Examples:
+  gt session prune-index --dry-run   # Show entries that would be dropped
+  gt session prune-index             # Drop them`,
+	RunE: runSessionPruneIndex,
+}
+
+func init() {
+	sessionPruneIndexCmd.Flags().BoolVar(&sessionPruneIndexDryRun, "dry-run", false, "Show what would be removed without changing any index")
+
+	sessionCmd.AddCommand(sessionPruneIndexCmd)
+}
+
+// sessionIndexPrune is the dangling entries found in one project's index.
+type sessionIndexPrune struct {
+	dir     string   // <configDir>/projects/<project>
+	missing []string // indexed session IDs whose transcript does not resolve
+}
+
+func runSessionPruneIndex(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	plan := planSessionIndexPrune(sessionGCConfigDirs(townRoot))
+	if len(plan) == 0 {
+		fmt.Println("No dangling sessions-index.json entries found")
+		return nil
+	}
+
+	var total int
+	for _, p := range plan {
+		fmt.Printf("%s\n", style.Bold.Render(filepath.Join(p.dir, "sessions-index.json")))
+		for _, id := range p.missing {
+			fmt.Printf("  %s %s\n", style.Dim.Render("rm"), id)
+		}
+		total += len(p.missing)
+	}
+
+	if sessionPruneIndexDryRun {
+		fmt.Printf("\n%d dangling entr(ies) in %d index(es)\n", total, len(plan))
+		fmt.Println(style.Dim.Render("Dry run: nothing removed"))
+		return nil
+	}
+
+	var removed int
+	for _, p := range plan {
+		n, err := pruneSessionIndex(p)
+		removed += n
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), p.dir, err)
+		}
+	}
+	fmt.Printf("\n%s Removed %d index entr(ies); previous indexes saved as sessions-index.json.bak\n", style.Bold.Render("✓"), removed)
+	return nil
+}
+
+// planSessionIndexPrune finds, for each project under each config dir, the
+// index entries whose transcript no longer resolves.
+func planSessionIndexPrune(configDirs []string) []sessionIndexPrune {
+	var plan []sessionIndexPrune
+	for _, configDir := range configDirs {
+		projectsDir := filepath.Join(configDir, "projects")
+		projects, err := os.ReadDir(projectsDir)
+		if err != nil {
+			continue
+		}
+		for _, proj := range projects {
+			if !proj.IsDir() {
+				continue
+			}
+			dir := filepath.Join(projectsDir, proj.Name())
+			data, err := os.ReadFile(filepath.Join(dir, "sessions-index.json"))
+			if err != nil {
+				continue
+			}
+			var index sessionsIndex
+			if json.Unmarshal(data, &index) != nil {
+				continue
+			}
+			if missing := danglingSessionIndexEntries(dir, &index); len(missing) > 0 {
+				plan = append(plan, sessionIndexPrune{dir: dir, missing: missing})
+			}
+		}
+	}
+	return plan
+}
+
+// danglingSessionIndexEntries returns the sorted IDs of entries in index
+// whose transcript in dir is missing. Shared by 'gt session gc' and
+// 'gt session prune-index' so both agree on which entries are stale.
+func danglingSessionIndexEntries(dir string, index *sessionsIndex) []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, rawEntry := range index.Entries {
+		var e sessionsIndexEntry
+		if json.Unmarshal(rawEntry, &e) != nil || e.SessionID == "" || seen[e.SessionID] {
+			continue
+		}
+		seen[e.SessionID] = true
+		if sessionTranscriptMissing(dir, e.SessionID) {
+			missing = append(missing, e.SessionID)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// sessionTranscriptMissing reports whether <id>.jsonl in dir does not
+// resolve. os.Stat follows symlinks, so a symlinked transcript counts only
+// while its target exists.
+func sessionTranscriptMissing(dir, id string) bool {
+	_, err := os.Stat(filepath.Join(dir, id+".jsonl"))
+	return os.IsNotExist(err)
+}
+
+// pruneSessionIndex rewrites a project's sessions-index.json without its
+// dangling entries. Entries are re-checked under the index lock, since a
+// transcript may have reappeared since planning. The previous index is saved
+// as sessions-index.json.bak.
+func pruneSessionIndex(p sessionIndexPrune) (int, error) {
+	indexPath := filepath.Join(p.dir, "sessions-index.json")
+	removed := 0
+	err := updateSessionsIndex(indexPath, func(index *sessionsIndex) (bool, error) {
+		dangling := make(map[string]bool)
+		for _, id := range danglingSessionIndexEntries(p.dir, index) {
+			dangling[id] = true
+		}
+		if len(dangling) == 0 {
+			return false, nil
+		}
+		// The index lock is held, so the file on disk is the index being pruned.
+		data, err := os.ReadFile(indexPath)
+		if err != nil {
+			return false, fmt.Errorf("reading sessions index: %w", err)
+		}
+		if err := atomicfile.WriteFile(indexPath+".bak", data, 0600); err != nil {
+			return false, fmt.Errorf("backing up sessions index: %w", err)
+		}
+		removed = removeSessionsIndexEntries(index, dangling)
+		return true, nil
+	})
+	return removed, err
+}
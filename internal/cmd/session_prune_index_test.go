@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPruneSessionIndex(t *testing.T) {
+	configDir := t.TempDir()
+	projDir := filepath.Join(configDir, "projects", "-home-gt-rig")
+	old := time.Now().Add(-48 * time.Hour)
+
+	writeSessionGCFixture(t, projDir, []string{"live", "deleted", "linked-live", "linked-dead"}, map[string]time.Time{
+		"live": old,
+	})
+	// A symlinked transcript is live only while its target exists.
+	target := filepath.Join(configDir, "other.jsonl")
+	if err := os.WriteFile(target, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, filepath.Join(projDir, "linked-live.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(configDir, "nowhere.jsonl"), filepath.Join(projDir, "linked-dead.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+	// Projects without an index are ignored.
+	if err := os.MkdirAll(filepath.Join(configDir, "projects", "-no-index"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := planSessionIndexPrune([]string{configDir})
+	if len(plan) != 1 || plan[0].dir != projDir {
+		t.Fatalf("plan = %+v, want one project", plan)
+	}
+	if want := []string{"deleted", "linked-dead"}; !reflect.DeepEqual(plan[0].missing, want) {
+		t.Errorf("missing = %v, want %v", plan[0].missing, want)
+	}
+
+	indexPath := filepath.Join(projDir, "sessions-index.json")
+	before, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := pruneSessionIndex(plan[0])
+	if err != nil {
+		t.Fatalf("pruneSessionIndex: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index sessionsIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, raw := range index.Entries {
+		var e sessionsIndexEntry
+		_ = json.Unmarshal(raw, &e)
+		ids = append(ids, e.SessionID)
+	}
+	if want := []string{"live", "linked-live"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("remaining entries = %v, want %v", ids, want)
+	}
+
+	backup, err := os.ReadFile(indexPath + ".bak")
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(backup) != string(before) {
+		t.Error("backup should hold the index as it was before pruning")
+	}
+	if _, err := os.Lstat(filepath.Join(projDir, "linked-dead.jsonl")); err != nil {
+		t.Error("prune-index should leave dangling symlinks alone")
+	}
+
+	if plan := planSessionIndexPrune([]string{configDir}); len(plan) != 0 {
+		t.Errorf("plan after prune = %+v, want empty", plan)
+	}
+}
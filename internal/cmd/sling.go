@@ -137,6 +137,7 @@ var (
 	slingFormula       string // --formula: override formula for dispatch (default: mol-polecat-work)
 	slingCrew          string // --crew: target a crew member in the specified rig
 	slingReviewOnly    bool   // --review-only: mark work as review-only (no merge/commit/push)
+	slingPriority      int    // --priority: scheduler dispatch priority (-1 = use the bead's own priority)
 )
 
 func init() {
@@ -167,6 +168,7 @@ func init() {
 	slingCmd.Flags().StringVar(&slingFormula, "formula", "", "Formula to apply (default: mol-polecat-work for polecat targets)")
 	slingCmd.Flags().StringVar(&slingCrew, "crew", "", "Target a crew member in the specified rig (e.g., --crew mel with target gastown → gastown/crew/mel)")
 	slingCmd.Flags().BoolVar(&slingReviewOnly, "review-only", false, "Mark work as review-only: assignee evaluates and reports back, must NOT merge/commit/push")
+	slingCmd.Flags().IntVar(&slingPriority, "priority", -1, "Scheduler dispatch priority when dispatch is deferred (0=highest, 4=lowest; default: the bead's own priority)")
 
 	slingCmd.AddCommand(slingRespawnResetCmd)
 	rootCmd.AddCommand(slingCmd)
@@ -333,6 +335,9 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 	if deferErr != nil {
 		return deferErr
 	}
+	if err := validateSlingPriority(slingPriority, deferred); err != nil {
+		return err
+	}
 
 	// Batch mode detection: multiple beads with optional rig target
 	// Pattern A (explicit rig):  gt sling gt-abc gt-def gt-ghi gastown
@@ -396,6 +401,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				Agent:        slingAgent,
 				HookRawBead:  slingHookRawBead,
 				Ralph:        slingRalph,
+				Priority:     slingPriorityOverride(),
 			})
 		}
 	}
@@ -437,6 +443,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 			Agent:        slingAgent,
 			HookRawBead:  slingHookRawBead,
 			Ralph:        slingRalph,
+			Priority:     slingPriorityOverride(),
 		})
 	}
 
@@ -478,6 +485,7 @@ func runSling(cmd *cobra.Command, args []string) (retErr error) {
 				Agent:        slingAgent,
 				HookRawBead:  slingHookRawBead,
 				Ralph:        slingRalph,
+				Priority:     slingPriorityOverride(),
 			})
 		}
 		// Dog targets (deacon/dogs, deacon/dogs/<name>, dog:, dog:<name>) fall through
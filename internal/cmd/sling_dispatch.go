@@ -45,6 +45,18 @@ type SlingParams struct {
 	CallerContext    string // Identifies the caller for shutdown messages (e.g., "queue-dispatch", "batch-sling")
 	TownRoot         string
 	BeadsDir         string
+
+	// OnStep, if set, is called as each dispatch sub-step begins and with the
+	// outcome of steps that produce artifacts (polecat, convoy, session).
+	// Used by `gt scheduler run --verbose` to stream progress.
+	OnStep func(step, detail string)
+}
+
+// reportStep forwards a sub-step notification to OnStep, if set.
+func (p SlingParams) reportStep(step, detail string) {
+	if p.OnStep != nil {
+		p.OnStep(step, detail)
+	}
 }
 
 // SlingResult captures the outcome of executeSling for caller-level tracking.
@@ -126,6 +138,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	}
 
 	// 1. Get bead info + status check
+	params.reportStep("bead-check", params.BeadID)
 	info, err := getBeadInfoFromTownRoot(townRoot, params.BeadID)
 	if err != nil {
 		result.ErrMsg = err.Error()
@@ -215,6 +228,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 				(info.Assignee == "" && (info.Status == "open" || info.Status == "in_progress")) ||
 				(info.Assignee != "" && isHookedAgentDeadFn(info.Assignee))
 			if stale {
+				params.reportStep("burn-molecules", strings.Join(existingMolecules, ", "))
 				fmt.Printf("  %s Burning %d stale molecule(s): %s\n",
 					style.Warning.Render("⚠"), len(existingMolecules), strings.Join(existingMolecules, ", "))
 				if err := burnExistingMolecules(existingMolecules, params.BeadID, townRoot); err != nil {
@@ -243,6 +257,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		// the --create flag for non-rig targets via resolveTarget.
 		Create: true,
 	}
+	params.reportStep("spawn-polecat", params.RigName)
 	spawnInfo, err := spawnPolecatForSling(params.RigName, spawnOpts)
 	if err != nil {
 		result.ErrMsg = err.Error()
@@ -250,6 +265,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	}
 	result.SpawnInfo = spawnInfo
 	result.PolecatName = spawnInfo.PolecatName
+	params.reportStep("polecat-spawned", fmt.Sprintf("%s (branch %s)", spawnInfo.PolecatName, spawnInfo.Branch))
 
 	targetAgent := spawnInfo.AgentID()
 	hookWorkDir := spawnInfo.ClonePath
@@ -259,12 +275,14 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	if !params.NoConvoy {
 		existingConvoy := isTrackedByConvoy(params.BeadID)
 		if existingConvoy == "" {
+			params.reportStep("create-convoy", params.BeadID)
 			var err error
 			convoyID, err = createAutoConvoy(params.BeadID, info.Title, params.Owned, params.Merge, params.BaseBranch)
 			if err != nil {
 				fmt.Printf("  %s Could not create auto-convoy: %v\n", style.Dim.Render("Warning:"), err)
 			} else {
 				fmt.Printf("  %s Created convoy %s\n", style.Bold.Render("→"), convoyID)
+				params.reportStep("convoy-created", convoyID)
 			}
 		} else {
 			fmt.Printf("  %s Already tracked by convoy %s\n", style.Dim.Render("○"), existingConvoy)
@@ -275,6 +293,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	formulaCooked := params.SkipCook
 	if params.FormulaName != "" && !formulaCooked {
 		workDir := beads.ResolveHookDir(townRoot, params.BeadID, hookWorkDir)
+		params.reportStep("cook-formula", params.FormulaName)
 		if err := CookFormula(params.FormulaName, workDir, townRoot); err != nil {
 			if params.FormulaFailFatal {
 				// Rollback spawned polecat on fatal cook failure
@@ -313,6 +332,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		}
 		varsForAttachment = append([]string(nil), allVars...)
 		formulaVarsForAttachment = strings.Join(allVars, "\n")
+		params.reportStep("instantiate-formula", params.FormulaName)
 		formulaResult, err := InstantiateFormulaOnBead(context.Background(), params.FormulaName, params.BeadID, info.Title, hookWorkDir, townRoot, true, allVars)
 		if err != nil {
 			if params.FormulaFailFatal {
@@ -338,6 +358,7 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	result.AttachedMolecule = attachedMoleculeID
 
 	// 7. Hook bead with retry
+	params.reportStep("hook-bead", targetAgent)
 	// Acquire per-assignee lock to serialize concurrent hook writes (issue #3114).
 	assigneeUnlock, assigneeLockErr := tryAcquireSlingAssigneeLock(townRoot, targetAgent)
 	if assigneeLockErr != nil {
@@ -386,15 +407,15 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 	}
 
 	// 11. Start polecat session
-	pane, err := spawnInfo.StartSession()
-	if err != nil {
+	params.reportStep("start-session", spawnInfo.SessionName)
+	if _, err := spawnInfo.StartSession(); err != nil {
 		fmt.Printf("  %s Could not start session: %v, cleaning up partial state...\n", style.Dim.Render("✗"), err)
 		rollbackSlingArtifactsFn(spawnInfo, beadToHook, hookWorkDir, convoyID)
 		result.ErrMsg = fmt.Sprintf("session failed: %v", err)
 		return result, fmt.Errorf("starting polecat session: %w", err)
 	}
 	fmt.Printf("  %s Session started for %s\n", style.Bold.Render("▶"), spawnInfo.PolecatName)
-	_ = pane
+	params.reportStep("session-started", spawnInfo.Pane)
 
 	result.Success = true
 	return result, nil
@@ -62,6 +62,32 @@ type ScheduleOptions struct {
 	Agent        string   // Agent override (e.g., "gemini", "codex")
 	HookRawBead  bool     // Hook raw bead without default formula
 	Ralph        bool     // Ralph Wiggum loop mode
+	Priority     *int     // Dispatch priority override (nil = use the work bead's priority)
+}
+
+// slingPriorityOverride returns the --priority flag as a schedule override,
+// or nil when the flag was not set.
+func slingPriorityOverride() *int {
+	if slingPriority < 0 {
+		return nil
+	}
+	p := slingPriority
+	return &p
+}
+
+// validateSlingPriority rejects a --priority outside the bead priority range
+// or on a sling that dispatches immediately, where it would have no effect.
+func validateSlingPriority(priority int, deferred bool) error {
+	if priority < 0 {
+		return nil
+	}
+	if priority > 4 {
+		return fmt.Errorf("invalid --priority %d: must be 0-4 (0=highest)", priority)
+	}
+	if !deferred {
+		return fmt.Errorf("--priority only applies to scheduled dispatch (scheduler.max_polecats > 0)")
+	}
+	return nil
 }
 
 // scheduleBead schedules a bead for deferred dispatch via the capacity scheduler.
@@ -106,9 +132,30 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 	if findErr != nil {
 		return fmt.Errorf("checking for existing sling context: %w", findErr)
 	}
+	var existing []slingContextRecord
 	if existingCtx != nil {
-		fmt.Printf("%s Bead %s is already scheduled (context: %s), no-op\n",
-			style.Dim.Render("○"), beadID, existingCtx.ID)
+		existing = append(existing, slingContextRecord{issue: existingCtx, workDir: townRoot, beadsDir: rigBeadsDir})
+	} else {
+		// The bead may be scheduled to another rig, whose context lives in
+		// that rig's beads dir.
+		all, listErr := listAllSlingContextRecordsWithError(townRoot)
+		if listErr != nil {
+			return fmt.Errorf("checking for existing sling context: %w", listErr)
+		}
+		existing = all
+	}
+	rec, existingFields := findScheduledContext(existing, beadID)
+	if existingCtx != nil && rec == nil {
+		// Unparseable context: dispatch will close it, but don't stack another.
+		rec = &existing[0]
+	}
+	replaced, err := checkAlreadyScheduled(beadID, rigName, rec, existingFields, opts.Force)
+	if err != nil {
+		return err
+	}
+	if rec != nil && replaced == nil {
+		fmt.Printf("%s Bead %s is already scheduled to %s (context: %s), no-op\n",
+			style.Dim.Render("○"), beadID, rigName, rec.issue.ID)
 		return nil
 	}
 
@@ -134,6 +181,9 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 
 	if opts.DryRun {
 		fmt.Printf("Would schedule %s → %s\n", beadID, rigName)
+		if replaced != nil {
+			fmt.Printf("  Would close sling context %s (scheduled to %s)\n", replaced.issue.ID, existingFields.TargetRig)
+		}
 		fmt.Printf("  Would create sling context bead\n")
 		if !opts.NoConvoy {
 			fmt.Printf("  Would create auto-convoy\n")
@@ -151,7 +201,7 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 
 	// Build sling context fields
 	fields := &capacity.SlingContextFields{
-		Version:    1,
+		Version:    capacity.SlingContextVersion,
 		WorkBeadID: beadID,
 		TargetRig:  rigName,
 		EnqueuedAt: time.Now().UTC().Format(time.RFC3339),
@@ -187,6 +237,7 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 		fields.Mode = "ralph"
 	}
 	fields.Owned = opts.Owned
+	fields.Priority = opts.Priority
 
 	// Create sling context bead in the target rig's beads dir so the rig's
 	// witness discovers it during patrol. (GH#3468)
@@ -194,6 +245,15 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 	if err != nil {
 		return fmt.Errorf("creating sling context: %w", err)
 	}
+	if replaced != nil {
+		// Close the old context only once the new one exists, so a failure
+		// never leaves the bead unscheduled.
+		if err := beadsForContextRecord(*replaced).CloseSlingContext(replaced.issue.ID, "rescheduled"); err != nil {
+			fmt.Printf("%s Could not close previous context %s: %v\n", style.Dim.Render("Warning:"), replaced.issue.ID, err)
+		} else {
+			fmt.Printf("%s Closed previous context %s (was scheduled to %s)\n", style.Dim.Render("○"), replaced.issue.ID, existingFields.TargetRig)
+		}
+	}
 
 	// Auto-convoy (unless --no-convoy)
 	if !opts.NoConvoy {
@@ -222,6 +282,21 @@ func scheduleBead(beadID, rigName string, opts ScheduleOptions) error {
 	return nil
 }
 
+// checkAlreadyScheduled decides what scheduling beadID to rigName does given
+// its existing open sling context rec (nil when unscheduled). Re-scheduling
+// to the same rig is a no-op (nil, nil); a context for another rig is an
+// error unless force is set, in which case rec is returned to be replaced.
+func checkAlreadyScheduled(beadID, rigName string, rec *slingContextRecord, fields *capacity.SlingContextFields, force bool) (*slingContextRecord, error) {
+	if rec == nil || fields == nil || fields.TargetRig == "" || fields.TargetRig == rigName {
+		return nil, nil
+	}
+	if !force {
+		return nil, fmt.Errorf("bead %s is already scheduled to %s (context: %s)\nUse 'gt scheduler move %s %s' to retarget it, or --force to reschedule",
+			beadID, fields.TargetRig, rec.issue.ID, beadID, rigName)
+	}
+	return rec, nil
+}
+
 // runBatchSchedule schedules multiple beads for deferred dispatch.
 // Returns error when all schedule attempts fail.
 func runBatchSchedule(beadIDs []string, rigName, townRoot string) error {
@@ -254,6 +329,7 @@ func runBatchSchedule(beadIDs []string, rigName, townRoot string) error {
 			Agent:        slingAgent,
 			HookRawBead:  slingHookRawBead,
 			Ralph:        slingRalph,
+			Priority:     slingPriorityOverride(),
 		})
 		if err != nil {
 			fmt.Printf("  %s %s: %v\n", style.Dim.Render("✗"), beadID, err)
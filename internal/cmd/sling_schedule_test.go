@@ -3,8 +3,11 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 	"github.com/steveyegge/gastown/internal/wisp"
 )
 
@@ -118,3 +121,67 @@ func TestResolveFormula(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateSlingPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority int
+		deferred bool
+		wantErr  bool
+	}{
+		{"unset immediate", -1, false, false},
+		{"unset deferred", -1, true, false},
+		{"highest deferred", 0, true, false},
+		{"lowest deferred", 4, true, false},
+		{"out of range", 5, true, true},
+		{"immediate dispatch", 1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSlingPriority(tt.priority, tt.deferred)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSlingPriority(%d, %v) error = %v, wantErr %v", tt.priority, tt.deferred, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEffectiveDispatchPriority(t *testing.T) {
+	info := beadStatusInfo{Priority: 3}
+	if got := effectiveDispatchPriority(&capacity.SlingContextFields{}, info); got != 3 {
+		t.Errorf("without override: got %d, want bead priority 3", got)
+	}
+	override := 0
+	if got := effectiveDispatchPriority(&capacity.SlingContextFields{Priority: &override}, info); got != 0 {
+		t.Errorf("with override: got %d, want 0", got)
+	}
+}
+
+func TestCheckAlreadyScheduled(t *testing.T) {
+	rec := &slingContextRecord{issue: &beads.Issue{ID: "hq-ctx1"}}
+
+	if replaced, err := checkAlreadyScheduled("gt-abc", "gastown", nil, nil, false); replaced != nil || err != nil {
+		t.Errorf("unscheduled: got (%v, %v), want (nil, nil)", replaced, err)
+	}
+
+	same := &capacity.SlingContextFields{WorkBeadID: "gt-abc", TargetRig: "gastown"}
+	if replaced, err := checkAlreadyScheduled("gt-abc", "gastown", rec, same, false); replaced != nil || err != nil {
+		t.Errorf("same rig: got (%v, %v), want no-op", replaced, err)
+	}
+
+	other := &capacity.SlingContextFields{WorkBeadID: "gt-abc", TargetRig: "beads"}
+	_, err := checkAlreadyScheduled("gt-abc", "gastown", rec, other, false)
+	if err == nil {
+		t.Fatal("different rig without --force: expected an error")
+	}
+	for _, want := range []string{"already scheduled to beads", "hq-ctx1", "gt scheduler move gt-abc gastown", "--force"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+
+	replaced, err := checkAlreadyScheduled("gt-abc", "gastown", rec, other, true)
+	if err != nil || replaced != rec {
+		t.Errorf("different rig with --force: got (%v, %v), want the existing context to replace", replaced, err)
+	}
+}
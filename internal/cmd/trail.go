@@ -3,9 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -355,7 +355,7 @@ func runTrailHooks(cmd *cobra.Command, args []string) error {
 		since = time.Now().Add(-duration)
 	}
 
-	entries, err := readHookTrailEntries(filepath.Join(townRoot, events.EventsFile), since, trailLimit)
+	entries, err := readHookTrailEntries(townRoot, since, trailLimit)
 	if err != nil {
 		return err
 	}
@@ -396,18 +396,23 @@ func runTrailHooks(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func readHookTrailEntries(eventsPath string, since time.Time, limit int) ([]HookEntry, error) {
+func readHookTrailEntries(townRoot string, since time.Time, limit int) ([]HookEntry, error) {
 	if limit <= 0 {
 		return []HookEntry{}, nil
 	}
 
-	data, err := os.ReadFile(eventsPath)
+	r, err := events.OpenHistory(townRoot)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("reading events file: %w", err)
 	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading events file: %w", err)
+	}
 
 	trimmed := strings.TrimSpace(string(data))
 	if trimmed == "" {
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	wispPurgeDB     string
+	wispPurgeBefore string
+	wispPurgeHost   string
+	wispPurgePort   int
+	wispPurgeDryRun bool
+	wispPurgeYes    bool
+	wispPurgeJSON   bool
+)
+
+var wispCmd = &cobra.Command{
+	Use:     "wisp",
+	GroupID: GroupServices,
+	Short:   "Manual wisp maintenance",
+	RunE:    requireSubcommand,
+}
+
+var wispPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete closed wisps closed before a date",
+	Long: `Delete closed wisps from one database that were closed before a cutoff.
+
+For one-off cleanups (e.g. after importing old data) without waiting for or
+reconfiguring the wisp reaper. Only closed wisps are deleted, along with their
+labels, comments, events and dependencies; mail is not touched.
+
+The cutoff is a date (YYYY-MM-DD, midnight UTC) or an RFC 3339 timestamp and
+must not be in the future. A per-type digest of what matches is printed
+first; use --dry-run to stop there. Irreversible: asks for confirmation
+unless --yes is given.
+
+Examples:
+  gt wisp purge --db gastown --before 2026-01-01 --dry-run
+  gt wisp purge --db gastown --before 2026-01-01 --yes`,
+	SilenceUsage: true,
+	RunE:         runWispPurge,
+}
+
+func init() {
+	defaultHost, defaultPort := reaperDefaultHostPort()
+	wispPurgeCmd.Flags().StringVar(&wispPurgeDB, "db", "", "Database to purge (required)")
+	wispPurgeCmd.Flags().StringVar(&wispPurgeBefore, "before", "", "Delete wisps closed before this date (YYYY-MM-DD or RFC 3339, required)")
+	wispPurgeCmd.Flags().StringVar(&wispPurgeHost, "host", defaultHost, "Dolt server host (env: GT_DOLT_HOST)")
+	wispPurgeCmd.Flags().IntVar(&wispPurgePort, "port", defaultPort, "Dolt server port (env: GT_DOLT_PORT)")
+	wispPurgeCmd.Flags().BoolVar(&wispPurgeDryRun, "dry-run", false, "Print the digest without deleting anything")
+	wispPurgeCmd.Flags().BoolVarP(&wispPurgeYes, "yes", "y", false, "Delete without confirmation")
+	wispPurgeCmd.Flags().BoolVar(&wispPurgeJSON, "json", false, "Output as JSON")
+	_ = wispPurgeCmd.MarkFlagRequired("db")
+	_ = wispPurgeCmd.MarkFlagRequired("before")
+
+	wispCmd.AddCommand(wispPurgeCmd)
+	rootCmd.AddCommand(wispCmd)
+}
+
+func runWispPurge(cmd *cobra.Command, args []string) error {
+	if err := reaper.ValidateDBName(wispPurgeDB); err != nil {
+		return err
+	}
+	cutoff, err := parsePurgeCutoff(wispPurgeBefore, time.Now())
+	if err != nil {
+		return err
+	}
+	if wispPurgeJSON && !wispPurgeDryRun && !wispPurgeYes {
+		return fmt.Errorf("--json needs --yes or --dry-run (cannot prompt)")
+	}
+	ctx, err := reaperContext()
+	if err != nil {
+		return err
+	}
+
+	db, err := reaper.OpenDB(ctx, wispPurgeHost, wispPurgePort, wispPurgeDB, 30*time.Second, 2*time.Minute)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", wispPurgeDB, err)
+	}
+	defer db.Close()
+	if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
+		return fmt.Errorf("%s: schema check: %w", wispPurgeDB, err)
+	} else if !ok {
+		return fmt.Errorf("%s has no wisps table", wispPurgeDB)
+	}
+
+	// Always digest first; only delete once the operator has seen it.
+	preview, err := reaper.PurgeWispsBefore(ctx, db, wispPurgeDB, cutoff, true)
+	if err != nil {
+		return err
+	}
+	if wispPurgeDryRun || preview.Deleted == 0 {
+		return printWispPurgeResult(preview)
+	}
+
+	if !wispPurgeYes {
+		_ = printWispPurgeResult(preview)
+		if !promptYesNo(fmt.Sprintf("Permanently delete %d closed wisp(s) from %s?", preview.Deleted, wispPurgeDB)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	result, err := reaper.PurgeWispsBefore(ctx, db, wispPurgeDB, cutoff, false)
+	if err != nil {
+		return err
+	}
+	return printWispPurgeResult(result)
+}
+
+// parsePurgeCutoff parses --before as a date (midnight UTC) or an RFC 3339
+// timestamp and rejects cutoffs after now.
+func parsePurgeCutoff(value string, now time.Time) (time.Time, error) {
+	cutoff, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		cutoff, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --before %q: want YYYY-MM-DD or RFC 3339", value)
+		}
+	}
+	if cutoff.After(now) {
+		return time.Time{}, fmt.Errorf("--before %s is in the future", value)
+	}
+	return cutoff, nil
+}
+
+func printWispPurgeResult(r *reaper.WispPurgeResult) error {
+	if wispPurgeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	types := make([]string, 0, len(r.Digest))
+	for wtype := range r.Digest {
+		types = append(types, wtype)
+	}
+	sort.Strings(types)
+	fmt.Printf("%s closed before %s\n", style.Bold.Render(r.Database), r.Cutoff.Format(time.RFC3339))
+	for _, wtype := range types {
+		fmt.Printf("  %s %d\n", style.Dim.Render(wtype+":"), r.Digest[wtype])
+	}
+	for _, a := range r.Anomalies {
+		fmt.Printf("  %s %s\n", style.Warning.Render("ANOMALY:"), a.Message)
+	}
+
+	switch {
+	case r.Deleted == 0:
+		fmt.Println("No closed wisps match.")
+	case r.DryRun:
+		fmt.Printf("[DRY RUN] would delete %d wisp(s)\n", r.Deleted)
+	default:
+		fmt.Printf("%s Deleted %d wisp(s)\n", style.Bold.Render("✓"), r.Deleted)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	wispDoctorHost string
+	wispDoctorPort int
+	wispDoctorFix  bool
+	wispDoctorJSON bool
+)
+
+var wispDoctorCmd = &cobra.Command{
+	Use:   "doctor <db>",
+	Short: "Report aux rows whose wisp or issue is gone",
+	Long: `Count orphaned auxiliary rows in one database.
+
+A row in labels, comments, events or dependencies (or their wisp_* twins) is
+orphaned when its issue_id matches no issue (or wisp). Older purges deleted
+aux rows best-effort, so databases can carry such debris. Counts are reported
+by table; with --fix the orphans are deleted in batches and the change is
+committed. Wisps and issues themselves are never touched.
+
+Examples:
+  gt wisp doctor gastown
+  gt wisp doctor gastown --fix`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runWispDoctor,
+}
+
+func init() {
+	defaultHost, defaultPort := reaperDefaultHostPort()
+	wispDoctorCmd.Flags().StringVar(&wispDoctorHost, "host", defaultHost, "Dolt server host (env: GT_DOLT_HOST)")
+	wispDoctorCmd.Flags().IntVar(&wispDoctorPort, "port", defaultPort, "Dolt server port (env: GT_DOLT_PORT)")
+	wispDoctorCmd.Flags().BoolVar(&wispDoctorFix, "fix", false, "Delete the orphaned rows")
+	wispDoctorCmd.Flags().BoolVar(&wispDoctorJSON, "json", false, "Output as JSON")
+
+	wispCmd.AddCommand(wispDoctorCmd)
+}
+
+func runWispDoctor(cmd *cobra.Command, args []string) error {
+	dbName := args[0]
+	if err := reaper.ValidateDBName(dbName); err != nil {
+		return err
+	}
+	ctx, err := reaperContext()
+	if err != nil {
+		return err
+	}
+
+	db, err := reaper.OpenDB(ctx, wispDoctorHost, wispDoctorPort, dbName, 30*time.Second, 2*time.Minute)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", dbName, err)
+	}
+	defer db.Close()
+	if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
+		return fmt.Errorf("%s: schema check: %w", dbName, err)
+	} else if !ok {
+		return fmt.Errorf("%s has no wisps table", dbName)
+	}
+
+	result, err := reaper.PurgeOrphans(ctx, db, dbName, !wispDoctorFix)
+	if err != nil {
+		return err
+	}
+	return printWispDoctorResult(result)
+}
+
+func printWispDoctorResult(r *reaper.OrphanResult) error {
+	if wispDoctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	tables := make([]string, 0, len(r.Orphans))
+	total := 0
+	for table, n := range r.Orphans {
+		tables = append(tables, table)
+		total += n
+	}
+	sort.Strings(tables)
+	fmt.Printf("%s orphaned aux rows\n", style.Bold.Render(r.Database))
+	for _, table := range tables {
+		fmt.Printf("  %s %d\n", style.Dim.Render(table+":"), r.Orphans[table])
+	}
+	for _, a := range r.Anomalies {
+		fmt.Printf("  %s %s\n", style.Warning.Render("ANOMALY:"), a.Message)
+	}
+
+	switch {
+	case total == 0:
+		fmt.Println("No orphaned rows.")
+	case r.DryRun:
+		fmt.Printf("%d orphaned row(s); run with --fix to delete them\n", total)
+	default:
+		fmt.Printf("%s Deleted %d orphaned row(s)\n", style.Bold.Render("✓"), r.Deleted)
+	}
+	return nil
+}
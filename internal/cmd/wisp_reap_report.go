@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	wispReapReportHost string
+	wispReapReportPort int
+	wispReapReportJSON bool
+)
+
+var wispReapReportCmd = &cobra.Command{
+	Use:   "reap-report [db]",
+	Short: "Preview what the next wisp reaper cycle would do",
+	Long: `Preview the effect of the next wisp reaper cycle on one database, or on
+every database the reaper would visit.
+
+The report runs the reaper's own dry-run queries with the town's effective
+wisp_reaper settings from daemon.json (max_age, delete_age, the auto-close
+window and business-day aging), so it answers "what will happen" with the
+same eligibility rules the cycle uses. Nothing is modified.
+
+For each database it shows:
+  - stale wisps to close, by status, and closed-molecule steps to close
+  - issues to auto-close, with ID, age and title, and the exemptions applied
+  - closed wisps to purge, by wisp type
+  - closed mail to purge
+
+Examples:
+  gt wisp reap-report gastown
+  gt wisp reap-report --json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runWispReapReport,
+}
+
+func init() {
+	defaultHost, defaultPort := reaperDefaultHostPort()
+	wispReapReportCmd.Flags().StringVar(&wispReapReportHost, "host", defaultHost, "Dolt server host (env: GT_DOLT_HOST)")
+	wispReapReportCmd.Flags().IntVar(&wispReapReportPort, "port", defaultPort, "Dolt server port (env: GT_DOLT_PORT)")
+	wispReapReportCmd.Flags().BoolVar(&wispReapReportJSON, "json", false, "Output as JSON")
+	wispCmd.AddCommand(wispReapReportCmd)
+}
+
+func runWispReapReport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	ctx, err := reaperContext()
+	if err != nil {
+		return err
+	}
+	patrolConfig := daemon.LoadPatrolConfig(townRoot)
+	opts, err := daemon.WispReaperPreviewOptions(patrolConfig)
+	if err != nil {
+		return fmt.Errorf("invalid wisp_reaper config in %s: %w", daemon.PatrolConfigFile(townRoot), err)
+	}
+
+	var databases []string
+	if len(args) == 1 {
+		databases = []string{args[0]}
+	} else {
+		var skip []string
+		if patrolConfig != nil && patrolConfig.Patrols != nil && patrolConfig.Patrols.WispReaper != nil {
+			skip = patrolConfig.Patrols.WispReaper.SkipDatabases
+		}
+		databases = skipMaintenanceDatabases(withoutDatabases(reaper.DiscoverDatabases(ctx, wispReapReportHost, wispReapReportPort), skip))
+	}
+
+	var reports []*reaper.PreviewResult
+	for _, dbName := range databases {
+		report, err := previewReaperDatabase(ctx, dbName, opts)
+		if err != nil {
+			if len(args) == 1 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", style.Warning.Render("⚠"), dbName, err)
+			continue
+		}
+		if report != nil {
+			reports = append(reports, report)
+		}
+	}
+
+	if wispReapReportJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+	if len(reports) == 0 {
+		fmt.Println("No reapable databases found.")
+		return nil
+	}
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		printReaperPreview(r)
+	}
+	return nil
+}
+
+// previewReaperDatabase runs reaper.Preview against one database. Databases
+// without the reaper schema yield nil, as the cycle skips them.
+func previewReaperDatabase(ctx context.Context, dbName string, opts reaper.PreviewOptions) (*reaper.PreviewResult, error) {
+	if err := reaper.ValidateDBName(dbName); err != nil {
+		return nil, err
+	}
+	db, err := reaper.OpenDB(ctx, wispReapReportHost, wispReapReportPort, dbName, 30*time.Second, 30*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", dbName, err)
+	}
+	defer db.Close()
+	if ok, err := reaper.HasReaperSchema(ctx, db); err != nil {
+		return nil, fmt.Errorf("%s: schema check: %w", dbName, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return reaper.Preview(ctx, db, dbName, opts)
+}
+
+// withoutDatabases drops the names in skip (case-insensitively).
+func withoutDatabases(databases, skip []string) []string {
+	kept := make([]string, 0, len(databases))
+	for _, name := range databases {
+		skipped := false
+		for _, s := range skip {
+			if strings.EqualFold(name, s) {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// formatCounts renders a count map as "a: 1, b: 2" in key order.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printReaperPreview(r *reaper.PreviewResult) {
+	fmt.Printf("%s\n", style.Bold.Render(r.Database))
+
+	fmt.Printf("  Wisps to close:    %d", r.WispsToClose)
+	if len(r.CloseByStatus) > 0 {
+		fmt.Printf(" (%s)", formatCounts(r.CloseByStatus))
+	}
+	if r.MoleculeStepsToClose > 0 {
+		fmt.Printf(" + %d closed-molecule step(s)", r.MoleculeStepsToClose)
+	}
+	fmt.Println()
+
+	fmt.Printf("  Issues to close:   %d\n", len(r.IssuesToAutoClose))
+	for _, e := range r.IssuesToAutoClose {
+		fmt.Printf("    %s %s %s\n", e.ID, style.Dim.Render(fmt.Sprintf("%dd", e.AgeDays)), e.Title)
+	}
+	fmt.Printf("    %s\n", style.Dim.Render("exempt: "+strings.Join(r.AutoCloseExemptions, "; ")))
+
+	fmt.Printf("  Wisps to purge:    %d", r.WispsToPurge)
+	if len(r.PurgeByType) > 0 {
+		fmt.Printf(" (%s)", formatCounts(r.PurgeByType))
+	}
+	fmt.Println()
+	if len(r.RetainedRecent) > 0 {
+		fmt.Printf("    %s\n", style.Dim.Render("kept as most recent of type: "+formatCounts(r.RetainedRecent)))
+	}
+	if len(r.RetainedExcluded) > 0 {
+		fmt.Printf("    %s\n", style.Dim.Render("retained (excluded type): "+formatCounts(r.RetainedExcluded)))
+	}
+	if r.DeferredRecentEvents > 0 {
+		fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("deferred (recent events): %d", r.DeferredRecentEvents)))
+	}
+	fmt.Printf("  Mail to purge:     %d\n", r.MailToPurge)
+
+	for _, a := range r.Anomalies {
+		fmt.Printf("  %s %s\n", style.Warning.Render("ANOMALY:"), a.Message)
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePurgeCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-01-01", want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2026-03-15", want: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "2026-03-01T08:30:00Z", want: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "2026-03-16", wantErr: true},           // future
+		{value: "2026-03-15T13:00:00Z", wantErr: true}, // future
+		{value: "last tuesday", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parsePurgeCutoff(tt.value, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePurgeCutoff(%q) = %v, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePurgeCutoff(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parsePurgeCutoff(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestReapReportHelpers(t *testing.T) {
+	got := withoutDatabases([]string{"hq", "gastown", "Scratch", "beads"}, []string{"scratch", "beads"})
+	if len(got) != 2 || got[0] != "hq" || got[1] != "gastown" {
+		t.Errorf("withoutDatabases = %v, want [hq gastown]", got)
+	}
+	if got := formatCounts(map[string]int{"patrol": 3, "mail": 1}); got != "mail: 1, patrol: 3" {
+		t.Errorf("formatCounts = %q", got)
+	}
+}
@@ -129,8 +129,8 @@ func (d *Daemon) runCompactorDog() {
 		return
 	}
 
-	threshold := compactorDogThreshold(d.patrolConfig)
-	mode := compactorDogMode(d.patrolConfig)
+	threshold := compactorDogThreshold(d.currentPatrolConfig())
+	mode := compactorDogMode(d.currentPatrolConfig())
 	d.logger.Printf("compactor_dog: starting compaction cycle (threshold=%d, mode=%s)", threshold, mode)
 	if mode == "surgical" {
 		d.logger.Printf("compactor_dog: WARNING: surgical mode uses DOLT_REBASE which is not safe with concurrent writes — will retry on graph-change errors")
@@ -188,7 +188,7 @@ func (d *Daemon) runCompactorDog() {
 
 		var compactErr error
 		if mode == "surgical" {
-			keepRecent := compactorDogKeepRecent(d.patrolConfig)
+			keepRecent := compactorDogKeepRecent(d.currentPatrolConfig())
 			compactErr = d.surgicalRebase(dbName, keepRecent)
 		} else {
 			compactErr = d.compactDatabase(dbName)
@@ -230,14 +230,14 @@ func (d *Daemon) runCompactorDog() {
 // compactorDatabases returns the list of databases to consider for compaction.
 // Checks its own config first, falls back to wisp_reaper config, then auto-discovery.
 func (d *Daemon) compactorDatabases() []string {
-	if d.patrolConfig != nil && d.patrolConfig.Patrols != nil {
-		if cd := d.patrolConfig.Patrols.CompactorDog; cd != nil {
+	if cfg := d.currentPatrolConfig(); cfg != nil && cfg.Patrols != nil {
+		if cd := cfg.Patrols.CompactorDog; cd != nil {
 			if len(cd.Databases) > 0 {
 				return cd.Databases
 			}
 		}
-		if d.patrolConfig.Patrols.WispReaper != nil {
-			if dbs := d.patrolConfig.Patrols.WispReaper.Databases; len(dbs) > 0 {
+		if cfg.Patrols.WispReaper != nil {
+			if dbs := cfg.Patrols.WispReaper.Databases; len(dbs) > 0 {
 				return dbs
 			}
 		}
@@ -0,0 +1,142 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// patrolTicker pairs a running patrol ticker with the function that derives
+// its interval from patrol config, so a config reload can re-arm it.
+type patrolTicker struct {
+	name     string
+	ticker   *time.Ticker
+	interval func(*DaemonPatrolConfig) time.Duration
+}
+
+// currentPatrolConfig returns the active patrol config.
+// Safe to call concurrently with reloadPatrolConfig.
+func (d *Daemon) currentPatrolConfig() *DaemonPatrolConfig {
+	d.patrolConfigMu.RLock()
+	defer d.patrolConfigMu.RUnlock()
+	return d.patrolConfig
+}
+
+// reloadPatrolConfig re-reads mayor/daemon.json, validates it, and swaps it in
+// as the active patrol config. On any read or validation error the old config
+// is kept and the error is returned. Running tickers are re-armed with their
+// (possibly changed) intervals so the next tick uses the new settings.
+func (d *Daemon) reloadPatrolConfig(tickers []patrolTicker) error {
+	newConfig, err := ReadPatrolConfig(d.config.TownRoot)
+	if err != nil {
+		return err
+	}
+	if err := ValidatePatrolConfig(newConfig); err != nil {
+		return err
+	}
+
+	d.patrolConfigMu.Lock()
+	oldConfig := d.patrolConfig
+	d.patrolConfig = newConfig
+	d.patrolConfigMu.Unlock()
+
+	// A reload is the operator's cue that a broken patrol may be fixed.
+	d.resetTrippedPatrols()
+
+	changes := diffPatrolConfig(oldConfig, newConfig)
+	if len(changes) == 0 {
+		d.logger.Printf("Patrol config reloaded from %s (no changes)", PatrolConfigFile(d.config.TownRoot))
+		return nil
+	}
+	d.logger.Printf("Patrol config reloaded from %s (%d changes):", PatrolConfigFile(d.config.TownRoot), len(changes))
+	for _, c := range changes {
+		d.logger.Printf("  %s", c)
+	}
+
+	for _, pt := range tickers {
+		if pt.ticker == nil {
+			if d.isPatrolActive(pt.name) {
+				d.logger.Printf("  %s: enabled in config but ticker was not started; restart the daemon to run it", pt.name)
+			}
+			continue
+		}
+		oldInterval := pt.interval(oldConfig)
+		newInterval := pt.interval(newConfig)
+		if oldInterval != newInterval {
+			pt.ticker.Reset(newInterval)
+			d.logger.Printf("  %s: interval %v -> %v", pt.name, oldInterval, newInterval)
+		}
+	}
+	return nil
+}
+
+// diffPatrolConfig returns a sorted, human-readable list of the settings that
+// differ between two patrol configs, keyed by their JSON path
+// (e.g. "patrols.wisp_reaper.max_age: 24h -> 48h").
+func diffPatrolConfig(oldConfig, newConfig *DaemonPatrolConfig) []string {
+	oldFlat := flattenPatrolConfig(oldConfig)
+	newFlat := flattenPatrolConfig(newConfig)
+
+	keys := make(map[string]bool, len(oldFlat)+len(newFlat))
+	for k := range oldFlat {
+		keys[k] = true
+	}
+	for k := range newFlat {
+		keys[k] = true
+	}
+
+	var changes []string
+	for k := range keys {
+		oldVal, hadOld := oldFlat[k]
+		newVal, hasNew := newFlat[k]
+		switch {
+		case !hadOld:
+			changes = append(changes, fmt.Sprintf("%s: (unset) -> %s", k, newVal))
+		case !hasNew:
+			changes = append(changes, fmt.Sprintf("%s: %s -> (unset)", k, oldVal))
+		case oldVal != newVal:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", k, oldVal, newVal))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// flattenPatrolConfig renders a patrol config as a map of dotted JSON paths to
+// JSON-encoded leaf values. A nil config flattens to an empty map.
+func flattenPatrolConfig(config *DaemonPatrolConfig) map[string]string {
+	flat := make(map[string]string)
+	if config == nil {
+		return flat
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return flat
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return flat
+	}
+	flattenJSON("", tree, flat)
+	return flat
+}
+
+func flattenJSON(prefix string, v interface{}, out map[string]string) {
+	if m, ok := v.(map[string]interface{}); ok && (len(m) > 0 || prefix == "") {
+		for k, child := range m {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenJSON(key, child, out)
+		}
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		out[prefix] = fmt.Sprintf("%v", v)
+		return
+	}
+	out[prefix] = string(data)
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestPatrolConfig(t *testing.T, townRoot, body string) {
+	t.Helper()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mayorDir, "daemon.json"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffPatrolConfig(t *testing.T) {
+	oldConfig := &DaemonPatrolConfig{
+		Type:    "daemon-patrol-config",
+		Version: 1,
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Enabled: true, MaxAgeStr: "24h"},
+		},
+	}
+	newConfig := &DaemonPatrolConfig{
+		Type:    "daemon-patrol-config",
+		Version: 1,
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Enabled: true, MaxAgeStr: "48h", IntervalStr: "30m"},
+		},
+	}
+
+	changes := diffPatrolConfig(oldConfig, newConfig)
+	want := []string{
+		`patrols.wisp_reaper.interval: (unset) -> "30m"`,
+		`patrols.wisp_reaper.max_age: "24h" -> "48h"`,
+	}
+	if strings.Join(changes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffPatrolConfig() =\n%s\nwant\n%s", strings.Join(changes, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := diffPatrolConfig(newConfig, newConfig); len(got) != 0 {
+		t.Errorf("diffPatrolConfig(same) = %v, want no changes", got)
+	}
+}
+
+func TestReloadPatrolConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	writeTestPatrolConfig(t, townRoot, `{
+		"type": "daemon-patrol-config",
+		"version": 1,
+		"patrols": {"wisp_reaper": {"enabled": true, "interval": "1h"}}
+	}`)
+
+	d := &Daemon{
+		config:       DefaultConfig(townRoot),
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: LoadPatrolConfig(townRoot),
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	tickers := []patrolTicker{{"wisp_reaper", ticker, wispReaperInterval}}
+
+	writeTestPatrolConfig(t, townRoot, `{
+		"type": "daemon-patrol-config",
+		"version": 1,
+		"patrols": {"wisp_reaper": {"enabled": true, "interval": "10m", "max_age": "48h"}}
+	}`)
+	if err := d.reloadPatrolConfig(tickers); err != nil {
+		t.Fatalf("reloadPatrolConfig: %v", err)
+	}
+	if got := wispReaperInterval(d.currentPatrolConfig()); got != 10*time.Minute {
+		t.Errorf("interval after reload = %v, want 10m", got)
+	}
+	if got := wispReaperMaxAge(d.currentPatrolConfig()); got != 48*time.Hour {
+		t.Errorf("max age after reload = %v, want 48h", got)
+	}
+}
+
+func TestReloadPatrolConfig_RejectsInvalid(t *testing.T) {
+	townRoot := t.TempDir()
+	writeTestPatrolConfig(t, townRoot, `{"type": "daemon-patrol-config", "version": 1}`)
+
+	original := LoadPatrolConfig(townRoot)
+	d := &Daemon{
+		config:       DefaultConfig(townRoot),
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: original,
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed json", `{"type": `},
+		{"wrong type", `{"type": "something-else", "version": 1}`},
+		{"bad duration", `{"type": "daemon-patrol-config", "version": 1, "patrols": {"wisp_reaper": {"max_age": "30min"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeTestPatrolConfig(t, townRoot, tt.body)
+			if err := d.reloadPatrolConfig(nil); err == nil {
+				t.Fatal("expected reload to fail")
+			}
+			if d.currentPatrolConfig() != original {
+				t.Error("expected old config to be kept after rejected reload")
+			}
+		})
+	}
+}
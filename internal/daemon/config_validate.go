@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// PatrolConfigIssue describes one unusable value in mayor/daemon.json.
+type PatrolConfigIssue struct {
+	// Field is the JSON path of the offending value (e.g. "patrols.wisp_reaper.max_age").
+	Field string `json:"field"`
+	// Value is the raw configured string.
+	Value string `json:"value"`
+	// Message explains why the value was rejected and what is used instead.
+	Message string `json:"message"`
+}
+
+func (i PatrolConfigIssue) Error() string {
+	return fmt.Sprintf("%s=%q: %s", i.Field, i.Value, i.Message)
+}
+
+// patrolDurationField is a configured duration string and the default the
+// interval/age helpers silently fall back to when it is unusable.
+type patrolDurationField struct {
+	field    string
+	value    string
+	fallback time.Duration
+}
+
+// patrolDurationFields lists every string-typed duration in the patrol config.
+// Keep in sync with the *Interval / *Age helpers that parse these lazily.
+func patrolDurationFields(config *DaemonPatrolConfig) []patrolDurationField {
+	if config == nil || config.Patrols == nil {
+		return nil
+	}
+	p := config.Patrols
+	var fields []patrolDurationField
+	if c := p.WispReaper; c != nil {
+		fields = append(fields,
+			patrolDurationField{"patrols.wisp_reaper.interval", c.IntervalStr, defaultWispReaperInterval},
+			patrolDurationField{"patrols.wisp_reaper.max_age", c.MaxAgeStr, defaultWispMaxAge},
+			patrolDurationField{"patrols.wisp_reaper.delete_age", c.DeleteAgeStr, defaultWispDeleteAge},
+			patrolDurationField{"patrols.wisp_reaper.max_cycle_duration", c.MaxCycleDurationStr, 0},
+			patrolDurationField{"patrols.wisp_reaper.stale_mail_age", c.StaleMailAgeStr, 0},
+			patrolDurationField{"patrols.wisp_reaper.purge_defer_recent_events", c.PurgeDeferRecentEventsStr, 0},
+		)
+		// A phase without a usable interval runs every cycle.
+		for _, phase := range slices.Sorted(maps.Keys(c.Phases)) {
+			fields = append(fields, patrolDurationField{"patrols.wisp_reaper.phases." + phase + ".interval", c.Phases[phase].IntervalStr, 0})
+		}
+	}
+	if c := p.DoltBackup; c != nil {
+		verifyInterval := c.VerifyIntervalStr
+		if verifyInterval == "off" {
+			verifyInterval = "" // disables verification; not a duration
+		}
+		fields = append(fields,
+			patrolDurationField{"patrols.dolt_backup.interval", c.IntervalStr, defaultDoltBackupInterval},
+			patrolDurationField{"patrols.dolt_backup.min_interval", c.MinIntervalStr, doltserver.DefaultBackupMinInterval},
+			patrolDurationField{"patrols.dolt_backup.timeout_per_gb", c.TimeoutPerGBStr, defaultDoltBackupTimeoutPerGB},
+			patrolDurationField{"patrols.dolt_backup.min_timeout", c.MinTimeoutStr, doltBackupTimeout},
+			patrolDurationField{"patrols.dolt_backup.max_timeout", c.MaxTimeoutStr, defaultDoltBackupMaxTimeout},
+			patrolDurationField{"patrols.dolt_backup.verify_interval", verifyInterval, defaultDoltBackupVerifyInterval},
+			patrolDurationField{"patrols.dolt_backup.verify_timeout", c.VerifyTimeoutStr, defaultDoltBackupVerifyTimeout},
+		)
+	}
+	if c := p.JsonlGitBackup; c != nil {
+		fields = append(fields, patrolDurationField{"patrols.jsonl_git_backup.interval", c.IntervalStr, defaultJsonlGitBackupInterval})
+	}
+	if c := p.DoctorDog; c != nil {
+		fields = append(fields, patrolDurationField{"patrols.doctor_dog.interval", c.IntervalStr, defaultDoctorDogInterval})
+	}
+	if c := p.CompactorDog; c != nil {
+		fields = append(fields, patrolDurationField{"patrols.compactor_dog.interval", c.IntervalStr, defaultCompactorDogInterval})
+	}
+	if c := p.CheckpointDog; c != nil {
+		fields = append(fields, patrolDurationField{"patrols.checkpoint_dog.interval", c.IntervalStr, defaultCheckpointDogInterval})
+	}
+	if c := p.QuotaDog; c != nil {
+		fields = append(fields, patrolDurationField{"patrols.quota_dog.interval", c.IntervalStr, defaultQuotaDogInterval})
+	}
+	if c := p.MainBranchTest; c != nil {
+		fields = append(fields,
+			patrolDurationField{"patrols.main_branch_test.interval", c.IntervalStr, defaultMainBranchTestInterval},
+			patrolDurationField{"patrols.main_branch_test.timeout", c.TimeoutStr, defaultMainBranchTestTimeout},
+		)
+	}
+	return fields
+}
+
+// PatrolConfigDurationIssues returns one issue for each configured duration
+// string that is unparseable or non-positive. Such values are otherwise
+// silently replaced by their defaults, so "30min" quietly becomes the default
+// with no indication that the config was ignored. Empty strings are not
+// issues: they mean "use the default".
+func PatrolConfigDurationIssues(config *DaemonPatrolConfig) []PatrolConfigIssue {
+	var issues []PatrolConfigIssue
+	for _, f := range patrolDurationFields(config) {
+		if f.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(f.value)
+		switch {
+		case err != nil:
+			issues = append(issues, PatrolConfigIssue{
+				Field:   f.field,
+				Value:   f.value,
+				Message: fmt.Sprintf("not a valid duration (e.g. \"30m\", \"24h\"); using default %v", f.fallback),
+			})
+		case d <= 0:
+			issues = append(issues, PatrolConfigIssue{
+				Field:   f.field,
+				Value:   f.value,
+				Message: fmt.Sprintf("must be positive; using default %v", f.fallback),
+			})
+		}
+	}
+	return issues
+}
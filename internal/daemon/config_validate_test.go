@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPatrolConfigDurationIssues(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{
+				IntervalStr:  "30min",
+				MaxAgeStr:    "-1h",
+				DeleteAgeStr: "168h",
+			},
+			DoltBackup:     &DoltBackupConfig{IntervalStr: "0s", TimeoutPerGBStr: "2 minutes", MaxTimeoutStr: "1h"},
+			MainBranchTest: &MainBranchTestConfig{TimeoutStr: ""},
+		},
+	}
+
+	issues := PatrolConfigDurationIssues(config)
+	got := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		got[issue.Field] = issue.Message
+	}
+
+	if len(issues) != 4 {
+		t.Fatalf("got %d issues, want 4: %v", len(issues), issues)
+	}
+	if msg := got["patrols.wisp_reaper.interval"]; !strings.Contains(msg, "not a valid duration") {
+		t.Errorf("wisp_reaper.interval message = %q, want parse error", msg)
+	}
+	if msg := got["patrols.wisp_reaper.max_age"]; !strings.Contains(msg, "must be positive") {
+		t.Errorf("wisp_reaper.max_age message = %q, want non-positive error", msg)
+	}
+	if msg := got["patrols.dolt_backup.interval"]; !strings.Contains(msg, "must be positive") {
+		t.Errorf("dolt_backup.interval message = %q, want non-positive error", msg)
+	}
+	if msg := got["patrols.dolt_backup.timeout_per_gb"]; !strings.Contains(msg, "not a valid duration") {
+		t.Errorf("dolt_backup.timeout_per_gb message = %q, want parse error", msg)
+	}
+	if _, ok := got["patrols.wisp_reaper.delete_age"]; ok {
+		t.Error("valid delete_age should not be reported")
+	}
+}
+
+func TestPatrolConfigDurationIssues_NilAndDefaults(t *testing.T) {
+	if issues := PatrolConfigDurationIssues(nil); len(issues) != 0 {
+		t.Errorf("nil config: got %v, want no issues", issues)
+	}
+	if issues := PatrolConfigDurationIssues(DefaultLifecycleConfig()); len(issues) != 0 {
+		t.Errorf("default lifecycle config: got %v, want no issues", issues)
+	}
+}
+
+func TestValidatePatrolConfig_RejectsBadDuration(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Type: "daemon-patrol-config",
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{MaxAgeStr: "30min"},
+		},
+	}
+	err := ValidatePatrolConfig(config)
+	if err == nil {
+		t.Fatal("expected validation error for bad duration")
+	}
+	if !strings.Contains(err.Error(), "patrols.wisp_reaper.max_age") {
+		t.Errorf("error %q should name the offending field", err)
+	}
+}
+
+func TestPatrolConfigDurationIssues_VerifyInterval(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			DoltBackup: &DoltBackupConfig{VerifyIntervalStr: "off", VerifyTimeoutStr: "half an hour"},
+		},
+	}
+	issues := PatrolConfigDurationIssues(config)
+	if len(issues) != 1 || issues[0].Field != "patrols.dolt_backup.verify_timeout" {
+		t.Fatalf("got %v, want only verify_timeout reported", issues)
+	}
+
+	config.Patrols.DoltBackup = &DoltBackupConfig{VerifyIntervalStr: "daily"}
+	issues = PatrolConfigDurationIssues(config)
+	if len(issues) != 1 || issues[0].Field != "patrols.dolt_backup.verify_interval" {
+		t.Fatalf("got %v, want verify_interval reported", issues)
+	}
+}
+
+func TestPatrolConfigDurationIssues_PhaseInterval(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Phases: map[string]ReaperPhaseConfig{
+				"purge":      {IntervalStr: "weekly"},
+				"auto-close": {IntervalStr: "168h"},
+				"reap":       {},
+			}},
+		},
+	}
+	issues := PatrolConfigDurationIssues(config)
+	if len(issues) != 1 || issues[0].Field != "patrols.wisp_reaper.phases.purge.interval" {
+		t.Fatalf("got %v, want only the purge phase interval reported", issues)
+	}
+}
+
+func TestValidatePatrolConfig_RejectsBadReaperSchema(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Type: "daemon-patrol-config",
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Schema: map[string]string{"issue": "bd_issues"}},
+		},
+	}
+	err := ValidatePatrolConfig(config)
+	if err == nil {
+		t.Fatal("expected validation error for unknown schema name")
+	}
+	if !strings.Contains(err.Error(), "patrols.wisp_reaper.schema") {
+		t.Errorf("error %q should name the offending field", err)
+	}
+
+	config.Patrols.WispReaper.Schema = map[string]string{"issues": "bd_issues"}
+	if err := ValidatePatrolConfig(config); err != nil {
+		t.Errorf("valid schema mapping rejected: %v", err)
+	}
+}
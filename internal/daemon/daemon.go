@@ -62,6 +62,10 @@ type Daemon struct {
 	doltServer    *DoltServerManager
 	krcPruner     *KRCPruner
 
+	// patrolConfigMu guards patrolConfig, which can be swapped at runtime by
+	// a config reload (SIGHUP / 'gt daemon reload'). Read via currentPatrolConfig.
+	patrolConfigMu sync.RWMutex
+
 	// disabledPatrols is loaded from town settings (disabled_patrols field).
 	// Provides a simple way to disable individual patrol dogs without editing
 	// mayor/daemon.json. Checked by isPatrolActive alongside patrolConfig.
@@ -130,6 +134,28 @@ type Daemon struct {
 	knownRigsCache      []string
 	knownRigsCacheValid bool
 
+	// wispReaperResumeAt is the label of the first database an inline reap
+	// cycle deferred when it ran out of max_cycle_duration; the next cycle
+	// starts there. Only accessed from the main loop goroutine - no sync needed.
+	wispReaperResumeAt string
+
+	// reaperNow is the wisp reaper's clock for its age cutoffs; nil means
+	// time.Now. Tests set it to freeze time at the max_age boundaries.
+	reaperNow func() time.Time
+
+	// patrolLogs holds dedicated logfiles for patrols that set log_file.
+	patrolLogs patrolLogs
+
+	// forcedPatrol is the patrol being run by a forced `gt daemon patrol run`;
+	// isPatrolActive treats it as enabled. Only accessed from the main loop
+	// goroutine - no sync needed.
+	forcedPatrol string
+
+	// patrolFailures collects the molecule step failures of the patrol being
+	// run by runPatrol. Only accessed from the main loop goroutine - no sync
+	// needed.
+	patrolFailures []string
+
 	// legacySocketCleanupOnce ensures upgrade cleanup only runs once per daemon
 	// lifetime, before any patrol agent can be started on the current socket.
 	legacySocketCleanupOnce sync.Once
@@ -281,6 +307,11 @@ func New(config *Config) (*Daemon, error) {
 			os.Setenv(k, v)
 			logger.Printf("Set env %s=%s from daemon.json", k, v)
 		}
+		// Bad duration strings silently fall back to defaults in the interval
+		// helpers; report them up front so misconfiguration is visible.
+		for _, issue := range PatrolConfigDurationIssues(patrolConfig) {
+			logger.Printf("ERROR: daemon.json: %v", issue)
+		}
 	}
 
 	// Load disabled_patrols from town settings (settings/config.json).
@@ -574,10 +605,11 @@ func (d *Daemon) Run() (err error) {
 	var doltRemotesTicker *time.Ticker
 	var doltRemotesChan <-chan time.Time
 	if d.isPatrolActive("dolt_remotes") {
-		interval := doltRemotesInterval(d.patrolConfig)
-		doltRemotesTicker = time.NewTicker(interval)
+		interval := doltRemotesInterval(d.currentPatrolConfig())
+		var stop func()
+		doltRemotesTicker, stop = d.newPatrolTicker("dolt_remotes", doltRemotesInterval)
 		doltRemotesChan = doltRemotesTicker.C
-		defer doltRemotesTicker.Stop()
+		defer stop()
 		d.logger.Printf("Dolt remotes push ticker started (interval %v)", interval)
 	}
 
@@ -586,10 +618,11 @@ func (d *Daemon) Run() (err error) {
 	var doltBackupTicker *time.Ticker
 	var doltBackupChan <-chan time.Time
 	if d.isPatrolActive("dolt_backup") {
-		interval := doltBackupInterval(d.patrolConfig)
-		doltBackupTicker = time.NewTicker(interval)
+		interval := doltBackupInterval(d.currentPatrolConfig())
+		var stop func()
+		doltBackupTicker, stop = d.newPatrolTicker("dolt_backup", doltBackupInterval)
 		doltBackupChan = doltBackupTicker.C
-		defer doltBackupTicker.Stop()
+		defer stop()
 		d.logger.Printf("Dolt backup ticker started (interval %v)", interval)
 	}
 
@@ -598,10 +631,11 @@ func (d *Daemon) Run() (err error) {
 	var jsonlGitBackupTicker *time.Ticker
 	var jsonlGitBackupChan <-chan time.Time
 	if d.isPatrolActive("jsonl_git_backup") {
-		interval := jsonlGitBackupInterval(d.patrolConfig)
-		jsonlGitBackupTicker = time.NewTicker(interval)
+		interval := jsonlGitBackupInterval(d.currentPatrolConfig())
+		var stop func()
+		jsonlGitBackupTicker, stop = d.newPatrolTicker("jsonl_git_backup", jsonlGitBackupInterval)
 		jsonlGitBackupChan = jsonlGitBackupTicker.C
-		defer jsonlGitBackupTicker.Stop()
+		defer stop()
 		d.logger.Printf("JSONL git backup ticker started (interval %v)", interval)
 	}
 
@@ -610,10 +644,11 @@ func (d *Daemon) Run() (err error) {
 	var wispReaperTicker *time.Ticker
 	var wispReaperChan <-chan time.Time
 	if d.isPatrolActive("wisp_reaper") {
-		interval := wispReaperInterval(d.patrolConfig)
-		wispReaperTicker = time.NewTicker(interval)
+		interval := wispReaperInterval(d.currentPatrolConfig())
+		var stop func()
+		wispReaperTicker, stop = d.newPatrolTicker("wisp_reaper", wispReaperInterval)
 		wispReaperChan = wispReaperTicker.C
-		defer wispReaperTicker.Stop()
+		defer stop()
 		d.logger.Printf("Wisp reaper ticker started (interval %v)", interval)
 	}
 
@@ -622,10 +657,11 @@ func (d *Daemon) Run() (err error) {
 	var doctorDogTicker *time.Ticker
 	var doctorDogChan <-chan time.Time
 	if d.isPatrolActive("doctor_dog") {
-		interval := doctorDogInterval(d.patrolConfig)
-		doctorDogTicker = time.NewTicker(interval)
+		interval := doctorDogInterval(d.currentPatrolConfig())
+		var stop func()
+		doctorDogTicker, stop = d.newPatrolTicker("doctor_dog", doctorDogInterval)
 		doctorDogChan = doctorDogTicker.C
-		defer doctorDogTicker.Stop()
+		defer stop()
 		d.logger.Printf("Doctor dog ticker started (interval %v)", interval)
 	}
 
@@ -634,10 +670,11 @@ func (d *Daemon) Run() (err error) {
 	var compactorDogTicker *time.Ticker
 	var compactorDogChan <-chan time.Time
 	if d.isPatrolActive("compactor_dog") {
-		interval := compactorDogInterval(d.patrolConfig)
-		compactorDogTicker = time.NewTicker(interval)
+		interval := compactorDogInterval(d.currentPatrolConfig())
+		var stop func()
+		compactorDogTicker, stop = d.newPatrolTicker("compactor_dog", compactorDogInterval)
 		compactorDogChan = compactorDogTicker.C
-		defer compactorDogTicker.Stop()
+		defer stop()
 		d.logger.Printf("Compactor dog ticker started (interval %v)", interval)
 	}
 
@@ -646,10 +683,11 @@ func (d *Daemon) Run() (err error) {
 	var checkpointDogTicker *time.Ticker
 	var checkpointDogChan <-chan time.Time
 	if d.isPatrolActive("checkpoint_dog") {
-		interval := checkpointDogInterval(d.patrolConfig)
-		checkpointDogTicker = time.NewTicker(interval)
+		interval := checkpointDogInterval(d.currentPatrolConfig())
+		var stop func()
+		checkpointDogTicker, stop = d.newPatrolTicker("checkpoint_dog", checkpointDogInterval)
 		checkpointDogChan = checkpointDogTicker.C
-		defer checkpointDogTicker.Stop()
+		defer stop()
 		d.logger.Printf("Checkpoint dog ticker started (interval %v)", interval)
 	}
 
@@ -659,11 +697,12 @@ func (d *Daemon) Run() (err error) {
 	var scheduledMaintenanceTicker *time.Ticker
 	var scheduledMaintenanceChan <-chan time.Time
 	if d.isPatrolActive("scheduled_maintenance") {
-		interval := maintenanceCheckInterval(d.patrolConfig)
-		scheduledMaintenanceTicker = time.NewTicker(interval)
+		interval := maintenanceCheckInterval(d.currentPatrolConfig())
+		var stop func()
+		scheduledMaintenanceTicker, stop = d.newPatrolTicker("scheduled_maintenance", maintenanceCheckInterval)
 		scheduledMaintenanceChan = scheduledMaintenanceTicker.C
-		defer scheduledMaintenanceTicker.Stop()
-		window := maintenanceWindow(d.patrolConfig)
+		defer stop()
+		window := maintenanceWindow(d.currentPatrolConfig())
 		d.logger.Printf("Scheduled maintenance ticker started (check interval %v, window %s)", interval, window)
 	}
 
@@ -672,10 +711,11 @@ func (d *Daemon) Run() (err error) {
 	var mainBranchTestTicker *time.Ticker
 	var mainBranchTestChan <-chan time.Time
 	if d.isPatrolActive("main_branch_test") {
-		interval := mainBranchTestInterval(d.patrolConfig)
-		mainBranchTestTicker = time.NewTicker(interval)
+		interval := mainBranchTestInterval(d.currentPatrolConfig())
+		var stop func()
+		mainBranchTestTicker, stop = d.newPatrolTicker("main_branch_test", mainBranchTestInterval)
 		mainBranchTestChan = mainBranchTestTicker.C
-		defer mainBranchTestTicker.Stop()
+		defer stop()
 		d.logger.Printf("Main branch test ticker started (interval %v)", interval)
 	}
 
@@ -684,13 +724,29 @@ func (d *Daemon) Run() (err error) {
 	var quotaDogTicker *time.Ticker
 	var quotaDogChan <-chan time.Time
 	if d.isPatrolActive("quota_dog") {
-		interval := quotaDogInterval(d.patrolConfig)
-		quotaDogTicker = time.NewTicker(interval)
+		interval := quotaDogInterval(d.currentPatrolConfig())
+		var stop func()
+		quotaDogTicker, stop = d.newPatrolTicker("quota_dog", quotaDogInterval)
 		quotaDogChan = quotaDogTicker.C
-		defer quotaDogTicker.Stop()
+		defer stop()
 		d.logger.Printf("Quota dog ticker started (interval %v)", interval)
 	}
 
+	// Tickers whose interval can be changed by a patrol config reload.
+	// Nil entries are patrols that were disabled at startup.
+	patrolTickers := []patrolTicker{
+		{"dolt_remotes", doltRemotesTicker, doltRemotesInterval},
+		{"dolt_backup", doltBackupTicker, doltBackupInterval},
+		{"jsonl_git_backup", jsonlGitBackupTicker, jsonlGitBackupInterval},
+		{"wisp_reaper", wispReaperTicker, wispReaperInterval},
+		{"doctor_dog", doctorDogTicker, doctorDogInterval},
+		{"compactor_dog", compactorDogTicker, compactorDogInterval},
+		{"checkpoint_dog", checkpointDogTicker, checkpointDogInterval},
+		{"scheduled_maintenance", scheduledMaintenanceTicker, maintenanceCheckInterval},
+		{"main_branch_test", mainBranchTestTicker, mainBranchTestInterval},
+		{"quota_dog", quotaDogTicker, quotaDogInterval},
+	}
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -710,6 +766,8 @@ func (d *Daemon) Run() (err error) {
 				// Lifecycle signal: immediate lifecycle processing (from gt handoff)
 				d.logger.Println("Received lifecycle signal, processing lifecycle requests immediately")
 				d.processLifecycleRequests()
+				// Also from 'gt daemon patrol run'.
+				d.processPatrolRunRequest()
 			} else if isReloadRestartSignal(sig) {
 				// Reload restart tracker from disk (from 'gt daemon clear-backoff')
 				d.logger.Println("Received reload-restart signal, reloading restart tracker from disk")
@@ -718,6 +776,12 @@ func (d *Daemon) Run() (err error) {
 						d.logger.Printf("Warning: failed to reload restart tracker: %v", err)
 					}
 				}
+			} else if isReloadConfigSignal(sig) {
+				// Reload patrol config from disk (from 'gt daemon reload')
+				d.logger.Println("Received reload-config signal, reloading patrol config from disk")
+				if err := d.reloadPatrolConfig(patrolTickers); err != nil {
+					d.logger.Printf("Warning: patrol config reload rejected, keeping current config: %v", err)
+				}
 			} else {
 				d.logger.Printf("Received signal %v, shutting down", sig)
 				return d.shutdown(state)
@@ -734,70 +798,70 @@ func (d *Daemon) Run() (err error) {
 			// Periodic Dolt remote push — pushes databases to their configured
 			// git remotes on a 15-minute cadence (independent of heartbeat).
 			if !d.isShutdownInProgress() {
-				d.pushDoltRemotes()
+				d.runPatrol("dolt_remotes")
 			}
 
 		case <-doltBackupChan:
 			// Periodic Dolt filesystem backup — syncs production databases to
 			// local backup directory on a 15-minute cadence.
 			if !d.isShutdownInProgress() {
-				d.syncDoltBackups()
+				d.runPatrol("dolt_backup")
 			}
 
 		case <-jsonlGitBackupChan:
 			// Periodic JSONL git backup — exports issues, scrubs ephemeral data,
 			// commits and pushes to git repo.
 			if !d.isShutdownInProgress() {
-				d.syncJsonlGitBackup()
+				d.runPatrol("jsonl_git_backup")
 			}
 
 		case <-wispReaperChan:
 			// Periodic wisp reaper — closes stale wisps (abandoned molecule steps,
 			// old patrol data) to prevent unbounded table growth (Clown Show audit).
 			if !d.isShutdownInProgress() {
-				d.reapWisps()
+				d.runPatrol("wisp_reaper")
 			}
 
 		case <-doctorDogChan:
 			// Doctor dog — comprehensive Dolt health monitor: connectivity, latency,
 			// gc, zombie detection, backup staleness, and disk usage checks.
 			if !d.isShutdownInProgress() {
-				d.runDoctorDog()
+				d.runPatrol("doctor_dog")
 			}
 
 		case <-compactorDogChan:
 			// Compactor dog — flattens Dolt commit history on production databases.
 			// Reclaims commit graph storage, then runs gc to reclaim chunks.
 			if !d.isShutdownInProgress() {
-				d.runCompactorDog()
+				d.runPatrol("compactor_dog")
 			}
 
 		case <-checkpointDogChan:
 			// Checkpoint dog — auto-commits WIP changes in active polecat
 			// worktrees to prevent data loss from session crashes.
 			if !d.isShutdownInProgress() {
-				d.runCheckpointDog()
+				d.runPatrol("checkpoint_dog")
 			}
 
 		case <-scheduledMaintenanceChan:
 			// Scheduled maintenance — checks if we're in the maintenance window
 			// and runs `gt maintain --force` when commit counts exceed threshold.
 			if !d.isShutdownInProgress() {
-				d.runScheduledMaintenance()
+				d.runPatrol("scheduled_maintenance")
 			}
 
 		case <-mainBranchTestChan:
 			// Main branch test runner — periodically runs quality gates on each
 			// rig's main branch to catch regressions from merges or direct pushes.
 			if !d.isShutdownInProgress() {
-				d.runMainBranchTests()
+				d.runPatrol("main_branch_test")
 			}
 
 		case <-quotaDogChan:
 			// Quota dog — scans for rate-limited sessions and automatically
 			// rotates credentials to available accounts via keychain swap.
 			if !d.isShutdownInProgress() {
-				d.runQuotaDog()
+				d.runPatrol("quota_dog")
 			}
 
 		case <-timer.C:
@@ -2113,7 +2177,7 @@ func (d *Daemon) readKnownRigsFromDisk() []string {
 // Otherwise, all known rigs are returned. In both cases, non-operational
 // rigs (parked/docked) are filtered out at list-building time. (Fixes upstream #2082)
 func (d *Daemon) getPatrolRigs(patrol string) []string {
-	configRigs := GetPatrolRigs(d.patrolConfig, patrol)
+	configRigs := GetPatrolRigs(d.currentPatrolConfig(), patrol)
 	var candidates []string
 	if len(configRigs) > 0 {
 		candidates = configRigs
@@ -2260,6 +2324,8 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 		}
 	}
 
+	d.closePatrolLogs()
+
 	state.Running = false
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save final state: %v", err)
@@ -101,7 +101,7 @@ func (d *Daemon) runDoctorDog() {
 	d.logger.Printf("doctor_dog: pouring molecule for agent execution")
 
 	port := d.doltServerPort()
-	latencyThreshold, orphanCount, backupStaleSec := doctorDogThresholds(d.patrolConfig)
+	latencyThreshold, orphanCount, backupStaleSec := doctorDogThresholds(d.currentPatrolConfig())
 
 	mol := d.pourDogMolecule(constants.MolDogDoctor, map[string]string{
 		"port":              strconv.Itoa(port),
@@ -52,6 +52,62 @@ type dogMol struct {
 	bdPath   string
 	townRoot string
 	logger   interface{ Printf(string, ...interface{}) }
+	onFail   func(string) // reports step failures to the running patrol; may be nil
+
+	// steps and closed record what the patrol asked for, whether or not bd
+	// carried it out, so tests can assert on the lifecycle without a live bd.
+	steps  map[string]stepOutcome
+	closed bool
+}
+
+// Molecule step states recorded by dogMol.
+const (
+	stepOpen   = "open"
+	stepClosed = "closed"
+	stepFailed = "failed"
+)
+
+// stepOutcome is the recorded state of one molecule step.
+type stepOutcome struct {
+	Status string // stepOpen, stepClosed or stepFailed
+	Reason string // failStep reason
+}
+
+// molOutcome is a snapshot of everything a patrol recorded on its molecule.
+type molOutcome struct {
+	Poured bool                   // a real molecule exists (bd succeeded)
+	Steps  map[string]stepOutcome // by step slug; only steps the patrol touched
+	Closed bool                   // close() was called
+}
+
+// recordStep notes the requested state of a step. The first close or fail
+// wins, matching bd, where a closed wisp stays closed.
+func (dm *dogMol) recordStep(stepSlug, status, reason string) {
+	if dm.steps == nil {
+		dm.steps = make(map[string]stepOutcome)
+	}
+	if prev, ok := dm.steps[stepSlug]; ok && prev.Status != stepOpen {
+		return
+	}
+	dm.steps[stepSlug] = stepOutcome{Status: status, Reason: reason}
+}
+
+// stepStatus returns the recorded state of a step; stepOpen with no reason if
+// the patrol never closed or failed it.
+func (dm *dogMol) stepStatus(stepSlug string) (status, reason string) {
+	if o, ok := dm.steps[stepSlug]; ok {
+		return o.Status, o.Reason
+	}
+	return stepOpen, ""
+}
+
+// outcome returns a copy of the recorded molecule lifecycle.
+func (dm *dogMol) outcome() molOutcome {
+	steps := make(map[string]stepOutcome, len(dm.steps))
+	for slug, o := range dm.steps {
+		steps[slug] = o
+	}
+	return molOutcome{Poured: dm.rootID != "", Steps: steps, Closed: dm.closed}
 }
 
 // pourDogMolecule creates an ephemeral wisp molecule from a formula.
@@ -63,6 +119,7 @@ func (d *Daemon) pourDogMolecule(formulaName string, vars map[string]string) *do
 		bdPath:   d.bdPath,
 		townRoot: d.config.TownRoot,
 		logger:   d.logger,
+		onFail:   d.notePatrolFailure,
 	}
 
 	// Build args: bd mol wisp <formula> --var k=v ...
@@ -94,6 +151,13 @@ func (d *Daemon) pourDogMolecule(formulaName string, vars map[string]string) *do
 
 // closeStep marks a molecule step as closed.
 func (dm *dogMol) closeStep(stepSlug string) {
+	dm.closeStepWithReason(stepSlug, "")
+}
+
+// closeStepWithReason marks a molecule step as closed with a note, e.g.
+// "reported" for a step that only observed what it would have done.
+func (dm *dogMol) closeStepWithReason(stepSlug, reason string) {
+	dm.recordStep(stepSlug, stepClosed, reason)
 	if dm.rootID == "" {
 		return // No molecule — graceful degradation.
 	}
@@ -104,7 +168,11 @@ func (dm *dogMol) closeStep(stepSlug string) {
 		return
 	}
 
-	if err := dm.closeWisp(stepID); err != nil {
+	var extra []string
+	if reason != "" {
+		extra = []string{"--reason", reason}
+	}
+	if err := dm.closeWisp(stepID, extra...); err != nil {
 		dm.logger.Printf("dog_molecule: close step %s (%s) failed after %d attempts (non-fatal): %v", stepSlug, stepID, dogCloseMaxAttempts, err)
 		return
 	}
@@ -112,6 +180,10 @@ func (dm *dogMol) closeStep(stepSlug string) {
 
 // failStep marks a molecule step as failed with a reason.
 func (dm *dogMol) failStep(stepSlug, reason string) {
+	dm.recordStep(stepSlug, stepFailed, reason)
+	if dm.onFail != nil {
+		dm.onFail(stepSlug + ": " + reason)
+	}
 	if dm.rootID == "" {
 		return
 	}
@@ -131,6 +203,7 @@ func (dm *dogMol) failStep(stepSlug, reason string) {
 // This prevents orphan step wisps from accumulating when callers forget to
 // explicitly close individual steps (the root cause of gt-3o59).
 func (dm *dogMol) close() {
+	dm.closed = true
 	if dm.rootID == "" {
 		return
 	}
@@ -203,7 +276,32 @@ func (dm *dogMol) discoverSteps() {
 		dm.logger.Printf("dog_molecule: discover steps: parse children JSON for %s failed: %v", dm.rootID, parseErr)
 		return
 	}
+	dm.mapChildSteps(children)
+	// bd succeeded, so no steps means its output no longer has the shape we
+	// parse; without this every closeStep would just log "unknown step".
+	if warning := childrenFormatWarning(dm.rootID, children, len(dm.stepIDs)); warning != "" {
+		dm.logger.Printf("%s", warning)
+	}
+}
+
+// childrenFormatWarning explains a successful `bd show --children` call that
+// yielded no steps, or returns "" when steps were found.
+func childrenFormatWarning(rootID string, children []childInfo, found int) string {
+	if found > 0 {
+		return ""
+	}
+	usable := 0
+	for _, child := range children {
+		if child.ID != "" && child.Title != "" {
+			usable++
+		}
+	}
+	return fmt.Sprintf("dog_molecule: WARNING: bd children format may have changed: parsed 0 steps from %d children of %s (%d with id and title)",
+		len(children), rootID, usable)
+}
 
+// mapChildSteps records the step slug of each child wisp.
+func (dm *dogMol) mapChildSteps(children []childInfo) {
 	// Map known step slugs from each child's title. The wisp title typically starts
 	// with the step title from the formula.
 	for _, child := range children {
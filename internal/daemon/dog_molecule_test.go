@@ -1,6 +1,12 @@
 package daemon
 
-import "testing"
+import (
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestParseWispID(t *testing.T) {
 	tests := []struct {
@@ -122,6 +128,33 @@ func TestParseChildrenJSON(t *testing.T) {
 	}
 }
 
+func TestMapChildStepsDetectsFormatDrift(t *testing.T) {
+	dm := &dogMol{rootID: "hq-wisp-root", stepIDs: make(map[string]string)}
+	children, err := parseChildrenJSON(`{"hq-wisp-root":[{"id":"hq-wisp-a","title":"Scan databases"},{"id":"hq-wisp-b","title":"Report"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm.mapChildSteps(children)
+	if dm.stepIDs["scan"] != "hq-wisp-a" || dm.stepIDs["report"] != "hq-wisp-b" {
+		t.Errorf("stepIDs = %v", dm.stepIDs)
+	}
+	if got := childrenFormatWarning(dm.rootID, children, len(dm.stepIDs)); got != "" {
+		t.Errorf("warning with steps found = %q, want none", got)
+	}
+
+	// Renamed fields decode as empty: bd succeeded but nothing maps.
+	drifted := &dogMol{rootID: "hq-wisp-root", stepIDs: make(map[string]string)}
+	children, err = parseChildrenJSON(`[{"issue_id":"hq-wisp-a","name":"Scan"},{"issue_id":"hq-wisp-b","name":"Report"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drifted.mapChildSteps(children)
+	got := childrenFormatWarning(drifted.rootID, children, len(drifted.stepIDs))
+	if !strings.Contains(got, "format may have changed: parsed 0 steps from 2 children of hq-wisp-root (0 with id and title)") {
+		t.Errorf("warning = %q", got)
+	}
+}
+
 func TestDogMolGracefulDegradation(t *testing.T) {
 	// A dogMol with empty rootID should be a no-op for all operations.
 	dm := &dogMol{
@@ -134,3 +167,60 @@ func TestDogMolGracefulDegradation(t *testing.T) {
 	dm.failStep("scan", "test failure")
 	dm.close()
 }
+
+func TestDogMolRecordsOutcome(t *testing.T) {
+	// No molecule (bd unavailable): outcomes are still recorded.
+	dm := &dogMol{stepIDs: make(map[string]string)}
+
+	dm.closeStep("scan")
+	dm.failStep("purge", "connect error")
+	dm.closeStep("purge") // a failed step stays failed
+	dm.close()
+
+	if status, _ := dm.stepStatus("scan"); status != stepClosed {
+		t.Errorf("scan = %s, want closed", status)
+	}
+	if status, reason := dm.stepStatus("purge"); status != stepFailed || reason != "connect error" {
+		t.Errorf("purge = %s (%q), want failed (connect error)", status, reason)
+	}
+	if status, _ := dm.stepStatus("report"); status != stepOpen {
+		t.Errorf("untouched report = %s, want open", status)
+	}
+
+	out := dm.outcome()
+	if out.Poured || !out.Closed || len(out.Steps) != 2 {
+		t.Errorf("outcome = %+v, want not poured, closed, 2 steps", out)
+	}
+}
+
+func TestCloseReaperStepReportOnly(t *testing.T) {
+	mol := &dogMol{stepIDs: make(map[string]string)}
+	closeReaperStep(mol, &WispReaperConfig{ReportOnly: true}, "reap")
+	closeReaperStep(mol, &WispReaperConfig{}, "purge")
+
+	if status, reason := mol.stepStatus("reap"); status != stepClosed || reason != "reported" {
+		t.Errorf("reap = %s (%q), want closed (reported)", status, reason)
+	}
+	if status, reason := mol.stepStatus("purge"); status != stepClosed || reason != "" {
+		t.Errorf("purge = %s (%q), want closed without a reason", status, reason)
+	}
+}
+
+func TestReapWispsInlineFailsScanWithoutTargets(t *testing.T) {
+	d := &Daemon{
+		config:       &Config{TownRoot: t.TempDir()},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{},
+	}
+	mol := &dogMol{stepIDs: make(map[string]string)}
+	config := &WispReaperConfig{Endpoints: []DoltEndpointConfig{{Address: "not-an-address"}}}
+
+	d.reapWispsInline(config, time.Hour, time.Hour, mol)
+
+	if status, reason := mol.stepStatus("scan"); status != stepFailed || reason != "no databases found" {
+		t.Errorf("scan = %s (%q), want failed (no databases found)", status, reason)
+	}
+	if status, _ := mol.stepStatus("reap"); status != stepOpen {
+		t.Errorf("reap = %s, want untouched", status)
+	}
+}
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +12,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/health"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -18,13 +21,23 @@ const (
 	defaultDoltBackupInterval = 15 * time.Minute
 	// doltBackupTimeout is generous so a large commit delta on a big database
 	// (e.g. hq under a wisp flood) does not blow the deadline mid-sync. The old
-	// 120s ceiling produced spurious exit-1 backup failures (gt-ye21).
+	// 120s ceiling produced spurious exit-1 backup failures (gt-ye21). It is
+	// also the floor for size-scaled sync timeouts (doltBackupSyncTimeout).
 	doltBackupTimeout = 5 * time.Minute
+	// defaultDoltBackupTimeoutPerGB and defaultDoltBackupMaxTimeout scale a
+	// big database's sync timeout with its size, up to a ceiling.
+	defaultDoltBackupTimeoutPerGB = 2 * time.Minute
+	defaultDoltBackupMaxTimeout   = time.Hour
 	// doltBackupRetries / doltBackupRetryDelay retry a failed sync after a short
 	// pause, so a transient lock (a concurrent dolt op holding the db) does not
 	// fail the whole backup cycle.
 	doltBackupRetries    = 1
 	doltBackupRetryDelay = 5 * time.Second
+	// defaultDoltBackupVerifyInterval and defaultDoltBackupVerifyTimeout
+	// keep restore verification off the sync cadence: each backup is
+	// restored at most once a day, and a cycle spends at most 30m on it.
+	defaultDoltBackupVerifyInterval = 24 * time.Hour
+	defaultDoltBackupVerifyTimeout  = 30 * time.Minute
 )
 
 // doltBackupInterval returns the configured backup interval, or the default (15m).
@@ -39,6 +52,62 @@ func doltBackupInterval(config *DaemonPatrolConfig) time.Duration {
 	return defaultDoltBackupInterval
 }
 
+// DoltBackupMinInterval returns how recently a database must have been backed
+// up for the patrol to skip it (default doltserver.DefaultBackupMinInterval).
+func DoltBackupMinInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.DoltBackup != nil {
+		if config.Patrols.DoltBackup.MinIntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.DoltBackup.MinIntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return doltserver.DefaultBackupMinInterval
+}
+
+// doltBackupDuration parses an optional duration from the dolt_backup
+// config, returning fallback when unset or invalid.
+func doltBackupDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// doltBackupVerifyInterval returns how often each backup is verified
+// (verify_interval, default 24h), or 0 when verification is "off".
+func doltBackupVerifyInterval(config *DoltBackupConfig) time.Duration {
+	if config == nil {
+		return defaultDoltBackupVerifyInterval
+	}
+	if config.VerifyIntervalStr == "off" {
+		return 0
+	}
+	return doltBackupDuration(config.VerifyIntervalStr, defaultDoltBackupVerifyInterval)
+}
+
+// doltBackupSyncTimeout returns how long a sync of a database of sizeBytes
+// may run: timeout_per_gb per GB, clamped to [min_timeout, max_timeout].
+func doltBackupSyncTimeout(config *DoltBackupConfig, sizeBytes int64) time.Duration {
+	perGB, floor, ceiling := defaultDoltBackupTimeoutPerGB, doltBackupTimeout, defaultDoltBackupMaxTimeout
+	if config != nil {
+		perGB = doltBackupDuration(config.TimeoutPerGBStr, perGB)
+		floor = doltBackupDuration(config.MinTimeoutStr, floor)
+		ceiling = doltBackupDuration(config.MaxTimeoutStr, ceiling)
+	}
+	if ceiling < floor {
+		ceiling = floor
+	}
+	timeout := time.Duration(float64(perGB) * float64(sizeBytes) / (1 << 30))
+	switch {
+	case timeout < floor:
+		return floor
+	case timeout > ceiling:
+		return ceiling
+	}
+	return timeout
+}
+
 // syncDoltBackups syncs each production database to its configured backup location.
 // Non-fatal: errors are logged but don't stop the daemon.
 func (d *Daemon) syncDoltBackups() {
@@ -68,10 +137,25 @@ func (d *Daemon) syncDoltBackups() {
 		return
 	}
 
-	config := d.patrolConfig.Patrols.DoltBackup
+	config := d.currentPatrolConfig().Patrols.DoltBackup
+	if config.DryRun {
+		plan := PlanDoltBackups(d.config.TownRoot, dataDir, config.Databases, DoltBackupMinInterval(d.currentPatrolConfig()), time.Now())
+		for _, line := range plan.Lines() {
+			d.logger.Printf("dolt_backup: dry run: %s", line)
+		}
+		mol.closeStep("sync")
+		mol.closeStep("verify")
+		mol.closeStep("offsite")
+		mol.closeStep("report")
+		return
+	}
 	databases := config.Databases
 	if len(databases) == 0 {
 		databases = d.discoverDatabasesWithBackups(dataDir)
+	} else if err := validateDoltBackupDatabases(dataDir, databases); err != nil {
+		d.logger.Printf("dolt_backup: %v", err)
+		mol.failStep("sync", err.Error())
+		return
 	}
 
 	if len(databases) == 0 {
@@ -82,19 +166,42 @@ func (d *Daemon) syncDoltBackups() {
 
 	d.logger.Printf("dolt_backup: syncing %d database(s)", len(databases))
 
-	synced := 0
-	var failures []string
+	minInterval := DoltBackupMinInterval(d.currentPatrolConfig())
+	synced, skipped := 0, 0
+	var failures, syncedDBs []string
 	for _, db := range databases {
+		// Skip a database a manual sync just backed up, so the two do not
+		// double up and contend on it.
+		if age, recent := doltserver.RecentBackupAge(d.config.TownRoot, db, minInterval, time.Now()); recent {
+			d.logger.Printf("dolt_backup: %s: backed up %d seconds ago, skipping", db, int(age.Seconds()))
+			skipped++
+			continue
+		}
 		backupName := db + "-backup"
-		if err := d.syncBackup(dataDir, db, backupName); err != nil {
+		if err := d.syncBackup(dataDir, db, backupName, config); err != nil {
 			d.logger.Printf("dolt_backup: %s: sync failed: %v", db, err)
 			failures = append(failures, db)
 		} else {
 			synced++
+			syncedDBs = append(syncedDBs, db)
+			if err := doltserver.RecordBackupSuccess(d.config.TownRoot, db, time.Now()); err != nil {
+				d.logger.Printf("dolt_backup: %s: recording backup time: %v", db, err)
+			}
+			// HEAD after the sync is the baseline for `gt dolt backup sync
+			// --since-last`; failing to read it never fails the backup.
+			if head := d.doltBackupHead(dataDir, db); head != "" {
+				if err := doltserver.RecordBackupCommit(d.config.TownRoot, db, head); err != nil {
+					d.logger.Printf("dolt_backup: %s: recording backup commit: %v", db, err)
+				}
+			}
 		}
 	}
 
-	d.logger.Printf("dolt_backup: synced %d/%d database(s)", synced, len(databases))
+	if skipped > 0 {
+		d.logger.Printf("dolt_backup: synced %d/%d database(s), %d skipped as recently backed up", synced, len(databases), skipped)
+	} else {
+		d.logger.Printf("dolt_backup: synced %d/%d database(s)", synced, len(databases))
+	}
 
 	if len(failures) > 0 {
 		mol.failStep("sync", fmt.Sprintf("synced %d/%d, failures: %s", synced, len(databases), strings.Join(failures, "; ")))
@@ -102,11 +209,32 @@ func (d *Daemon) syncDoltBackups() {
 		mol.closeStep("sync")
 	}
 
+	// Verify: restore fresh backups to a temp dir and sanity-check them, so a
+	// backup that syncs cleanly but cannot be restored is caught before it is
+	// needed. Same check as `gt dolt backup verify`. A full restore is costly,
+	// so each backup is verified once per verify_interval, within
+	// verify_timeout per cycle.
+	switch verify := d.verifyDoltBackups(dataDir, syncedDBs, config); {
+	case len(verify.Failures) > 0:
+		mol.failStep("verify", fmt.Sprintf("verification failed: %s", strings.Join(verify.Failures, "; ")))
+	case verify.Verified == 0:
+		mol.closeStepWithReason("verify", "skipped: "+verify.skipReason(len(syncedDBs)))
+	default:
+		mol.closeStep("verify")
+	}
+
 	// Offsite sync: rsync local backups to iCloud Drive for cloud replication.
 	// This is a stopgap until proper dolt remote push is configured.
 	if synced > 0 {
-		d.syncOffsiteBackup()
-		mol.closeStep("offsite")
+		offsite, err := d.syncOffsiteBackup()
+		switch {
+		case err != nil:
+			mol.failStep("offsite", err.Error())
+		case offsite != nil && len(offsite.Failed) > 0:
+			mol.failStep("offsite", offsite.Summary())
+		default:
+			mol.closeStep("offsite")
+		}
 	} else {
 		mol.closeStep("offsite")
 	}
@@ -114,15 +242,39 @@ func (d *Daemon) syncDoltBackups() {
 	mol.closeStep("report")
 }
 
+// doltBackupHead returns db's HEAD commit, or "" (logged) if it cannot be read.
+func (d *Daemon) doltBackupHead(dataDir, db string) string {
+	parentCtx := d.ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parentCtx, time.Minute)
+	defer cancel()
+	head, err := doltserver.BackupHead(ctx, dataDir, db)
+	if err != nil {
+		d.logger.Printf("dolt_backup: %s: %v", db, err)
+	}
+	return head
+}
+
+// errDoltBackupTimeout marks a sync killed at its timeout. The backup may
+// hold a partial sync, so it is reported as such and not retried.
+var errDoltBackupTimeout = errors.New("sync aborted at timeout, backup may be incomplete")
+
 // syncBackup runs `dolt backup sync <backup-name>` for a single database,
 // retrying once on failure so a transient lock or large delta does not fail the
-// cycle (gt-ye21).
-func (d *Daemon) syncBackup(dataDir, db, backupName string) error {
+// cycle (gt-ye21). The timeout scales with the database's size on disk.
+func (d *Daemon) syncBackup(dataDir, db, backupName string, config *DoltBackupConfig) error {
 	parentCtx := d.ctx
 	if parentCtx == nil {
 		parentCtx = context.Background()
 	}
 	dbDir := filepath.Join(dataDir, db)
+	size, err := health.DirSize(dbDir)
+	if err != nil {
+		d.logger.Printf("dolt_backup: %s: sizing data dir: %v", db, err)
+	}
+	timeout := doltBackupSyncTimeout(config, size)
 
 	var lastErr error
 	for attempt := 0; attempt <= doltBackupRetries; attempt++ {
@@ -137,52 +289,407 @@ func (d *Daemon) syncBackup(dataDir, db, backupName string) error {
 			}
 		}
 
-		ctx, cancel := context.WithTimeout(parentCtx, doltBackupTimeout)
+		ctx, cancel := context.WithTimeout(parentCtx, timeout)
 		cmd := exec.CommandContext(ctx, "dolt", "backup", "sync", backupName)
 		cmd.Dir = dbDir
 		util.SetProcessGroup(cmd)
 
 		output, err := cmd.CombinedOutput()
+		timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
 		cancel()
 		if err == nil {
 			d.logger.Printf("dolt_backup: %s: synced to %s", db, backupName)
 			return nil
 		}
+		if timedOut {
+			d.logger.Printf("dolt_backup: %s: ABORTED sync to %s after %v (%.1f GB on disk); the backup may be incomplete — raise patrols.dolt_backup.timeout_per_gb or max_timeout",
+				db, backupName, timeout, float64(size)/(1<<30))
+			return fmt.Errorf("%w (after %v)", errDoltBackupTimeout, timeout)
+		}
 		lastErr = fmt.Errorf("%s: %s", err, strings.TrimSpace(string(output)))
 	}
 	return lastErr
 }
 
-// syncOffsiteBackup rsyncs the local backup directory to iCloud Drive.
-// iCloud automatically syncs to Apple's cloud, providing offsite replication.
-// Non-fatal: if iCloud is unavailable or rsync fails, we just log and continue.
-func (d *Daemon) syncOffsiteBackup() {
-	backupDir := filepath.Join(d.config.TownRoot, ".dolt-backup")
-	if _, err := os.Stat(backupDir); os.IsNotExist(err) {
-		return
+// doltBackupVerifyRun is what one cycle's backup verification did.
+type doltBackupVerifyRun struct {
+	Verified int      // backups that passed
+	Failures []string // "<db>: <reason>" per backup that failed
+	Disabled bool     // verify_interval is "off"
+	Deferred int      // due backups left for a later cycle at verify_timeout
+}
+
+// skipReason explains a cycle that verified nothing, given how many
+// databases were synced.
+func (r doltBackupVerifyRun) skipReason(synced int) string {
+	switch {
+	case synced == 0:
+		return "no databases synced"
+	case r.Disabled:
+		return "verify_interval is off"
+	case r.Deferred > 0:
+		return fmt.Sprintf("verify_timeout reached, %d deferred", r.Deferred)
+	default:
+		return "verified within verify_interval"
 	}
+}
 
-	// iCloud Drive path (macOS)
-	homeDir, err := os.UserHomeDir()
+// verifyDoltBackups runs doltserver.VerifyBackup for each database whose
+// backup is due for verification, stopping at verify_timeout.
+func (d *Daemon) verifyDoltBackups(dataDir string, databases []string, config *DoltBackupConfig) doltBackupVerifyRun {
+	var run doltBackupVerifyRun
+	interval := doltBackupVerifyInterval(config)
+	if interval == 0 {
+		run.Disabled = true
+		return run
+	}
+	timeout := defaultDoltBackupVerifyTimeout
+	if config != nil {
+		timeout = doltBackupDuration(config.VerifyTimeoutStr, timeout)
+	}
+
+	parentCtx := d.ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	cycleCtx, cancelCycle := context.WithTimeout(parentCtx, timeout)
+	defer cancelCycle()
+
+	for _, db := range databases {
+		if !doltserver.BackupVerifyDue(d.config.TownRoot, db, interval, time.Now()) {
+			continue
+		}
+		if cycleCtx.Err() != nil {
+			run.Deferred++
+			continue
+		}
+		ctx, cancel := context.WithTimeout(cycleCtx, doltBackupTimeout)
+		result, err := doltserver.VerifyBackup(ctx, dataDir, db)
+		cancel()
+		if err != nil {
+			if errors.Is(cycleCtx.Err(), context.DeadlineExceeded) {
+				d.logger.Printf("dolt_backup: %s: verify stopped at verify_timeout %v, retrying next cycle", db, timeout)
+				run.Deferred++
+				continue
+			}
+			d.logger.Printf("dolt_backup: %s: verify error: %v", db, err)
+			run.Failures = append(run.Failures, fmt.Sprintf("%s: %v", db, err))
+			continue
+		}
+		for _, c := range result.Checks {
+			if !c.OK {
+				d.logger.Printf("dolt_backup: %s: verify check %s FAILED: %s", db, c.Name, c.Detail)
+				run.Failures = append(run.Failures, fmt.Sprintf("%s: %s", db, c.Name))
+			}
+		}
+		if result.Passed() {
+			d.logger.Printf("dolt_backup: %s: backup verified", db)
+			run.Verified++
+			if err := doltserver.RecordBackupVerified(d.config.TownRoot, db, time.Now()); err != nil {
+				d.logger.Printf("dolt_backup: %s: recording verification time: %v", db, err)
+			}
+		}
+	}
+	if run.Deferred > 0 {
+		d.logger.Printf("dolt_backup: verify_timeout %v reached, %d backup(s) deferred to a later cycle", timeout, run.Deferred)
+	}
+	return run
+}
+
+// OffsiteResult records which databases' backups replicated offsite.
+type OffsiteResult struct {
+	Dir        string // offsite (iCloud Drive) backup dir
+	Replicated []string
+	Failed     []string // "<db>: <reason>"
+}
+
+// Summary describes the result for logs and the molecule's offsite step.
+func (r *OffsiteResult) Summary() string {
+	total := len(r.Replicated) + len(r.Failed)
+	if len(r.Failed) == 0 {
+		return fmt.Sprintf("replicated %d/%d database(s)", len(r.Replicated), total)
+	}
+	return fmt.Sprintf("replicated %d/%d database(s), failures: %s", len(r.Replicated), total, strings.Join(r.Failed, "; "))
+}
+
+// syncOffsiteBackup replicates every local backup offsite for the patrol.
+func (d *Daemon) syncOffsiteBackup() (*OffsiteResult, error) {
+	return SyncOffsiteBackup(d.config.TownRoot, nil, d.logger.Printf)
+}
+
+// SyncOffsiteBackup rsyncs each database's local backup (<townRoot>/.dolt-backup)
+// to iCloud Drive. iCloud automatically syncs to Apple's cloud, providing
+// offsite replication. databases limits the copy to those backups; nil means
+// all of them. Databases are copied one at a time so a failure in one subtree
+// (bad permissions, a broken symlink) does not hide the others' success.
+// Offsite copies of databases no longer backed up locally are left in place.
+// Returns nil, nil when there is nothing to replicate or no iCloud Drive, and
+// an error only if the offsite dir cannot be prepared.
+//
+// This is the offsite half of the dolt_backup patrol; `gt dolt backup sync
+// --offsite-only` runs it on its own when the local backups are current.
+func SyncOffsiteBackup(townRoot string, databases []string, logf func(format string, args ...interface{})) (*OffsiteResult, error) {
+	backupDir := filepath.Join(townRoot, ".dolt-backup")
+	available, err := offsiteBackupDatabases(backupDir)
+	if err != nil || len(available) == 0 {
+		return nil, nil
+	}
+	result := &OffsiteResult{}
+	if databases == nil {
+		databases = available
+	} else {
+		have := make(map[string]bool, len(available))
+		for _, db := range available {
+			have[db] = true
+		}
+		var present []string
+		for _, db := range databases {
+			if have[db] {
+				present = append(present, db)
+			} else {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: no local backup in %s", db, backupDir))
+			}
+		}
+		databases = present
+	}
+
+	icloudDir, err := offsiteBackupDir()
 	if err != nil {
-		return
+		return nil, nil
 	}
-	icloudDir := filepath.Join(homeDir, "Library", "Mobile Documents", "com~apple~CloudDocs", "gt-dolt-backup")
 	if err := os.MkdirAll(icloudDir, 0755); err != nil {
-		d.logger.Printf("dolt_backup: offsite: cannot create iCloud dir: %v", err)
-		return
+		logf("dolt_backup: offsite: cannot create iCloud dir: %v", err)
+		return nil, fmt.Errorf("cannot create iCloud dir: %w", err)
+	}
+	result.Dir = icloudDir
+
+	for _, db := range databases {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		src := filepath.Join(backupDir, db) + "/"
+		dst := filepath.Join(icloudDir, db) + "/"
+		cmd := exec.CommandContext(ctx, "rsync", "-a", "--delete", src, dst)
+		util.SetDetachedProcessGroup(cmd)
+		output, err := cmd.CombinedOutput()
+		cancel()
+		if err != nil {
+			logf("dolt_backup: offsite: %s: sync failed: %v (%s)", db, err, strings.TrimSpace(string(output)))
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", db, err))
+			continue
+		}
+		result.Replicated = append(result.Replicated, db)
+	}
+
+	logf("dolt_backup: offsite: %s", result.Summary())
+	return result, nil
+}
+
+// offsiteBackupDir returns the iCloud Drive (macOS) dir that local backups
+// are replicated to.
+func offsiteBackupDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, "Library", "Mobile Documents", "com~apple~CloudDocs", "gt-dolt-backup"), nil
+}
+
+// DoltBackupPlan describes what a dolt_backup run would do, for dry runs.
+type DoltBackupPlan struct {
+	DataDir        string                `json:"data_dir"`
+	DataDirExists  bool                  `json:"data_dir_exists"`
+	OffsiteDir     string                `json:"offsite_dir,omitempty"`
+	OffsiteProblem string                `json:"offsite_problem,omitempty"` // why the offsite target does not resolve
+	Databases      []DoltBackupPlanEntry `json:"databases"`
+}
+
+// DoltBackupPlanEntry is one database in a DoltBackupPlan.
+type DoltBackupPlanEntry struct {
+	Database string            `json:"database"`
+	Backup   string            `json:"backup"`            // backup that would be synced (<db>-backup)
+	Remotes  map[string]string `json:"remotes,omitempty"` // every configured backup: name → URL
+	Offsite  string            `json:"offsite,omitempty"` // offsite path the local backup would replicate to
+	Skip     string            `json:"skip,omitempty"`    // why the database would not be synced
+}
+
+// PlanDoltBackups works out which databases a dolt_backup run would sync, to
+// which backups, and where the offsite copy would land, without syncing
+// anything. databases limits the plan to those databases; empty means every
+// database in dataDir with a <db>-backup backup, as the patrol discovers them.
+// Databases backed up within minInterval are marked as skipped.
+func PlanDoltBackups(townRoot, dataDir string, databases []string, minInterval time.Duration, now time.Time) *DoltBackupPlan {
+	plan := &DoltBackupPlan{DataDir: dataDir}
+	if info, err := os.Stat(dataDir); err == nil && info.IsDir() {
+		plan.DataDirExists = true
+	}
+
+	switch dir, err := offsiteBackupDir(); {
+	case runtime.GOOS != "darwin":
+		plan.OffsiteProblem = "offsite replication uses iCloud Drive and is macOS-only"
+	case err != nil:
+		plan.OffsiteProblem = fmt.Sprintf("cannot resolve home dir: %v", err)
+	default:
+		plan.OffsiteDir = dir
+		if _, err := os.Stat(filepath.Dir(dir)); err != nil {
+			plan.OffsiteProblem = fmt.Sprintf("iCloud Drive not found at %s", filepath.Dir(dir))
+		}
+	}
+	if !plan.DataDirExists {
+		return plan
+	}
+
+	explicit := len(databases) > 0
+	if !explicit {
+		entries, err := os.ReadDir(dataDir)
+		if err != nil {
+			return plan
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				databases = append(databases, entry.Name())
+			}
+		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	for _, db := range databases {
+		entry := DoltBackupPlanEntry{Database: db, Backup: db + "-backup"}
+		entry.Remotes = listBackupRemotes(dataDir, db)
+		if _, ok := entry.Remotes[entry.Backup]; !ok {
+			if !explicit {
+				continue // discovery only picks up databases with a <db>-backup
+			}
+			entry.Skip = fmt.Sprintf("no %s backup configured", entry.Backup)
+		} else if age, recent := doltserver.RecentBackupAge(townRoot, db, minInterval, now); recent {
+			entry.Skip = fmt.Sprintf("backed up %d seconds ago", int(age.Seconds()))
+		}
+		if plan.OffsiteDir != "" {
+			entry.Offsite = filepath.Join(plan.OffsiteDir, db)
+		}
+		plan.Databases = append(plan.Databases, entry)
+	}
+	return plan
+}
+
+// Lines renders the plan one line per fact, for logs and CLI output.
+func (p *DoltBackupPlan) Lines() []string {
+	var lines []string
+	if !p.DataDirExists {
+		return append(lines, fmt.Sprintf("data dir %s does not exist, nothing would sync", p.DataDir))
+	}
+	lines = append(lines, fmt.Sprintf("data dir %s", p.DataDir))
+	if p.OffsiteProblem != "" {
+		lines = append(lines, fmt.Sprintf("offsite: %s", p.OffsiteProblem))
+	} else {
+		lines = append(lines, fmt.Sprintf("offsite dir %s", p.OffsiteDir))
+	}
+	if len(p.Databases) == 0 {
+		return append(lines, "no databases with backup remotes found")
+	}
+	for _, e := range p.Databases {
+		if e.Skip != "" {
+			lines = append(lines, fmt.Sprintf("%s: skip (%s)", e.Database, e.Skip))
+			continue
+		}
+		line := fmt.Sprintf("%s → %s (%s)", e.Database, e.Backup, e.Remotes[e.Backup])
+		if e.Offsite != "" {
+			line += " → " + e.Offsite
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// DoltBackupRemotes is the backup configuration of one database.
+type DoltBackupRemotes struct {
+	Database string            `json:"database"`
+	Remotes  map[string]string `json:"remotes"`         // configured backups: name → URL
+	Expected string            `json:"expected"`        // backup the patrol syncs (<db>-backup)
+	Missing  bool              `json:"missing"`         // Expected is not configured, so the patrol skips the database
+	Error    string            `json:"error,omitempty"` // why the backups could not be listed
+}
+
+// ListDoltBackupRemotes reports the backups configured for every database in
+// dataDir, flagging those without the <db>-backup backup the dolt_backup
+// patrol looks for; discovery skips such databases silently.
+func ListDoltBackupRemotes(dataDir string) ([]DoltBackupRemotes, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	var result []DoltBackupRemotes
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		db := entry.Name()
+		r := DoltBackupRemotes{Database: db, Expected: db + "-backup"}
+		r.Remotes = listBackupRemotes(dataDir, db)
+		if r.Remotes == nil {
+			r.Error = "dolt backup failed"
+			r.Remotes = map[string]string{}
+		}
+		_, ok := r.Remotes[r.Expected]
+		r.Missing = !ok
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// listBackupRemotes returns the backups configured for dataDir/db as
+// name → URL, or nil if they cannot be listed.
+func listBackupRemotes(dataDir, db string) map[string]string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "rsync", "-a", "--delete", backupDir+"/", icloudDir+"/")
+	cmd := exec.CommandContext(ctx, "dolt", "backup", "-v")
+	cmd.Dir = filepath.Join(dataDir, db)
 	util.SetDetachedProcessGroup(cmd)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		d.logger.Printf("dolt_backup: offsite sync failed: %v (%s)", err, strings.TrimSpace(string(output)))
-	} else {
-		d.logger.Printf("dolt_backup: offsite synced to iCloud")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseBackupRemotes(string(output))
+}
+
+// parseBackupRemotes parses `dolt backup -v` output ("<name> <url> ..." per
+// line) into name → URL.
+func parseBackupRemotes(output string) map[string]string {
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			remotes[fields[0]] = fields[1]
+		}
+	}
+	return remotes
+}
+
+// offsiteBackupDatabases lists the per-database subdirectories of the local
+// backup dir, skipping hidden entries.
+func offsiteBackupDatabases(backupDir string) ([]string, error) {
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	var databases []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			databases = append(databases, entry.Name())
+		}
+	}
+	return databases, nil
+}
+
+// validateDoltBackupDatabases checks that each configured database name is a
+// Dolt database in dataDir (a directory with a .dolt folder), so a typo fails
+// up front instead of as an opaque 'dolt backup sync' error.
+func validateDoltBackupDatabases(dataDir string, databases []string) error {
+	for _, db := range databases {
+		info, err := os.Stat(filepath.Join(dataDir, db, ".dolt"))
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("configured database %s not found in data dir %s", db, dataDir)
+		}
 	}
+	return nil
 }
 
 // discoverDatabasesWithBackups lists databases in the data directory
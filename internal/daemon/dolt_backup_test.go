@@ -0,0 +1,214 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOffsiteBackupDatabases(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"hq", "gastown", ".tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := offsiteBackupDatabases(dir)
+	if err != nil {
+		t.Fatalf("offsiteBackupDatabases: %v", err)
+	}
+	if want := []string{"gastown", "hq"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("offsiteBackupDatabases = %v, want %v", got, want)
+	}
+
+	if _, err := offsiteBackupDatabases(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing backup dir")
+	}
+}
+
+func TestOffsiteResultSummary(t *testing.T) {
+	ok := &OffsiteResult{Replicated: []string{"hq", "gastown"}}
+	if got, want := ok.Summary(), "replicated 2/2 database(s)"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+
+	partial := &OffsiteResult{
+		Replicated: []string{"hq"},
+		Failed:     []string{"gastown: exit status 23", "beads: exit status 23"},
+	}
+	want := "replicated 1/3 database(s), failures: gastown: exit status 23; beads: exit status 23"
+	if got := partial.Summary(); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
+
+func TestSyncOffsiteBackupUnknownDatabase(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	if err := os.MkdirAll(filepath.Join(townRoot, ".dolt-backup", "hq"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SyncOffsiteBackup(townRoot, []string{"gastown"}, t.Logf)
+	if err != nil {
+		t.Fatalf("SyncOffsiteBackup: %v", err)
+	}
+	if result == nil || len(result.Replicated) != 0 || len(result.Failed) != 1 {
+		t.Fatalf("result = %+v, want one failure for the database with no local backup", result)
+	}
+
+	if result, err := SyncOffsiteBackup(t.TempDir(), nil, t.Logf); result != nil || err != nil {
+		t.Errorf("no local backups: got %+v, %v; want nil, nil", result, err)
+	}
+}
+
+func TestParseBackupRemotes(t *testing.T) {
+	out := "hq-backup file:///town/.dolt-backup/hq {}\nhq-offsite aws://bucket/hq {}\n\n"
+	want := map[string]string{
+		"hq-backup":  "file:///town/.dolt-backup/hq",
+		"hq-offsite": "aws://bucket/hq",
+	}
+	if got := parseBackupRemotes(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBackupRemotes = %v, want %v", got, want)
+	}
+}
+
+func TestPlanDoltBackupsMissingDataDir(t *testing.T) {
+	townRoot := t.TempDir()
+	plan := PlanDoltBackups(townRoot, filepath.Join(townRoot, ".dolt-data"), nil, 0, time.Now())
+	if plan.DataDirExists {
+		t.Error("DataDirExists = true for a missing data dir")
+	}
+	if len(plan.Databases) != 0 {
+		t.Errorf("Databases = %v, want none", plan.Databases)
+	}
+	if lines := plan.Lines(); len(lines) != 1 {
+		t.Errorf("Lines() = %v, want a single data dir line", lines)
+	}
+}
+
+func TestListDoltBackupRemotes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt is a shell script")
+	}
+	fakeBinDir := t.TempDir()
+	script := `#!/bin/sh
+case "$(basename "$PWD")" in
+  hq) echo "hq-backup file:///town/.dolt-backup/hq {}" ;;
+  gastown) echo "offsite aws://bucket/gastown {}" ;;
+  *) exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(fakeBinDir, "dolt"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", fakeBinDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dataDir := t.TempDir()
+	for _, name := range []string{"hq", "gastown", "broken", ".tmp"} {
+		if err := os.MkdirAll(filepath.Join(dataDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := ListDoltBackupRemotes(dataDir)
+	if err != nil {
+		t.Fatalf("ListDoltBackupRemotes: %v", err)
+	}
+	want := []DoltBackupRemotes{
+		{Database: "broken", Remotes: map[string]string{}, Expected: "broken-backup", Missing: true, Error: "dolt backup failed"},
+		{Database: "gastown", Remotes: map[string]string{"offsite": "aws://bucket/gastown"}, Expected: "gastown-backup", Missing: true},
+		{Database: "hq", Remotes: map[string]string{"hq-backup": "file:///town/.dolt-backup/hq"}, Expected: "hq-backup"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListDoltBackupRemotes =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if _, err := ListDoltBackupRemotes(filepath.Join(dataDir, "missing")); err == nil {
+		t.Error("expected an error for a missing data dir")
+	}
+}
+
+func TestValidateDoltBackupDatabases(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "hq", ".dolt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "notdolt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateDoltBackupDatabases(dir, []string{"hq"}); err != nil {
+		t.Errorf("valid database: %v", err)
+	}
+	for _, db := range []string{"hqq", "notdolt"} {
+		err := validateDoltBackupDatabases(dir, []string{"hq", db})
+		if err == nil || !strings.Contains(err.Error(), "configured database "+db+" not found") {
+			t.Errorf("database %s: err = %v, want not-found error", db, err)
+		}
+	}
+}
+
+func TestDoltBackupSyncTimeout(t *testing.T) {
+	const gb = int64(1) << 30
+	tests := []struct {
+		name   string
+		config *DoltBackupConfig
+		size   int64
+		want   time.Duration
+	}{
+		{"small database gets the floor", nil, 100 << 20, 5 * time.Minute},
+		{"scales with size", nil, 10 * gb, 20 * time.Minute},
+		{"capped at the ceiling", nil, 100 * gb, time.Hour},
+		{"configured rate", &DoltBackupConfig{TimeoutPerGBStr: "30s"}, 20 * gb, 10 * time.Minute},
+		{"configured floor", &DoltBackupConfig{MinTimeoutStr: "15m"}, gb, 15 * time.Minute},
+		{"configured ceiling", &DoltBackupConfig{MaxTimeoutStr: "10m"}, 10 * gb, 10 * time.Minute},
+		{"ceiling below floor", &DoltBackupConfig{MinTimeoutStr: "20m", MaxTimeoutStr: "10m"}, 10 * gb, 20 * time.Minute},
+		{"invalid values keep defaults", &DoltBackupConfig{TimeoutPerGBStr: "fast"}, 10 * gb, 20 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doltBackupSyncTimeout(tt.config, tt.size); got != tt.want {
+				t.Errorf("doltBackupSyncTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoltBackupVerifyInterval(t *testing.T) {
+	if got := doltBackupVerifyInterval(nil); got != defaultDoltBackupVerifyInterval {
+		t.Errorf("default = %v, want %v", got, defaultDoltBackupVerifyInterval)
+	}
+	if got := doltBackupVerifyInterval(&DoltBackupConfig{VerifyIntervalStr: "168h"}); got != 168*time.Hour {
+		t.Errorf("configured = %v, want 168h", got)
+	}
+	if got := doltBackupVerifyInterval(&DoltBackupConfig{VerifyIntervalStr: "off"}); got != 0 {
+		t.Errorf("off = %v, want 0", got)
+	}
+}
+
+func TestDoltBackupVerifySkipReason(t *testing.T) {
+	tests := []struct {
+		run    doltBackupVerifyRun
+		synced int
+		want   string
+	}{
+		{doltBackupVerifyRun{}, 0, "no databases synced"},
+		{doltBackupVerifyRun{Disabled: true}, 2, "verify_interval is off"},
+		{doltBackupVerifyRun{Deferred: 1}, 2, "verify_timeout reached, 1 deferred"},
+		{doltBackupVerifyRun{}, 2, "verified within verify_interval"},
+	}
+	for _, tt := range tests {
+		if got := tt.run.skipReason(tt.synced); got != tt.want {
+			t.Errorf("skipReason(%+v, %d) = %q, want %q", tt.run, tt.synced, got, tt.want)
+		}
+	}
+}
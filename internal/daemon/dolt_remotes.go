@@ -47,7 +47,7 @@ func (d *Daemon) pushDoltRemotes() {
 		return
 	}
 
-	config := d.patrolConfig.Patrols.DoltRemotes
+	config := d.currentPatrolConfig().Patrols.DoltRemotes
 	remote := config.Remote
 	branch := config.Branch
 	if branch == "" {
@@ -89,7 +89,7 @@ func (d *Daemon) syncJsonlGitBackup() {
 	mol := d.pourDogMolecule(constants.MolDogJSONL, nil)
 	defer mol.close()
 
-	config := d.patrolConfig.Patrols.JsonlGitBackup
+	config := d.currentPatrolConfig().Patrols.JsonlGitBackup
 
 	// Resolve git repo path.
 	gitRepo := config.GitRepo
@@ -0,0 +1,235 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// patrolIntervals maps each ticker-driven patrol to its interval helper, so
+// status tooling reports the same cadence the daemon's tickers use.
+var patrolIntervals = map[string]func(*DaemonPatrolConfig) time.Duration{
+	"dolt_remotes":          doltRemotesInterval,
+	"dolt_backup":           doltBackupInterval,
+	"jsonl_git_backup":      jsonlGitBackupInterval,
+	"wisp_reaper":           wispReaperInterval,
+	"doctor_dog":            doctorDogInterval,
+	"compactor_dog":         compactorDogInterval,
+	"checkpoint_dog":        checkpointDogInterval,
+	"scheduled_maintenance": maintenanceCheckInterval,
+	"main_branch_test":      mainBranchTestInterval,
+	"quota_dog":             quotaDogInterval,
+}
+
+// PatrolInterval returns how often the named patrol runs under config, or 0
+// for an unknown patrol.
+func PatrolInterval(config *DaemonPatrolConfig, patrol string) time.Duration {
+	if fn, ok := patrolIntervals[patrol]; ok {
+		return fn(config)
+	}
+	return 0
+}
+
+// defaultPatrolFailureLimit is how many consecutive failed runs trip a
+// patrol's circuit breaker.
+const defaultPatrolFailureLimit = 5
+
+// PatrolFailureLimit returns how many consecutive failed runs disable a
+// patrol (patrols.failure_limit, default 5), or 0 if the circuit breaker is
+// turned off with a negative limit.
+func PatrolFailureLimit(config *DaemonPatrolConfig) int {
+	if config != nil && config.Patrols != nil && config.Patrols.FailureLimit != 0 {
+		if config.Patrols.FailureLimit < 0 {
+			return 0
+		}
+		return config.Patrols.FailureLimit
+	}
+	return defaultPatrolFailureLimit
+}
+
+// PatrolHealth is what the daemon recorded about a patrol's most recent runs.
+// A run fails when any step of its dog molecule is failed; LastError holds
+// those step failures. Tripped is set once ConsecutiveFailures reaches the
+// failure limit: the daemon stops running the patrol on its ticker until it
+// is reset ('gt daemon patrol reset' or a config reload).
+type PatrolHealth struct {
+	LastRun             time.Time `json:"last_run"`
+	LastDurationMS      int64     `json:"last_duration_ms"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Tripped             bool      `json:"tripped,omitempty"`
+}
+
+// PatrolHealthFile returns the path of the per-patrol health record.
+func PatrolHealthFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "patrol-health.json")
+}
+
+// LoadPatrolHealth reads the per-patrol health record, keyed by patrol name.
+// A missing file yields an empty map: no patrol has run yet.
+func LoadPatrolHealth(townRoot string) (map[string]PatrolHealth, error) {
+	health := make(map[string]PatrolHealth)
+	data, err := os.ReadFile(PatrolHealthFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return health, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+// lockPatrolHealth takes the cross-process lock that serializes
+// read-modify-write updates of the patrol health file: the daemon records
+// runs while 'gt daemon patrol reset' clears streaks from another process.
+// The caller must Unlock the returned lock.
+func lockPatrolHealth(townRoot string) (*flock.Flock, error) {
+	path := PatrolHealthFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring patrol health lock: %w", err)
+	}
+	return fl, nil
+}
+
+// ResetPatrolHealth clears a patrol's failure streak and re-enables it if its
+// circuit breaker tripped. Reports whether it had tripped.
+func ResetPatrolHealth(townRoot, name string) (bool, error) {
+	fl, err := lockPatrolHealth(townRoot)
+	if err != nil {
+		return false, err
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	health, err := LoadPatrolHealth(townRoot)
+	if err != nil {
+		return false, err
+	}
+	h, ok := health[name]
+	if !ok {
+		return false, nil
+	}
+	tripped := h.Tripped
+	h.Tripped = false
+	h.ConsecutiveFailures = 0
+	health[name] = h
+	return tripped, atomicfile.EnsureDirAndWriteJSON(PatrolHealthFile(townRoot), health)
+}
+
+// patrolTripEscalate alerts when a patrol's circuit breaker trips. A
+// variable so tests can capture it.
+var patrolTripEscalate = (*Daemon).escalate
+
+// isPatrolTripped reports whether the named patrol's circuit breaker is open.
+func (d *Daemon) isPatrolTripped(name string) bool {
+	health, err := LoadPatrolHealth(d.config.TownRoot)
+	return err == nil && health[name].Tripped
+}
+
+// resetTrippedPatrols re-enables every patrol whose circuit breaker tripped.
+// Called on a patrol config reload.
+func (d *Daemon) resetTrippedPatrols() {
+	health, err := LoadPatrolHealth(d.config.TownRoot)
+	if err != nil {
+		return
+	}
+	var reset []string
+	for name, h := range health {
+		if h.Tripped {
+			if _, err := ResetPatrolHealth(d.config.TownRoot, name); err != nil {
+				d.logger.Printf("patrol_health: resetting %s: %v", name, err)
+				continue
+			}
+			reset = append(reset, name)
+		}
+	}
+	if len(reset) > 0 {
+		sort.Strings(reset)
+		d.logger.Printf("patrol_health: re-enabled after config reload: %s", strings.Join(reset, ", "))
+	}
+}
+
+// runPatrol runs the named patrol and records its outcome in the patrol
+// health file. A patrol whose circuit breaker tripped is skipped unless it
+// is being forced on demand. Called from the main loop only.
+func (d *Daemon) runPatrol(name string) {
+	run, ok := patrolRunners[name]
+	if !ok {
+		return
+	}
+	if d.forcedPatrol != name && d.isPatrolTripped(name) {
+		d.logger.Printf("patrol_health: %s: skipped, disabled after repeated failures ('gt daemon patrol reset %s' to re-enable)", name, name)
+		return
+	}
+	d.patrolFailures = nil
+	start := time.Now()
+	run(d)
+	failures := d.patrolFailures
+	d.patrolFailures = nil
+
+	prev, next, err := d.recordPatrolRun(name, start, time.Since(start), failures)
+	if err != nil {
+		d.logger.Printf("patrol_health: writing %s: %v", name, err)
+	}
+	if next.Tripped && !prev.Tripped {
+		msg := fmt.Sprintf("disabled after %d consecutive failures (last: %s); run 'gt daemon patrol reset %s' to re-enable",
+			next.ConsecutiveFailures, next.LastError, name)
+		d.logger.Printf("patrol_health: %s: %s", name, msg)
+		patrolTripEscalate(d, name, msg)
+	}
+}
+
+// recordPatrolRun folds one run of the named patrol into the health file
+// under the patrol health lock, returning its health before and after. next
+// is still returned when only the write fails.
+func (d *Daemon) recordPatrolRun(name string, start time.Time, elapsed time.Duration, failures []string) (prev, next PatrolHealth, err error) {
+	fl, err := lockPatrolHealth(d.config.TownRoot)
+	if err != nil {
+		return prev, next, err
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	health, err := LoadPatrolHealth(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("patrol_health: reading %s: %v (starting fresh)", PatrolHealthFile(d.config.TownRoot), err)
+		health = make(map[string]PatrolHealth)
+	}
+	prev = health[name]
+	next = nextPatrolHealth(prev, start, elapsed, failures, PatrolFailureLimit(d.currentPatrolConfig()))
+	health[name] = next
+	return prev, next, atomicfile.EnsureDirAndWriteJSON(PatrolHealthFile(d.config.TownRoot), health)
+}
+
+// nextPatrolHealth folds one run into prev, tripping the circuit breaker once
+// the failure streak reaches limit (0 = never). A successful run clears it.
+func nextPatrolHealth(prev PatrolHealth, start time.Time, elapsed time.Duration, failures []string, limit int) PatrolHealth {
+	next := PatrolHealth{
+		LastRun:        start.UTC(),
+		LastDurationMS: elapsed.Milliseconds(),
+	}
+	if len(failures) > 0 {
+		next.LastError = strings.Join(failures, "; ")
+		next.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+		next.Tripped = prev.Tripped || (limit > 0 && next.ConsecutiveFailures >= limit)
+	}
+	return next
+}
+
+// notePatrolFailure records a failure in the patrol currently running, for
+// runPatrol to report.
+func (d *Daemon) notePatrolFailure(msg string) {
+	d.patrolFailures = append(d.patrolFailures, msg)
+}
@@ -0,0 +1,164 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestRunPatrolRecordsHealth(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{},
+	}
+
+	fail := true
+	patrolRunners["test_patrol"] = func(d *Daemon) {
+		// A real patrol reports failures through its dog molecule.
+		mol := &dogMol{logger: d.logger, onFail: d.notePatrolFailure}
+		if fail {
+			mol.failStep("scan", "no databases found")
+		} else {
+			mol.closeStep("scan")
+		}
+		mol.close()
+	}
+	t.Cleanup(func() { delete(patrolRunners, "test_patrol") })
+
+	for i := 0; i < 2; i++ {
+		d.runPatrol("test_patrol")
+	}
+	health, err := LoadPatrolHealth(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatrolHealth: %v", err)
+	}
+	h := health["test_patrol"]
+	if h.LastRun.IsZero() || h.LastError != "scan: no databases found" || h.ConsecutiveFailures != 2 {
+		t.Fatalf("after two failed runs: %+v", h)
+	}
+
+	fail = false
+	d.runPatrol("test_patrol")
+	health, err = LoadPatrolHealth(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatrolHealth: %v", err)
+	}
+	if h := health["test_patrol"]; h.LastError != "" || h.ConsecutiveFailures != 0 {
+		t.Errorf("a successful run should reset the failure streak: %+v", h)
+	}
+	if len(d.patrolFailures) != 0 {
+		t.Errorf("patrolFailures should be cleared after a run, got %v", d.patrolFailures)
+	}
+}
+
+func TestRunPatrolCircuitBreaker(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{FailureLimit: 2}},
+	}
+
+	runs := 0
+	patrolRunners["test_patrol"] = func(d *Daemon) {
+		runs++
+		d.notePatrolFailure("sync: remote unreachable")
+	}
+	var alerts []string
+	patrolTripEscalate = func(_ *Daemon, source, message string) { alerts = append(alerts, source) }
+	t.Cleanup(func() {
+		delete(patrolRunners, "test_patrol")
+		patrolTripEscalate = (*Daemon).escalate
+	})
+
+	for i := 0; i < 4; i++ {
+		d.runPatrol("test_patrol")
+	}
+	if runs != 2 {
+		t.Errorf("patrol ran %d times, want 2 (stopped once tripped)", runs)
+	}
+	if len(alerts) != 1 || alerts[0] != "test_patrol" {
+		t.Errorf("alerts = %v, want one for test_patrol", alerts)
+	}
+	health, err := LoadPatrolHealth(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatrolHealth: %v", err)
+	}
+	if h := health["test_patrol"]; !h.Tripped || h.ConsecutiveFailures != 2 {
+		t.Fatalf("after tripping: %+v", h)
+	}
+
+	tripped, err := ResetPatrolHealth(townRoot, "test_patrol")
+	if err != nil || !tripped {
+		t.Fatalf("ResetPatrolHealth = %v, %v; want true, nil", tripped, err)
+	}
+	d.runPatrol("test_patrol")
+	if runs != 3 {
+		t.Errorf("patrol should run again after reset, ran %d times", runs)
+	}
+}
+
+func TestResetPatrolHealthWaitsForLock(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{FailureLimit: 1}},
+	}
+	if _, _, err := d.recordPatrolRun("test_patrol", time.Now(), time.Second, []string{"scan: failed"}); err != nil {
+		t.Fatalf("recordPatrolRun: %v", err)
+	}
+
+	fl, err := lockPatrolHealth(townRoot)
+	if err != nil {
+		t.Fatalf("lockPatrolHealth: %v", err)
+	}
+	done := make(chan bool)
+	go func() {
+		tripped, _ := ResetPatrolHealth(townRoot, "test_patrol")
+		done <- tripped
+	}()
+	select {
+	case <-done:
+		t.Fatal("ResetPatrolHealth finished while the patrol health lock was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	_ = fl.Unlock()
+	if tripped := <-done; !tripped {
+		t.Error("ResetPatrolHealth should report the tripped patrol once the lock is released")
+	}
+}
+
+func TestPatrolFailureLimit(t *testing.T) {
+	if got := PatrolFailureLimit(nil); got != defaultPatrolFailureLimit {
+		t.Errorf("default limit = %d, want %d", got, defaultPatrolFailureLimit)
+	}
+	off := &DaemonPatrolConfig{Patrols: &PatrolsConfig{FailureLimit: -1}}
+	if got := PatrolFailureLimit(off); got != 0 {
+		t.Errorf("negative limit = %d, want 0 (off)", got)
+	}
+}
+
+func TestLoadPatrolHealthMissing(t *testing.T) {
+	health, err := LoadPatrolHealth(t.TempDir())
+	if err != nil || len(health) != 0 {
+		t.Errorf("LoadPatrolHealth with no file = %v, %v; want empty map", health, err)
+	}
+}
+
+func TestPatrolIntervalCoversEveryPatrol(t *testing.T) {
+	for _, name := range PatrolRunNames() {
+		if PatrolInterval(nil, name) <= 0 {
+			t.Errorf("PatrolInterval(%s) has no default interval", name)
+		}
+	}
+	if got := PatrolInterval(nil, "no_such_patrol"); got != 0 {
+		t.Errorf("unknown patrol interval = %v, want 0", got)
+	}
+	if got := PatrolInterval(nil, "wisp_reaper"); got != time.Hour {
+		t.Errorf("wisp_reaper interval = %v, want 1h", got)
+	}
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// patrolStartJitter returns a random delay in [0, interval/4) applied before a
+// patrol's ticker starts. Without it every patrol started with the daemon
+// ticks in lockstep, so the backup and reaper open their Dolt connections in
+// the same second.
+func patrolStartJitter(interval time.Duration) time.Duration {
+	window := interval / 4
+	if window <= 0 {
+		return 0
+	}
+	return rand.N(window)
+}
+
+// newPatrolTicker returns a ticker for the named patrol whose ticks start
+// after a random patrolStartJitter offset and then arrive exactly interval
+// apart. The interval is re-read from the current config when the offset
+// elapses, so a reload during the offset is not lost. The returned stop func
+// stops both the pending start and the ticker.
+func (d *Daemon) newPatrolTicker(name string, intervalFn func(*DaemonPatrolConfig) time.Duration) (*time.Ticker, func()) {
+	interval := intervalFn(d.currentPatrolConfig())
+	jitter := patrolStartJitter(interval)
+
+	ticker := time.NewTicker(interval)
+	if jitter <= 0 {
+		return ticker, ticker.Stop
+	}
+	ticker.Stop()
+	start := time.AfterFunc(jitter, func() {
+		ticker.Reset(intervalFn(d.currentPatrolConfig()))
+	})
+	d.logger.Printf("%s: first run offset by %v", name, jitter.Round(time.Second))
+	return ticker, func() {
+		start.Stop()
+		ticker.Stop()
+	}
+}
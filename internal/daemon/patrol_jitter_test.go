@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestPatrolStartJitter(t *testing.T) {
+	if got := patrolStartJitter(0); got != 0 {
+		t.Errorf("patrolStartJitter(0) = %v, want 0", got)
+	}
+	if got := patrolStartJitter(3 * time.Nanosecond); got != 0 {
+		t.Errorf("patrolStartJitter(3ns) = %v, want 0", got)
+	}
+
+	interval := 20 * time.Minute
+	for i := 0; i < 1000; i++ {
+		got := patrolStartJitter(interval)
+		if got < 0 || got >= interval/4 {
+			t.Fatalf("patrolStartJitter(%v) = %v, want [0, %v)", interval, got, interval/4)
+		}
+	}
+}
+
+func TestNewPatrolTicker(t *testing.T) {
+	d := &Daemon{
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{},
+	}
+	interval := 40 * time.Millisecond
+	intervalFn := func(*DaemonPatrolConfig) time.Duration { return interval }
+
+	start := time.Now()
+	ticker, stop := d.newPatrolTicker("test_patrol", intervalFn)
+	defer stop()
+
+	first := <-ticker.C
+	second := <-ticker.C
+
+	// First tick: jitter (< interval/4) plus one interval.
+	if got := first.Sub(start); got < interval || got > interval+interval/4+200*time.Millisecond {
+		t.Errorf("first tick after %v, want between %v and ~%v", got, interval, interval+interval/4)
+	}
+	// Steady state is exactly interval; allow scheduler slop.
+	if got := second.Sub(first); got < interval-10*time.Millisecond {
+		t.Errorf("second tick %v after first, want ~%v", got, interval)
+	}
+}
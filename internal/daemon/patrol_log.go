@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// patrolLogs holds the dedicated loggers of patrols configured with their own
+// log_file. Loggers are opened on first use and reopened if a config reload
+// changes the path.
+type patrolLogs struct {
+	mu      sync.Mutex
+	loggers map[string]*patrolLog
+}
+
+type patrolLog struct {
+	path   string
+	writer *lumberjack.Logger
+	logger *log.Logger
+}
+
+// patrolLogger returns the logger a patrol should write to: a dedicated,
+// rotated logfile when logFile is set, otherwise the daemon log. Relative
+// paths are resolved against the daemon log's directory. Every line keeps its
+// "<patrol>:" prefix either way, so the daemon log can still be split by
+// patrol with grep.
+func (d *Daemon) patrolLogger(patrol, logFile string) *log.Logger {
+	if logFile == "" {
+		return d.logger
+	}
+	path := logFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(d.config.LogFile), path)
+	}
+
+	d.patrolLogs.mu.Lock()
+	defer d.patrolLogs.mu.Unlock()
+	if pl, ok := d.patrolLogs.loggers[patrol]; ok {
+		if pl.path == path {
+			return pl.logger
+		}
+		_ = pl.writer.Close()
+		delete(d.patrolLogs.loggers, patrol)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		d.logger.Printf("%s: cannot create log dir for %s, logging here instead: %v", patrol, path, err)
+		return d.logger
+	}
+	// Same rotation policy as daemon.log.
+	w := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    100, // megabytes
+		MaxBackups: 3,
+		MaxAge:     7, // days
+		Compress:   true,
+	}
+	if d.patrolLogs.loggers == nil {
+		d.patrolLogs.loggers = make(map[string]*patrolLog)
+	}
+	pl := &patrolLog{path: path, writer: w, logger: log.New(w, "", log.LstdFlags)}
+	d.patrolLogs.loggers[patrol] = pl
+	d.logger.Printf("%s: logging to %s", patrol, path)
+	return pl.logger
+}
+
+// patrolDebugf logs to logger only when GT_DEBUG is set. It is for routine,
+// expected detail that would otherwise repeat on every patrol cycle.
+func patrolDebugf(logger *log.Logger, format string, args ...interface{}) {
+	if os.Getenv("GT_DEBUG") != "" {
+		logger.Printf(format, args...)
+	}
+}
+
+// closePatrolLogs closes all dedicated patrol logfiles.
+func (d *Daemon) closePatrolLogs() {
+	d.patrolLogs.mu.Lock()
+	defer d.patrolLogs.mu.Unlock()
+	for patrol, pl := range d.patrolLogs.loggers {
+		_ = pl.writer.Close()
+		delete(d.patrolLogs.loggers, patrol)
+	}
+}
+
+// PatrolLogFile returns the dedicated logfile a patrol is configured to write
+// to, resolved the same way as patrolLogger, or "" if the patrol logs to the
+// daemon log. Only wisp_reaper supports a dedicated logfile today.
+func PatrolLogFile(townRoot, patrol string) string {
+	var logFile string
+	if cfg := LoadPatrolConfig(townRoot); cfg != nil && cfg.Patrols != nil {
+		if patrol == "wisp_reaper" && cfg.Patrols.WispReaper != nil {
+			logFile = cfg.Patrols.WispReaper.LogFile
+		}
+	}
+	if logFile == "" || filepath.IsAbs(logFile) {
+		return logFile
+	}
+	return filepath.Join(filepath.Dir(DefaultConfig(townRoot).LogFile), logFile)
+}
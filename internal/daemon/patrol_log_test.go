@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPatrolLogger(t *testing.T) {
+	dir := t.TempDir()
+	d := &Daemon{
+		config: &Config{LogFile: filepath.Join(dir, "daemon.log")},
+		logger: log.New(io.Discard, "", 0),
+	}
+	defer d.closePatrolLogs()
+
+	if got := d.patrolLogger("wisp_reaper", ""); got != d.logger {
+		t.Fatal("patrolLogger with no log_file should return the daemon logger")
+	}
+
+	logger := d.patrolLogger("wisp_reaper", "reaper.log")
+	if logger == d.logger {
+		t.Fatal("patrolLogger with log_file returned the daemon logger")
+	}
+	if again := d.patrolLogger("wisp_reaper", "reaper.log"); again != logger {
+		t.Error("patrolLogger should reuse the open logger for the same path")
+	}
+	logger.Printf("wisp_reaper: hq: reaped 3 stale wisps")
+
+	data, err := os.ReadFile(filepath.Join(dir, "reaper.log"))
+	if err != nil {
+		t.Fatalf("reading reaper.log: %v", err)
+	}
+	if !strings.Contains(string(data), "wisp_reaper: hq: reaped 3 stale wisps") {
+		t.Errorf("reaper.log = %q, want the reaper line", data)
+	}
+
+	// A reload that moves the logfile opens the new path.
+	moved := filepath.Join(dir, "logs", "reaper.log")
+	if got := d.patrolLogger("wisp_reaper", moved); got == logger {
+		t.Error("patrolLogger should reopen when the path changes")
+	} else {
+		got.Printf("wisp_reaper: moved")
+	}
+	if _, err := os.Stat(moved); err != nil {
+		t.Errorf("expected %s to be created: %v", moved, err)
+	}
+}
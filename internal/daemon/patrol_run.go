@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// patrolRunners maps each ticker-driven patrol to the function its ticker
+// calls, so `gt daemon patrol run` can trigger one on demand.
+var patrolRunners = map[string]func(*Daemon){
+	"dolt_remotes":          (*Daemon).pushDoltRemotes,
+	"dolt_backup":           (*Daemon).syncDoltBackups,
+	"jsonl_git_backup":      (*Daemon).syncJsonlGitBackup,
+	"wisp_reaper":           (*Daemon).reapWisps,
+	"doctor_dog":            (*Daemon).runDoctorDog,
+	"compactor_dog":         (*Daemon).runCompactorDog,
+	"checkpoint_dog":        (*Daemon).runCheckpointDog,
+	"scheduled_maintenance": (*Daemon).runScheduledMaintenance,
+	"main_branch_test":      (*Daemon).runMainBranchTests,
+	"quota_dog":             (*Daemon).runQuotaDog,
+}
+
+// PatrolRunNames returns the patrols that can be run on demand, sorted.
+func PatrolRunNames() []string {
+	names := make([]string, 0, len(patrolRunners))
+	for name := range patrolRunners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PatrolRunRequest asks the running daemon to run one patrol once.
+type PatrolRunRequest struct {
+	Patrol      string    `json:"patrol"`
+	Force       bool      `json:"force,omitempty"` // run even if the patrol is disabled
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// PatrolRunResult is the daemon's answer to a PatrolRunRequest.
+type PatrolRunResult struct {
+	Patrol      string    `json:"patrol"`
+	RequestedAt time.Time `json:"requested_at"` // echoes the request, to match it up
+	Ran         bool      `json:"ran"`
+	Skipped     string    `json:"skipped,omitempty"` // why it did not run
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+}
+
+// PatrolRunRequestFile returns the path of the pending patrol run request.
+func PatrolRunRequestFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "patrol-run.json")
+}
+
+// PatrolRunResultFile returns the path of the last patrol run result.
+func PatrolRunResultFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "patrol-run-result.json")
+}
+
+// WritePatrolRunRequest records a request for the daemon to pick up on its
+// next lifecycle signal.
+func WritePatrolRunRequest(townRoot string, req *PatrolRunRequest) error {
+	if _, ok := patrolRunners[req.Patrol]; !ok {
+		return fmt.Errorf("unknown patrol %q", req.Patrol)
+	}
+	path := PatrolRunRequestFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return atomicfile.WriteJSON(path, req)
+}
+
+// LoadPatrolRunResult reads the last patrol run result, or nil if there is none.
+func LoadPatrolRunResult(townRoot string) (*PatrolRunResult, error) {
+	data, err := os.ReadFile(PatrolRunResultFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var result PatrolRunResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// processPatrolRunRequest runs the pending patrol run request, if any, and
+// writes its result. Called from the main loop, so the patrol never overlaps
+// its own ticker.
+func (d *Daemon) processPatrolRunRequest() {
+	path := PatrolRunRequestFile(d.config.TownRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	_ = os.Remove(path)
+
+	var req PatrolRunRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		d.logger.Printf("patrol_run: invalid request: %v", err)
+		return
+	}
+	result := d.runPatrolOnce(&req)
+	if err := atomicfile.WriteJSON(PatrolRunResultFile(d.config.TownRoot), result); err != nil {
+		d.logger.Printf("patrol_run: writing result: %v", err)
+	}
+}
+
+// patrolConfigured reports whether config has the patrols section that
+// patrol's runner reads. The on-demand patrols are all opt-in and dereference
+// their section, so --force may skip the enabled gate but not a missing
+// section. Patrols without a section of their own are always configured.
+func patrolConfigured(config *DaemonPatrolConfig, patrol string) bool {
+	var p *PatrolsConfig
+	if config != nil {
+		p = config.Patrols
+	}
+	switch patrol {
+	case "dolt_remotes":
+		return p != nil && p.DoltRemotes != nil
+	case "dolt_backup":
+		return p != nil && p.DoltBackup != nil
+	case "jsonl_git_backup":
+		return p != nil && p.JsonlGitBackup != nil
+	case "wisp_reaper":
+		return p != nil && p.WispReaper != nil
+	case "doctor_dog":
+		return p != nil && p.DoctorDog != nil
+	case "compactor_dog":
+		return p != nil && p.CompactorDog != nil
+	case "checkpoint_dog":
+		return p != nil && p.CheckpointDog != nil
+	case "scheduled_maintenance":
+		return p != nil && p.ScheduledMaintenance != nil
+	case "main_branch_test":
+		return p != nil && p.MainBranchTest != nil
+	case "quota_dog":
+		return p != nil && p.QuotaDog != nil
+	}
+	return true
+}
+
+// runPatrolOnce runs req.Patrol once, honoring its enabled gate unless
+// req.Force is set.
+func (d *Daemon) runPatrolOnce(req *PatrolRunRequest) *PatrolRunResult {
+	result := &PatrolRunResult{Patrol: req.Patrol, RequestedAt: req.RequestedAt}
+	_, ok := patrolRunners[req.Patrol]
+	switch {
+	case !ok:
+		result.Skipped = "unknown patrol"
+	case d.isShutdownInProgress():
+		result.Skipped = "shutdown in progress"
+	case req.Force && !patrolConfigured(d.currentPatrolConfig(), req.Patrol):
+		result.Skipped = "patrol has no config in mayor/daemon.json (--force cannot run it without one)"
+	case !req.Force && !d.isPatrolActive(req.Patrol):
+		result.Skipped = "patrol is disabled (use --force to run anyway)"
+	case !req.Force && d.isPatrolTripped(req.Patrol):
+		result.Skipped = "patrol was disabled after repeated failures (use --force to run anyway, or 'gt daemon patrol reset')"
+	}
+	if result.Skipped != "" {
+		d.logger.Printf("patrol_run: %s: skipped: %s", req.Patrol, result.Skipped)
+		return result
+	}
+
+	if req.Force {
+		d.forcedPatrol = req.Patrol
+		defer func() { d.forcedPatrol = "" }()
+	}
+	d.logger.Printf("patrol_run: %s: running on demand (force=%v)", req.Patrol, req.Force)
+	result.StartedAt = time.Now()
+	d.runPatrol(req.Patrol)
+	result.FinishedAt = time.Now()
+	result.Ran = true
+	d.logger.Printf("patrol_run: %s: finished in %v", req.Patrol, result.FinishedAt.Sub(result.StartedAt).Round(time.Millisecond))
+	return result
+}
@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRunPatrolOnce(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{},
+	}
+
+	var runs int
+	var activeDuringRun bool
+	patrolRunners["test_patrol"] = func(d *Daemon) {
+		runs++
+		activeDuringRun = d.isPatrolActive("test_patrol")
+	}
+	t.Cleanup(func() { delete(patrolRunners, "test_patrol") })
+
+	// IsPatrolEnabled defaults unknown patrols to enabled; disable it explicitly.
+	d.disabledPatrols = map[string]bool{"test_patrol": true}
+
+	result := d.runPatrolOnce(&PatrolRunRequest{Patrol: "test_patrol"})
+	if result.Ran || result.Skipped == "" || runs != 0 {
+		t.Fatalf("disabled patrol without force: ran=%v skipped=%q runs=%d", result.Ran, result.Skipped, runs)
+	}
+
+	result = d.runPatrolOnce(&PatrolRunRequest{Patrol: "test_patrol", Force: true})
+	if !result.Ran || runs != 1 {
+		t.Fatalf("forced run: ran=%v runs=%d, want ran once", result.Ran, runs)
+	}
+	if !activeDuringRun {
+		t.Error("isPatrolActive should be true during a forced run")
+	}
+	if d.isPatrolActive("test_patrol") {
+		t.Error("force should not outlive the run")
+	}
+
+	if result := d.runPatrolOnce(&PatrolRunRequest{Patrol: "no_such_patrol", Force: true}); result.Ran {
+		t.Error("unknown patrol should not run")
+	}
+}
+
+func TestRunPatrolOnceForceWithoutConfig(t *testing.T) {
+	// No daemon.json: the patrol config is nil.
+	d := &Daemon{
+		config: &Config{TownRoot: t.TempDir()},
+		logger: log.New(io.Discard, "", 0),
+	}
+	result := d.runPatrolOnce(&PatrolRunRequest{Patrol: "wisp_reaper", Force: true})
+	if result.Ran || result.Skipped == "" {
+		t.Fatalf("forced wisp_reaper with no daemon.json: ran=%v skipped=%q, want skipped", result.Ran, result.Skipped)
+	}
+
+	// daemon.json without a wisp_reaper section.
+	d.patrolConfig = &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	result = d.runPatrolOnce(&PatrolRunRequest{Patrol: "wisp_reaper", Force: true})
+	if result.Ran || result.Skipped == "" {
+		t.Fatalf("forced wisp_reaper with no section: ran=%v skipped=%q, want skipped", result.Ran, result.Skipped)
+	}
+
+	for _, name := range PatrolRunNames() {
+		if patrolConfigured(nil, name) {
+			t.Errorf("patrolConfigured(nil, %q) = true, want false", name)
+		}
+	}
+}
+
+func TestPatrolRunRequestRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := WritePatrolRunRequest(townRoot, &PatrolRunRequest{Patrol: "janitor_dog"}); err == nil {
+		t.Error("expected an error for an unknown patrol")
+	}
+
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{},
+	}
+	// wisp_reaper is opt-in, so without --force it is skipped and nothing runs.
+	req := &PatrolRunRequest{Patrol: "wisp_reaper", RequestedAt: time.Now()}
+	if err := WritePatrolRunRequest(townRoot, req); err != nil {
+		t.Fatalf("WritePatrolRunRequest: %v", err)
+	}
+	d.processPatrolRunRequest()
+
+	if _, err := os.Stat(PatrolRunRequestFile(townRoot)); !os.IsNotExist(err) {
+		t.Error("request file should be consumed")
+	}
+	result, err := LoadPatrolRunResult(townRoot)
+	if err != nil || result == nil {
+		t.Fatalf("LoadPatrolRunResult = %v, %v", result, err)
+	}
+	if result.Patrol != "wisp_reaper" || !result.RequestedAt.Equal(req.RequestedAt) || result.Ran {
+		t.Errorf("result = %+v, want a skipped wisp_reaper run for the request", result)
+	}
+}
+
+func TestPatrolRunNames(t *testing.T) {
+	names := PatrolRunNames()
+	if !slices.IsSorted(names) {
+		t.Errorf("PatrolRunNames not sorted: %v", names)
+	}
+	for _, want := range []string{"wisp_reaper", "dolt_backup", "doctor_dog"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("PatrolRunNames missing %s", want)
+		}
+	}
+}
@@ -149,7 +149,7 @@ func (d *Daemon) runScheduledMaintenance() {
 		return
 	}
 
-	window := maintenanceWindow(d.patrolConfig)
+	window := maintenanceWindow(d.currentPatrolConfig())
 	if window == "" {
 		d.logger.Printf("scheduled_maintenance: no window configured, skipping")
 		return
@@ -163,7 +163,7 @@ func (d *Daemon) runScheduledMaintenance() {
 	}
 
 	// Check if we already ran recently (respect interval).
-	interval := maintenanceInterval(d.patrolConfig)
+	interval := maintenanceInterval(d.currentPatrolConfig())
 	if !shouldRunMaintenance(now, d.lastMaintenanceRun, interval) {
 		return // Already ran this window
 	}
@@ -171,7 +171,7 @@ func (d *Daemon) runScheduledMaintenance() {
 	d.logger.Printf("scheduled_maintenance: in window %s, checking commit counts", window)
 
 	// Check if any database exceeds the threshold.
-	threshold := maintenanceThreshold(d.patrolConfig)
+	threshold := maintenanceThreshold(d.currentPatrolConfig())
 	databases := d.compactorDatabases() // Reuse the same DB discovery
 	if len(databases) == 0 {
 		d.logger.Printf("scheduled_maintenance: no databases found")
@@ -13,6 +13,7 @@ func daemonSignals() []os.Signal {
 		syscall.SIGTERM,
 		syscall.SIGUSR1,
 		syscall.SIGUSR2,
+		syscall.SIGHUP,
 	}
 }
 
@@ -23,3 +24,7 @@ func isLifecycleSignal(sig os.Signal) bool {
 func isReloadRestartSignal(sig os.Signal) bool {
 	return sig == syscall.SIGUSR2
 }
+
+func isReloadConfigSignal(sig os.Signal) bool {
+	return sig == syscall.SIGHUP
+}
@@ -10,6 +10,7 @@ package daemon
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/atomicfile"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/reaper"
 )
 
 // Config holds daemon configuration.
@@ -131,6 +133,11 @@ type PatrolsConfig struct {
 	MainBranchTest         *MainBranchTestConfig          `json:"main_branch_test,omitempty"`
 	QuotaDog               *QuotaDogConfig                `json:"quota_dog,omitempty"`
 	RestartTracker         *RestartTrackerConfig          `json:"restart_tracker,omitempty"`
+
+	// FailureLimit disables a patrol after this many consecutive failed runs,
+	// until 'gt daemon patrol reset' or a config reload (default 5; negative
+	// turns the circuit breaker off).
+	FailureLimit int `json:"failure_limit,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -165,6 +172,30 @@ type DoltBackupConfig struct {
 	// Databases lists specific database names to back up.
 	// If empty, auto-discovers databases with configured backup remotes.
 	Databases []string `json:"databases,omitempty"`
+
+	// MinIntervalStr skips a database backed up more recently than this
+	// (e.g. by 'gt dolt backup sync'), as a string (default "1m").
+	MinIntervalStr string `json:"min_interval,omitempty"`
+
+	// DryRun logs which databases would sync, to which backups and offsite
+	// path, without running dolt backup sync. Use it to check a new host's
+	// configuration before enabling real backups.
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// Sync timeouts scale with the database's size on disk: TimeoutPerGBStr
+	// per GB (default "2m"), clamped to [MinTimeoutStr, MaxTimeoutStr]
+	// (default "5m" and "1h"). A sync that hits its timeout is aborted and
+	// reported as a possibly incomplete backup, not retried.
+	TimeoutPerGBStr string `json:"timeout_per_gb,omitempty"`
+	MinTimeoutStr   string `json:"min_timeout,omitempty"`
+	MaxTimeoutStr   string `json:"max_timeout,omitempty"`
+
+	// VerifyIntervalStr is how often each backup is restored and
+	// sanity-checked, as a string (default "24h"); "off" disables
+	// verification. VerifyTimeoutStr bounds all verification in one cycle
+	// (default "30m"); backups not reached are verified on a later cycle.
+	VerifyIntervalStr string `json:"verify_interval,omitempty"`
+	VerifyTimeoutStr  string `json:"verify_timeout,omitempty"`
 }
 
 // JsonlGitBackupConfig holds configuration for the jsonl_git_backup patrol.
@@ -214,19 +245,67 @@ func PatrolConfigFile(townRoot string) string {
 // LoadPatrolConfig loads patrol configuration from mayor/daemon.json.
 // Returns nil if the file doesn't exist or can't be parsed.
 func LoadPatrolConfig(townRoot string) *DaemonPatrolConfig {
-	configFile := PatrolConfigFile(townRoot)
-	data, err := os.ReadFile(configFile)
+	config, err := ReadPatrolConfig(townRoot)
 	if err != nil {
+		if !os.IsNotExist(err) {
+			// Log parse errors to help debug config issues (was previously silent).
+			fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+		}
 		return nil
 	}
+	return config
+}
+
+// ReadPatrolConfig loads patrol configuration from mayor/daemon.json,
+// returning read and parse errors instead of swallowing them.
+// A missing file is reported as an os.IsNotExist error.
+func ReadPatrolConfig(townRoot string) (*DaemonPatrolConfig, error) {
+	configFile := PatrolConfigFile(townRoot)
+	data, err := os.ReadFile(configFile) //nolint:gosec // G304: path constructed internally
+	if err != nil {
+		return nil, err
+	}
 
 	var config DaemonPatrolConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		// Log parse errors to help debug config issues (was previously silent).
-		fmt.Fprintf(os.Stderr, "daemon: failed to parse %s: %v\n", configFile, err)
-		return nil
+		return nil, fmt.Errorf("failed to parse %s: %w", configFile, err)
 	}
-	return &config
+	return &config, nil
+}
+
+// ValidatePatrolConfig checks a loaded patrol config for values the daemon
+// cannot use. Used to reject a bad config on reload rather than swapping it in.
+func ValidatePatrolConfig(config *DaemonPatrolConfig) error {
+	if config == nil {
+		return fmt.Errorf("patrol config is empty")
+	}
+	if config.Type != "" && config.Type != "daemon-patrol-config" {
+		return fmt.Errorf("unexpected patrol config type %q (want %q)", config.Type, "daemon-patrol-config")
+	}
+	if config.Version < 0 {
+		return fmt.Errorf("invalid patrol config version %d", config.Version)
+	}
+	if config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if _, err := reaper.NewSchema(config.Patrols.WispReaper.Schema); err != nil {
+			return fmt.Errorf("patrols.wisp_reaper.schema: %w", err)
+		}
+		if _, err := reaper.ParseHolidays(config.Patrols.WispReaper.Holidays); err != nil {
+			return fmt.Errorf("patrols.wisp_reaper.holidays: %w", err)
+		}
+		switch mode := config.Patrols.WispReaper.DigestMail; mode {
+		case "", reaperDigestCycle, reaperDigestDaily:
+		default:
+			return fmt.Errorf("patrols.wisp_reaper.digest_mail: %q is not %q or %q", mode, reaperDigestCycle, reaperDigestDaily)
+		}
+	}
+	if issues := PatrolConfigDurationIssues(config); len(issues) > 0 {
+		errs := make([]error, len(issues))
+		for i, issue := range issues {
+			errs[i] = issue
+		}
+		return errors.Join(errs...)
+	}
+	return nil
 }
 
 // SavePatrolConfig saves patrol configuration to mayor/daemon.json.
@@ -377,12 +456,16 @@ func loadDisabledPatrolsFromTownSettings(townRoot string) map[string]bool {
 // isPatrolActive checks whether a patrol should run, combining the
 // daemon patrol config (mayor/daemon.json) with the town-level
 // disabled_patrols list (settings/config.json). A patrol is active
-// only if it is enabled in daemon config AND not in the disabled list.
+// only if it is enabled in daemon config AND not in the disabled list,
+// or while it is being run by a forced `gt daemon patrol run`.
 func (d *Daemon) isPatrolActive(patrol string) bool {
+	if d.forcedPatrol != "" && patrol == d.forcedPatrol {
+		return true
+	}
 	if d.disabledPatrols[patrol] {
 		return false
 	}
-	return IsPatrolEnabled(d.patrolConfig, patrol)
+	return IsPatrolEnabled(d.currentPatrolConfig(), patrol)
 }
 
 // LifecycleAction represents a lifecycle request action.
@@ -1,13 +1,21 @@
 package daemon
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log"
+	"net"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/reaper"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -21,6 +29,7 @@ const (
 	defaultWispDeleteAge = 7 * 24 * time.Hour
 	// Alert threshold: if open wisp count exceeds this, the Dog should escalate.
 	// Shared with `gt reaper run` warning. See reaper.DefaultAlertThreshold.
+	// Also the per-type threshold for wisp types without their own.
 	wispAlertThreshold = reaper.DefaultAlertThreshold
 	// Closed mail older than this is permanently deleted. Formula var: mail_delete_age.
 	defaultMailDeleteAge = 7 * 24 * time.Hour
@@ -36,6 +45,224 @@ type WispReaperConfig struct {
 	MaxAgeStr    string   `json:"max_age,omitempty"`
 	DeleteAgeStr string   `json:"delete_age,omitempty"`
 	Databases    []string `json:"databases,omitempty"`
+	// CloseBatchSize is how many stale wisps are closed per UPDATE
+	// (default reaper.DefaultBatchSize). Reaps spanning several batches log
+	// progress after each one.
+	CloseBatchSize int `json:"close_batch_size,omitempty"`
+	// Endpoints lists Dolt servers to reap when databases are sharded across
+	// several instances. When empty, the daemon's own Dolt server is reaped
+	// using Databases (or discovery).
+	Endpoints []DoltEndpointConfig `json:"endpoints,omitempty"`
+	// MaxCycleDurationStr caps how long one inline reap cycle may run
+	// (e.g. "20m"). Once spent, remaining databases and phases are deferred
+	// to the next cycle, which starts with the first deferred database.
+	// Empty means no budget.
+	MaxCycleDurationStr string `json:"max_cycle_duration,omitempty"`
+	// SkipDatabases are never reaped, in addition to reaper.SystemDatabases.
+	// Applied after discovery and Databases, so nothing can re-add them.
+	SkipDatabases []string `json:"skip_databases,omitempty"`
+	// LogFile routes the reaper's per-database output to its own rotated
+	// logfile instead of daemon.log (relative paths are under the daemon
+	// dir). The cycle summary and threshold warnings still go to daemon.log.
+	LogFile string `json:"log_file,omitempty"`
+	// AutoCloseSince and AutoCloseUntil (YYYY-MM-DD or RFC 3339) limit
+	// auto-close to stale issues last updated in [since, until), for
+	// backfilling an old town a window at a time. Empty means open-ended.
+	AutoCloseSince string `json:"auto_close_since,omitempty"`
+	AutoCloseUntil string `json:"auto_close_until,omitempty"`
+	// BusinessDaysOnly ages issues for auto-close by weekday time only, so
+	// weekends and Holidays (YYYY-MM-DD, UTC) do not count toward the stale
+	// age. See reaper.AutoCloseOptions.
+	BusinessDaysOnly bool     `json:"business_days_only,omitempty"`
+	Holidays         []string `json:"holidays,omitempty"`
+	// Schema renames the tables and columns the reaper queries, keyed by
+	// their default beads name (e.g. {"issues": "bd_issues", "status":
+	// "state"}), for forks with a differently named schema. See
+	// reaper.NewSchema for the names that can be mapped.
+	Schema map[string]string `json:"schema,omitempty"`
+	// StaleMailAgeStr enables closing open mail with no updates for this
+	// long (e.g. "720h"), so unread messages to agents that are gone age
+	// into the closed-mail purge. Empty leaves open mail alone.
+	StaleMailAgeStr string `json:"stale_mail_age,omitempty"`
+	// AlertThreshold is the open-wisp count per wisp type above which the
+	// reaper warns (default reaper.DefaultAlertThreshold). AlertThresholds
+	// overrides it for individual types, e.g. {"patrol": 5000, "mail": 50};
+	// per-type thresholds are checked inline, so setting them keeps cycles
+	// inline.
+	AlertThreshold  int            `json:"alert_threshold,omitempty"`
+	AlertThresholds map[string]int `json:"alert_thresholds,omitempty"`
+	// DigestMail mails the mayor a summary of what the reaper did:
+	// per-database reaped, purged, mail-purged and auto-closed counts, plus
+	// threshold alerts and errors. "cycle" sends one after every cycle,
+	// "daily" rolls cycles up into one mail a day. Empty sends none. The
+	// counts come from the inline reaper, so setting it keeps cycles inline.
+	DigestMail string `json:"digest_mail,omitempty"`
+	// Tombstone makes the purge step keep closed wisps and mail past
+	// delete_age as stub rows (status "purged", description emptied, aux
+	// rows removed) instead of deleting them, for integrations that break
+	// when an ID vanishes. See reaper.PurgeOptions.
+	Tombstone bool `json:"tombstone,omitempty"`
+	// KeepRecentPerType spares the N most recently closed wisps of each
+	// wisp_type from the purge even past delete_age, so a rolling sample of
+	// every type stays around for reference. See
+	// reaper.PurgeOptions.KeepRecentPerType.
+	KeepRecentPerType int `json:"keep_recent_per_type,omitempty"`
+	// PurgeExcludeTypes are wisp types the purge never touches, whatever
+	// their age, e.g. ["audit"] while first rolling purging out. Their
+	// closed wisps are reported as retained (excluded type) in the log and
+	// digest. See reaper.PurgeOptions.ExcludeTypes.
+	PurgeExcludeTypes []string `json:"purge_exclude_types,omitempty"`
+	// PurgeDeferRecentEventsStr defers purging closed wisps that have
+	// wisp_events activity within this long (e.g. "72h"), since recent
+	// activity may still reference them. Empty defers nothing. See
+	// reaper.PurgeOptions.RecentEventWindow.
+	PurgeDeferRecentEventsStr string `json:"purge_defer_recent_events,omitempty"`
+	// PurgeBatchConcurrency is how many closed-wisp delete batches the
+	// purge runs at once within one database (default 1, one at a time).
+	// Databases are still purged one after another. The log reports the
+	// measured speedup. See reaper.PurgeOptions.BatchConcurrency.
+	PurgeBatchConcurrency int `json:"purge_batch_concurrency,omitempty"`
+	// ReportOnly keeps the reaper in standing report mode: every cycle runs
+	// the scans, digest and threshold alerts but no UPDATE or DELETE, and
+	// each step closes as "reported". Unlike DryRun it is meant to be left
+	// on while watching trends before enabling real reaping. It always runs
+	// inline so the daemon owns the step outcomes.
+	ReportOnly bool `json:"report_only,omitempty"`
+	// Connection overrides the reaper's Dolt connection parameters (user,
+	// password env var, tls, charset, collation, timeouts). Unset fields keep
+	// the defaults. See reaper.DoltConnConfig.
+	Connection *reaper.DoltConnConfig `json:"connection,omitempty"`
+	// FirstRunDeleteLimit guards a town the reaper has never purged: if the
+	// first purge would delete more rows than this (default 10000, negative
+	// disables), the counts are logged, an alert is raised and the purge is
+	// skipped until FirstRunAcknowledged is set. Once a purge has run, the
+	// guard no longer applies.
+	FirstRunDeleteLimit  int  `json:"first_run_delete_limit,omitempty"`
+	FirstRunAcknowledged bool `json:"first_run_acknowledged,omitempty"`
+	// Phases toggles and schedules the reap, purge and auto-close phases
+	// independently, e.g. {"auto-close": {"interval": "24h"}, "purge":
+	// {"interval": "168h"}} with a 24h patrol interval. See
+	// ReaperPhaseConfig. Towns with a phase schedule always reap inline.
+	Phases map[string]ReaperPhaseConfig `json:"phases,omitempty"`
+}
+
+// DoltEndpointConfig is one Dolt server the wisp reaper connects to.
+type DoltEndpointConfig struct {
+	// Address is the server's host:port (e.g. "10.0.0.5:3307").
+	Address string `json:"address"`
+	// Databases limits reaping to these databases; empty means discover
+	// them with SHOW DATABASES on this endpoint.
+	Databases []string `json:"databases,omitempty"`
+}
+
+// reaperTarget is one database on one Dolt server.
+type reaperTarget struct {
+	host   string
+	port   int
+	dbName string
+	label  string // dbName, or host:port/dbName when endpoints are configured
+}
+
+// wispReaperTargets resolves the databases to reap and the server each one
+// lives on. Without endpoints this is the local Dolt server, as before.
+// Endpoints with an unparseable address are logged and skipped.
+func (d *Daemon) wispReaperTargets(ctx context.Context, config *WispReaperConfig) []reaperTarget {
+	logger := d.wispReaperLogger(config)
+	if len(config.Endpoints) == 0 {
+		host, port := "127.0.0.1", d.doltServerPort()
+		databases := d.wispReaperDatabases(ctx, config)
+		targets := make([]reaperTarget, 0, len(databases))
+		for _, dbName := range databases {
+			targets = append(targets, reaperTarget{host: host, port: port, dbName: dbName, label: dbName})
+		}
+		return targets
+	}
+
+	var targets []reaperTarget
+	for _, ep := range config.Endpoints {
+		host, portStr, err := net.SplitHostPort(ep.Address)
+		port, portErr := strconv.Atoi(portStr)
+		if err != nil || portErr != nil || port <= 0 {
+			logger.Printf("wisp_reaper: skipping endpoint %q: want host:port", ep.Address)
+			continue
+		}
+		databases := ep.Databases
+		if len(databases) == 0 {
+			databases = reaper.DiscoverDatabases(ctx, host, port)
+		}
+		databases = d.excludeReaperDatabases(config, databases)
+		for _, dbName := range databases {
+			targets = append(targets, reaperTarget{host: host, port: port, dbName: dbName, label: ep.Address + "/" + dbName})
+		}
+	}
+	return targets
+}
+
+// wispReaperLogger returns the logger for reaper output: config.LogFile when
+// set, otherwise the daemon log.
+func (d *Daemon) wispReaperLogger(config *WispReaperConfig) *log.Logger {
+	return d.patrolLogger("wisp_reaper", config.LogFile)
+}
+
+// wispReaperDatabases returns the databases to reap on the local Dolt server:
+// config.Databases, or discovery when unset, minus excluded databases.
+func (d *Daemon) wispReaperDatabases(ctx context.Context, config *WispReaperConfig) []string {
+	databases := config.Databases
+	if len(databases) == 0 {
+		databases = reaper.DiscoverDatabases(ctx, "127.0.0.1", d.doltServerPort())
+	}
+	return d.excludeReaperDatabases(config, databases)
+}
+
+// excludeReaperDatabases drops system databases and config.SkipDatabases
+// from databases, logging whatever it drops at debug level (GT_DEBUG): the
+// same databases are dropped every cycle.
+func (d *Daemon) excludeReaperDatabases(config *WispReaperConfig, databases []string) []string {
+	logger := d.wispReaperLogger(config)
+	var kept, skipped []string
+	for _, name := range databases {
+		if reaper.IsSystemDatabase(name) || containsFold(config.SkipDatabases, name) {
+			skipped = append(skipped, name)
+			continue
+		}
+		kept = append(kept, name)
+	}
+	if len(skipped) > 0 {
+		patrolDebugf(logger, "wisp_reaper: skipping excluded databases: %s", strings.Join(skipped, ", "))
+	}
+	return kept
+}
+
+// skipMaintenanceTargets drops databases marked as under maintenance
+// ('gt dolt maintenance on'), logging each skip. Markers are keyed by
+// database name, so a marker covers that database on every endpoint.
+func (d *Daemon) skipMaintenanceTargets(targets []reaperTarget, logger *log.Logger) []reaperTarget {
+	kept := targets[:0:0]
+	for _, t := range targets {
+		marker, err := doltserver.LoadMaintenance(d.config.TownRoot, t.dbName)
+		if marker == nil {
+			kept = append(kept, t)
+			continue
+		}
+		reason := marker.Reason
+		if err != nil {
+			reason = fmt.Sprintf("unreadable marker: %v", err)
+		}
+		if reason == "" {
+			reason = "no reason given"
+		}
+		logger.Printf("wisp_reaper: %s: skipped, under maintenance (%s)", t.label, reason)
+	}
+	return kept
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
 }
 
 // wispReaperInterval returns the configured interval, or the default (1h).
@@ -74,6 +301,151 @@ func wispDeleteAge(config *DaemonPatrolConfig) time.Duration {
 	return defaultWispDeleteAge
 }
 
+// wispReaperStaleMailAge returns the configured stale mail age, or 0 (stale
+// mail closing disabled) when unset or invalid.
+func wispReaperStaleMailAge(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if config.Patrols.WispReaper.StaleMailAgeStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.WispReaper.StaleMailAgeStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return 0
+}
+
+// wispReaperDeferRecentEvents returns the purge's recent-event window from
+// purge_defer_recent_events, or 0 when unset. An invalid value is an error
+// rather than 0: purging without the window would delete the very wisps the
+// setting was meant to keep.
+func wispReaperDeferRecentEvents(config *WispReaperConfig) (time.Duration, error) {
+	if config == nil || config.PurgeDeferRecentEventsStr == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(config.PurgeDeferRecentEventsStr)
+	if err != nil {
+		return 0, fmt.Errorf("purge_defer_recent_events: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("purge_defer_recent_events: %q must be positive", config.PurgeDeferRecentEventsStr)
+	}
+	return d, nil
+}
+
+// wispReaperAlertThreshold returns the open-wisp alert threshold for
+// wispType: its own from alert_thresholds, else alert_threshold, else
+// wispAlertThreshold. Non-positive values count as unset.
+func wispReaperAlertThreshold(config *DaemonPatrolConfig, wispType string) int {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		c := config.Patrols.WispReaper
+		if n := c.AlertThresholds[wispType]; n > 0 {
+			return n
+		}
+		if c.AlertThreshold > 0 {
+			return c.AlertThreshold
+		}
+	}
+	return wispAlertThreshold
+}
+
+// wispAlertBreaches returns a warning for each wisp type whose open count
+// exceeds its alert threshold, sorted by type.
+func wispAlertBreaches(config *DaemonPatrolConfig, openByType map[string]int) []string {
+	types := make([]string, 0, len(openByType))
+	for wtype := range openByType {
+		types = append(types, wtype)
+	}
+	sort.Strings(types)
+	var warnings []string
+	for _, wtype := range types {
+		if threshold := wispReaperAlertThreshold(config, wtype); openByType[wtype] > threshold {
+			warnings = append(warnings, fmt.Sprintf("wisp_reaper: WARNING: %d open %s wisps exceed threshold %d — investigate wisp lifecycle",
+				openByType[wtype], wtype, threshold))
+		}
+	}
+	return warnings
+}
+
+// validatePurgeExcludeTypes checks purge_exclude_types before any purge runs.
+func validatePurgeExcludeTypes(types []string) error {
+	for _, wtype := range types {
+		if err := reaper.ValidateWispType(wtype); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatTypeCounts renders per-type counts as "type=n" pairs sorted by type.
+func formatTypeCounts(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for wtype := range counts {
+		types = append(types, wtype)
+	}
+	sort.Strings(types)
+	parts := make([]string, len(types))
+	for i, wtype := range types {
+		parts[i] = fmt.Sprintf("%s=%d", wtype, counts[wtype])
+	}
+	return strings.Join(parts, " ")
+}
+
+// wispReaperMaxCycleDuration returns the configured cycle budget, or 0 (no
+// budget) when unset or invalid.
+func wispReaperMaxCycleDuration(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if config.Patrols.WispReaper.MaxCycleDurationStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.WispReaper.MaxCycleDurationStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return 0
+}
+
+// reaperCycleBudget tracks elapsed time against the wisp reaper's
+// max_cycle_duration. Once exhausted it stays exhausted for the rest of the
+// cycle, so every later phase is skipped too.
+type reaperCycleBudget struct {
+	limit    time.Duration
+	start    time.Time
+	now      func() time.Time
+	deferred string          // label of the first target skipped
+	phases   map[string]bool // phases cut short or skipped entirely
+}
+
+func newReaperCycleBudget(limit time.Duration) *reaperCycleBudget {
+	return &reaperCycleBudget{limit: limit, start: time.Now(), now: time.Now, phases: map[string]bool{}}
+}
+
+// exhausted reports whether phase must stop before processing t. The first
+// call that finds the budget spent records t as the resume point.
+func (b *reaperCycleBudget) exhausted(phase string, t reaperTarget) bool {
+	if b.limit <= 0 {
+		return false
+	}
+	if b.deferred == "" {
+		if b.now().Sub(b.start) < b.limit {
+			return false
+		}
+		b.deferred = t.label
+	}
+	b.phases[phase] = true
+	return true
+}
+
+// rotateReaperTargets returns targets starting at the one labelled resumeAt,
+// so a cycle that was cut short is continued before anything is repeated.
+// Targets are returned unchanged when resumeAt is empty or unknown.
+func rotateReaperTargets(targets []reaperTarget, resumeAt string) []reaperTarget {
+	for i, t := range targets {
+		if t.label == resumeAt {
+			return append(append([]reaperTarget{}, targets[i:]...), targets[:i]...)
+		}
+	}
+	return targets
+}
+
 // reapWisps is the thin orchestrator for the wisp_reaper patrol.
 // It pours a mol-dog-reaper molecule, then dispatches a Dog to execute it.
 // The Dog reads the formula steps and calls `gt reaper` CLI helpers.
@@ -83,42 +455,120 @@ func (d *Daemon) reapWisps() {
 		return
 	}
 
-	config := d.patrolConfig.Patrols.WispReaper
-	maxAge := wispReaperMaxAge(d.patrolConfig)
-	deleteAge := wispDeleteAge(d.patrolConfig)
+	config := d.currentPatrolConfig().Patrols.WispReaper
+	logger := d.wispReaperLogger(config)
+	maxAge := wispReaperMaxAge(d.currentPatrolConfig())
+	deleteAge := wispDeleteAge(d.currentPatrolConfig())
 
 	vars := map[string]string{
 		"max_age":         maxAge.String(),
 		"purge_age":       deleteAge.String(),
 		"stale_issue_age": defaultStaleIssueAge.String(),
 		"mail_delete_age": defaultMailDeleteAge.String(),
-		"alert_threshold": fmt.Sprintf("%d", wispAlertThreshold),
+		"alert_threshold": fmt.Sprintf("%d", wispReaperAlertThreshold(d.currentPatrolConfig(), "")),
 		"dolt_port":       fmt.Sprintf("%d", d.doltServerPort()),
 	}
 
-	if config.DryRun {
+	if config.DryRun || config.ReportOnly {
 		vars["dry_run"] = "true"
 	}
-	if len(config.Databases) > 0 {
-		vars["databases"] = strings.Join(config.Databases, ",")
+	if staleMailAge := wispReaperStaleMailAge(d.currentPatrolConfig()); staleMailAge > 0 {
+		vars["stale_mail_age"] = staleMailAge.String()
+	}
+	if config.Tombstone {
+		vars["tombstone"] = "true"
+	}
+	if config.KeepRecentPerType > 0 {
+		vars["keep_recent_per_type"] = fmt.Sprintf("%d", config.KeepRecentPerType)
+	}
+	if len(config.PurgeExcludeTypes) > 0 {
+		vars["exclude_types"] = strings.Join(config.PurgeExcludeTypes, ",")
+	}
+	if window, _ := wispReaperDeferRecentEvents(config); window > 0 {
+		vars["defer_recent_events"] = window.String()
+	}
+	if config.PurgeBatchConcurrency > 1 {
+		vars["batch_concurrency"] = fmt.Sprintf("%d", config.PurgeBatchConcurrency)
+	}
+	if config.BusinessDaysOnly {
+		vars["business_days"] = "true"
+		vars["holidays"] = strings.Join(config.Holidays, ",")
+	}
+	// Resolve the list here whenever something must be excluded, so the Dog
+	// never falls back to its own unfiltered discovery.
+	if len(config.Databases) > 0 || len(config.SkipDatabases) > 0 {
+		ctx, err := wispReaperContext(config)
+		if err != nil {
+			logger.Printf("wisp_reaper: %v", err)
+			return
+		}
+		databases := d.wispReaperDatabases(ctx, config)
+		if len(databases) == 0 {
+			logger.Printf("wisp_reaper: no databases left to reap after exclusions")
+			return
+		}
+		vars["databases"] = strings.Join(databases, ",")
 	}
 
 	// Pour the molecule for observability tracking.
 	mol := d.pourDogMolecule(constants.MolDogReaper, vars)
 	defer mol.close()
 
-	if config.DryRun {
-		d.logger.Printf("wisp_reaper: DRY RUN — reporting only, no changes will be made")
+	if config.DryRun && !config.ReportOnly {
+		logger.Printf("wisp_reaper: DRY RUN — reporting only, no changes will be made")
+	}
+	if reason := d.wispReaperInlineReason(config); reason != "" {
+		logger.Printf("wisp_reaper: %s, running inline", reason)
+		d.reapWispsInline(config, maxAge, deleteAge, mol)
+		return
 	}
 
 	// Try dispatching to a Dog for formula-driven execution.
 	if err := d.dispatchReaperDog(vars); err != nil {
-		d.logger.Printf("wisp_reaper: Dog dispatch failed (%v), running inline fallback", err)
+		logger.Printf("wisp_reaper: Dog dispatch failed (%v), running inline fallback", err)
 		d.reapWispsInline(config, maxAge, deleteAge, mol)
 		return
 	}
 
-	d.logger.Printf("wisp_reaper: dispatched to Dog for formula-driven execution")
+	logger.Printf("wisp_reaper: dispatched to Dog for formula-driven execution")
+}
+
+// wispReaperInlineReason returns why this cycle must run inline rather than
+// through the mol-dog-reaper formula, or "" when a Dog can run it. The formula
+// always runs every phase against a single Dolt server and reports nothing
+// back to the daemon, so anything the daemon must see or decide per cycle
+// keeps the cycle inline.
+func (d *Daemon) wispReaperInlineReason(config *WispReaperConfig) string {
+	if _, err := wispReaperDeferRecentEvents(config); err != nil {
+		// The formula would purge without the window; inline skips the purge.
+		return "invalid purge_defer_recent_events, skipping purge"
+	}
+	switch {
+	case config.ReportOnly:
+		return "REPORT ONLY — counting what would be reaped"
+	case d.reaperFirstRunPending(config):
+		return "no purge recorded yet, checking the first purge size"
+	case len(config.Phases) > 0:
+		return "per-phase schedule configured"
+	case len(config.Endpoints) > 0:
+		return fmt.Sprintf("%d Dolt endpoints configured", len(config.Endpoints))
+	case config.DigestMail != "":
+		return "digest_mail configured"
+	case len(config.AlertThresholds) > 0:
+		// The formula compares one alert_threshold with each type's count.
+		return "per-type alert_thresholds configured"
+	}
+	return ""
+}
+
+// closeReaperStep closes an inline reaper step, noting "reported" when the
+// reaper is in report-only mode and the step changed nothing.
+func closeReaperStep(mol *dogMol, config *WispReaperConfig, step string) {
+	if config.ReportOnly {
+		mol.closeStepWithReason(step, "reported")
+		return
+	}
+	mol.closeStep(step)
 }
 
 // dispatchReaperDog dispatches the mol-dog-reaper formula to a Dog via gt sling.
@@ -142,199 +592,514 @@ func (d *Daemon) dispatchReaperDog(vars map[string]string) error {
 	return nil
 }
 
+// wispReaperAutoCloseOptions builds the auto-close options from the
+// auto_close_since/auto_close_until window and the business-day settings.
+func wispReaperAutoCloseOptions(config *WispReaperConfig) (reaper.AutoCloseOptions, error) {
+	window, err := reaper.ParseAutoCloseWindow(config.AutoCloseSince, config.AutoCloseUntil)
+	if err != nil {
+		return reaper.AutoCloseOptions{}, fmt.Errorf("auto_close window: %w", err)
+	}
+	holidays, err := reaper.ParseHolidays(config.Holidays)
+	if err != nil {
+		return reaper.AutoCloseOptions{}, fmt.Errorf("holidays: %w", err)
+	}
+	return reaper.AutoCloseOptions{Window: window, BusinessDaysOnly: config.BusinessDaysOnly, Holidays: holidays}, nil
+}
+
+// WispReaperPreviewOptions returns the ages and auto-close options an inline
+// reaper cycle would run with under config, for previewing a cycle outside
+// the daemon (gt wisp reap-report).
+func WispReaperPreviewOptions(config *DaemonPatrolConfig) (reaper.PreviewOptions, error) {
+	opts := reaper.PreviewOptions{
+		MaxAge:        wispReaperMaxAge(config),
+		PurgeAge:      wispDeleteAge(config),
+		MailDeleteAge: defaultMailDeleteAge,
+		StaleIssueAge: defaultStaleIssueAge,
+	}
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		closeOpts, err := wispReaperAutoCloseOptions(config.Patrols.WispReaper)
+		if err != nil {
+			return reaper.PreviewOptions{}, err
+		}
+		opts.AutoClose = closeOpts
+		opts.KeepRecentPerType = config.Patrols.WispReaper.KeepRecentPerType
+		opts.ExcludeTypes = config.Patrols.WispReaper.PurgeExcludeTypes
+		window, err := wispReaperDeferRecentEvents(config.Patrols.WispReaper)
+		if err != nil {
+			return reaper.PreviewOptions{}, err
+		}
+		opts.RecentEventWindow = window
+	}
+	return opts, nil
+}
+
+// reaperClock returns the current time on the wisp reaper's clock.
+func (d *Daemon) reaperClock() time.Time {
+	if d.reaperNow != nil {
+		return d.reaperNow()
+	}
+	return time.Now()
+}
+
+// warnReaperClockSkew warns when the Dolt server at endpoint disagrees with
+// this host's clock by more than reaper.ClockSkewThreshold. Reaper cutoffs are
+// computed from the local clock but compared against server-written
+// timestamps, so drift closes fresh wisps early or never reaps old ones.
+func (d *Daemon) warnReaperClockSkew(endpoint string, db *sql.DB) {
+	skew, err := reaper.ClockSkew(db)
+	if err != nil {
+		d.logger.Printf("wisp_reaper: %s: clock skew check failed: %v", endpoint, err)
+		return
+	}
+	if reaper.ClockSkewExceeded(skew) {
+		d.logger.Printf("wisp_reaper: WARNING: Dolt server %s clock is %v off from this host (threshold %v) — age-based reaping is skewed",
+			endpoint, skew.Round(time.Second), reaper.ClockSkewThreshold)
+	}
+}
+
+// wispReaperContext returns the context the daemon's reaper calls run under,
+// carrying config's schema mapping and connection settings. It is built
+// fresh each cycle, so a reload that drops the connection section goes back
+// to the default connection.
+func wispReaperContext(config *WispReaperConfig) (context.Context, error) {
+	schema, err := reaper.NewSchema(config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema mapping: %w", err)
+	}
+	ctx := reaper.WithSchema(context.Background(), schema)
+	if config.Connection == nil {
+		return ctx, nil
+	}
+	if err := config.Connection.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid connection config: %w", err)
+	}
+	return reaper.WithConnConfig(ctx, *config.Connection), nil
+}
+
 // reapWispsInline is the fallback that runs the reaper cycle inline when
 // Dog dispatch is unavailable. Delegates to the reaper package for SQL execution.
 func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge time.Duration, mol *dogMol) {
-	databases := config.Databases
-	if len(databases) == 0 {
-		databases = reaper.DiscoverDatabases("127.0.0.1", d.doltServerPort())
+	logger := d.wispReaperLogger(config)
+	ctx, err := wispReaperContext(config)
+	if err != nil {
+		logger.Printf("wisp_reaper: %v", err)
+		mol.failStep("scan", err.Error())
+		return
 	}
-	if len(databases) == 0 {
-		d.logger.Printf("wisp_reaper: no databases to reap")
+
+	targets := d.wispReaperTargets(ctx, config)
+	if len(targets) == 0 {
+		logger.Printf("wisp_reaper: no databases to reap")
 		mol.failStep("scan", "no databases found")
 		return
 	}
-	d.logger.Printf("wisp_reaper: scanning %d databases (inline fallback)", len(databases))
+	logger.Printf("wisp_reaper: scanning %d databases (inline fallback)", len(targets))
+	if config.Connection != nil {
+		logger.Printf("wisp_reaper: connecting as %s", reaper.RedactDSN(config.Connection.DSN(targets[0].host, targets[0].port, "", 0, 0)))
+	}
 	mol.closeStep("scan")
 
-	port := d.doltServerPort()
-	dryRun := config.DryRun
+	targets = d.skipMaintenanceTargets(targets, logger)
+	targets = rotateReaperTargets(targets, d.wispReaperResumeAt)
+	budget := newReaperCycleBudget(wispReaperMaxCycleDuration(d.currentPatrolConfig()))
+	defer func() { d.wispReaperResumeAt = budget.deferred }()
+	deferring := func(phase string) {
+		logger.Printf("wisp_reaper: cycle budget exhausted, deferring %s from %s", phase, budget.deferred)
+	}
+
+	dryRun := config.DryRun || config.ReportOnly
+	digest := newReaperDigest(d.reaperClock(), dryRun)
+	defer func() { d.deliverReaperDigest(config, digest, d.reaperClock()) }()
 	var totalReaped, totalMoleculeSteps, totalOpen, totalPurged, totalMailPurged, totalAutoClosed int
+	openByType := make(map[string]int)
+	phaseSkips := d.reaperPhaseSkips(config, logger)
 
 	// Step 2: Reap
 	reapErrors := 0
-	for _, dbName := range databases {
+	reapTargets := targets
+	if phaseSkips["reap"] != "" {
+		reapTargets = nil
+	}
+	// Hooked work owned by a live polecat is long-running, not abandoned.
+	liveOwners := session.LivePolecatAddresses()
+	totalSparedLive := 0
+	skewChecked := make(map[string]bool)
+	for _, t := range reapTargets {
+		if budget.exhausted("reap", t) {
+			deferring("reap")
+			break
+		}
+		dbName := t.dbName
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 10*time.Second, 10*time.Second)
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: connect error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: connect error: %v", t.label, err)
+			digest.addError(t.label, "connect", err)
 			reapErrors++
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			d.logger.Printf("wisp_reaper: %s: skipped (no reaper schema)", dbName)
+		if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
+			logger.Printf("wisp_reaper: %s: skipped (no reaper schema)", t.label)
 			db.Close()
 			continue
 		}
-		result, err := reaper.Reap(db, dbName, maxAge, dryRun)
+		if endpoint := net.JoinHostPort(t.host, strconv.Itoa(t.port)); !skewChecked[endpoint] {
+			skewChecked[endpoint] = true
+			d.warnReaperClockSkew(endpoint, db)
+		}
+		result, err := reaper.ReapWithOptions(ctx, db, dbName, maxAge, dryRun, reaper.ReapOptions{
+			CloseBatchSize: config.CloseBatchSize,
+			Progress: func(description string, closed int) {
+				logger.Printf("wisp_reaper: %s: closed %d %s so far", t.label, closed, description)
+			},
+			Now:        d.reaperClock,
+			LiveOwners: liveOwners,
+		})
+		if err == nil {
+			if byType, typeErr := reaper.CountOpenWispsByType(ctx, db); typeErr == nil {
+				for wtype, n := range byType {
+					openByType[wtype] += n
+				}
+			} else {
+				logger.Printf("wisp_reaper: %s: open wisps by type: %v", t.label, typeErr)
+			}
+		}
 		db.Close()
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: reap error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: reap error: %v", t.label, err)
+			digest.addError(t.label, "reap", err)
 			reapErrors++
 			continue
 		}
+		digest.counts(t.label).Reaped += result.Reaped
 		totalReaped += result.Reaped
 		totalMoleculeSteps += result.MoleculeStepsClosed
 		totalOpen += result.OpenRemain
+		totalSparedLive += result.SparedLive
 		if result.Reaped > 0 || result.MoleculeStepsClosed > 0 {
-			reapSummary := fmt.Sprintf("wisp_reaper: %s: reaped %d stale wisps", dbName, result.Reaped)
+			reapSummary := fmt.Sprintf("wisp_reaper: %s: reaped %d stale wisps", t.label, result.Reaped)
 			if result.MoleculeStepsClosed > 0 {
 				reapSummary += fmt.Sprintf(", closed %d molecule steps", result.MoleculeStepsClosed)
 			}
-			d.logger.Printf("%s, %d open remain", reapSummary, result.OpenRemain)
+			logger.Printf("%s, %d open remain", reapSummary, result.OpenRemain)
+		}
+		for _, a := range result.Anomalies {
+			logger.Printf("wisp_reaper: %s: ANOMALY: %s", t.label, a.Message)
 		}
 	}
-	if reapErrors > 0 {
+	if totalSparedLive > 0 {
+		logger.Printf("wisp_reaper: spared %d stale hooked/in-progress wisps still owned by a live polecat", totalSparedLive)
+	}
+	if reason := phaseSkips["reap"]; reason != "" {
+		mol.closeStepWithReason("reap", "skipped: "+reason)
+	} else if budget.phases["reap"] {
+		mol.failStep("reap", "cycle budget exhausted, deferring")
+	} else if reapErrors > 0 {
 		mol.failStep("reap", fmt.Sprintf("%d databases had reap errors", reapErrors))
 	} else {
-		mol.closeStep("reap")
+		closeReaperStep(mol, config, "reap")
+		d.noteReaperPhaseRun(config, "reap", dryRun, logger)
 	}
 
 	// Step 3: Purge
 	purgeErrors := 0
-	for _, dbName := range databases {
+	deferWindow, deferErr := wispReaperDeferRecentEvents(config)
+	purgeOpts := reaper.PurgeOptions{
+		Tombstone:         config.Tombstone,
+		KeepRecentPerType: config.KeepRecentPerType,
+		ExcludeTypes:      config.PurgeExcludeTypes,
+		RecentEventWindow: deferWindow,
+		BatchConcurrency:  config.PurgeBatchConcurrency,
+		Now:               d.reaperClock,
+	}
+	purgeTargets := targets
+	purgeHeld := false
+	excludeErr := validatePurgeExcludeTypes(config.PurgeExcludeTypes)
+	if phaseSkips["purge"] != "" {
+		purgeTargets = nil
+	} else if excludeErr != nil {
+		logger.Printf("wisp_reaper: invalid purge_exclude_types: %v", excludeErr)
+		purgeTargets = nil
+	} else if deferErr != nil {
+		logger.Printf("wisp_reaper: skipping purge: %v", deferErr)
+		purgeTargets = nil
+	} else if d.holdFirstRunPurge(ctx, config, targets, deleteAge, purgeOpts, logger, digest) {
+		purgeHeld = true
+		purgeTargets = nil
+	}
+	for _, t := range purgeTargets {
+		if budget.exhausted("purge", t) {
+			deferring("purge")
+			break
+		}
+		dbName := t.dbName
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 30*time.Second, 30*time.Second)
+		db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 30*time.Second, 30*time.Second)
 		if err != nil {
 			purgeErrors++
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
+		if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
 			db.Close()
 			continue
 		}
-		result, err := reaper.Purge(db, dbName, deleteAge, defaultMailDeleteAge, dryRun)
+		result, err := reaper.PurgeWithOptions(ctx, db, dbName, deleteAge, defaultMailDeleteAge, dryRun, purgeOpts)
 		db.Close()
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: purge error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: purge error: %v", t.label, err)
+			digest.addError(t.label, "purge", err)
 			purgeErrors++
 			continue
 		}
+		digest.counts(t.label).Purged += result.WispsPurged
+		digest.counts(t.label).MailPurged += result.MailPurged
 		totalPurged += result.WispsPurged
 		totalMailPurged += result.MailPurged
+		if b := result.ReopenBuckets; b != nil {
+			logger.Printf("wisp_reaper: %s: purge candidates aged_out=%d reclosed=%d", t.label, b.AgedOut, b.Reclosed)
+		}
+		if len(result.RetainedRecent) > 0 {
+			logger.Printf("wisp_reaper: %s: kept as most recent of type (keep_recent_per_type=%d): %s",
+				t.label, config.KeepRecentPerType, formatTypeCounts(result.RetainedRecent))
+		}
+		if len(result.RetainedExcluded) > 0 {
+			logger.Printf("wisp_reaper: %s: retained (excluded type): %s", t.label, formatTypeCounts(result.RetainedExcluded))
+			digest.counts(t.label).RetainedExcluded = result.RetainedExcluded
+		}
+		if result.DeferredRecentEvents > 0 {
+			logger.Printf("wisp_reaper: %s: deferred %d purge candidates with wisp_events activity in the last %v",
+				t.label, result.DeferredRecentEvents, purgeOpts.RecentEventWindow)
+		}
+		if result.WispDelete != nil {
+			logger.Printf("wisp_reaper: %s: purge deleted %d wisps in %s", t.label, result.WispsPurged, result.WispDelete)
+		}
 		for _, a := range result.Anomalies {
-			d.logger.Printf("wisp_reaper: %s: ANOMALY: %s", dbName, a.Message)
+			logger.Printf("wisp_reaper: %s: ANOMALY: %s", t.label, a.Message)
 		}
 	}
-	if purgeErrors > 0 {
+	if reason := phaseSkips["purge"]; reason != "" {
+		mol.closeStepWithReason("purge", "skipped: "+reason)
+	} else if excludeErr != nil {
+		mol.failStep("purge", "invalid purge_exclude_types")
+	} else if deferErr != nil {
+		mol.failStep("purge", "invalid purge_defer_recent_events")
+	} else if purgeHeld {
+		closeHeldPurgeStep(mol)
+	} else if budget.phases["purge"] {
+		mol.failStep("purge", "cycle budget exhausted, deferring")
+	} else if purgeErrors > 0 {
 		mol.failStep("purge", fmt.Sprintf("%d databases had purge errors", purgeErrors))
 	} else {
-		mol.closeStep("purge")
+		closeReaperStep(mol, config, "purge")
+		d.noteReaperPhaseRun(config, "purge", dryRun, logger)
+		if !dryRun {
+			if err := recordReaperRun(d.config.TownRoot, d.reaperClock()); err != nil {
+				logger.Printf("wisp_reaper: recording first purge: %v", err)
+			}
+		}
 	}
 
 	// Step 3b: Close plugin receipts (fast-track — 1h instead of 7d stale age)
 	pluginReceiptAge := 1 * time.Hour
 	var totalPluginClosed int
-	for _, dbName := range databases {
+	for _, t := range targets {
+		if budget.exhausted("plugin-receipts", t) {
+			deferring("plugin-receipts")
+			break
+		}
+		dbName := t.dbName
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 10*time.Second, 10*time.Second)
 		if err != nil {
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
+		if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
 			db.Close()
 			continue
 		}
-		result, err := reaper.ClosePluginReceipts(db, dbName, pluginReceiptAge, dryRun)
+		result, err := reaper.ClosePluginReceiptsWithOptions(ctx, db, dbName, pluginReceiptAge, dryRun, reaper.CloseOptions{Now: d.reaperClock})
 		db.Close()
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: plugin receipt close error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: plugin receipt close error: %v", t.label, err)
 			continue
 		}
 		totalPluginClosed += result.Closed
 		if result.Closed > 0 {
-			d.logger.Printf("wisp_reaper: %s: closed %d plugin receipts", dbName, result.Closed)
+			logger.Printf("wisp_reaper: %s: closed %d plugin receipts", t.label, result.Closed)
 		}
 	}
 
 	// Step 3c: Close plugin dispatch mails (daemon→dog instruction beads that are never closed)
 	pluginDispatchAge := 1 * time.Hour
 	var totalDispatchClosed int
-	for _, dbName := range databases {
+	for _, t := range targets {
+		if budget.exhausted("plugin-dispatches", t) {
+			deferring("plugin-dispatches")
+			break
+		}
+		dbName := t.dbName
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 10*time.Second, 10*time.Second)
 		if err != nil {
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
+		if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
 			db.Close()
 			continue
 		}
-		result, err := reaper.ClosePluginDispatches(db, dbName, pluginDispatchAge, dryRun)
+		result, err := reaper.ClosePluginDispatchesWithOptions(ctx, db, dbName, pluginDispatchAge, dryRun, reaper.CloseOptions{Now: d.reaperClock})
 		db.Close()
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: plugin dispatch close error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: plugin dispatch close error: %v", t.label, err)
 			continue
 		}
 		totalDispatchClosed += result.Closed
 		if result.Closed > 0 {
-			d.logger.Printf("wisp_reaper: %s: closed %d plugin dispatches", dbName, result.Closed)
+			logger.Printf("wisp_reaper: %s: closed %d plugin dispatches", t.label, result.Closed)
+		}
+	}
+
+	// Step 3d: Close stale unread mail (opt-in via stale_mail_age)
+	var totalStaleMailClosed int
+	if staleMailAge := wispReaperStaleMailAge(d.currentPatrolConfig()); staleMailAge > 0 {
+		for _, t := range targets {
+			if budget.exhausted("stale-mail", t) {
+				deferring("stale-mail")
+				break
+			}
+			dbName := t.dbName
+			if err := reaper.ValidateDBName(dbName); err != nil {
+				continue
+			}
+			db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 10*time.Second, 10*time.Second)
+			if err != nil {
+				continue
+			}
+			if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
+				db.Close()
+				continue
+			}
+			result, err := reaper.CloseStaleMailWithOptions(ctx, db, dbName, staleMailAge, dryRun, reaper.CloseOptions{Now: d.reaperClock})
+			db.Close()
+			if err != nil {
+				logger.Printf("wisp_reaper: %s: stale mail close error: %v", t.label, err)
+				continue
+			}
+			totalStaleMailClosed += result.Closed
+			if result.Closed > 0 {
+				logger.Printf("wisp_reaper: %s: closed %d stale mail (no updates for %v)", t.label, result.Closed, staleMailAge)
+			}
 		}
 	}
 
 	// Step 4: Auto-close
 	autoCloseErrors := 0
-	for _, dbName := range databases {
+	var totalExempted reaper.AutoCloseExemptCounts
+	closeTargets := targets
+	closeOpts, optsErr := wispReaperAutoCloseOptions(config)
+	closeOpts.Now = d.reaperClock
+	if phaseSkips["auto-close"] != "" {
+		closeTargets = nil
+	} else if optsErr != nil {
+		// Never fall back to the open-ended window: a bad bound must not turn
+		// a gradual backfill into closing the whole stale backlog at once.
+		logger.Printf("wisp_reaper: skipping auto-close: %v", optsErr)
+		closeTargets = nil
+	}
+	for _, t := range closeTargets {
+		if budget.exhausted("auto-close", t) {
+			deferring("auto-close")
+			break
+		}
+		dbName := t.dbName
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		db, err := reaper.OpenDB(ctx, t.host, t.port, dbName, 10*time.Second, 10*time.Second)
 		if err != nil {
 			autoCloseErrors++
 			continue
 		}
 		// Auto-close operates on the issues table, not wisps, but if the database
 		// has no beads schema at all we should skip it too.
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
+		if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
 			db.Close()
 			continue
 		}
-		result, err := reaper.AutoClose(db, dbName, defaultStaleIssueAge, dryRun)
+		result, err := reaper.AutoCloseWithOptions(ctx, db, dbName, defaultStaleIssueAge, dryRun, closeOpts)
 		db.Close()
 		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: auto-close error: %v", dbName, err)
+			logger.Printf("wisp_reaper: %s: auto-close error: %v", t.label, err)
+			digest.addError(t.label, "auto-close", err)
 			autoCloseErrors++
 			continue
 		}
+		digest.counts(t.label).AutoClosed += result.Closed
+		for _, entry := range result.ClosedEntries {
+			logger.Printf("wisp_reaper: %s: auto-closed %s", t.label, entry)
+		}
+		if result.Exempted.Total() > 0 {
+			logger.Printf("wisp_reaper: %s: auto-close exempted stale issues: %s", t.label, result.Exempted)
+		}
+		for _, a := range result.Anomalies {
+			logger.Printf("wisp_reaper: %s: auto-close anomaly: %s", t.label, a.Message)
+		}
 		totalAutoClosed += result.Closed
+		totalExempted.Add(result.Exempted)
 	}
-	if autoCloseErrors > 0 {
+	if reason := phaseSkips["auto-close"]; reason != "" {
+		mol.closeStepWithReason("auto-close", "skipped: "+reason)
+	} else if optsErr != nil {
+		mol.failStep("auto-close", "invalid auto-close config: "+optsErr.Error())
+	} else if budget.phases["auto-close"] {
+		mol.failStep("auto-close", "cycle budget exhausted, deferring")
+	} else if autoCloseErrors > 0 {
 		mol.failStep("auto-close", fmt.Sprintf("%d databases had auto-close errors", autoCloseErrors))
 	} else {
-		mol.closeStep("auto-close")
+		closeReaperStep(mol, config, "auto-close")
+		d.noteReaperPhaseRun(config, "auto-close", dryRun, logger)
 	}
 
 	// Step 5: Report
-	if totalOpen > wispAlertThreshold {
-		d.logger.Printf("wisp_reaper: WARNING: %d open wisps exceed threshold %d — investigate wisp lifecycle",
-			totalOpen, wispAlertThreshold)
+	for _, warning := range wispAlertBreaches(d.currentPatrolConfig(), openByType) {
+		digest.Alerts = append(digest.Alerts, warning)
+		logger.Printf("%s", warning)
+		if logger != d.logger {
+			d.logger.Printf("%s", warning)
+		}
 	}
 	summary := fmt.Sprintf("wisp_reaper: cycle complete — reaped=%d", totalReaped)
 	if totalMoleculeSteps > 0 {
 		summary += fmt.Sprintf(" molecule_steps_closed=%d", totalMoleculeSteps)
 	}
+	if totalStaleMailClosed > 0 {
+		summary += fmt.Sprintf(" stale_mail_closed=%d", totalStaleMailClosed)
+	}
 	summary += fmt.Sprintf(" purged=%d mail_purged=%d plugin_closed=%d dispatch_closed=%d auto_closed=%d open=%d databases=%d dryRun=%v",
-		totalPurged, totalMailPurged, totalPluginClosed, totalDispatchClosed, totalAutoClosed, totalOpen, len(databases), dryRun)
-	d.logger.Printf("%s", summary)
-	mol.closeStep("report")
+		totalPurged, totalMailPurged, totalPluginClosed, totalDispatchClosed, totalAutoClosed, totalOpen, len(targets), dryRun)
+	if totalExempted.Total() > 0 {
+		summary += fmt.Sprintf(" auto_close_exempt_priority=%d auto_close_exempt_type=%d auto_close_exempt_dependency=%d auto_close_exempt_keep_label=%d",
+			totalExempted.Priority, totalExempted.Type, totalExempted.Dependency, totalExempted.KeepLabel)
+	}
+	if config.ReportOnly {
+		summary += " reportOnly=true"
+	}
+	if budget.deferred != "" {
+		summary += fmt.Sprintf(" deferred_from=%s", budget.deferred)
+	}
+	logger.Printf("%s", summary)
+	if logger != d.logger {
+		d.logger.Printf("%s", summary)
+	}
+	closeReaperStep(mol, config, "report")
 }
 
 // doltServerPort returns the configured Dolt server port.
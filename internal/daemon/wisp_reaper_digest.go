@@ -0,0 +1,231 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Values for WispReaperConfig.DigestMail.
+const (
+	reaperDigestCycle = "cycle"
+	reaperDigestDaily = "daily"
+)
+
+// reaperDigestMaxErrors caps the errors listed in one digest; the rest are
+// counted, so a database failing every cycle cannot bloat a daily rollup.
+const reaperDigestMaxErrors = 20
+
+// reaperDigest accumulates what one or more inline reaper cycles did, for
+// the digest mail.
+type reaperDigest struct {
+	Since         time.Time                      `json:"since"`
+	Cycles        int                            `json:"cycles"`
+	DryRun        bool                           `json:"dry_run,omitempty"`
+	Databases     map[string]*reaperDigestCounts `json:"databases"`
+	Alerts        []string                       `json:"alerts,omitempty"`
+	Errors        []string                       `json:"errors,omitempty"`
+	ErrorsDropped int                            `json:"errors_dropped,omitempty"`
+}
+
+// reaperDigestCounts is one database's totals in a reaperDigest.
+type reaperDigestCounts struct {
+	Reaped     int `json:"reaped"`
+	Purged     int `json:"purged"`
+	MailPurged int `json:"mail_purged"`
+	AutoClosed int `json:"auto_closed"`
+	// RetainedExcluded is the latest count, by wisp type, of closed wisps
+	// past the purge age kept because their type is excluded from the purge.
+	RetainedExcluded map[string]int `json:"retained_excluded,omitempty"`
+}
+
+func newReaperDigest(now time.Time, dryRun bool) *reaperDigest {
+	return &reaperDigest{
+		Since:     now.UTC(),
+		Cycles:    1,
+		DryRun:    dryRun,
+		Databases: make(map[string]*reaperDigestCounts),
+	}
+}
+
+// counts returns the totals for a database, creating them on first use.
+func (g *reaperDigest) counts(label string) *reaperDigestCounts {
+	c, ok := g.Databases[label]
+	if !ok {
+		c = &reaperDigestCounts{}
+		g.Databases[label] = c
+	}
+	return c
+}
+
+func (g *reaperDigest) addError(label, phase string, err error) {
+	if len(g.Errors) >= reaperDigestMaxErrors {
+		g.ErrorsDropped++
+		return
+	}
+	g.Errors = append(g.Errors, fmt.Sprintf("%s: %s: %v", label, phase, err))
+}
+
+// merge folds a later cycle into g. Alerts are replaced rather than
+// appended: only the latest cycle's open-wisp counts are current.
+func (g *reaperDigest) merge(cycle *reaperDigest) {
+	g.Cycles += cycle.Cycles
+	g.DryRun = g.DryRun || cycle.DryRun
+	if g.Databases == nil {
+		g.Databases = make(map[string]*reaperDigestCounts)
+	}
+	for label, c := range cycle.Databases {
+		total := g.counts(label)
+		total.Reaped += c.Reaped
+		total.Purged += c.Purged
+		total.MailPurged += c.MailPurged
+		total.AutoClosed += c.AutoClosed
+		if len(c.RetainedExcluded) > 0 {
+			total.RetainedExcluded = c.RetainedExcluded // A snapshot, not a running total
+		}
+	}
+	g.Alerts = cycle.Alerts
+	for _, e := range cycle.Errors {
+		if len(g.Errors) >= reaperDigestMaxErrors {
+			g.ErrorsDropped++
+			continue
+		}
+		g.Errors = append(g.Errors, e)
+	}
+	g.ErrorsDropped += cycle.ErrorsDropped
+}
+
+// subject is the digest mail's subject line.
+func (g *reaperDigest) subject() string {
+	var total reaperDigestCounts
+	for _, c := range g.Databases {
+		total.Reaped += c.Reaped
+		total.Purged += c.Purged
+		total.MailPurged += c.MailPurged
+		total.AutoClosed += c.AutoClosed
+	}
+	subject := fmt.Sprintf("Wisp reaper digest: reaped %d, purged %d, mail purged %d, auto-closed %d",
+		total.Reaped, total.Purged, total.MailPurged, total.AutoClosed)
+	if n := len(g.Errors) + g.ErrorsDropped; n > 0 {
+		subject += fmt.Sprintf(", %d error(s)", n)
+	}
+	if g.DryRun {
+		subject += " (dry run)"
+	}
+	return subject
+}
+
+// body is the digest mail's body: a per-database table, then any alerts and
+// errors.
+func (g *reaperDigest) body(now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d reaper cycle(s) since %s (%s ago).\n\n",
+		g.Cycles, g.Since.Format(time.RFC3339), now.Sub(g.Since).Round(time.Minute))
+
+	labels := make([]string, 0, len(g.Databases))
+	for label := range g.Databases {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	if len(labels) == 0 {
+		b.WriteString("No databases reaped.\n")
+	} else {
+		fmt.Fprintf(&b, "%-24s %8s %8s %12s %12s\n", "DATABASE", "REAPED", "PURGED", "MAIL_PURGED", "AUTO_CLOSED")
+		for _, label := range labels {
+			c := g.Databases[label]
+			fmt.Fprintf(&b, "%-24s %8d %8d %12d %12d\n", label, c.Reaped, c.Purged, c.MailPurged, c.AutoClosed)
+		}
+	}
+
+	var retained []string
+	for _, label := range labels {
+		if c := g.Databases[label]; len(c.RetainedExcluded) > 0 {
+			retained = append(retained, fmt.Sprintf("  - %s: %s\n", label, formatTypeCounts(c.RetainedExcluded)))
+		}
+	}
+	if len(retained) > 0 {
+		b.WriteString("\nRetained (excluded type):\n")
+		b.WriteString(strings.Join(retained, ""))
+	}
+
+	if len(g.Alerts) > 0 {
+		b.WriteString("\nAlerts:\n")
+		for _, a := range g.Alerts {
+			fmt.Fprintf(&b, "  - %s\n", strings.TrimPrefix(a, "wisp_reaper: WARNING: "))
+		}
+	}
+	if len(g.Errors) > 0 {
+		b.WriteString("\nErrors:\n")
+		for _, e := range g.Errors {
+			fmt.Fprintf(&b, "  - %s\n", e)
+		}
+		if g.ErrorsDropped > 0 {
+			fmt.Fprintf(&b, "  - ... and %d more (see the daemon log)\n", g.ErrorsDropped)
+		}
+	}
+	return b.String()
+}
+
+// reaperDigestFile holds the daily rollup between cycles, so it survives a
+// daemon restart.
+func reaperDigestFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "reaper-digest.json")
+}
+
+// deliverReaperDigest mails the cycle's digest to the mayor, or folds it
+// into the pending daily rollup and mails that once a day has passed,
+// according to config.DigestMail.
+func (d *Daemon) deliverReaperDigest(config *WispReaperConfig, cycle *reaperDigest, now time.Time) {
+	logger := d.wispReaperLogger(config)
+	switch config.DigestMail {
+	case "":
+		return
+	case reaperDigestCycle:
+		d.sendReaperDigest(cycle, now)
+	case reaperDigestDaily:
+		path := reaperDigestFile(d.config.TownRoot)
+		pending := &reaperDigest{}
+		if data, err := os.ReadFile(path); err == nil {
+			if err := json.Unmarshal(data, pending); err != nil {
+				logger.Printf("wisp_reaper: digest: discarding unreadable %s: %v", path, err)
+				pending = &reaperDigest{}
+			}
+		}
+		if pending.Since.IsZero() {
+			pending = cycle
+		} else {
+			pending.merge(cycle)
+		}
+		if now.Sub(pending.Since) < 24*time.Hour {
+			if err := atomicfile.EnsureDirAndWriteJSON(path, pending); err != nil {
+				logger.Printf("wisp_reaper: digest: saving rollup: %v", err)
+			}
+			return
+		}
+		if d.sendReaperDigest(pending, now) {
+			_ = os.Remove(path)
+		}
+	default:
+		logger.Printf("wisp_reaper: digest: unknown digest_mail %q (want %q or %q)", config.DigestMail, reaperDigestCycle, reaperDigestDaily)
+	}
+}
+
+// sendReaperDigest mails a digest to the mayor and reports whether it was sent.
+func (d *Daemon) sendReaperDigest(g *reaperDigest, now time.Time) bool {
+	cmd := exec.Command(d.gtPath, "mail", "send", "mayor/", "-s", g.subject(), "-m", g.body(now)) //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	util.SetDetachedProcessGroup(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("wisp_reaper: digest: mail to mayor failed: %v (%s)", err, strings.TrimSpace(string(output)))
+		return false
+	}
+	return true
+}
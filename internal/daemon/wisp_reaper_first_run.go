@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+// defaultFirstRunDeleteLimit is how many rows the first purge may delete
+// before it is held for acknowledgement.
+const defaultFirstRunDeleteLimit = 10000
+
+// reaperFirstRunEscalate alerts when the first-run guard holds a purge. A
+// variable so tests can capture it.
+var reaperFirstRunEscalate = (*Daemon).escalate
+
+// reaperFirstRunCount counts what a purge of one target would delete. skip
+// is true for a database without the reaper schema. A variable so tests can
+// stand in for a Dolt server.
+var reaperFirstRunCount = countFirstRunPurge
+
+// reaperRunRecord is the reaper's per-patrol state: when a purge first ran
+// for real, and when a held first run was escalated. A zero FirstPurge means
+// the reaper has never deleted anything here.
+type reaperRunRecord struct {
+	FirstPurge    time.Time `json:"first_purge"`
+	HoldEscalated time.Time `json:"hold_escalated,omitempty"`
+}
+
+// reaperRunFile returns the path of the reaper's run record.
+func reaperRunFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "reaper-run.json")
+}
+
+// loadReaperRunRecord reads the run record; a missing or unreadable file is
+// an empty record.
+func loadReaperRunRecord(townRoot string) reaperRunRecord {
+	var rec reaperRunRecord
+	data, err := os.ReadFile(reaperRunFile(townRoot))
+	if err != nil || json.Unmarshal(data, &rec) != nil {
+		return reaperRunRecord{}
+	}
+	return rec
+}
+
+// reaperHasRun reports whether a real purge has been recorded.
+func reaperHasRun(townRoot string) bool {
+	return !loadReaperRunRecord(townRoot).FirstPurge.IsZero()
+}
+
+// recordReaperRun notes that a real purge ran, ending the first-run guard.
+func recordReaperRun(townRoot string, now time.Time) error {
+	rec := loadReaperRunRecord(townRoot)
+	if !rec.FirstPurge.IsZero() {
+		return nil
+	}
+	rec.FirstPurge = now.UTC()
+	return atomicfile.EnsureDirAndWriteJSON(reaperRunFile(townRoot), rec)
+}
+
+// escalateFirstRunHold alerts about a held first run once. Later held cycles
+// only log; the escalation time is kept in the run record so a daemon
+// restart does not alert again.
+func (d *Daemon) escalateFirstRunHold(msg string, now time.Time) {
+	townRoot := d.config.TownRoot
+	rec := loadReaperRunRecord(townRoot)
+	if !rec.HoldEscalated.IsZero() {
+		return
+	}
+	rec.HoldEscalated = now.UTC()
+	if err := atomicfile.EnsureDirAndWriteJSON(reaperRunFile(townRoot), rec); err != nil {
+		d.logger.Printf("wisp_reaper: recording first-run escalation: %v", err)
+	}
+	reaperFirstRunEscalate(d, "wisp_reaper", msg)
+}
+
+// closeHeldPurgeStep closes the purge step of a cycle whose purge was held
+// by the first-run guard. A hold is not a failure, so it must not count
+// towards the patrol circuit breaker.
+func closeHeldPurgeStep(mol *dogMol) {
+	mol.closeStepWithReason("purge", "held: first run over delete limit, awaiting first_run_acknowledged")
+}
+
+// wispReaperFirstRunLimit returns the first-run delete limit
+// (first_run_delete_limit, default 10000), or 0 when the guard is off: a
+// negative limit, first_run_acknowledged, or a dry run that deletes nothing.
+func wispReaperFirstRunLimit(config *WispReaperConfig) int {
+	if config.FirstRunAcknowledged || config.DryRun || config.ReportOnly || config.FirstRunDeleteLimit < 0 {
+		return 0
+	}
+	if config.FirstRunDeleteLimit > 0 {
+		return config.FirstRunDeleteLimit
+	}
+	return defaultFirstRunDeleteLimit
+}
+
+// reaperFirstRunPending reports whether the next purge is subject to the
+// first-run guard. The guard needs the counts, so such cycles run inline.
+func (d *Daemon) reaperFirstRunPending(config *WispReaperConfig) bool {
+	return wispReaperFirstRunLimit(config) > 0 && !reaperHasRun(d.config.TownRoot)
+}
+
+// countFirstRunPurge counts what a purge of t would delete, as a dry run.
+func countFirstRunPurge(ctx context.Context, t reaperTarget, deleteAge time.Duration, opts reaper.PurgeOptions) (wisps, mail int, skip bool, err error) {
+	db, err := reaper.OpenDB(ctx, t.host, t.port, t.dbName, 30*time.Second, 30*time.Second)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer db.Close()
+	if ok, _ := reaper.HasReaperSchema(ctx, db); !ok {
+		return 0, 0, true, nil
+	}
+	result, err := reaper.PurgeWithOptions(ctx, db, t.dbName, deleteAge, defaultMailDeleteAge, true, opts)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return result.WispsPurged, result.MailPurged, false, nil
+}
+
+// holdFirstRunPurge counts what the purge would delete on a town the reaper
+// has never purged. Over the limit, or when a database cannot be counted, it
+// logs, alerts once, and returns true so the cycle skips the purge until
+// first_run_acknowledged is set.
+func (d *Daemon) holdFirstRunPurge(ctx context.Context, config *WispReaperConfig, targets []reaperTarget, deleteAge time.Duration, opts reaper.PurgeOptions, logger *log.Logger, digest *reaperDigest) bool {
+	if !d.reaperFirstRunPending(config) {
+		return false
+	}
+	limit := wispReaperFirstRunLimit(config)
+
+	type pending struct {
+		label       string
+		wisps, mail int
+	}
+	var counts []pending
+	var uncounted []string
+	total := 0
+	for _, t := range targets {
+		if reaper.ValidateDBName(t.dbName) != nil {
+			continue
+		}
+		wisps, mail, skip, err := reaperFirstRunCount(ctx, t, deleteAge, opts)
+		if err != nil {
+			// An uncounted database could hold any number of rows, so it
+			// holds the purge rather than letting it through.
+			logger.Printf("wisp_reaper: %s: first-run count error: %v", t.label, err)
+			uncounted = append(uncounted, t.label)
+			continue
+		}
+		if skip {
+			continue
+		}
+		counts = append(counts, pending{t.label, wisps, mail})
+		total += wisps + mail
+	}
+	if total <= limit && len(uncounted) == 0 {
+		return false
+	}
+
+	for _, c := range counts {
+		if c.wisps > 0 || c.mail > 0 {
+			logger.Printf("wisp_reaper: %s: first run would purge %d wisps, %d mail", c.label, c.wisps, c.mail)
+		}
+	}
+	var msg string
+	if total > limit {
+		msg = fmt.Sprintf("first run would delete %d rows, over first_run_delete_limit %d; purge skipped until patrols.wisp_reaper.first_run_acknowledged is set",
+			total, limit)
+	} else {
+		msg = fmt.Sprintf("first run could not count %s against first_run_delete_limit %d; purge skipped until the count succeeds or patrols.wisp_reaper.first_run_acknowledged is set",
+			strings.Join(uncounted, ", "), limit)
+	}
+	logger.Printf("wisp_reaper: WARNING: %s", msg)
+	digest.Alerts = append(digest.Alerts, "wisp_reaper: WARNING: "+msg)
+	d.escalateFirstRunHold(msg, d.reaperClock())
+	return true
+}
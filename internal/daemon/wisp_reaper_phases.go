@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// reaperScheduledPhases are the wisp_reaper steps WispReaperConfig.Phases
+// can toggle and schedule on their own.
+var reaperScheduledPhases = []string{"reap", "purge", "auto-close"}
+
+// ReaperPhaseConfig schedules one wisp_reaper phase independently of the
+// others. The patrol still ticks at wisp_reaper.interval; a phase with a
+// longer interval sits out the cycles in between, so the patrol interval
+// should be the shortest phase interval.
+type ReaperPhaseConfig struct {
+	// Enabled turns the phase off when false. Default on.
+	Enabled *bool `json:"enabled,omitempty"`
+	// IntervalStr runs the phase at most this often (e.g. "168h"). Empty
+	// runs it every cycle.
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// reaperPhaseRunsFile records when each scheduled phase last ran, so phase
+// intervals survive a daemon restart.
+func reaperPhaseRunsFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "reaper-phases.json")
+}
+
+func loadReaperPhaseRuns(townRoot string) map[string]time.Time {
+	runs := make(map[string]time.Time)
+	if data, err := os.ReadFile(reaperPhaseRunsFile(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &runs)
+	}
+	return runs
+}
+
+// recordReaperPhaseRun notes that phase completed at now.
+func recordReaperPhaseRun(townRoot, phase string, now time.Time) error {
+	runs := loadReaperPhaseRuns(townRoot)
+	runs[phase] = now.UTC()
+	return atomicfile.EnsureDirAndWriteJSON(reaperPhaseRunsFile(townRoot), runs)
+}
+
+// reaperPhaseSkip returns why phase should sit out a cycle at now, given when
+// it last ran, or "" when it is due.
+func reaperPhaseSkip(config *WispReaperConfig, phase string, lastRun, now time.Time) string {
+	pc, ok := config.Phases[phase]
+	if !ok {
+		return ""
+	}
+	if pc.Enabled != nil && !*pc.Enabled {
+		return "disabled"
+	}
+	interval, err := time.ParseDuration(pc.IntervalStr)
+	if err != nil || interval <= 0 || lastRun.IsZero() {
+		return ""
+	}
+	if since := now.Sub(lastRun); since < interval {
+		return fmt.Sprintf("not due (ran %v ago, interval %v)", since.Round(time.Minute), interval)
+	}
+	return ""
+}
+
+// reaperPhaseSkips returns, for each scheduled phase that should not run this
+// cycle, the reason it is skipped. Unknown phase names and bad intervals are
+// logged and ignored.
+func (d *Daemon) reaperPhaseSkips(config *WispReaperConfig, logger *log.Logger) map[string]string {
+	skips := make(map[string]string)
+	if len(config.Phases) == 0 {
+		return skips
+	}
+	names := make([]string, 0, len(config.Phases))
+	for name := range config.Phases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.Contains(reaperScheduledPhases, name) {
+			logger.Printf("wisp_reaper: ignoring unknown phase %q in phases (want one of %v)", name, reaperScheduledPhases)
+			continue
+		}
+		if s := config.Phases[name].IntervalStr; s != "" {
+			if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+				logger.Printf("wisp_reaper: phase %s: invalid interval %q, running every cycle", name, s)
+			}
+		}
+	}
+
+	runs := loadReaperPhaseRuns(d.config.TownRoot)
+	now := d.reaperClock()
+	for _, phase := range reaperScheduledPhases {
+		if reason := reaperPhaseSkip(config, phase, runs[phase], now); reason != "" {
+			skips[phase] = reason
+			logger.Printf("wisp_reaper: skipping %s: %s", phase, reason)
+		}
+	}
+	return skips
+}
+
+// noteReaperPhaseRun records a completed phase when it is on its own
+// schedule. Dry runs change nothing, so they do not count as a run.
+func (d *Daemon) noteReaperPhaseRun(config *WispReaperConfig, phase string, dryRun bool, logger *log.Logger) {
+	if dryRun {
+		return
+	}
+	if pc, ok := config.Phases[phase]; !ok || pc.IntervalStr == "" {
+		return
+	}
+	if err := recordReaperPhaseRun(d.config.TownRoot, phase, d.reaperClock()); err != nil {
+		logger.Printf("wisp_reaper: recording %s run: %v", phase, err)
+	}
+}
@@ -1,15 +1,21 @@
 package daemon
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/reaper"
 )
 
 func TestWispReaperInterval(t *testing.T) {
@@ -74,6 +80,55 @@ func TestWispDeleteAge(t *testing.T) {
 	}
 }
 
+func TestWispReaperStaleMailAge(t *testing.T) {
+	if got := wispReaperStaleMailAge(nil); got != 0 {
+		t.Errorf("expected stale mail closing off by default, got %v", got)
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{
+				Enabled:         true,
+				StaleMailAgeStr: "720h",
+			},
+		},
+	}
+	if got := wispReaperStaleMailAge(config); got != 30*24*time.Hour {
+		t.Errorf("expected 720h, got %v", got)
+	}
+
+	config.Patrols.WispReaper.StaleMailAgeStr = "soon"
+	if got := wispReaperStaleMailAge(config); got != 0 {
+		t.Errorf("expected invalid value to leave stale mail closing off, got %v", got)
+	}
+}
+
+func TestWispAlertBreaches(t *testing.T) {
+	openByType := map[string]int{"patrol": 3000, "mail": 60, "task": 10}
+
+	// Unconfigured: every type is held to the global default.
+	if got := wispAlertBreaches(nil, openByType); len(got) != 1 || !strings.Contains(got[0], "3000 open patrol wisps exceed threshold 800") {
+		t.Errorf("default breaches = %q, want only patrol over 800", got)
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{
+				Enabled:         true,
+				AlertThreshold:  1000,
+				AlertThresholds: map[string]int{"patrol": 5000, "mail": 50, "task": 0},
+			},
+		},
+	}
+	got := wispAlertBreaches(config, openByType)
+	if len(got) != 1 || !strings.Contains(got[0], "60 open mail wisps exceed threshold 50") {
+		t.Errorf("configured breaches = %q, want only mail over 50", got)
+	}
+	if n := wispReaperAlertThreshold(config, "task"); n != 1000 {
+		t.Errorf("task threshold = %d, want the configured default 1000", n)
+	}
+}
+
 func TestDefaultReaperIntervalIsOneHour(t *testing.T) {
 	// Verify the default changed from 30m to 1h per issue gt-caf7.
 	if defaultWispReaperInterval != 1*time.Hour {
@@ -81,6 +136,44 @@ func TestDefaultReaperIntervalIsOneHour(t *testing.T) {
 	}
 }
 
+func TestWispReaperInlineReason(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := recordReaperRun(townRoot, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	d := &Daemon{config: &Config{TownRoot: townRoot}}
+
+	tests := []struct {
+		name   string
+		config WispReaperConfig
+		inline bool
+	}{
+		{"default dispatches a Dog", WispReaperConfig{Enabled: true}, false},
+		{"report only", WispReaperConfig{ReportOnly: true}, true},
+		{"phases", WispReaperConfig{Phases: map[string]ReaperPhaseConfig{"purge": {}}}, true},
+		{"endpoints", WispReaperConfig{Endpoints: []DoltEndpointConfig{{}}}, true},
+		{"digest mail", WispReaperConfig{DigestMail: "daily"}, true},
+		{"per-type alert thresholds", WispReaperConfig{AlertThresholds: map[string]int{"mail": 50}}, true},
+		{"single alert threshold", WispReaperConfig{AlertThreshold: 500}, false},
+		{"invalid defer window", WispReaperConfig{PurgeDeferRecentEventsStr: "1 day"}, true},
+		{"valid defer window", WispReaperConfig{PurgeDeferRecentEventsStr: "24h"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := d.wispReaperInlineReason(&tt.config)
+			if (reason != "") != tt.inline {
+				t.Errorf("wispReaperInlineReason() = %q, want inline=%v", reason, tt.inline)
+			}
+		})
+	}
+
+	// The first-run guard keeps a fresh town inline.
+	fresh := &Daemon{config: &Config{TownRoot: t.TempDir()}}
+	if reason := fresh.wispReaperInlineReason(&WispReaperConfig{Enabled: true}); reason == "" {
+		t.Error("fresh town: want inline for the first-run guard")
+	}
+}
+
 func TestDispatchReaperDogUsesDogPoolSling(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("test uses Unix shell script mock")
@@ -118,3 +211,408 @@ func TestDispatchReaperDogUsesDogPoolSling(t *testing.T) {
 		}
 	}
 }
+
+func TestWispReaperTargets(t *testing.T) {
+	d := &Daemon{logger: log.New(io.Discard, "", 0)}
+
+	local := d.wispReaperTargets(context.Background(), &WispReaperConfig{Databases: []string{"hq", "gastown"}})
+	wantLocal := []reaperTarget{
+		{host: "127.0.0.1", port: 3307, dbName: "hq", label: "hq"},
+		{host: "127.0.0.1", port: 3307, dbName: "gastown", label: "gastown"},
+	}
+	if !reflect.DeepEqual(local, wantLocal) {
+		t.Errorf("single-endpoint targets = %+v, want %+v", local, wantLocal)
+	}
+
+	sharded := d.wispReaperTargets(context.Background(), &WispReaperConfig{
+		Databases: []string{"ignored-when-endpoints-set"},
+		Endpoints: []DoltEndpointConfig{
+			{Address: "10.0.0.5:3307", Databases: []string{"hq"}},
+			{Address: "not-an-address", Databases: []string{"lost"}},
+			{Address: "dolt-b:3308", Databases: []string{"gastown", "beads"}},
+		},
+	})
+	wantSharded := []reaperTarget{
+		{host: "10.0.0.5", port: 3307, dbName: "hq", label: "10.0.0.5:3307/hq"},
+		{host: "dolt-b", port: 3308, dbName: "gastown", label: "dolt-b:3308/gastown"},
+		{host: "dolt-b", port: 3308, dbName: "beads", label: "dolt-b:3308/beads"},
+	}
+	if !reflect.DeepEqual(sharded, wantSharded) {
+		t.Errorf("multi-endpoint targets = %+v, want %+v", sharded, wantSharded)
+	}
+}
+
+func TestReaperCycleBudget(t *testing.T) {
+	a := reaperTarget{dbName: "hq", label: "hq"}
+	b := reaperTarget{dbName: "gastown", label: "gastown"}
+
+	unlimited := newReaperCycleBudget(0)
+	unlimited.start = time.Now().Add(-24 * time.Hour)
+	if unlimited.exhausted("reap", a) {
+		t.Fatal("zero budget must never be exhausted")
+	}
+
+	clock := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	budget := newReaperCycleBudget(10 * time.Minute)
+	budget.start = clock
+	budget.now = func() time.Time { return clock }
+
+	if budget.exhausted("reap", a) {
+		t.Fatal("budget exhausted before any time passed")
+	}
+	clock = clock.Add(11 * time.Minute)
+	if !budget.exhausted("reap", b) {
+		t.Fatal("budget should be exhausted after 11m of 10m")
+	}
+	if !budget.exhausted("purge", a) {
+		t.Fatal("later phases must stay deferred once exhausted")
+	}
+	if budget.deferred != "gastown" {
+		t.Errorf("deferred = %q, want first skipped target gastown", budget.deferred)
+	}
+	if !budget.phases["reap"] || !budget.phases["purge"] || budget.phases["auto-close"] {
+		t.Errorf("phases = %v, want reap and purge only", budget.phases)
+	}
+}
+
+func TestRotateReaperTargets(t *testing.T) {
+	targets := []reaperTarget{{label: "a"}, {label: "b"}, {label: "c"}}
+	labels := func(ts []reaperTarget) string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.label)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := labels(rotateReaperTargets(targets, "b")); got != "b,c,a" {
+		t.Errorf("resume at b = %s, want b,c,a", got)
+	}
+	if got := labels(rotateReaperTargets(targets, "")); got != "a,b,c" {
+		t.Errorf("no resume point = %s, want a,b,c", got)
+	}
+	if got := labels(rotateReaperTargets(targets, "gone")); got != "a,b,c" {
+		t.Errorf("unknown resume point = %s, want a,b,c", got)
+	}
+	if got := labels(targets); got != "a,b,c" {
+		t.Errorf("input mutated: %s", got)
+	}
+}
+
+func TestWispReaperTargets_ExcludesSystemAndSkippedDatabases(t *testing.T) {
+	t.Setenv("GT_DEBUG", "1")
+	var logBuf strings.Builder
+	d := &Daemon{logger: log.New(&logBuf, "", 0)}
+
+	targets := d.wispReaperTargets(context.Background(), &WispReaperConfig{
+		Databases:     []string{"hq", "information_schema", "MySQL", "scratch", "gastown"},
+		SkipDatabases: []string{"Scratch"},
+	})
+	var got []string
+	for _, tgt := range targets {
+		got = append(got, tgt.dbName)
+	}
+	if strings.Join(got, ",") != "hq,gastown" {
+		t.Errorf("targets = %v, want [hq gastown]", got)
+	}
+	if !strings.Contains(logBuf.String(), "information_schema, MySQL, scratch") {
+		t.Errorf("expected skipped databases to be logged, got %q", logBuf.String())
+	}
+
+	sharded := d.wispReaperTargets(context.Background(), &WispReaperConfig{
+		SkipDatabases: []string{"beads"},
+		Endpoints:     []DoltEndpointConfig{{Address: "dolt-b:3308", Databases: []string{"performance_schema", "beads", "wallet"}}},
+	})
+	if len(sharded) != 1 || sharded[0].dbName != "wallet" {
+		t.Errorf("endpoint targets = %+v, want only wallet", sharded)
+	}
+
+	// The same databases are skipped every cycle: only logged with GT_DEBUG.
+	t.Setenv("GT_DEBUG", "")
+	logBuf.Reset()
+	d.wispReaperTargets(context.Background(), &WispReaperConfig{Databases: []string{"hq", "mysql"}})
+	if logBuf.Len() != 0 {
+		t.Errorf("skipped databases logged without GT_DEBUG: %q", logBuf.String())
+	}
+}
+
+func TestReaperDigest(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	first := newReaperDigest(start, false)
+	first.counts("hq").Reaped = 3
+	first.counts("hq").MailPurged = 2
+	first.Alerts = []string{"wisp_reaper: WARNING: 600 open patrol wisps exceed threshold 500 — investigate wisp lifecycle"}
+
+	second := newReaperDigest(start.Add(time.Hour), false)
+	second.counts("hq").Reaped = 1
+	second.counts("gastown").AutoClosed = 4
+	second.addError("gastown", "purge", errors.New("lock wait timeout"))
+
+	first.merge(second)
+	if first.Cycles != 2 {
+		t.Errorf("Cycles = %d, want 2", first.Cycles)
+	}
+	if got := *first.counts("hq"); got != (reaperDigestCounts{Reaped: 4, MailPurged: 2}) {
+		t.Errorf("hq counts = %+v", got)
+	}
+	if len(first.Alerts) != 0 {
+		t.Errorf("merge should keep only the latest cycle's alerts, got %v", first.Alerts)
+	}
+
+	if got, want := first.subject(), "Wisp reaper digest: reaped 4, purged 0, mail purged 2, auto-closed 4, 1 error(s)"; got != want {
+		t.Errorf("subject = %q, want %q", got, want)
+	}
+	body := first.body(start.Add(2 * time.Hour))
+	for _, want := range []string{"2 reaper cycle(s)", "gastown", "hq", "gastown: purge: lock wait timeout"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestReaperDigestCapsErrors(t *testing.T) {
+	g := newReaperDigest(time.Now(), false)
+	for i := 0; i < reaperDigestMaxErrors+3; i++ {
+		g.addError("hq", "reap", errors.New("boom"))
+	}
+	if len(g.Errors) != reaperDigestMaxErrors || g.ErrorsDropped != 3 {
+		t.Errorf("errors = %d listed, %d dropped; want %d, 3", len(g.Errors), g.ErrorsDropped, reaperDigestMaxErrors)
+	}
+}
+
+func TestReaperClock(t *testing.T) {
+	d := &Daemon{}
+	if got := d.reaperClock(); time.Since(got) > time.Minute {
+		t.Errorf("default reaperClock() = %v, want about now", got)
+	}
+	frozen := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	d.reaperNow = func() time.Time { return frozen }
+	if got := d.reaperClock(); !got.Equal(frozen) {
+		t.Errorf("reaperClock() = %v, want %v", got, frozen)
+	}
+}
+
+func TestWispReaperAutoCloseOptions(t *testing.T) {
+	opts, err := wispReaperAutoCloseOptions(&WispReaperConfig{
+		AutoCloseUntil:   "2025-01-01",
+		BusinessDaysOnly: true,
+		Holidays:         []string{"2024-12-25"},
+	})
+	if err != nil {
+		t.Fatalf("wispReaperAutoCloseOptions: %v", err)
+	}
+	if !opts.BusinessDaysOnly || len(opts.Holidays) != 1 || opts.Window.Until.IsZero() {
+		t.Errorf("opts = %+v, want business days, one holiday and an until bound", opts)
+	}
+	if _, err := wispReaperAutoCloseOptions(&WispReaperConfig{Holidays: []string{"Christmas"}}); err == nil {
+		t.Error("expected an error for a non-date holiday")
+	}
+}
+
+func TestWispReaperPreviewOptions(t *testing.T) {
+	opts, err := WispReaperPreviewOptions(nil)
+	if err != nil {
+		t.Fatalf("nil config: %v", err)
+	}
+	if opts.MaxAge != defaultWispMaxAge || opts.PurgeAge != defaultWispDeleteAge || opts.StaleIssueAge != defaultStaleIssueAge {
+		t.Errorf("defaults = %+v", opts)
+	}
+
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{WispReaper: &WispReaperConfig{
+		MaxAgeStr:         "2h",
+		BusinessDaysOnly:  true,
+		KeepRecentPerType: 5,
+	}}}
+	opts, err = WispReaperPreviewOptions(config)
+	if err != nil {
+		t.Fatalf("configured: %v", err)
+	}
+	if opts.MaxAge != 2*time.Hour || !opts.AutoClose.BusinessDaysOnly || opts.KeepRecentPerType != 5 {
+		t.Errorf("configured = %+v, want max age 2h, business days and keep 5 per type", opts)
+	}
+
+	config.Patrols.WispReaper.Holidays = []string{"someday"}
+	if _, err := WispReaperPreviewOptions(config); err == nil {
+		t.Error("expected an error for an invalid holiday")
+	}
+}
+
+func TestFormatTypeCounts(t *testing.T) {
+	if got := formatTypeCounts(map[string]int{"patrol": 2, "mail": 1}); got != "mail=1 patrol=2" {
+		t.Errorf("formatTypeCounts = %q, want %q", got, "mail=1 patrol=2")
+	}
+}
+
+func TestWispReaperFirstRunLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		config WispReaperConfig
+		want   int
+	}{
+		{"default", WispReaperConfig{}, defaultFirstRunDeleteLimit},
+		{"configured", WispReaperConfig{FirstRunDeleteLimit: 500}, 500},
+		{"disabled", WispReaperConfig{FirstRunDeleteLimit: -1}, 0},
+		{"acknowledged", WispReaperConfig{FirstRunAcknowledged: true}, 0},
+		{"dry run", WispReaperConfig{DryRun: true}, 0},
+		{"report only", WispReaperConfig{ReportOnly: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wispReaperFirstRunLimit(&tt.config); got != tt.want {
+				t.Errorf("wispReaperFirstRunLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldFirstRunPurgeDoesNotTripBreaker(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		logger:       log.New(io.Discard, "", 0),
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{FailureLimit: 2}},
+	}
+	config := &WispReaperConfig{Enabled: true, FirstRunDeleteLimit: 100}
+	targets := []reaperTarget{{dbName: "gastown", label: "gastown"}, {dbName: "beads", label: "beads"}}
+
+	reaperFirstRunCount = func(_ context.Context, t reaperTarget, _ time.Duration, _ reaper.PurgeOptions) (int, int, bool, error) {
+		if t.dbName == "gastown" {
+			return 90, 0, false, nil
+		}
+		return 20, 5, false, nil
+	}
+	var alerts []string
+	reaperFirstRunEscalate = func(_ *Daemon, _, message string) { alerts = append(alerts, message) }
+	var trips []string
+	patrolTripEscalate = func(_ *Daemon, source, _ string) { trips = append(trips, source) }
+
+	held := 0
+	patrolRunners["test_reaper"] = func(d *Daemon) {
+		mol := &dogMol{logger: d.logger, onFail: d.notePatrolFailure}
+		if d.holdFirstRunPurge(context.Background(), config, targets, time.Hour, reaper.PurgeOptions{}, d.logger, &reaperDigest{}) {
+			held++
+			closeHeldPurgeStep(mol)
+		} else {
+			mol.closeStep("purge")
+		}
+		mol.close()
+	}
+	t.Cleanup(func() {
+		delete(patrolRunners, "test_reaper")
+		reaperFirstRunCount = countFirstRunPurge
+		reaperFirstRunEscalate = (*Daemon).escalate
+		patrolTripEscalate = (*Daemon).escalate
+	})
+
+	for i := 0; i < 4; i++ {
+		d.runPatrol("test_reaper")
+	}
+	if held != 4 {
+		t.Errorf("held %d of 4 cycles over the limit", held)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("escalated %d times, want once across held cycles: %v", len(alerts), alerts)
+	}
+	if len(trips) != 0 {
+		t.Errorf("held purges tripped the circuit breaker: %v", trips)
+	}
+	health, err := LoadPatrolHealth(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatrolHealth: %v", err)
+	}
+	if h := health["test_reaper"]; h.Tripped || h.ConsecutiveFailures != 0 {
+		t.Errorf("health after held cycles = %+v, want no failures", h)
+	}
+
+	// A database that cannot be counted holds the purge even though the
+	// others are under the limit.
+	reaperFirstRunCount = func(_ context.Context, t reaperTarget, _ time.Duration, _ reaper.PurgeOptions) (int, int, bool, error) {
+		if t.dbName == "beads" {
+			return 0, 0, false, errors.New("connection refused")
+		}
+		return 10, 0, false, nil
+	}
+	d.runPatrol("test_reaper")
+	if held != 5 {
+		t.Error("purge not held when a database could not be counted")
+	}
+	if len(alerts) != 1 {
+		t.Errorf("escalated again while the hold was already escalated: %v", alerts)
+	}
+	if loadReaperRunRecord(townRoot).HoldEscalated.IsZero() {
+		t.Error("escalation not recorded in the run record")
+	}
+}
+
+func TestReaperFirstRunPending(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{config: &Config{TownRoot: townRoot}}
+	config := &WispReaperConfig{Enabled: true}
+
+	if !d.reaperFirstRunPending(config) {
+		t.Fatal("first run not pending on a fresh town")
+	}
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := recordReaperRun(townRoot, first); err != nil {
+		t.Fatalf("recordReaperRun: %v", err)
+	}
+	if d.reaperFirstRunPending(config) {
+		t.Error("first run still pending after a recorded purge")
+	}
+
+	// Later purges keep the original record.
+	if err := recordReaperRun(townRoot, first.Add(time.Hour)); err != nil {
+		t.Fatalf("recordReaperRun: %v", err)
+	}
+	data, err := os.ReadFile(reaperRunFile(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "2026-03-01T12:00:00Z") {
+		t.Errorf("run record = %s, want the first purge time", data)
+	}
+}
+
+func TestReaperPhaseSkip(t *testing.T) {
+	off := false
+	config := &WispReaperConfig{Phases: map[string]ReaperPhaseConfig{
+		"purge":      {IntervalStr: "168h"},
+		"auto-close": {Enabled: &off},
+		"reap":       {IntervalStr: "bogus"},
+	}}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	if got := reaperPhaseSkip(config, "purge", time.Time{}, now); got != "" {
+		t.Errorf("purge never run: skip = %q, want due", got)
+	}
+	if got := reaperPhaseSkip(config, "purge", now.Add(-24*time.Hour), now); !strings.HasPrefix(got, "not due") {
+		t.Errorf("purge ran a day ago: skip = %q, want not due", got)
+	}
+	if got := reaperPhaseSkip(config, "purge", now.Add(-8*24*time.Hour), now); got != "" {
+		t.Errorf("purge ran 8 days ago: skip = %q, want due", got)
+	}
+	if got := reaperPhaseSkip(config, "auto-close", time.Time{}, now); got != "disabled" {
+		t.Errorf("auto-close disabled: skip = %q", got)
+	}
+	if got := reaperPhaseSkip(config, "reap", now.Add(-time.Minute), now); got != "" {
+		t.Errorf("invalid interval: skip = %q, want due every cycle", got)
+	}
+	if got := reaperPhaseSkip(&WispReaperConfig{}, "reap", now, now); got != "" {
+		t.Errorf("unscheduled phase: skip = %q, want due", got)
+	}
+}
+
+func TestReaperPhaseRuns(t *testing.T) {
+	townRoot := t.TempDir()
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if err := recordReaperPhaseRun(townRoot, "purge", at); err != nil {
+		t.Fatalf("recordReaperPhaseRun: %v", err)
+	}
+	if err := recordReaperPhaseRun(townRoot, "auto-close", at.Add(time.Hour)); err != nil {
+		t.Fatalf("recordReaperPhaseRun: %v", err)
+	}
+	runs := loadReaperPhaseRuns(townRoot)
+	if !runs["purge"].Equal(at) || !runs["auto-close"].Equal(at.Add(time.Hour)) || len(runs) != 2 {
+		t.Errorf("loadReaperPhaseRuns = %v", runs)
+	}
+}
@@ -0,0 +1,194 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// AccountsConfigCheck verifies mayor/accounts.json parses and validates,
+// that every account's config_dir exists, and that ~/.claude resolves.
+// 'gt account switch' makes ~/.claude a symlink to the active account, so a
+// moved or deleted config_dir leaves Claude with no config at all.
+type AccountsConfigCheck struct {
+	BaseCheck
+}
+
+// NewAccountsConfigCheck creates a new accounts configuration check.
+func NewAccountsConfigCheck() *AccountsConfigCheck {
+	return &AccountsConfigCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "accounts-config",
+			CheckDescription: "Check accounts.json is valid and ~/.claude resolves",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// Run validates accounts.json and the config directories it names.
+func (c *AccountsConfigCheck) Run(ctx *CheckContext) *CheckResult {
+	var details []string
+	status := StatusOK
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not determine home directory",
+			Details: []string{err.Error()},
+		}
+	}
+	claudeDir := filepath.Join(home, ".claude")
+	if info, err := os.Lstat(claudeDir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if _, err := filepath.EvalSymlinks(claudeDir); err != nil {
+			target, _ := os.Readlink(claudeDir)
+			status = StatusError
+			details = append(details, fmt.Sprintf("~/.claude is a broken symlink to %s", target))
+		}
+	}
+
+	accountsPath := constants.MayorAccountsPath(ctx.TownRoot)
+	cfg, err := config.LoadAccountsConfig(accountsPath)
+	if errors.Is(err, config.ErrNotFound) {
+		if status == StatusOK {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusOK,
+				Message: "No accounts configured (using ~/.claude)",
+			}
+		}
+	} else if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "mayor/accounts.json is invalid",
+			Details: append(details, err.Error()),
+			FixHint: "Fix or remove mayor/accounts.json, then re-add accounts with 'gt account add'",
+		}
+	} else {
+		handles := make([]string, 0, len(cfg.Accounts))
+		for handle := range cfg.Accounts {
+			handles = append(handles, handle)
+		}
+		sort.Strings(handles)
+		for _, handle := range handles {
+			dir := cfg.Accounts[handle].ConfigDir
+			if _, err := os.Stat(dir); err != nil {
+				if status == StatusOK {
+					status = StatusWarning
+				}
+				details = append(details, fmt.Sprintf("account %s: config_dir %s does not exist", handle, dir))
+			}
+		}
+	}
+
+	if status == StatusOK {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d account(s) configured, ~/.claude resolves", len(cfg.Accounts)),
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Message: fmt.Sprintf("%d account configuration problem(s)", len(details)),
+		Details: details,
+		FixHint: "Restore the missing directories, or run 'gt account switch <handle>' to relink ~/.claude",
+	}
+}
+
+// SharedCommandsSymlinkCheck detects broken commands/ and skills/ symlinks
+// in ~/.claude and the account config directories. 'gt account add' links
+// each account's commands/ to the shared ~/.claude/commands so custom
+// commands and skills work whichever account is active; a dangling link
+// silently hides them.
+type SharedCommandsSymlinkCheck struct {
+	FixableCheck
+	broken []string // Dangling symlinks, cached for Fix
+}
+
+// NewSharedCommandsSymlinkCheck creates a new shared commands symlink check.
+func NewSharedCommandsSymlinkCheck() *SharedCommandsSymlinkCheck {
+	return &SharedCommandsSymlinkCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "commands-symlinks",
+				CheckDescription: "Detect broken commands/skills symlinks in Claude config dirs",
+				CheckCategory:    CategoryConfig,
+			},
+		},
+	}
+}
+
+// Run checks the commands/ and skills/ entries of every Claude config dir.
+func (c *SharedCommandsSymlinkCheck) Run(ctx *CheckContext) *CheckResult {
+	c.broken = nil
+
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claude"))
+	}
+	if cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(ctx.TownRoot)); err == nil {
+		handles := make([]string, 0, len(cfg.Accounts))
+		for handle := range cfg.Accounts {
+			handles = append(handles, handle)
+		}
+		sort.Strings(handles)
+		for _, handle := range handles {
+			dirs = append(dirs, cfg.Accounts[handle].ConfigDir)
+		}
+	}
+
+	var details []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		for _, name := range []string{"commands", "skills"} {
+			link := filepath.Join(dir, name)
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+			info, err := os.Lstat(link)
+			if err != nil || info.Mode()&os.ModeSymlink == 0 {
+				continue
+			}
+			if _, err := filepath.EvalSymlinks(link); err != nil {
+				target, _ := os.Readlink(link)
+				c.broken = append(c.broken, link)
+				details = append(details, fmt.Sprintf("%s -> %s (missing)", link, target))
+			}
+		}
+	}
+
+	if len(c.broken) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "All commands/skills symlinks resolve",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d broken commands/skills symlink(s)", len(c.broken)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to remove them, then 'gt account add' or 'gt account switch' to relink",
+	}
+}
+
+// Fix removes the dangling symlinks found by Run.
+func (c *SharedCommandsSymlinkCheck) Fix(ctx *CheckContext) error {
+	for _, link := range c.broken {
+		if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %w", link, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// setupAccountsTown creates a town with a fake HOME and an accounts.json
+// containing one account per handle, each with its own config dir.
+func setupAccountsTown(t *testing.T, handles ...string) (townRoot, home string, cfg *config.AccountsConfig) {
+	t.Helper()
+	townRoot = t.TempDir()
+	home = t.TempDir()
+	t.Setenv("HOME", home)
+
+	if len(handles) == 0 {
+		return townRoot, home, nil
+	}
+	cfg = &config.AccountsConfig{
+		Version:  config.CurrentAccountsVersion,
+		Accounts: make(map[string]config.Account),
+		Default:  handles[0],
+	}
+	for _, handle := range handles {
+		dir := filepath.Join(home, "claude-accounts", handle)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		cfg.Accounts[handle] = config.Account{Email: handle + "@example.com", ConfigDir: dir}
+	}
+	if err := config.SaveAccountsConfig(constants.MayorAccountsPath(townRoot), cfg); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, home, cfg
+}
+
+func TestAccountsConfigCheck_NoAccounts(t *testing.T) {
+	townRoot, _, _ := setupAccountsTown(t)
+
+	result := NewAccountsConfigCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
+
+func TestAccountsConfigCheck_Valid(t *testing.T) {
+	townRoot, home, cfg := setupAccountsTown(t, "work", "personal")
+	if err := os.Symlink(cfg.Accounts["work"].ConfigDir, filepath.Join(home, ".claude")); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewAccountsConfigCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s %v", result.Status, result.Message, result.Details)
+	}
+}
+
+func TestAccountsConfigCheck_InvalidJSON(t *testing.T) {
+	townRoot, _, _ := setupAccountsTown(t)
+	path := constants.MayorAccountsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewAccountsConfigCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Errorf("Status = %v, want Error: %s", result.Status, result.Message)
+	}
+}
+
+func TestAccountsConfigCheck_MissingConfigDir(t *testing.T) {
+	townRoot, _, cfg := setupAccountsTown(t, "work")
+	if err := os.RemoveAll(cfg.Accounts["work"].ConfigDir); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewAccountsConfigCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusWarning {
+		t.Errorf("Status = %v, want Warning: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], "account work") {
+		t.Errorf("Details = %v, want one entry for account work", result.Details)
+	}
+}
+
+func TestAccountsConfigCheck_DanglingClaudeSymlink(t *testing.T) {
+	townRoot, home, _ := setupAccountsTown(t)
+	if err := os.Symlink(filepath.Join(home, "gone"), filepath.Join(home, ".claude")); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewAccountsConfigCheck().Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Errorf("Status = %v, want Error: %s", result.Status, result.Message)
+	}
+	if !strings.Contains(result.FixHint, "gt account switch") {
+		t.Errorf("FixHint = %q, want mention of 'gt account switch'", result.FixHint)
+	}
+}
+
+func TestSharedCommandsSymlinkCheck_DetectsAndFixes(t *testing.T) {
+	townRoot, home, cfg := setupAccountsTown(t, "work")
+	shared := filepath.Join(home, ".claude", "commands")
+	if err := os.MkdirAll(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	accountDir := cfg.Accounts["work"].ConfigDir
+	if err := os.Symlink(shared, filepath.Join(accountDir, "commands")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(home, "missing-skills"), filepath.Join(accountDir, "skills")); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewSharedCommandsSymlinkCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want Warning: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], "skills") {
+		t.Errorf("Details = %v, want only the skills link", result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(accountDir, "skills")); !os.IsNotExist(err) {
+		t.Errorf("dangling skills link still present after Fix")
+	}
+	if _, err := os.Lstat(filepath.Join(accountDir, "commands")); err != nil {
+		t.Errorf("valid commands link removed by Fix: %v", err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after Fix Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
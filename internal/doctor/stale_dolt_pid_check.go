@@ -0,0 +1,100 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// StaleDoltServerPIDCheck detects dolt-server.pid files that no longer name
+// the running server: a dead PID, a corrupt file, or a live PID reused by an
+// unrelated process while another process holds the configured port. bd
+// trusts the file and can hang connecting to the wrong server.
+// It applies the same rules as beads.CleanStaleDoltServerPID without
+// removing anything until --fix.
+type StaleDoltServerPIDCheck struct {
+	FixableCheck
+	staleDirs []string // .beads dirs with a stale PID file, cached for Fix
+}
+
+// NewStaleDoltServerPIDCheck creates a new stale dolt-server.pid check.
+func NewStaleDoltServerPIDCheck() *StaleDoltServerPIDCheck {
+	return &StaleDoltServerPIDCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "stale-dolt-server-pid",
+				CheckDescription: "Detect dolt-server.pid files that do not name the running server",
+				CheckCategory:    CategoryInfrastructure,
+			},
+		},
+	}
+}
+
+// Run checks the town and rig .beads directories for stale PID files.
+func (c *StaleDoltServerPIDCheck) Run(ctx *CheckContext) *CheckResult {
+	c.staleDirs = nil
+
+	var details []string
+	for _, beadsDir := range doltPIDBeadsDirs(ctx.TownRoot) {
+		reason := beads.StaleDoltServerPIDReason(beadsDir)
+		if reason == "" {
+			continue
+		}
+		c.staleDirs = append(c.staleDirs, beadsDir)
+		relPath, _ := filepath.Rel(ctx.TownRoot, filepath.Join(beadsDir, "dolt", "dolt-server.pid"))
+		details = append(details, fmt.Sprintf("%s: %s", relPath, reason))
+	}
+
+	if len(c.staleDirs) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No stale dolt-server.pid files",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d stale dolt-server.pid file(s)", len(c.staleDirs)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to remove them",
+	}
+}
+
+// Fix removes the stale PID files found by Run.
+func (c *StaleDoltServerPIDCheck) Fix(ctx *CheckContext) error {
+	for _, beadsDir := range c.staleDirs {
+		beads.CleanStaleDoltServerPID(beadsDir)
+	}
+	return nil
+}
+
+// doltPIDBeadsDirs returns the town and rig .beads directories that have a
+// dolt/ subdirectory, where a dolt-server.pid can be left behind.
+func doltPIDBeadsDirs(townRoot string) []string {
+	candidates := []string{filepath.Join(townRoot, ".beads")}
+	rigs := loadRigNames(filepath.Join(townRoot, "mayor", "rigs.json"))
+	names := make([]string, 0, len(rigs))
+	for name := range rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		candidates = append(candidates,
+			filepath.Join(townRoot, name, ".beads"),
+			filepath.Join(townRoot, name, "mayor", "rig", ".beads"),
+		)
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if info, err := os.Stat(filepath.Join(dir, "dolt")); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
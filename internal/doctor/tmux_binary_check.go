@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// TmuxBinaryCheck verifies that tmux is installed. Every agent session runs
+// in tmux, so nothing can start without it. Informational, no auto-fix.
+type TmuxBinaryCheck struct {
+	BaseCheck
+}
+
+// NewTmuxBinaryCheck creates a new tmux availability check.
+func NewTmuxBinaryCheck() *TmuxBinaryCheck {
+	return &TmuxBinaryCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "tmux-binary",
+			CheckDescription: "Check that tmux is installed",
+			CheckCategory:    CategoryInfrastructure,
+		},
+	}
+}
+
+// Run checks if tmux is available in PATH and reports its version.
+func (c *TmuxBinaryCheck) Run(ctx *CheckContext) *CheckResult {
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "tmux not found in PATH",
+			Details: []string{"Agent sessions run in tmux and cannot start without it"},
+			FixHint: "Install tmux (e.g. 'brew install tmux' or 'apt install tmux')",
+		}
+	}
+
+	out, err := exec.Command(path, "-V").Output() //nolint:gosec // G204: path from LookPath
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "tmux found but 'tmux -V' failed",
+			Details: []string{err.Error()},
+			FixHint: "Check the tmux install at " + path,
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: strings.TrimSpace(string(out)),
+	}
+}
@@ -0,0 +1,120 @@
+package doltserver
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// doltCommitHash matches a Dolt commit hash (32 base32 characters).
+var doltCommitHash = regexp.MustCompile(`^[0-9a-v]{32}$`)
+
+// BackupDelta describes what a backup sync of one database will carry: the
+// commits made since the commit recorded at its last backup.
+type BackupDelta struct {
+	Database   string `json:"database"`
+	LastCommit string `json:"last_commit,omitempty"`
+	HeadCommit string `json:"head_commit"`
+	// NewCommits counts commits reachable from HEAD but not from
+	// LastCommit; with no LastCommit it is the whole history.
+	NewCommits    int      `json:"new_commits"`
+	ChangedTables []string `json:"changed_tables,omitempty"`
+	// FirstBackup is set when no backed-up commit is recorded yet, so the
+	// delta cannot be narrowed down.
+	FirstBackup bool `json:"first_backup,omitempty"`
+}
+
+// Summary renders the delta as one line for logs and reports.
+func (d *BackupDelta) Summary() string {
+	switch {
+	case d.FirstBackup:
+		return fmt.Sprintf("no backed-up commit recorded; HEAD %s has %d commit(s) of history", shortHash(d.HeadCommit), d.NewCommits)
+	case d.NewCommits == 0:
+		return fmt.Sprintf("no new commits since last backup (HEAD %s)", shortHash(d.HeadCommit))
+	}
+	summary := fmt.Sprintf("%d new commit(s) since %s, %d table(s) changed", d.NewCommits, shortHash(d.LastCommit), len(d.ChangedTables))
+	if len(d.ChangedTables) > 0 {
+		summary += ": " + strings.Join(d.ChangedTables, ", ")
+	}
+	return summary
+}
+
+// BackupHead returns the HEAD commit hash of the database in dataDir/<db>.
+func BackupHead(ctx context.Context, dataDir, db string) (string, error) {
+	out, err := runDoltIn(ctx, filepath.Join(dataDir, db), "sql", "-r", "csv", "-q", "SELECT DOLT_HASHOF('HEAD')")
+	if err != nil {
+		return "", fmt.Errorf("reading HEAD of %s: %w", db, err)
+	}
+	values, err := parseCSVColumn(out)
+	if err != nil || len(values) != 1 || !doltCommitHash.MatchString(values[0]) {
+		return "", fmt.Errorf("reading HEAD of %s: unexpected output %q", db, strings.TrimSpace(out))
+	}
+	return values[0], nil
+}
+
+// ComputeBackupDelta compares the HEAD of the database in dataDir/<db> with
+// lastCommit, the commit recorded at its last backup (see
+// RecordBackupCommit), and counts the new commits and changed tables.
+func ComputeBackupDelta(ctx context.Context, dataDir, db, lastCommit string) (*BackupDelta, error) {
+	head, err := BackupHead(ctx, dataDir, db)
+	if err != nil {
+		return nil, err
+	}
+	delta := &BackupDelta{Database: db, HeadCommit: head}
+	dbDir := filepath.Join(dataDir, db)
+
+	if lastCommit == "" || !doltCommitHash.MatchString(lastCommit) {
+		delta.FirstBackup = true
+		n, err := doltCount(ctx, dbDir, "SELECT COUNT(*) FROM dolt_log")
+		if err != nil {
+			return nil, fmt.Errorf("counting commits in %s: %w", db, err)
+		}
+		delta.NewCommits = n
+		return delta, nil
+	}
+	delta.LastCommit = lastCommit
+	if lastCommit == head {
+		return delta, nil
+	}
+
+	n, err := doltCount(ctx, dbDir, fmt.Sprintf("SELECT COUNT(*) FROM dolt_log('%s..HEAD')", lastCommit))
+	if err != nil {
+		return nil, fmt.Errorf("counting new commits in %s: %w", db, err)
+	}
+	delta.NewCommits = n
+
+	out, err := runDoltIn(ctx, dbDir, "sql", "-r", "csv", "-q",
+		fmt.Sprintf("SELECT DISTINCT table_name FROM dolt_diff_summary('%s', 'HEAD') ORDER BY table_name", lastCommit))
+	if err != nil {
+		return nil, fmt.Errorf("listing changed tables in %s: %w", db, err)
+	}
+	tables, err := parseCSVColumn(out)
+	if err != nil {
+		return nil, fmt.Errorf("listing changed tables in %s: %w", db, err)
+	}
+	delta.ChangedTables = tables
+	return delta, nil
+}
+
+// doltCount runs a single-value COUNT query in dir.
+func doltCount(ctx context.Context, dir, query string) (int, error) {
+	out, err := runDoltIn(ctx, dir, "sql", "-r", "csv", "-q", query)
+	if err != nil {
+		return 0, err
+	}
+	values, err := parseCSVColumn(out)
+	if err != nil || len(values) != 1 {
+		return 0, fmt.Errorf("unexpected output %q", strings.TrimSpace(out))
+	}
+	return strconv.Atoi(values[0])
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,105 @@
+package doltserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const (
+	testHeadCommit = "0123456789abcdefghijklmnopqrstuv"
+	testLastCommit = "vutsrqponmlkjihgfedcba9876543210"
+)
+
+// installFakeDolt puts a dolt on PATH that answers the delta queries.
+func installFakeDolt(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script needs a POSIX shell")
+	}
+	bin := t.TempDir()
+	script := `#!/bin/sh
+case "$5" in
+  *DOLT_HASHOF*) printf 'hash\n` + testHeadCommit + `\n' ;;
+  *"dolt_log('"*) printf 'count\n3\n' ;;
+  *dolt_log*) printf 'count\n42\n' ;;
+  *dolt_diff_summary*) printf 'table_name\nissues\nwisps\n' ;;
+  *) echo "unexpected query: $5" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "dolt"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestComputeBackupDelta(t *testing.T) {
+	installFakeDolt(t)
+	dataDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dataDir, "hq"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	delta, err := ComputeBackupDelta(ctx, dataDir, "hq", testLastCommit)
+	if err != nil {
+		t.Fatalf("ComputeBackupDelta: %v", err)
+	}
+	want := &BackupDelta{Database: "hq", LastCommit: testLastCommit, HeadCommit: testHeadCommit, NewCommits: 3, ChangedTables: []string{"issues", "wisps"}}
+	if !reflect.DeepEqual(delta, want) {
+		t.Errorf("delta = %+v, want %+v", delta, want)
+	}
+
+	delta, err = ComputeBackupDelta(ctx, dataDir, "hq", testHeadCommit)
+	if err != nil {
+		t.Fatalf("ComputeBackupDelta at HEAD: %v", err)
+	}
+	if delta.NewCommits != 0 || len(delta.ChangedTables) != 0 {
+		t.Errorf("unchanged delta = %+v, want no commits or tables", delta)
+	}
+
+	delta, err = ComputeBackupDelta(ctx, dataDir, "hq", "")
+	if err != nil {
+		t.Fatalf("ComputeBackupDelta first backup: %v", err)
+	}
+	if !delta.FirstBackup || delta.NewCommits != 42 {
+		t.Errorf("first backup delta = %+v, want FirstBackup with 42 commits", delta)
+	}
+}
+
+func TestBackupDeltaSummary(t *testing.T) {
+	tests := []struct {
+		delta BackupDelta
+		want  string
+	}{
+		{BackupDelta{HeadCommit: testHeadCommit, NewCommits: 42, FirstBackup: true}, "no backed-up commit recorded; HEAD 01234567 has 42 commit(s) of history"},
+		{BackupDelta{HeadCommit: testHeadCommit, LastCommit: testHeadCommit}, "no new commits since last backup (HEAD 01234567)"},
+		{BackupDelta{HeadCommit: testHeadCommit, LastCommit: testLastCommit, NewCommits: 3, ChangedTables: []string{"issues", "wisps"}}, "3 new commit(s) since vutsrqpo, 2 table(s) changed: issues, wisps"},
+	}
+	for _, tt := range tests {
+		if got := tt.delta.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBackupHeadRejectsUnexpectedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script needs a POSIX shell")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "dolt"), []byte("#!/bin/sh\nprintf 'hash\\nnot-a-hash\\n'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	dataDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dataDir, "hq"), 0755)
+
+	if _, err := BackupHead(context.Background(), dataDir, "hq"); err == nil || !strings.Contains(err.Error(), "unexpected output") {
+		t.Errorf("BackupHead err = %v, want unexpected output", err)
+	}
+}
@@ -0,0 +1,130 @@
+package doltserver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// DefaultBackupMinInterval is how recently a database must have been backed
+// up for another sync to be skipped. It only absorbs a patrol tick and a
+// manual run landing together, so it is short.
+const DefaultBackupMinInterval = time.Minute
+
+// BackupState records the last successful `dolt backup sync` per database,
+// shared by the dolt_backup patrol and the backup commands.
+type BackupState struct {
+	LastSuccess map[string]time.Time `json:"last_success"`
+	// LastCommit is the HEAD commit each database was at when it was last
+	// backed up, the baseline for the next backup's delta.
+	LastCommit map[string]string `json:"last_commit,omitempty"`
+	// LastVerified is when each database's backup last passed VerifyBackup.
+	LastVerified map[string]time.Time `json:"last_verified,omitempty"`
+}
+
+// BackupStateFile returns the path of the shared backup state.
+func BackupStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "dolt-backup-state.json")
+}
+
+// LoadBackupState reads the backup state; a missing file is an empty state.
+func LoadBackupState(townRoot string) (*BackupState, error) {
+	state := &BackupState{LastSuccess: make(map[string]time.Time)}
+	data, err := os.ReadFile(BackupStateFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.LastSuccess == nil {
+		state.LastSuccess = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// RecentBackupAge reports how long ago db was last backed up and whether that
+// is within minInterval, in which case the caller should skip the sync. An
+// unreadable state never skips.
+func RecentBackupAge(townRoot, db string, minInterval time.Duration, now time.Time) (time.Duration, bool) {
+	if minInterval <= 0 {
+		return 0, false
+	}
+	state, err := LoadBackupState(townRoot)
+	if err != nil {
+		return 0, false
+	}
+	last, ok := state.LastSuccess[db]
+	if !ok {
+		return 0, false
+	}
+	age := now.Sub(last)
+	return age, age >= 0 && age < minInterval
+}
+
+// RecordBackupSuccess records a successful sync of db at t.
+func RecordBackupSuccess(townRoot, db string, t time.Time) error {
+	return updateBackupState(townRoot, func(state *BackupState) {
+		state.LastSuccess[db] = t.UTC()
+	})
+}
+
+// RecordBackupCommit records commit as the HEAD db was backed up at.
+func RecordBackupCommit(townRoot, db, commit string) error {
+	return updateBackupState(townRoot, func(state *BackupState) {
+		if state.LastCommit == nil {
+			state.LastCommit = make(map[string]string)
+		}
+		state.LastCommit[db] = commit
+	})
+}
+
+// RecordBackupVerified records t as the last time db's backup verified.
+func RecordBackupVerified(townRoot, db string, t time.Time) error {
+	return updateBackupState(townRoot, func(state *BackupState) {
+		if state.LastVerified == nil {
+			state.LastVerified = make(map[string]time.Time)
+		}
+		state.LastVerified[db] = t.UTC()
+	})
+}
+
+// BackupVerifyDue reports whether db's backup has not passed verification
+// within interval. A never-verified backup or an unreadable state is due.
+func BackupVerifyDue(townRoot, db string, interval time.Duration, now time.Time) bool {
+	state, err := LoadBackupState(townRoot)
+	if err != nil {
+		return true
+	}
+	last, ok := state.LastVerified[db]
+	return !ok || now.Sub(last) >= interval
+}
+
+// updateBackupState applies update to the backup state. The state file is
+// locked so the patrol and a manual run do not drop each other's updates.
+func updateBackupState(townRoot string, update func(*BackupState)) error {
+	path := BackupStateFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	state, err := LoadBackupState(townRoot)
+	if err != nil {
+		// A corrupt state only costs one redundant sync; start fresh.
+		state = &BackupState{LastSuccess: make(map[string]time.Time)}
+	}
+	update(state)
+	return atomicfile.WriteJSON(path, state)
+}
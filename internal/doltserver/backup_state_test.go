@@ -0,0 +1,85 @@
+package doltserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecentBackupAge(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, skip := RecentBackupAge(townRoot, "hq", time.Minute, now); skip {
+		t.Error("no state yet: should not skip")
+	}
+
+	if err := RecordBackupSuccess(townRoot, "hq", now.Add(-20*time.Second)); err != nil {
+		t.Fatalf("RecordBackupSuccess: %v", err)
+	}
+	if err := RecordBackupSuccess(townRoot, "gastown", now.Add(-5*time.Minute)); err != nil {
+		t.Fatalf("RecordBackupSuccess: %v", err)
+	}
+
+	age, skip := RecentBackupAge(townRoot, "hq", time.Minute, now)
+	if !skip || age != 20*time.Second {
+		t.Errorf("hq: age=%v skip=%v, want 20s true", age, skip)
+	}
+	if _, skip := RecentBackupAge(townRoot, "gastown", time.Minute, now); skip {
+		t.Error("gastown backed up 5m ago: should not skip a 1m minimum")
+	}
+	if _, skip := RecentBackupAge(townRoot, "hq", 0, now); skip {
+		t.Error("a zero minimum interval should never skip")
+	}
+
+	state, err := LoadBackupState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadBackupState: %v", err)
+	}
+	if len(state.LastSuccess) != 2 {
+		t.Errorf("LastSuccess = %v, want both databases", state.LastSuccess)
+	}
+}
+
+func TestRecordBackupCommit(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := RecordBackupSuccess(townRoot, "hq", now); err != nil {
+		t.Fatalf("RecordBackupSuccess: %v", err)
+	}
+	if err := RecordBackupCommit(townRoot, "hq", "abc"); err != nil {
+		t.Fatalf("RecordBackupCommit: %v", err)
+	}
+
+	state, err := LoadBackupState(townRoot)
+	if err != nil {
+		t.Fatalf("LoadBackupState: %v", err)
+	}
+	if got := state.LastCommit["hq"]; got != "abc" {
+		t.Errorf("LastCommit[hq] = %q, want abc", got)
+	}
+	if !state.LastSuccess["hq"].Equal(now) {
+		t.Errorf("LastSuccess[hq] = %v, want %v (kept across commit update)", state.LastSuccess["hq"], now)
+	}
+}
+
+func TestBackupVerifyDue(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if !BackupVerifyDue(townRoot, "hq", 24*time.Hour, now) {
+		t.Error("never verified: should be due")
+	}
+	if err := RecordBackupVerified(townRoot, "hq", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("RecordBackupVerified: %v", err)
+	}
+	if BackupVerifyDue(townRoot, "hq", 24*time.Hour, now) {
+		t.Error("verified an hour ago: should not be due with a 24h interval")
+	}
+	if !BackupVerifyDue(townRoot, "hq", 30*time.Minute, now) {
+		t.Error("verified an hour ago: should be due with a 30m interval")
+	}
+	if !BackupVerifyDue(townRoot, "gastown", 24*time.Hour, now) {
+		t.Error("another database's verification should not count")
+	}
+}
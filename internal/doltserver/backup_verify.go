@@ -0,0 +1,176 @@
+package doltserver
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BackupCheck is the outcome of one sanity check against a restored backup.
+type BackupCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// BackupVerifyResult holds the outcome of verifying a database's backup.
+type BackupVerifyResult struct {
+	Database   string         `json:"database"`
+	BackupName string         `json:"backup_name"`
+	URL        string         `json:"url,omitempty"`
+	Checks     []BackupCheck  `json:"checks"`
+	TableRows  map[string]int `json:"table_rows,omitempty"`
+}
+
+// Passed reports whether every check succeeded.
+func (r *BackupVerifyResult) Passed() bool {
+	if len(r.Checks) == 0 {
+		return false
+	}
+	for _, c := range r.Checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *BackupVerifyResult) addCheck(name string, ok bool, detail string) {
+	r.Checks = append(r.Checks, BackupCheck{Name: name, OK: ok, Detail: detail})
+}
+
+// VerifyBackup restores the <db>-backup backup of the database in
+// dataDir/<db> into a temporary directory and runs sanity checks against the
+// copy: the restore must succeed, SELECT 1 must answer, and the restored
+// database must contain at least one non-empty table. The temporary copy is
+// always removed. The live database is never touched.
+//
+// An error is returned only when verification could not be attempted (no
+// such backup configured, temp dir unavailable); failed checks are reported
+// in the result.
+func VerifyBackup(ctx context.Context, dataDir, db string) (*BackupVerifyResult, error) {
+	result := &BackupVerifyResult{Database: db, BackupName: db + "-backup"}
+
+	out, err := runDoltIn(ctx, filepath.Join(dataDir, db), "backup", "-v")
+	if err != nil {
+		return nil, fmt.Errorf("listing backups for %s: %w", db, err)
+	}
+	url := parseBackupURL(out, result.BackupName)
+	if url == "" {
+		return nil, fmt.Errorf("database %s has no %s backup configured", db, result.BackupName)
+	}
+	result.URL = url
+
+	tmpDir, err := os.MkdirTemp("", "gt-backup-verify-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := runDoltIn(ctx, tmpDir, "backup", "restore", url, db); err != nil {
+		result.addCheck("restore", false, err.Error())
+		return result, nil
+	}
+	result.addCheck("restore", true, "")
+	restored := filepath.Join(tmpDir, db)
+
+	out, err = runDoltIn(ctx, restored, "sql", "-r", "csv", "-q", "SELECT 1")
+	if rows, parseErr := parseCSVColumn(out); err != nil || parseErr != nil || len(rows) != 1 || rows[0] != "1" {
+		detail := strings.TrimSpace(out)
+		if err != nil {
+			detail = err.Error()
+		}
+		result.addCheck("select-1", false, detail)
+		return result, nil
+	}
+	result.addCheck("select-1", true, "")
+
+	out, err = runDoltIn(ctx, restored, "sql", "-r", "csv", "-q", "SHOW TABLES")
+	if err != nil {
+		result.addCheck("row-counts", false, err.Error())
+		return result, nil
+	}
+	tables, err := parseCSVColumn(out)
+	if err != nil {
+		result.addCheck("row-counts", false, fmt.Sprintf("parsing table list: %v", err))
+		return result, nil
+	}
+	result.TableRows = make(map[string]int, len(tables))
+	total := 0
+	for _, table := range tables {
+		out, err := runDoltIn(ctx, restored, "sql", "-r", "csv", "-q", fmt.Sprintf("SELECT COUNT(*) FROM `%s`", strings.ReplaceAll(table, "`", "``")))
+		if err != nil {
+			result.addCheck("row-counts", false, fmt.Sprintf("counting %s: %v", table, err))
+			return result, nil
+		}
+		counts, err := parseCSVColumn(out)
+		if err != nil || len(counts) != 1 {
+			result.addCheck("row-counts", false, fmt.Sprintf("counting %s: unexpected output %q", table, strings.TrimSpace(out)))
+			return result, nil
+		}
+		n, err := strconv.Atoi(counts[0])
+		if err != nil {
+			result.addCheck("row-counts", false, fmt.Sprintf("counting %s: %v", table, err))
+			return result, nil
+		}
+		result.TableRows[table] = n
+		total += n
+	}
+	detail := fmt.Sprintf("%d tables, %d rows", len(tables), total)
+	result.addCheck("row-counts", len(tables) > 0 && total > 0, detail)
+	return result, nil
+}
+
+// runDoltIn runs a dolt CLI command in dir and returns its stdout. On failure
+// the error carries dolt's stderr.
+func runDoltIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "dolt", args...)
+	cmd.Dir = dir
+	setProcessGroup(cmd)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return string(out), fmt.Errorf("dolt %s: %w: %s", args[0], err, msg)
+		}
+		return string(out), fmt.Errorf("dolt %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// parseBackupURL finds the URL for the named backup in `dolt backup -v`
+// output, whose lines are "<name> <url> [params]".
+func parseBackupURL(output, name string) string {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == name {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// parseCSVColumn returns the first column of every data row in `dolt sql -r
+// csv` output, skipping the header.
+func parseCSVColumn(output string) ([]string, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	values := make([]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		if len(rec) > 0 {
+			values = append(values, rec[0])
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,45 @@
+package doltserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBackupURL(t *testing.T) {
+	output := "hq-backup file:///town/.dolt-backup/hq {}\ngastown-backup file:///town/.dolt-backup/gastown {}\n"
+	if got := parseBackupURL(output, "gastown-backup"); got != "file:///town/.dolt-backup/gastown" {
+		t.Errorf("parseBackupURL = %q", got)
+	}
+	if got := parseBackupURL(output, "beads-backup"); got != "" {
+		t.Errorf("missing backup should yield empty URL, got %q", got)
+	}
+}
+
+func TestParseCSVColumn(t *testing.T) {
+	got, err := parseCSVColumn("Tables_in_hq\nissues\nwisps\n")
+	if err != nil {
+		t.Fatalf("parseCSVColumn: %v", err)
+	}
+	if want := []string{"issues", "wisps"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCSVColumn = %v, want %v", got, want)
+	}
+	if got, _ := parseCSVColumn(""); len(got) != 0 {
+		t.Errorf("empty output should yield no values, got %v", got)
+	}
+}
+
+func TestBackupVerifyResultPassed(t *testing.T) {
+	r := &BackupVerifyResult{}
+	if r.Passed() {
+		t.Error("result with no checks must not pass")
+	}
+	r.addCheck("restore", true, "")
+	r.addCheck("select-1", true, "")
+	if !r.Passed() {
+		t.Error("all-OK checks should pass")
+	}
+	r.addCheck("row-counts", false, "0 tables, 0 rows")
+	if r.Passed() {
+		t.Error("a failed check must fail the result")
+	}
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/steveyegge/gastown/internal/atomicfile"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/reaper"
 	"github.com/steveyegge/gastown/internal/style"
 	"gopkg.in/yaml.v3"
 )
@@ -3972,6 +3973,10 @@ type HealthMetrics struct {
 	// LastCommitDB is the database that had the most recent commit.
 	LastCommitDB string `json:"last_commit_db,omitempty"`
 
+	// ClockSkew is how far the server's NOW() is ahead of this host's clock
+	// (negative when behind). Reaper age cutoffs are off by this much.
+	ClockSkew time.Duration `json:"clock_skew_ns"`
+
 	// Healthy indicates whether the server is within acceptable resource limits.
 	Healthy bool `json:"healthy"`
 
@@ -4028,7 +4033,18 @@ func GetHealthMetrics(townRoot string) *HealthMetrics {
 			"server is in READ-ONLY mode — requires restart to recover")
 	}
 
-	// 5. Commit freshness: check the most recent commit across all databases.
+	// 5. Clock skew: reaper cutoffs use the local clock against timestamps
+	// written by the server, so a drifted clock reaps too early or never.
+	if skew, err := MeasureClockSkew(townRoot); err == nil {
+		metrics.ClockSkew = skew
+		if reaper.ClockSkewExceeded(skew) {
+			metrics.Warnings = append(metrics.Warnings,
+				fmt.Sprintf("server clock differs from host by %v (threshold %v) — age-based reaping is skewed",
+					skew.Round(time.Second), reaper.ClockSkewThreshold))
+		}
+	}
+
+	// 6. Commit freshness: check the most recent commit across all databases.
 	// A gap >1 hour suggests writes are failing or the server was recently down.
 	if commitAge, commitDB, err := GetLastCommitAge(townRoot); err == nil {
 		metrics.LastCommitAge = commitAge
@@ -4213,6 +4229,24 @@ func MeasureQueryLatency(townRoot string) (time.Duration, error) {
 	return elapsed, nil
 }
 
+// MeasureClockSkew returns how far the Dolt server's clock is ahead of this
+// host's (negative when behind), using a single SELECT NOW().
+func MeasureClockSkew(townRoot string) (time.Duration, error) {
+	config := DefaultConfig(townRoot)
+
+	dsn := fmt.Sprintf("%s@tcp(%s:%d)/?parseTime=true", config.User, config.EffectiveHost(), config.Port)
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return 0, fmt.Errorf("opening mysql connection: %w", err)
+	}
+	defer db.Close()
+
+	db.SetConnMaxLifetime(5 * time.Second)
+	db.SetMaxOpenConns(1)
+
+	return reaper.ClockSkew(db)
+}
+
 // GetLastCommitAge returns the age and database name of the most recent Dolt commit
 // across all databases. This detects commit gaps — periods where no writes persisted.
 //
@@ -0,0 +1,107 @@
+package doltserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// MaintenanceMarker records that a database is under maintenance (e.g. a
+// schema migration). While it is set, the wisp reaper leaves the database
+// alone so its UPDATEs and DELETEs do not collide with the migration.
+type MaintenanceMarker struct {
+	Database string    `json:"database"`
+	Reason   string    `json:"reason,omitempty"`
+	SetBy    string    `json:"set_by,omitempty"`
+	SetAt    time.Time `json:"set_at"`
+}
+
+// MaintenanceDir returns the directory holding one marker file per database
+// under maintenance.
+func MaintenanceDir(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "maintenance")
+}
+
+func maintenanceFile(townRoot, db string) (string, error) {
+	if db == "" || strings.ContainsAny(db, `/\`) || strings.HasPrefix(db, ".") {
+		return "", fmt.Errorf("invalid database name %q", db)
+	}
+	return filepath.Join(MaintenanceDir(townRoot), db+".json"), nil
+}
+
+// SetMaintenance marks db as under maintenance, replacing any existing marker.
+func SetMaintenance(townRoot string, marker *MaintenanceMarker) error {
+	path, err := maintenanceFile(townRoot, marker.Database)
+	if err != nil {
+		return err
+	}
+	return atomicfile.EnsureDirAndWriteJSON(path, marker)
+}
+
+// ClearMaintenance removes db's maintenance marker and reports whether one
+// was set.
+func ClearMaintenance(townRoot, db string) (bool, error) {
+	path, err := maintenanceFile(townRoot, db)
+	if err != nil {
+		return false, err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// LoadMaintenance returns db's maintenance marker, or nil if it is not under
+// maintenance. An unreadable marker still counts as set: when in doubt, the
+// reaper should stay away.
+func LoadMaintenance(townRoot, db string) (*MaintenanceMarker, error) {
+	path, err := maintenanceFile(townRoot, db)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return &MaintenanceMarker{Database: db}, err
+	}
+	marker := &MaintenanceMarker{Database: db}
+	if err := json.Unmarshal(data, marker); err != nil {
+		return &MaintenanceMarker{Database: db}, err
+	}
+	return marker, nil
+}
+
+// ListMaintenance returns every database under maintenance, sorted by name.
+func ListMaintenance(townRoot string) ([]*MaintenanceMarker, error) {
+	entries, err := os.ReadDir(MaintenanceDir(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var markers []*MaintenanceMarker
+	for _, entry := range entries {
+		db, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		marker, _ := LoadMaintenance(townRoot, db)
+		if marker != nil {
+			markers = append(markers, marker)
+		}
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i].Database < markers[j].Database })
+	return markers, nil
+}
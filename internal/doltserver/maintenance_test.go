@@ -0,0 +1,57 @@
+package doltserver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMarker(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if m, err := LoadMaintenance(townRoot, "hq"); m != nil || err != nil {
+		t.Fatalf("LoadMaintenance before set = %v, %v; want nil, nil", m, err)
+	}
+
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := SetMaintenance(townRoot, &MaintenanceMarker{Database: "hq", Reason: "v3 migration", SetAt: at}); err != nil {
+		t.Fatalf("SetMaintenance: %v", err)
+	}
+	m, err := LoadMaintenance(townRoot, "hq")
+	if err != nil || m == nil || m.Reason != "v3 migration" || !m.SetAt.Equal(at) {
+		t.Fatalf("LoadMaintenance = %+v, %v", m, err)
+	}
+	markers, err := ListMaintenance(townRoot)
+	if err != nil || len(markers) != 1 || markers[0].Database != "hq" {
+		t.Fatalf("ListMaintenance = %v, %v", markers, err)
+	}
+
+	if cleared, err := ClearMaintenance(townRoot, "hq"); !cleared || err != nil {
+		t.Fatalf("ClearMaintenance = %v, %v; want true, nil", cleared, err)
+	}
+	if cleared, err := ClearMaintenance(townRoot, "hq"); cleared || err != nil {
+		t.Errorf("second ClearMaintenance = %v, %v; want false, nil", cleared, err)
+	}
+}
+
+func TestMaintenanceMarkerUnreadableCountsAsSet(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(MaintenanceDir(townRoot), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(MaintenanceDir(townRoot), "hq.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := LoadMaintenance(townRoot, "hq"); m == nil || err == nil {
+		t.Errorf("LoadMaintenance of a corrupt marker = %v, %v; want a marker and an error", m, err)
+	}
+}
+
+func TestMaintenanceRejectsPathNames(t *testing.T) {
+	for _, db := range []string{"", "../hq", ".hidden", `a\b`} {
+		if err := SetMaintenance(t.TempDir(), &MaintenanceMarker{Database: db}); err == nil {
+			t.Errorf("SetMaintenance(%q) succeeded, want error", db)
+		}
+	}
+}
@@ -1,7 +1,8 @@
 // Package events provides event logging for the gt activity feed.
 //
 // Events are written to ~/gt/.events.jsonl (raw audit log) and later
-// curated by the feed daemon into ~/.feed.jsonl (user-facing).
+// curated by the feed daemon into ~/.feed.jsonl (user-facing). The raw log
+// is rolled to .events.jsonl.1, .2, ... once it grows past a size cap.
 package events
 
 import (
@@ -72,8 +73,10 @@ const (
 	// Scheduler events
 	TypeSchedulerEnqueue        = "scheduler_enqueue"         // Bead scheduled for deferred dispatch
 	TypeSchedulerDispatch       = "scheduler_dispatch"        // Bead dispatched from scheduler
-	TypeSchedulerDispatchFailed = "scheduler_dispatch_failed" // Bead dispatch failed (requeued)
+	TypeSchedulerDispatchFailed = "scheduler_dispatch_failed" // Bead dispatch failed
+	TypeSchedulerRequeue        = "scheduler_requeue"         // Failed bead left scheduled for another attempt
 	TypeSchedulerCloseRetry     = "scheduler_close_retry"     // Context close needed last-resort attempt
+	TypeSchedulerStalled        = "scheduler_stalled"         // Dispatch cycle with scheduled work moved nothing
 )
 
 // EventsFile is the name of the raw events log.
@@ -131,6 +134,11 @@ func write(event Event) error {
 	}
 	defer fl.Unlock() //nolint:errcheck // best-effort unlock
 
+	maxBytes, keep := rotationLimits()
+	if err := rotateIfNeeded(eventsPath, len(data), maxBytes, keep); err != nil {
+		return err
+	}
+
 	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
 	if err != nil {
 		return fmt.Errorf("opening events file: %w", err)
@@ -368,3 +376,23 @@ func SchedulerDispatchFailedPayload(beadID, rig, errMsg string) map[string]inter
 		"error": errMsg,
 	}
 }
+
+// SchedulerRequeuePayload creates a payload for a bead re-queued after a
+// failed dispatch. attempt is the number of failed dispatches so far.
+func SchedulerRequeuePayload(beadID, rig string, attempt int, reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"bead":    beadID,
+		"rig":     rig,
+		"attempt": attempt,
+		"reason":  reason,
+	}
+}
+
+// SchedulerStalledPayload creates a payload for scheduler stall events.
+func SchedulerStalledPayload(reason string, scheduled int, detail string) map[string]interface{} {
+	return map[string]interface{}{
+		"reason":    reason,
+		"scheduled": scheduled,
+		"detail":    detail,
+	}
+}
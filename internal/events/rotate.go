@@ -0,0 +1,148 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Defaults for size-based rotation of the events file.
+const (
+	DefaultMaxBytes int64 = 50 * 1024 * 1024
+	DefaultKeep           = 3
+)
+
+// rotationLimits returns the size at which the events file is rolled and how
+// many rolls are kept. GT_EVENTS_MAX_BYTES (0 disables rotation) and
+// GT_EVENTS_KEEP override the defaults.
+func rotationLimits() (maxBytes int64, keep int) {
+	maxBytes, keep = DefaultMaxBytes, DefaultKeep
+	if v := os.Getenv("GT_EVENTS_MAX_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			maxBytes = n
+		}
+	}
+	if v := os.Getenv("GT_EVENTS_KEEP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			keep = n
+		}
+	}
+	return maxBytes, keep
+}
+
+// rollPath returns the path of the n-th roll of path (path.1 is the newest).
+func rollPath(path string, n int) string {
+	return path + "." + strconv.Itoa(n)
+}
+
+// rotateIfNeeded rolls path to path.1 when appending incoming bytes would push
+// it past maxBytes, shifting older rolls up and dropping any beyond keep.
+// With keep 0 the file is simply started over. Callers hold the events lock.
+func rotateIfNeeded(path string, incoming int, maxBytes int64, keep int) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 || info.Size()+int64(incoming) <= maxBytes {
+		return nil
+	}
+
+	if keep == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing events file: %w", err)
+		}
+		return nil
+	}
+	if err := os.Remove(rollPath(path, keep)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing oldest events roll: %w", err)
+	}
+	for n := keep - 1; n >= 1; n-- {
+		if err := os.Rename(rollPath(path, n), rollPath(path, n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("shifting events roll %d: %w", n, err)
+		}
+	}
+	if err := os.Rename(path, rollPath(path, 1)); err != nil {
+		return fmt.Errorf("rolling events file: %w", err)
+	}
+	return nil
+}
+
+// Rotated reports whether path no longer names the file f has open, as
+// after rotateIfNeeded renames it to path.1. Tailers holding the events file
+// open should drain f and then reopen path. A path that does not exist yet
+// (keep 0 removes it until the next write) is not reported as rotated.
+func Rotated(f *os.File, path string) bool {
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	open, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return !os.SameFile(current, open)
+}
+
+// LogFiles returns the events file in townRoot followed by its existing
+// rolls, newest first. Readers that need history older than the active
+// file (e.g. seance session lookup) should scan all of them.
+func LogFiles(townRoot string) []string {
+	path := filepath.Join(townRoot, EventsFile)
+	files := []string{path}
+	for n := 1; ; n++ {
+		roll := rollPath(path, n)
+		if _, err := os.Stat(roll); err != nil {
+			return files
+		}
+		files = append(files, roll)
+	}
+}
+
+// OpenHistory opens the events file in townRoot together with its rolls as
+// one stream, oldest roll first, so readers that scan history see events
+// from before the last rotation too. With no events file or rolls at all it
+// returns the os.IsNotExist error for the active file.
+func OpenHistory(townRoot string) (io.ReadCloser, error) {
+	files := LogFiles(townRoot)
+	h := &history{}
+	var missing error
+	for i := len(files) - 1; i >= 0; i-- {
+		f, err := os.Open(files[i]) //nolint:gosec // G304: path is constructed internally
+		if os.IsNotExist(err) {
+			missing = err
+			continue
+		}
+		if err != nil {
+			_ = h.Close()
+			return nil, err
+		}
+		h.files = append(h.files, f)
+	}
+	if len(h.files) == 0 {
+		return nil, missing
+	}
+	readers := make([]io.Reader, len(h.files))
+	for i, f := range h.files {
+		readers[i] = f
+	}
+	h.Reader = io.MultiReader(readers...)
+	return h, nil
+}
+
+// history is the reader returned by OpenHistory.
+type history struct {
+	io.Reader
+	files []*os.File
+}
+
+func (h *history) Close() error {
+	var firstErr error
+	for _, f := range h.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotateIfNeeded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, EventsFile)
+	write := func(p, content string) {
+		t.Helper()
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(p string) string {
+		t.Helper()
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+
+	write(path, "aaaa\n")
+	if err := rotateIfNeeded(path, 5, 100, 2); err != nil {
+		t.Fatal(err)
+	}
+	if read(path) != "aaaa\n" {
+		t.Fatal("file under the cap should not be rolled")
+	}
+
+	for _, content := range []string{"first\n", "second\n", "third\n"} {
+		write(path, content)
+		if err := rotateIfNeeded(path, 10, 10, 2); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("active file should be rolled away, stat err = %v", err)
+		}
+	}
+	if got := read(rollPath(path, 1)); got != "third\n" {
+		t.Errorf("roll 1 = %q, want third", got)
+	}
+	if got := read(rollPath(path, 2)); got != "second\n" {
+		t.Errorf("roll 2 = %q, want second", got)
+	}
+	if _, err := os.Stat(rollPath(path, 3)); !os.IsNotExist(err) {
+		t.Error("rolls beyond keep should be dropped")
+	}
+
+	write(path, "fourth\n")
+	want := []string{path, rollPath(path, 1), rollPath(path, 2)}
+	got := LogFiles(dir)
+	if len(got) != len(want) {
+		t.Fatalf("LogFiles = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("LogFiles[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRotateIfNeeded_Disabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+	if err := os.WriteFile(path, []byte("large enough\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := rotateIfNeeded(path, 100, 0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(rollPath(path, 1)); !os.IsNotExist(err) {
+		t.Error("max bytes 0 should disable rotation")
+	}
+}
+
+func TestRotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if Rotated(f, path) {
+		t.Fatal("freshly opened file reported as rotated")
+	}
+
+	if err := rotateIfNeeded(path, 100, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	if Rotated(f, path) {
+		t.Error("missing path should not count as rotated until it is recreated")
+	}
+	if err := os.WriteFile(path, []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !Rotated(f, path) {
+		t.Error("file rolled to path.1 not reported as rotated")
+	}
+}
+
+func TestOpenHistory(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenHistory(dir); !os.IsNotExist(err) {
+		t.Fatalf("no events file: err = %v, want not-exist", err)
+	}
+
+	path := filepath.Join(dir, EventsFile)
+	for _, f := range []struct{ path, content string }{
+		{rollPath(path, 2), "oldest\n"},
+		{rollPath(path, 1), "older\n"},
+		{path, "newest\n"},
+	} {
+		if err := os.WriteFile(f.path, []byte(f.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := OpenHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "oldest\nolder\nnewest\n" {
+		t.Errorf("history = %q, want rolls oldest first then the active file", data)
+	}
+}
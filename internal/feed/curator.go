@@ -113,7 +113,7 @@ func (c *Curator) Start() error {
 		}
 
 		c.wg.Add(1)
-		go c.run(file)
+		go c.run(file, eventsPath)
 	})
 	return c.startErr
 }
@@ -126,9 +126,11 @@ func (c *Curator) Stop() {
 
 // run is the main curator loop.
 // ZFC: No in-memory state to clean up - state is derived from the events file.
-func (c *Curator) run(file *os.File) {
+// When the events file is rotated, the rest of the old file is read and
+// then the new one is followed from its start.
+func (c *Curator) run(file *os.File, eventsPath string) {
 	defer c.wg.Done()
-	defer file.Close()
+	defer func() { _ = file.Close() }()
 
 	reader := bufio.NewReader(file)
 	ticker := time.NewTicker(100 * time.Millisecond)
@@ -148,6 +150,16 @@ func (c *Curator) run(file *os.File) {
 				}
 				c.processLine(line)
 			}
+			if events.Rotated(file, eventsPath) {
+				next, err := os.Open(eventsPath) //nolint:gosec // G304: path is constructed internally
+				if err != nil {
+					log.Printf("warning: reopening rotated events file: %v", err)
+					continue
+				}
+				_ = file.Close()
+				file = next
+				reader.Reset(file)
+			}
 		}
 	}
 }
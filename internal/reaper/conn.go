@@ -0,0 +1,144 @@
+package reaper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// DefaultConnectTimeout is how long reaper connections wait to be established
+// when DoltConnConfig.ConnectTimeoutStr is unset.
+const DefaultConnectTimeout = 5 * time.Second
+
+// DoltConnConfig holds the parameters of the reaper's connections to Dolt.
+// Zero values keep the defaults: user GT_DOLT_USER (or root), password from
+// GT_DOLT_PASSWORD, no TLS, the server's charset and collation, and per-call
+// read and write timeouts. Sessions are always UTC (see OpenDB).
+type DoltConnConfig struct {
+	User string `json:"user,omitempty"`
+	// PasswordEnv names the environment variable holding the password
+	// (default GT_DOLT_PASSWORD), so no secret is stored in daemon.json.
+	PasswordEnv string `json:"password_env,omitempty"`
+	// TLS is the driver's tls mode: "true", "skip-verify", "preferred" or
+	// "false" (the default).
+	TLS       string `json:"tls,omitempty"`
+	Charset   string `json:"charset,omitempty"`
+	Collation string `json:"collation,omitempty"`
+	// ConnectTimeoutStr bounds establishing a connection (default 5s).
+	ConnectTimeoutStr string `json:"connect_timeout,omitempty"`
+	// ReadTimeoutStr and WriteTimeoutStr, when set, replace the timeouts
+	// each caller picks for its phase.
+	ReadTimeoutStr  string `json:"read_timeout,omitempty"`
+	WriteTimeoutStr string `json:"write_timeout,omitempty"`
+}
+
+// Validate reports settings the driver would reject.
+func (c DoltConnConfig) Validate() error {
+	switch c.TLS {
+	case "", "true", "false", "skip-verify", "preferred":
+	default:
+		return fmt.Errorf("invalid tls %q: want true, false, skip-verify or preferred", c.TLS)
+	}
+	for name, value := range map[string]string{
+		"connect_timeout": c.ConnectTimeoutStr,
+		"read_timeout":    c.ReadTimeoutStr,
+		"write_timeout":   c.WriteTimeoutStr,
+	} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid %s %q: want a positive duration", name, value)
+		}
+	}
+	return nil
+}
+
+// user returns the configured user, GT_DOLT_USER, or root.
+func (c DoltConnConfig) user() string {
+	if c.User != "" {
+		return c.User
+	}
+	if u := os.Getenv("GT_DOLT_USER"); u != "" {
+		return u
+	}
+	return "root"
+}
+
+// password reads the password from PasswordEnv (default GT_DOLT_PASSWORD).
+func (c DoltConnConfig) password() string {
+	env := c.PasswordEnv
+	if env == "" {
+		env = "GT_DOLT_PASSWORD"
+	}
+	return os.Getenv(env)
+}
+
+// durationOr parses an optional duration, returning fallback when unset or invalid.
+func durationOr(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// DSN builds the driver DSN for dbName on host:port. dbName may be empty to
+// connect without selecting a database.
+func (c DoltConnConfig) DSN(host string, port int, dbName string, readTimeout, writeTimeout time.Duration) string {
+	cfg := mysql.NewConfig()
+	cfg.User = c.user()
+	cfg.Passwd = c.password()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(host, strconv.Itoa(port))
+	cfg.DBName = dbName
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	cfg.Params = map[string]string{"time_zone": "'+00:00'"}
+	cfg.Timeout = durationOr(c.ConnectTimeoutStr, DefaultConnectTimeout)
+	cfg.ReadTimeout = durationOr(c.ReadTimeoutStr, readTimeout)
+	cfg.WriteTimeout = durationOr(c.WriteTimeoutStr, writeTimeout)
+	cfg.TLSConfig = c.TLS
+	if c.Charset != "" {
+		_ = cfg.Apply(mysql.Charset(c.Charset, c.Collation))
+	} else {
+		cfg.Collation = c.Collation
+	}
+	return cfg.FormatDSN()
+}
+
+// dsnPassword matches the password part of "user:password@net(addr)/".
+var dsnPassword = regexp.MustCompile(`^([^:@/]*):.*@([a-z]+\()`)
+
+// RedactDSN hides the password in a DSN so it can be logged.
+func RedactDSN(dsn string) string {
+	if cfg, err := mysql.ParseDSN(dsn); err == nil {
+		if cfg.Passwd == "" {
+			return dsn
+		}
+		cfg.Passwd = "***"
+		return cfg.FormatDSN()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "$1:***@$2")
+}
+
+type connConfigKey struct{}
+
+// WithConnConfig returns a context whose reaper connections (OpenDB,
+// DiscoverDatabases, ListServerDatabases) use c, the way WithSchema carries
+// the schema mapping. Callers check c with Validate first.
+func WithConnConfig(ctx context.Context, c DoltConnConfig) context.Context {
+	return context.WithValue(ctx, connConfigKey{}, c)
+}
+
+// ConnConfigFrom returns the connection parameters carried by ctx, or the
+// defaults when there are none.
+func ConnConfigFrom(ctx context.Context) DoltConnConfig {
+	c, _ := ctx.Value(connConfigKey{}).(DoltConnConfig)
+	return c
+}
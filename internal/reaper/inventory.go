@@ -0,0 +1,73 @@
+package reaper
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TableInventory counts the rows of a beads table by status.
+type TableInventory struct {
+	Present bool `json:"present"`
+	Open    int  `json:"open"` // open, hooked or in_progress
+	Closed  int  `json:"closed"`
+	Total   int  `json:"total"`
+}
+
+// DatabaseInventory is the row inventory of one beads database.
+type DatabaseInventory struct {
+	Issues TableInventory `json:"issues"`
+	Wisps  TableInventory `json:"wisps"`
+}
+
+// InspectDatabase reports whether db has the issues and wisps tables and
+// counts their rows by status. A missing table is reported, not an error.
+func InspectDatabase(ctx context.Context, db *sql.DB) (*DatabaseInventory, error) {
+	inv := &DatabaseInventory{}
+	for _, t := range []struct {
+		name string
+		into *TableInventory
+	}{{"issues", &inv.Issues}, {"wisps", &inv.Wisps}} {
+		if err := inspectTable(ctx, db, t.name, t.into); err != nil {
+			return nil, err
+		}
+	}
+	return inv, nil
+}
+
+func inspectTable(ctx context.Context, db *sql.DB, table string, into *TableInventory) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+
+	present, err := tableExists(ctx, db, table)
+	if err != nil {
+		return fmt.Errorf("check %s table: %w", table, err)
+	}
+	if !present {
+		return nil
+	}
+	into.Present = true
+
+	// table is one of InspectDatabase's fixed names, never user input.
+	query := fmt.Sprintf("SELECT status, COUNT(*) AS cnt FROM %s GROUP BY status", table)
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, query))
+	if err != nil {
+		return fmt.Errorf("count %s by status: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status sql.NullString
+		var cnt int
+		if err := rows.Scan(&status, &cnt); err != nil {
+			return fmt.Errorf("scan %s count: %w", table, err)
+		}
+		into.Total += cnt
+		switch status.String {
+		case "open", "hooked", "in_progress":
+			into.Open += cnt
+		case "closed":
+			into.Closed += cnt
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,194 @@
+package reaper
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PreviewOptions are the settings a reaper cycle would run with.
+type PreviewOptions struct {
+	MaxAge        time.Duration
+	PurgeAge      time.Duration
+	MailDeleteAge time.Duration
+	StaleIssueAge time.Duration
+	AutoClose     AutoCloseOptions
+	// KeepRecentPerType is PurgeOptions.KeepRecentPerType.
+	KeepRecentPerType int
+	// ExcludeTypes is PurgeOptions.ExcludeTypes.
+	ExcludeTypes []string
+	// RecentEventWindow is PurgeOptions.RecentEventWindow.
+	RecentEventWindow time.Duration
+	// Now, if set, replaces time.Now for the reap and purge cutoffs.
+	Now func() time.Time
+}
+
+// PreviewResult is what one reaper cycle would do to a database.
+type PreviewResult struct {
+	Database string `json:"database"`
+	// WispsToClose counts stale open wisps, broken down by status in
+	// CloseByStatus. Closed-molecule steps are counted separately.
+	WispsToClose         int            `json:"wisps_to_close"`
+	CloseByStatus        map[string]int `json:"close_by_status,omitempty"`
+	MoleculeStepsToClose int            `json:"molecule_steps_to_close,omitempty"`
+	// IssuesToAutoClose lists the stale issues auto-close would close;
+	// AutoCloseExemptions describes what kept other issues out.
+	IssuesToAutoClose   []ClosedEntry  `json:"issues_to_auto_close,omitempty"`
+	AutoCloseExemptions []string       `json:"auto_close_exemptions"`
+	WispsToPurge        int            `json:"wisps_to_purge"`
+	PurgeByType         map[string]int `json:"purge_by_type,omitempty"`
+	// RetainedRecent counts wisps past the purge age kept by
+	// KeepRecentPerType, by wisp type.
+	RetainedRecent map[string]int `json:"retained_recent,omitempty"`
+	// RetainedExcluded counts wisps past the purge age kept by
+	// ExcludeTypes, by wisp type.
+	RetainedExcluded map[string]int `json:"retained_excluded,omitempty"`
+	// DeferredRecentEvents counts wisps past the purge age kept by
+	// RecentEventWindow.
+	DeferredRecentEvents int       `json:"deferred_recent_events,omitempty"`
+	MailToPurge          int       `json:"mail_to_purge"`
+	Anomalies            []Anomaly `json:"anomalies,omitempty"`
+}
+
+// Preview reports what a reaper cycle with opts would close, auto-close and
+// purge in a database, without modifying anything. It runs the dry-run paths
+// of Reap, AutoClose and Purge, so the counts follow the real eligibility
+// rules, plus a per-status breakdown of the reap candidates.
+func Preview(ctx context.Context, db *sql.DB, dbName string, opts PreviewOptions) (*PreviewResult, error) {
+	if err := ValidateDBName(dbName); err != nil {
+		return nil, err
+	}
+	for _, wtype := range opts.ExcludeTypes {
+		if err := ValidateWispType(wtype); err != nil {
+			return nil, err
+		}
+	}
+	result := &PreviewResult{Database: dbName, AutoCloseExemptions: autoCloseExemptions(opts.AutoClose)}
+
+	reap, err := ReapWithOptions(ctx, db, dbName, opts.MaxAge, true, ReapOptions{Now: opts.Now})
+	if err != nil {
+		return nil, fmt.Errorf("reap: %w", err)
+	}
+	result.WispsToClose = reap.Reaped
+	result.MoleculeStepsToClose = reap.MoleculeStepsClosed
+	result.Anomalies = append(result.Anomalies, reap.Anomalies...)
+	if reap.Reaped > 0 {
+		byStatus, err := staleWispsByStatus(ctx, db, dbName, nowUTC(opts.Now).Add(-opts.MaxAge))
+		if err != nil {
+			return nil, err
+		}
+		result.CloseByStatus = byStatus
+	}
+
+	autoCloseOpts := opts.AutoClose
+	if autoCloseOpts.Now == nil {
+		autoCloseOpts.Now = opts.Now
+	}
+	autoClose, err := AutoCloseWithOptions(ctx, db, dbName, opts.StaleIssueAge, true, autoCloseOpts)
+	if err != nil {
+		return nil, fmt.Errorf("auto-close: %w", err)
+	}
+	result.IssuesToAutoClose = autoClose.ClosedEntries
+	result.Anomalies = append(result.Anomalies, autoClose.Anomalies...)
+
+	now := nowUTC(opts.Now)
+	if opts.KeepRecentPerType > 0 {
+		retained, err := retainedRecentWisps(ctx, db, now.Add(-opts.PurgeAge), opts.KeepRecentPerType)
+		if err != nil {
+			return nil, fmt.Errorf("count retained wisps: %w", err)
+		}
+		for _, wtype := range opts.ExcludeTypes {
+			delete(retained, wtype)
+		}
+		if len(retained) > 0 {
+			result.RetainedRecent = retained
+		}
+	}
+	if len(opts.ExcludeTypes) > 0 {
+		excluded, err := retainedExcludedWisps(ctx, db, now.Add(-opts.PurgeAge), opts.ExcludeTypes)
+		if err != nil {
+			return nil, fmt.Errorf("count excluded wisps: %w", err)
+		}
+		if len(excluded) > 0 {
+			result.RetainedExcluded = excluded
+		}
+	}
+	var eventSince time.Time
+	if opts.RecentEventWindow > 0 {
+		deferred, since, err := deferredRecentEventWisps(ctx, db, now.Add(-opts.PurgeAge), now.Add(-opts.RecentEventWindow), opts.KeepRecentPerType, opts.ExcludeTypes)
+		if err != nil {
+			return nil, fmt.Errorf("count wisps with recent events: %w", err)
+		}
+		result.DeferredRecentEvents = deferred
+		eventSince = since
+	}
+	digest, _, purged, anomalies, err := purgeClosedWispsBefore(ctx, db, dbName, now.Add(-opts.PurgeAge), opts.KeepRecentPerType, opts.ExcludeTypes, eventSince, true, false, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("purge wisps: %w", err)
+	}
+	result.WispsToPurge = purged
+	if purged > 0 {
+		result.PurgeByType = digest
+	}
+	result.Anomalies = append(result.Anomalies, anomalies...)
+
+	mail, err := purgeOldMail(ctx, db, dbName, now.Add(-opts.MailDeleteAge), true, false)
+	if err != nil {
+		return nil, fmt.Errorf("purge mail: %w", err)
+	}
+	result.MailToPurge = mail
+
+	return result, nil
+}
+
+// staleWispsByStatus counts Reap's age-based candidates by wisp status.
+func staleWispsByStatus(ctx context.Context, db *sql.DB, dbName string, cutoff time.Time) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+
+	parentJoin, parentWhere := parentExcludeJoin(dbName)
+	query := fmt.Sprintf("SELECT w.status, COUNT(*) FROM wisps w %s %s WHERE %s GROUP BY w.status",
+		parentJoin, closedMoleculeStepExcludeJoin("closed_molecule_step"), staleWispWhere(parentWhere))
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, query), cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("count stale wisps by status: %w", err)
+	}
+	defer rows.Close()
+
+	byStatus := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("scan stale wisp status: %w", err)
+		}
+		byStatus[status] += count
+	}
+	return byStatus, rows.Err()
+}
+
+// autoCloseExemptions describes the rules that keep stale issues open.
+func autoCloseExemptions(opts AutoCloseOptions) []string {
+	exemptions := []string{
+		"priority 0-1",
+		"epics and convoys",
+		"labeled " + strings.Join(AutoCloseExemptLabels, ", "),
+		"blocked by or blocking an open issue",
+	}
+	if w := opts.Window; !w.Since.IsZero() || !w.Until.IsZero() {
+		since, until := "any time", "now"
+		if !w.Since.IsZero() {
+			since = w.Since.Format(time.RFC3339)
+		}
+		if !w.Until.IsZero() {
+			until = w.Until.Format(time.RFC3339)
+		}
+		exemptions = append(exemptions, fmt.Sprintf("last updated outside [%s, %s)", since, until))
+	}
+	if opts.BusinessDaysOnly {
+		exemptions = append(exemptions, fmt.Sprintf("not stale in business days (weekends and %d holiday(s) not counted)", len(opts.Holidays)))
+	}
+	return exemptions
+}
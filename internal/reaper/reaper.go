@@ -9,13 +9,15 @@ package reaper
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"time"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
 // validDBName matches safe database names (alphanumeric, underscore, hyphen).
@@ -31,6 +33,20 @@ var DefaultDatabases = []string{"hq"}
 // testPollutionPrefixes are database name prefixes created by tests.
 var testPollutionPrefixes = []string{"testdb_", "beads_t", "beads_pt", "doctest_"}
 
+// SystemDatabases are server bookkeeping databases that must never be reaped,
+// whatever discovery or configuration says.
+var SystemDatabases = []string{"information_schema", "mysql", "performance_schema", "sys", "dolt_cluster"}
+
+// IsSystemDatabase reports whether name is one of SystemDatabases.
+func IsSystemDatabase(name string) bool {
+	for _, sys := range SystemDatabases {
+		if strings.EqualFold(name, sys) {
+			return true
+		}
+	}
+	return false
+}
+
 // isNothingToCommit returns true if the error is a Dolt "nothing to commit" error.
 func isNothingToCommit(err error) bool {
 	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nothing to commit")
@@ -48,23 +64,51 @@ func isTableNotFound(err error) bool {
 	return strings.Contains(msg, "table not found") || strings.Contains(msg, "doesn't exist")
 }
 
+// isRetriableDeleteError returns true for transient failures where rerunning
+// the whole delete transaction can succeed: lock contention, Dolt
+// serialization conflicts, and a dropped connection.
+func isRetriableDeleteError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "serialization failure") ||
+		strings.Contains(msg, "lock wait timeout") ||
+		strings.Contains(msg, "deadlock") ||
+		strings.Contains(msg, "try restarting transaction") ||
+		strings.Contains(msg, "optimistic lock")
+}
+
 // DiscoverDatabases queries SHOW DATABASES on the Dolt server and returns
 // all production databases, filtering out system databases and test pollution.
 // Falls back to DefaultDatabases on any error.
-func DiscoverDatabases(host string, port int) []string {
-	dsn := fmt.Sprintf("root@tcp(%s:%d)/?parseTime=true&timeout=5s", host, port)
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
+func DiscoverDatabases(ctx context.Context, host string, port int) []string {
+	databases, err := ListServerDatabases(ctx, host, port)
+	if err != nil || len(databases) == 0 {
 		return DefaultDatabases
 	}
+	return databases
+}
+
+// ListServerDatabases is DiscoverDatabases without the fallback: it returns
+// the production databases on the server, or the error that prevented
+// listing them.
+func ListServerDatabases(ctx context.Context, host string, port int) ([]string, error) {
+	db, err := sql.Open("mysql", ConnConfigFrom(ctx).DSN(host, port, "", 0, 0))
+	if err != nil {
+		return nil, err
+	}
 	defer db.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	rows, err := db.QueryContext(ctx, "SHOW DATABASES")
 	if err != nil {
-		return DefaultDatabases
+		return nil, fmt.Errorf("show databases: %w", err)
 	}
 	defer rows.Close()
 
@@ -74,7 +118,7 @@ func DiscoverDatabases(host string, port int) []string {
 		if err := rows.Scan(&name); err != nil {
 			continue
 		}
-		if name == "information_schema" || name == "mysql" {
+		if IsSystemDatabase(name) {
 			continue
 		}
 		lower := strings.ToLower(name)
@@ -90,11 +134,7 @@ func DiscoverDatabases(host string, port int) []string {
 		}
 		databases = append(databases, name)
 	}
-
-	if len(databases) == 0 {
-		return DefaultDatabases
-	}
-	return databases
+	return databases, rows.Err()
 }
 
 // ScanResult holds the results of scanning a database for reaper candidates.
@@ -111,29 +151,116 @@ type ScanResult struct {
 
 // ReapResult holds the results of a reap operation.
 type ReapResult struct {
-	Database            string    `json:"database"`
-	Reaped              int       `json:"reaped"`
-	MoleculeStepsClosed int       `json:"molecule_steps_closed,omitempty"`
-	OpenRemain          int       `json:"open_remain"`
-	DryRun              bool      `json:"dry_run,omitempty"`
-	Anomalies           []Anomaly `json:"anomalies,omitempty"`
+	Database            string `json:"database"`
+	Reaped              int    `json:"reaped"`
+	MoleculeStepsClosed int    `json:"molecule_steps_closed,omitempty"`
+	OpenRemain          int    `json:"open_remain"`
+	// NullCreatedAt counts open wisps with no created_at. Their age is
+	// unknown, so they are never reaped; an anomaly asks for the data to be
+	// fixed.
+	NullCreatedAt int `json:"null_created_at,omitempty"`
+	// SparedLive counts hooked or in-progress wisps past the max age that
+	// were left open because a live session still owns them (see
+	// ReapOptions.LiveOwners).
+	SparedLive int       `json:"spared_live,omitempty"`
+	DryRun     bool      `json:"dry_run,omitempty"`
+	Anomalies  []Anomaly `json:"anomalies,omitempty"`
 }
 
 // PurgeResult holds the results of a purge operation.
 type PurgeResult struct {
-	Database    string    `json:"database"`
-	WispsPurged int       `json:"wisps_purged"`
-	MailPurged  int       `json:"mail_purged"`
-	DryRun      bool      `json:"dry_run,omitempty"`
-	Anomalies   []Anomaly `json:"anomalies,omitempty"`
+	Database    string `json:"database"`
+	WispsPurged int    `json:"wisps_purged"`
+	MailPurged  int    `json:"mail_purged"`
+	// StaleMailClosed counts open mail closed by CloseStaleMail in the same
+	// pass (gt reaper purge --stale-mail-age); Purge itself never sets it.
+	StaleMailClosed int            `json:"stale_mail_closed,omitempty"`
+	ReopenBuckets   *ReopenBuckets `json:"reopen_buckets,omitempty"`
+	// Tombstoned is set when purged rows were reduced to stubs (see
+	// PurgeOptions.Tombstone) rather than deleted.
+	Tombstoned bool `json:"tombstoned,omitempty"`
+	// RetainedRecent counts, by wisp_type, closed wisps past the purge age
+	// that were kept because they are among the most recent of their type
+	// (see PurgeOptions.KeepRecentPerType).
+	RetainedRecent map[string]int `json:"retained_recent,omitempty"`
+	// RetainedExcluded counts, by wisp_type, closed wisps past the purge age
+	// that were kept because their type is excluded from the purge (see
+	// PurgeOptions.ExcludeTypes).
+	RetainedExcluded map[string]int `json:"retained_excluded,omitempty"`
+	// DeferredRecentEvents counts closed wisps past the purge age that were
+	// kept because wisp_events has activity on them inside the recent-event
+	// window (see PurgeOptions.RecentEventWindow).
+	DeferredRecentEvents int `json:"deferred_recent_events,omitempty"`
+	// WispDelete times the closed-wisp delete batches (see
+	// PurgeOptions.BatchConcurrency). Nil when nothing was deleted.
+	WispDelete *DeleteTiming `json:"wisp_delete,omitempty"`
+	DryRun     bool          `json:"dry_run,omitempty"`
+	Anomalies  []Anomaly     `json:"anomalies,omitempty"`
+}
+
+// DeleteTiming measures a batched delete. Busy sums the time each batch's
+// transaction took, which is what the batches would have taken back to back;
+// Elapsed is the wall-clock time they actually took.
+type DeleteTiming struct {
+	Batches     int           `json:"batches"`
+	Concurrency int           `json:"concurrency"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Busy        time.Duration `json:"busy"`
+}
+
+// Speedup returns Busy/Elapsed: about 1 for serial deletes, up to
+// Concurrency when the batches overlap fully.
+func (t *DeleteTiming) Speedup() float64 {
+	if t == nil || t.Elapsed <= 0 {
+		return 1
+	}
+	return float64(t.Busy) / float64(t.Elapsed)
+}
+
+// String renders the timing as "N batches in 1.2s (concurrency 4, 3.1x)".
+func (t *DeleteTiming) String() string {
+	return fmt.Sprintf("%d batches in %s (concurrency %d, %.1fx)",
+		t.Batches, t.Elapsed.Round(time.Millisecond), t.Concurrency, t.Speedup())
+}
+
+// WispPurgeResult is the outcome of a manual PurgeWispsBefore.
+type WispPurgeResult struct {
+	Database  string         `json:"database"`
+	Cutoff    time.Time      `json:"cutoff"`
+	Digest    map[string]int `json:"digest"` // candidates by wisp_type
+	Deleted   int            `json:"deleted"`
+	DryRun    bool           `json:"dry_run,omitempty"`
+	Anomalies []Anomaly      `json:"anomalies,omitempty"`
+}
+
+// ReopenBuckets splits purge candidates by whether they were closed once and
+// stayed closed (aged out) or were reopened and closed again (churn).
+type ReopenBuckets struct {
+	AgedOut  int `json:"aged_out"`
+	Reclosed int `json:"reclosed"`
 }
 
 // ClosedEntry records an individual issue closure with details for logging.
 type ClosedEntry struct {
-	ID       string `json:"id"`
-	Title    string `json:"title"`
-	AgeDays  int    `json:"age_days"`
-	Database string `json:"database"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Assignee  string `json:"assignee,omitempty"`
+	Priority  int    `json:"priority"`
+	IssueType string `json:"issue_type,omitempty"`
+	AgeDays   int    `json:"age_days"`
+	Database  string `json:"database"`
+}
+
+// String renders the entry for the per-closure log as "ID Title (Nd stale,
+// type PN, assignee:A, db:D)", naming who the issue was assigned to so
+// closures can be attributed.
+func (e ClosedEntry) String() string {
+	assignee := e.Assignee
+	if assignee == "" {
+		assignee = "unassigned"
+	}
+	return fmt.Sprintf("%s %s (%dd stale, %s P%d, assignee:%s, db:%s)",
+		e.ID, e.Title, e.AgeDays, e.IssueType, e.Priority, assignee, e.Database)
 }
 
 // AutoCloseResult holds the results of an auto-close operation.
@@ -142,7 +269,39 @@ type AutoCloseResult struct {
 	Closed        int           `json:"closed"`
 	ClosedEntries []ClosedEntry `json:"closed_entries,omitempty"`
 	DryRun        bool          `json:"dry_run,omitempty"`
-	Anomalies     []Anomaly     `json:"anomalies,omitempty"`
+	// Exempted counts the stale issues each exemption rule kept open.
+	Exempted  AutoCloseExemptCounts `json:"exempted"`
+	Anomalies []Anomaly             `json:"anomalies,omitempty"`
+}
+
+// AutoCloseExemptCounts tallies open issues past the stale cutoff that an
+// auto-close exemption rule kept open. The rules are counted independently,
+// so an issue matching several counts under each. Counts use the wall-clock
+// stale age and ignore AutoCloseOptions.BusinessDaysOnly.
+type AutoCloseExemptCounts struct {
+	Priority   int `json:"priority"`   // P0/P1
+	Type       int `json:"type"`       // epics and convoys
+	Dependency int `json:"dependency"` // blocked by or blocking an open issue
+	KeepLabel  int `json:"keep_label"` // carries an AutoCloseExemptLabels label
+}
+
+// Add accumulates other into c.
+func (c *AutoCloseExemptCounts) Add(other AutoCloseExemptCounts) {
+	c.Priority += other.Priority
+	c.Type += other.Type
+	c.Dependency += other.Dependency
+	c.KeepLabel += other.KeepLabel
+}
+
+// Total returns the sum over all rules.
+func (c AutoCloseExemptCounts) Total() int {
+	return c.Priority + c.Type + c.Dependency + c.KeepLabel
+}
+
+// String renders the counts as "priority=N type=N dependency=N keep-label=N".
+func (c AutoCloseExemptCounts) String() string {
+	return fmt.Sprintf("priority=%d type=%d dependency=%d keep-label=%d",
+		c.Priority, c.Type, c.Dependency, c.KeepLabel)
 }
 
 // Anomaly represents an unexpected condition found during reaper operations.
@@ -157,10 +316,22 @@ const (
 	DefaultQueryTimeout = 30 * time.Second
 	// DefaultBatchSize is the number of rows per batch DELETE operation.
 	DefaultBatchSize = 100
+	// MaxInClauseSize caps the number of placeholders in a single IN (...)
+	// list. Batches larger than this are deleted in sub-batches so the select
+	// batch size can grow without producing statements that some MySQL/Dolt
+	// configurations reject.
+	MaxInClauseSize = 500
+	// DeleteChunkMaxAttempts is how many times a chunk's delete transaction is
+	// tried when it fails with a retriable error.
+	DeleteChunkMaxAttempts = 3
 	// DefaultAlertThreshold is the open-wisp count above which callers should
 	// surface a warning. Sized above the natural steady-state for the current
 	// dog/deacon emit rate (~23 wisps/h × 24h TTL ≈ 550). See hq-57jr8.
 	DefaultAlertThreshold = 800
+	// ClockSkewThreshold is the host/server clock difference above which
+	// callers should warn: every age cutoff is computed from the local clock
+	// but compared against timestamps the Dolt server wrote.
+	ClockSkewThreshold = 5 * time.Minute
 )
 
 // ValidateDBName returns an error if the database name is unsafe.
@@ -171,16 +342,44 @@ func ValidateDBName(dbName string) error {
 	return nil
 }
 
-// OpenDB opens a connection to the Dolt server for a given database.
-func OpenDB(host string, port int, dbName string, readTimeout, writeTimeout time.Duration) (*sql.DB, error) {
+// OpenDB opens a connection to the Dolt server for a given database, with
+// the connection parameters carried by ctx (see WithConnConfig). Every reaper session is unambiguously
+// UTC: DATETIME values are parsed as UTC, time.Time arguments are sent in
+// UTC, and the session time_zone is '+00:00' so NOW() and any server-side
+// conversion agree with the UTC cutoffs the reaper computes, whatever the
+// server's system time zone.
+func OpenDB(ctx context.Context, host string, port int, dbName string, readTimeout, writeTimeout time.Duration) (*sql.DB, error) {
 	if err := ValidateDBName(dbName); err != nil {
 		return nil, err
 	}
-	dsn := fmt.Sprintf("root@tcp(%s:%d)/%s?parseTime=true&timeout=5s&readTimeout=%s&writeTimeout=%s",
-		host, port, dbName,
-		fmt.Sprintf("%ds", int(readTimeout.Seconds())),
-		fmt.Sprintf("%ds", int(writeTimeout.Seconds())))
-	return sql.Open("mysql", dsn)
+	return sql.Open("mysql", openDBDSN(ctx, host, port, dbName, readTimeout, writeTimeout))
+}
+
+func openDBDSN(ctx context.Context, host string, port int, dbName string, readTimeout, writeTimeout time.Duration) string {
+	return ConnConfigFrom(ctx).DSN(host, port, dbName, readTimeout, writeTimeout)
+}
+
+// ClockSkew returns how far the Dolt server's NOW() is ahead of the local
+// clock (negative when behind). The local reading is taken at the midpoint of
+// the round trip so query latency does not count as skew.
+func ClockSkew(db *sql.DB) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+	defer cancel()
+
+	var serverNow time.Time
+	start := time.Now()
+	if err := db.QueryRowContext(ctx, "SELECT NOW()").Scan(&serverNow); err != nil {
+		return 0, fmt.Errorf("select now: %w", err)
+	}
+	end := time.Now()
+	localNow := start.Add(end.Sub(start) / 2)
+	return serverNow.Sub(localNow), nil
+}
+
+// ClockSkewExceeded reports whether skew, in either direction, is past
+// ClockSkewThreshold.
+func ClockSkewExceeded(skew time.Duration) bool {
+	return skew > ClockSkewThreshold || skew < -ClockSkewThreshold
 }
 
 // parentExcludeJoin returns a LEFT JOIN clause and WHERE condition that restricts
@@ -251,13 +450,14 @@ type sqlRunner interface {
 // operations (wisps and issues). Returns false (no error) when tables are missing
 // — callers use this to skip databases that have incomplete beads schema (e.g.
 // partially initialized databases on the central Dolt server).
-func HasReaperSchema(db *sql.DB) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func HasReaperSchema(ctx context.Context, db *sql.DB) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var count int
 	err := db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name IN ('wisps', 'issues', 'wisp_dependencies') AND table_schema = DATABASE()").Scan(&count)
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name IN (?, ?, ?) AND table_schema = DATABASE()",
+		schemaName(ctx, "wisps"), schemaName(ctx, "issues"), schemaName(ctx, "wisp_dependencies")).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("check reaper schema: %w", err)
 	}
@@ -276,22 +476,40 @@ func HasReaperSchema(db *sql.DB) (bool, error) {
 	return hasColumns(ctx, db, "dependencies", "depends_on_issue_id", "depends_on_wisp_id", "depends_on_external")
 }
 
+// tableExists and hasColumns take default-schema names and map them.
 func tableExists(ctx context.Context, db *sql.DB, table string) (bool, error) {
 	var count int
 	err := db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name = ? AND table_schema = DATABASE()", table).Scan(&count)
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name = ? AND table_schema = DATABASE()", schemaName(ctx, table)).Scan(&count)
 	return count > 0, err
 }
 
+// partitionTables splits tables into those present in the connected
+// database and those missing, probing each once.
+func partitionTables(ctx context.Context, db *sql.DB, tables []string) (present, missing []string, err error) {
+	for _, table := range tables {
+		ok, err := tableExists(ctx, db, table)
+		if err != nil {
+			return nil, nil, fmt.Errorf("check %s: %w", table, err)
+		}
+		if ok {
+			present = append(present, table)
+		} else {
+			missing = append(missing, table)
+		}
+	}
+	return present, missing, nil
+}
+
 func hasColumns(ctx context.Context, db *sql.DB, table string, columns ...string) (bool, error) {
 	if len(columns) == 0 {
 		return true, nil
 	}
 	placeholders := strings.TrimRight(strings.Repeat("?,", len(columns)), ",")
 	args := make([]interface{}, 0, len(columns)+1)
-	args = append(args, table)
+	args = append(args, schemaName(ctx, table))
 	for _, column := range columns {
-		args = append(args, column)
+		args = append(args, schemaName(ctx, column))
 	}
 	var count int
 	query := fmt.Sprintf("SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name IN (%s)", placeholders)
@@ -299,13 +517,24 @@ func hasColumns(ctx context.Context, db *sql.DB, table string, columns ...string
 	return count == len(columns), err
 }
 
+// ScanOptions tunes a scan beyond its age thresholds.
+type ScanOptions struct {
+	// Now, if set, replaces time.Now when computing the age cutoffs.
+	Now func() time.Time
+}
+
 // Scan counts reaper candidates in a database without modifying anything.
-func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssueAge time.Duration) (*ScanResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+func Scan(ctx context.Context, db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssueAge time.Duration) (*ScanResult, error) {
+	return ScanWithOptions(ctx, db, dbName, maxAge, purgeAge, mailDeleteAge, staleIssueAge, ScanOptions{})
+}
+
+// ScanWithOptions is Scan with explicit options.
+func ScanWithOptions(ctx context.Context, db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssueAge time.Duration, opts ScanOptions) (*ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
 	defer cancel()
 
 	result := &ScanResult{Database: dbName}
-	now := time.Now().UTC()
+	now := nowUTC(opts.Now)
 	parentJoin, parentWhere := parentExcludeJoin(dbName)
 	moleculeStepJoin := closedMoleculeStepJoin("closed_molecule_step")
 	moleculeStepExcludeJoin := closedMoleculeStepExcludeJoin("closed_molecule_step")
@@ -313,19 +542,19 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 	moleculeStepQuery := fmt.Sprintf(
 		"SELECT COUNT(*) FROM wisps w %s WHERE %s AND w.issue_type != 'agent'",
 		moleculeStepJoin, openWispStatusWhere)
-	if err := db.QueryRowContext(ctx, moleculeStepQuery).Scan(&result.MoleculeStepCandidates); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, moleculeStepQuery)).Scan(&result.MoleculeStepCandidates); err != nil {
 		return nil, fmt.Errorf("count molecule step candidates: %w", err)
 	}
 
 	// Count reap candidates: open wisps past max_age with eligible parent status.
-	// Must match Reap() eligibility semantics exactly, including the exclusion of
+	// Must match Reap eligibility semantics exactly, including the exclusion of
 	// agent beads, otherwise scan can report candidates that reap will never close.
 	// Uses LEFT JOIN anti-pattern instead of correlated EXISTS to avoid O(n*m) cost (gt-jd1z).
 	// Closed-molecule steps are counted separately above and excluded here so counts stay disjoint.
 	reapQuery := fmt.Sprintf(
 		"SELECT COUNT(*) FROM wisps w %s %s WHERE %s AND w.created_at < ? AND w.issue_type != 'agent' AND %s AND closed_molecule_step.issue_id IS NULL",
 		parentJoin, moleculeStepExcludeJoin, openWispStatusWhere, parentWhere)
-	if err := db.QueryRowContext(ctx, reapQuery, now.Add(-maxAge)).Scan(&result.ReapCandidates); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, reapQuery), now.Add(-maxAge)).Scan(&result.ReapCandidates); err != nil {
 		return nil, fmt.Errorf("count reap candidates: %w", err)
 	}
 
@@ -334,7 +563,7 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 	// The parent check (correlated subqueries on wisp_dependencies) was causing O(n*m) query
 	// cost with 1800+ closed wisps, leading to CPU spikes and connection timeouts (gt-wvd2).
 	purgeQuery := "SELECT COUNT(*) FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?"
-	if err := db.QueryRowContext(ctx, purgeQuery, now.Add(-purgeAge)).Scan(&result.PurgeCandidates); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, purgeQuery), now.Add(-purgeAge)).Scan(&result.PurgeCandidates); err != nil {
 		return nil, fmt.Errorf("count purge candidates: %w", err)
 	}
 
@@ -342,7 +571,7 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 	// The issues/labels tables may not exist on the gt Dolt server if beads
 	// stores its data on a separate Dolt instance. Skip gracefully.
 	mailQuery := "SELECT COUNT(*) FROM issues WHERE status = 'closed' AND closed_at < ? AND id IN (SELECT issue_id FROM labels WHERE label = 'gt:message')"
-	if err := db.QueryRowContext(ctx, mailQuery, now.Add(-mailDeleteAge)).Scan(&result.MailCandidates); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, mailQuery), now.Add(-mailDeleteAge)).Scan(&result.MailCandidates); err != nil {
 		if !isTableNotFound(err) {
 			return nil, fmt.Errorf("count mail candidates: %w", err)
 		}
@@ -370,7 +599,7 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 			WHERE d.depends_on_issue_id IS NOT NULL
 			AND blocker.status IN ('open', 'in_progress')
 		)`
-	if err := db.QueryRowContext(ctx, staleQuery, now.Add(-staleIssueAge)).Scan(&result.StaleCandidates); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, staleQuery), now.Add(-staleIssueAge)).Scan(&result.StaleCandidates); err != nil {
 		if !isTableNotFound(err) {
 			return nil, fmt.Errorf("count stale candidates: %w", err)
 		}
@@ -379,7 +608,7 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 
 	// Total open wisps.
 	openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
-	if err := db.QueryRowContext(ctx, openQuery).Scan(&result.OpenWisps); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, openQuery)).Scan(&result.OpenWisps); err != nil {
 		return nil, fmt.Errorf("count open wisps: %w", err)
 	}
 
@@ -389,7 +618,7 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 		LEFT JOIN wisps pw ON pw.id = wd.depends_on_wisp_id LEFT JOIN issues pi ON pi.id = wd.depends_on_issue_id
 		WHERE wd.type = 'parent-child' AND wd.depends_on_external IS NULL AND (wd.depends_on_wisp_id IS NOT NULL OR wd.depends_on_issue_id IS NOT NULL) AND pw.id IS NULL AND pi.id IS NULL`
 	var danglingCount int
-	if err := db.QueryRowContext(ctx, danglingQuery).Scan(&danglingCount); err == nil && danglingCount > 0 {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, danglingQuery)).Scan(&danglingCount); err == nil && danglingCount > 0 {
 		result.Anomalies = append(result.Anomalies, Anomaly{
 			Type:    "dangling_parent_ref",
 			Message: fmt.Sprintf("%d wisp(s) have parent dependency records pointing to purged/missing parents", danglingCount),
@@ -400,14 +629,103 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 	return result, nil
 }
 
+// ReapOptions tunes how Reap closes wisps. The zero value uses defaults.
+type ReapOptions struct {
+	// CloseBatchSize is the number of wisp IDs selected and closed per UPDATE.
+	// Zero or negative uses DefaultBatchSize. IN clauses are still capped at
+	// MaxInClauseSize, so large values are split into several UPDATEs.
+	CloseBatchSize int
+	// Progress, if set, is called after each close batch once a reap needs
+	// more than one batch, with the running total closed for that phase
+	// (e.g. "stale wisps"). Small reaps that fit in one batch never call it.
+	Progress func(description string, closed int)
+	// Now, if set, replaces time.Now when computing the max-age cutoff, so
+	// tests can freeze time at the age boundary.
+	Now func() time.Time
+	// LiveOwners lists the assignees (e.g. "gastown/polecats/Toast") whose
+	// sessions are running. Hooked and in-progress wisps assigned to them are
+	// long-running work, not abandoned, and are never closed by age.
+	LiveOwners []string
+}
+
+// liveOwnedWispWhere matches hooked or in-progress wisps assigned to one of n
+// live owners, bound as ? placeholders after the cutoff.
+func liveOwnedWispWhere(n int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", n), ",")
+	return fmt.Sprintf("w.status IN ('hooked', 'in_progress') AND COALESCE(w.assignee, '') IN (%s)", placeholders)
+}
+
+// staleWispWhere is the WHERE clause for open wisps Reap closes by age: created
+// before the ? cutoff, not agent beads, with no open parent (parentWhere from
+// parentExcludeJoin) and not a closed-molecule step.
+func staleWispWhere(parentWhere string) string {
+	return fmt.Sprintf(
+		"%s AND w.created_at < ? AND w.issue_type != 'agent' AND %s AND closed_molecule_step.issue_id IS NULL", openWispStatusWhere, parentWhere)
+}
+
+// nowUTC returns now() in UTC, or the current time when now is nil.
+func nowUTC(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now().UTC()
+	}
+	return now().UTC()
+}
+
+// CountOpenWisps returns how many wisps in the connected database are open,
+// hooked or in progress.
+func CountOpenWisps(ctx context.Context, db *sql.DB) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+	var n int
+	openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, openQuery)).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count open wisps: %w", err)
+	}
+	return n, nil
+}
+
+// CountOpenWispsByType returns the open, hooked or in-progress wisps in the
+// connected database by wisp_type ("unknown" when unset).
+func CountOpenWispsByType(ctx context.Context, db *sql.DB) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+	query := "SELECT COALESCE(wisp_type, 'unknown') AS wtype, COUNT(*) AS cnt FROM wisps WHERE status IN ('open', 'hooked', 'in_progress') GROUP BY wtype"
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, query))
+	if err != nil {
+		return nil, fmt.Errorf("count open wisps by type: %w", err)
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var wtype string
+		var cnt int
+		if err := rows.Scan(&wtype, &cnt); err != nil {
+			return nil, fmt.Errorf("scan open wisp count: %w", err)
+		}
+		counts[wtype] += cnt
+	}
+	return counts, rows.Err()
+}
+
 // Reap closes stale wisps in a database whose parent molecule is already closed.
 // UPDATEs are batched to avoid holding a write lock for extended periods on large tables.
-func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapResult, error) {
+func Reap(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapResult, error) {
+	return ReapWithOptions(ctx, db, dbName, maxAge, dryRun, ReapOptions{})
+}
+
+// ReapWithOptions is Reap with a configurable close batch size and progress
+// reporting for large reaps.
+func ReapWithOptions(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool, opts ReapOptions) (*ReapResult, error) {
+	batchSize := opts.CloseBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
 	// Use a longer timeout to accommodate batched processing across large tables.
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
-	cutoff := time.Now().UTC().Add(-maxAge)
+	cutoff := nowUTC(opts.Now).Add(-maxAge)
 	parentJoin, parentWhere := parentExcludeJoin(dbName)
 	moleculeStepJoin := closedMoleculeStepJoin("closed_molecule_step")
 	moleculeStepExcludeJoin := closedMoleculeStepExcludeJoin("closed_molecule_step")
@@ -415,26 +733,41 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 	// identity and should not be closed by the wisp reaper regardless of age.
 	// Closed-molecule steps are closed immediately through a separate path, so stale
 	// max-age counts exclude them to keep dry-run and scan counts disjoint.
-	whereClause := fmt.Sprintf(
-		"%s AND w.created_at < ? AND w.issue_type != 'agent' AND %s AND closed_molecule_step.issue_id IS NULL", openWispStatusWhere, parentWhere)
+	whereClause := staleWispWhere(parentWhere)
+	staleArgs := []interface{}{cutoff}
 
 	result := &ReapResult{Database: dbName, DryRun: dryRun}
 
+	if len(opts.LiveOwners) > 0 {
+		liveWhere := liveOwnedWispWhere(len(opts.LiveOwners))
+		for _, owner := range opts.LiveOwners {
+			staleArgs = append(staleArgs, owner)
+		}
+		sparedQuery := fmt.Sprintf("SELECT COUNT(*) FROM wisps w %s %s WHERE %s AND %s", parentJoin, moleculeStepExcludeJoin, whereClause, liveWhere)
+		if err := db.QueryRowContext(ctx, schemaSQL(ctx, sparedQuery), staleArgs...).Scan(&result.SparedLive); err != nil {
+			return nil, fmt.Errorf("count live-owned wisps: %w", err)
+		}
+		whereClause = fmt.Sprintf("%s AND NOT (%s)", whereClause, liveWhere)
+	}
+
 	if dryRun {
 		moleculeStepCountQuery := fmt.Sprintf(
 			"SELECT COUNT(*) FROM wisps w %s WHERE %s AND w.issue_type != 'agent'",
 			moleculeStepJoin, openWispStatusWhere)
-		if err := db.QueryRowContext(ctx, moleculeStepCountQuery).Scan(&result.MoleculeStepsClosed); err != nil {
+		if err := db.QueryRowContext(ctx, schemaSQL(ctx, moleculeStepCountQuery)).Scan(&result.MoleculeStepsClosed); err != nil {
 			return nil, fmt.Errorf("dry-run molecule step count: %w", err)
 		}
 		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM wisps w %s %s WHERE %s", parentJoin, moleculeStepExcludeJoin, whereClause)
-		if err := db.QueryRowContext(ctx, countQuery, cutoff).Scan(&result.Reaped); err != nil {
+		if err := db.QueryRowContext(ctx, schemaSQL(ctx, countQuery), staleArgs...).Scan(&result.Reaped); err != nil {
 			return nil, fmt.Errorf("dry-run count: %w", err)
 		}
 		openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
-		if err := db.QueryRowContext(ctx, openQuery).Scan(&result.OpenRemain); err != nil {
+		if err := db.QueryRowContext(ctx, schemaSQL(ctx, openQuery)).Scan(&result.OpenRemain); err != nil {
 			return nil, fmt.Errorf("count open: %w", err)
 		}
+		if err := countNullCreatedAt(ctx, db, result); err != nil {
+			return nil, err
+		}
 		return result, nil
 	}
 
@@ -457,8 +790,8 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 
 	moleculeStepIDQuery := fmt.Sprintf(
 		"SELECT w.id FROM wisps w %s WHERE %s AND w.issue_type != 'agent' LIMIT %d",
-		moleculeStepJoin, openWispStatusWhere, DefaultBatchSize)
-	moleculeStepsClosed, err := closeWispsInBatches(ctx, conn, moleculeStepIDQuery, nil, "closed molecule steps")
+		moleculeStepJoin, openWispStatusWhere, batchSize)
+	moleculeStepsClosed, err := closeWispsInBatches(ctx, conn, moleculeStepIDQuery, nil, "closed molecule steps", batchSize, opts.Progress)
 	if err != nil {
 		return nil, err
 	}
@@ -469,9 +802,9 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 	// Uses LEFT JOIN anti-pattern instead of correlated EXISTS to avoid O(n*m) cost (gt-jd1z).
 	idQuery := fmt.Sprintf(
 		"SELECT w.id FROM wisps w %s %s WHERE %s LIMIT %d",
-		parentJoin, moleculeStepExcludeJoin, whereClause, DefaultBatchSize)
+		parentJoin, moleculeStepExcludeJoin, whereClause, batchSize)
 
-	totalReaped, err := closeWispsInBatches(ctx, conn, idQuery, []interface{}{cutoff}, "stale wisps")
+	totalReaped, err := closeWispsInBatches(ctx, conn, idQuery, staleArgs, "stale wisps", batchSize, opts.Progress)
 	if err != nil {
 		return nil, err
 	}
@@ -501,17 +834,40 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 	}
 
 	openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
-	if err := conn.QueryRowContext(ctx, openQuery).Scan(&result.OpenRemain); err != nil {
+	if err := conn.QueryRowContext(ctx, schemaSQL(ctx, openQuery)).Scan(&result.OpenRemain); err != nil {
 		return result, fmt.Errorf("count open: %w", err)
 	}
+	if err := countNullCreatedAt(ctx, conn, result); err != nil {
+		return result, err
+	}
 
 	return result, nil
 }
 
-func closeWispsInBatches(ctx context.Context, runner sqlRunner, idQuery string, queryArgs []interface{}, description string) (int, error) {
+// countNullCreatedAt records the open wisps whose created_at is NULL. The
+// stale-wisp filter (created_at < cutoff) never matches them, and treating
+// an unknown age as ancient would close wisps that may be brand new, so they
+// are left open and reported instead.
+func countNullCreatedAt(ctx context.Context, runner sqlRunner, result *ReapResult) error {
+	query := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress') AND created_at IS NULL AND issue_type != 'agent'"
+	if err := runner.QueryRowContext(ctx, schemaSQL(ctx, query)).Scan(&result.NullCreatedAt); err != nil {
+		return fmt.Errorf("count null created_at: %w", err)
+	}
+	if result.NullCreatedAt > 0 {
+		result.Anomalies = append(result.Anomalies, Anomaly{
+			Type:    "null_created_at",
+			Message: fmt.Sprintf("%d open wisps have no created_at and are never reaped; set created_at or close them by hand", result.NullCreatedAt),
+			Count:   result.NullCreatedAt,
+		})
+	}
+	return nil
+}
+
+func closeWispsInBatches(ctx context.Context, runner sqlRunner, idQuery string, queryArgs []interface{}, description string, batchSize int, progress func(string, int)) (int, error) {
 	total := 0
+	multiBatch := false
 	for {
-		rows, err := runner.QueryContext(ctx, idQuery, queryArgs...)
+		rows, err := runner.QueryContext(ctx, schemaSQL(ctx, idQuery), queryArgs...)
 		if err != nil {
 			return total, fmt.Errorf("select %s batch: %w", description, err)
 		}
@@ -535,41 +891,148 @@ func closeWispsInBatches(ctx context.Context, runner sqlRunner, idQuery string,
 			return total, nil
 		}
 
-		placeholders := make([]string, len(ids))
-		args := make([]interface{}, len(ids))
-		for i, id := range ids {
-			placeholders[i] = "?"
-			args[i] = id
+		for _, chunk := range chunkIDs(ids, MaxInClauseSize) {
+			placeholders := make([]string, len(chunk))
+			args := make([]interface{}, len(chunk))
+			for i, id := range chunk {
+				placeholders[i] = "?"
+				args[i] = id
+			}
+			inClause := strings.Join(placeholders, ",")
+
+			updateQuery := fmt.Sprintf(
+				"UPDATE wisps SET status='closed', closed_at=NOW() WHERE id IN (%s) AND status IN ('open', 'hooked', 'in_progress') AND issue_type != 'agent'",
+				inClause)
+			sqlResult, err := runner.ExecContext(ctx, schemaSQL(ctx, updateQuery), args...)
+			if err != nil {
+				return total, fmt.Errorf("close %s batch: %w", description, err)
+			}
+
+			affected, _ := sqlResult.RowsAffected()
+			total += int(affected)
 		}
-		inClause := strings.Join(placeholders, ",")
 
-		updateQuery := fmt.Sprintf(
-			"UPDATE wisps SET status='closed', closed_at=NOW() WHERE id IN (%s) AND status IN ('open', 'hooked', 'in_progress') AND issue_type != 'agent'",
-			inClause)
-		sqlResult, err := runner.ExecContext(ctx, updateQuery, args...)
-		if err != nil {
-			return total, fmt.Errorf("close %s batch: %w", description, err)
+		// A full batch means more may follow; from then on, report progress.
+		if len(ids) >= batchSize {
+			multiBatch = true
+		}
+		if multiBatch && progress != nil {
+			progress(description, total)
 		}
+	}
+}
+
+// TombstoneStatus is the status a tombstoned row is left with.
+const TombstoneStatus = "purged"
+
+// PurgeOptions tunes a purge beyond its age thresholds.
+type PurgeOptions struct {
+	// Tombstone keeps each purged wisp or mail as a stub row instead of
+	// deleting it: status becomes TombstoneStatus and the description is
+	// emptied, while id, title and closed_at stay. Aux rows (labels,
+	// comments, events, dependencies) are still deleted. Use it when
+	// external systems hold references that break if an ID vanishes.
+	Tombstone bool
+	// KeepRecentPerType spares the N most recently closed wisps of each
+	// wisp_type from the purge, whatever their age, so a recent sample of
+	// every type is always retained. Zero keeps none.
+	KeepRecentPerType int
+	// ExcludeTypes are wisp types the purge never touches, whatever their
+	// age ("unknown" matches wisps with no type). Each must pass
+	// ValidateWispType.
+	ExcludeTypes []string
+	// RecentEventWindow defers the purge of any wisp with a wisp_events row
+	// newer than this, whatever its closed_at: a wisp reopened and closed
+	// again, or otherwise still active, may be referenced by work that
+	// would break if it vanished. Zero defers nothing; so does a database
+	// without a wisp_events table.
+	RecentEventWindow time.Duration
+	// BatchConcurrency is how many closed-wisp delete batches run at once
+	// within the database. Each batch is its own transaction over a
+	// disjoint set of IDs, and the next IDs are selected only once every
+	// batch in flight has committed, so no row is deleted twice. Zero or
+	// one deletes batch by batch, as before. Databases are still purged one
+	// at a time; this does not change that.
+	BatchConcurrency int
+	// Now, if set, replaces time.Now when computing the purge and mail
+	// cutoffs, so tests can freeze time at the age boundary.
+	Now func() time.Time
+}
+
+// wispTypePattern matches the wisp types ExcludeTypes may name.
+var wispTypePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]+$`)
 
-		affected, _ := sqlResult.RowsAffected()
-		total += int(affected)
+// ValidateWispType checks that a wisp type is safe to inline in a query.
+func ValidateWispType(wispType string) error {
+	if !wispTypePattern.MatchString(wispType) {
+		return fmt.Errorf("invalid wisp type %q: want letters, digits, '_', '.', ':' or '-'", wispType)
 	}
+	return nil
 }
 
 // Purge deletes old closed wisps and mail from a database.
-func Purge(db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dryRun bool) (*PurgeResult, error) {
-	result := &PurgeResult{Database: dbName, DryRun: dryRun}
+func Purge(ctx context.Context, db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dryRun bool) (*PurgeResult, error) {
+	return PurgeWithOptions(ctx, db, dbName, purgeAge, mailDeleteAge, dryRun, PurgeOptions{})
+}
+
+// PurgeWithOptions is Purge with explicit options.
+func PurgeWithOptions(ctx context.Context, db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dryRun bool, opts PurgeOptions) (*PurgeResult, error) {
+	result := &PurgeResult{Database: dbName, DryRun: dryRun, Tombstoned: opts.Tombstone}
+	now := nowUTC(opts.Now)
+	for _, wtype := range opts.ExcludeTypes {
+		if err := ValidateWispType(wtype); err != nil {
+			return nil, err
+		}
+	}
+
+	// Count what KeepRecentPerType spares before the purge removes the rest.
+	if opts.KeepRecentPerType > 0 {
+		retained, err := retainedRecentWisps(ctx, db, now.Add(-purgeAge), opts.KeepRecentPerType)
+		if err != nil {
+			return nil, fmt.Errorf("count retained wisps: %w", err)
+		}
+		for _, wtype := range opts.ExcludeTypes {
+			delete(retained, wtype) // Counted under RetainedExcluded
+		}
+		if len(retained) > 0 {
+			result.RetainedRecent = retained
+		}
+	}
+	if len(opts.ExcludeTypes) > 0 {
+		excluded, err := retainedExcludedWisps(ctx, db, now.Add(-purgeAge), opts.ExcludeTypes)
+		if err != nil {
+			return nil, fmt.Errorf("count excluded wisps: %w", err)
+		}
+		if len(excluded) > 0 {
+			result.RetainedExcluded = excluded
+		}
+	}
+
+	var eventSince time.Time
+	if opts.RecentEventWindow > 0 {
+		deferred, since, err := deferredRecentEventWisps(ctx, db, now.Add(-purgeAge), now.Add(-opts.RecentEventWindow), opts.KeepRecentPerType, opts.ExcludeTypes)
+		if err != nil {
+			return nil, fmt.Errorf("count wisps with recent events: %w", err)
+		}
+		result.DeferredRecentEvents = deferred
+		eventSince = since
+	}
 
 	// Purge closed wisps.
-	purged, anomalies, err := purgeClosedWisps(db, dbName, purgeAge, dryRun)
+	timing := &DeleteTiming{}
+	_, buckets, purged, anomalies, err := purgeClosedWispsBefore(ctx, db, dbName, now.Add(-purgeAge), opts.KeepRecentPerType, opts.ExcludeTypes, eventSince, dryRun, opts.Tombstone, opts.BatchConcurrency, timing)
 	if err != nil {
 		return nil, fmt.Errorf("purge wisps: %w", err)
 	}
 	result.WispsPurged = purged
+	result.ReopenBuckets = buckets
+	if timing.Batches > 0 {
+		result.WispDelete = timing
+	}
 	result.Anomalies = append(result.Anomalies, anomalies...)
 
 	// Purge old mail.
-	mailPurged, err := purgeOldMail(db, dbName, mailDeleteAge, dryRun)
+	mailPurged, err := purgeOldMail(ctx, db, dbName, now.Add(-mailDeleteAge), dryRun, opts.Tombstone)
 	if err != nil {
 		return result, fmt.Errorf("purge mail: %w", err)
 	}
@@ -578,70 +1041,126 @@ func Purge(db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dry
 	return result, nil
 }
 
-func purgeClosedWisps(db *sql.DB, dbName string, purgeAge time.Duration, dryRun bool) (int, []Anomaly, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+// PurgeWispsBefore deletes closed wisps closed before cutoff, for one-off
+// manual purges outside the reaper's age thresholds. The result carries the
+// per-type digest of candidates; with dryRun nothing is deleted. Mail is not
+// touched.
+func PurgeWispsBefore(ctx context.Context, db *sql.DB, dbName string, cutoff time.Time, dryRun bool) (*WispPurgeResult, error) {
+	if err := ValidateDBName(dbName); err != nil {
+		return nil, err
+	}
+	if cutoff.After(time.Now()) {
+		return nil, fmt.Errorf("cutoff %s is in the future", cutoff.Format(time.RFC3339))
+	}
+	digest, _, deleted, anomalies, err := purgeClosedWispsBefore(ctx, db, dbName, cutoff.UTC(), 0, nil, time.Time{}, dryRun, false, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("purge wisps: %w", err)
+	}
+	return &WispPurgeResult{
+		Database:  dbName,
+		Cutoff:    cutoff.UTC(),
+		Digest:    digest,
+		Deleted:   deleted,
+		DryRun:    dryRun,
+		Anomalies: anomalies,
+	}, nil
+}
+
+// purgeClosedWispsBefore deletes (or, with tombstone, stubs out) closed wisps
+// closed before deleteCutoff and returns the candidate digest by wisp_type,
+// the candidates split by reopen history (nil when there are none or the
+// database has no wisp_events table) and the number purged (the candidate
+// count under dryRun). With keepRecent > 0 the most recent keepRecent closed
+// wisps of each type are never candidates, nor are wisps of the excludeTypes,
+// nor, when eventSince is set, wisps with a wisp_events row at or after it. Up
+// to concurrency delete batches run at once; timing, if non-nil, records how
+// long they took.
+func purgeClosedWispsBefore(ctx context.Context, db *sql.DB, dbName string, deleteCutoff time.Time, keepRecent int, excludeTypes []string, eventSince time.Time, dryRun, tombstone bool, concurrency int, timing *DeleteTiming) (map[string]int, *ReopenBuckets, int, []Anomaly, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
+	deleteCutoff = deleteCutoff.UTC()
 
-	deleteCutoff := time.Now().UTC().Add(-purgeAge)
 	var anomalies []Anomaly
 
-	// Digest: count by wisp_type.
+	// Probe the aux tables once rather than failing a DELETE against a
+	// missing one in every batch. wisp_events also feeds the reopen split.
+	auxTables, missingAux, err := partitionTables(ctx, db, []string{"wisp_labels", "wisp_comments", "wisp_events", "wisp_dependencies"})
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	// Digest: count by wisp_type, and how many of those were reopened and
+	// closed again. beads records a reopen (an update moving a closed issue
+	// or wisp back to a non-closed status) as a 'reopened' event, which for
+	// wisps lands in wisp_events.
 	// No parent check — closed wisps past the delete age are unconditionally purgeable.
 	// The parent check (correlated subqueries on wisp_dependencies) was causing O(n*m)
 	// query cost with 1800+ closed wisps, leading to CPU spikes and timeouts (gt-wvd2).
-	digestQuery := "SELECT COALESCE(w.wisp_type, 'unknown') AS wtype, COUNT(*) AS cnt FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ? GROUP BY wtype"
-	rows, err := db.QueryContext(ctx, digestQuery, deleteCutoff)
+	// The reopen set is a derived table rather than a correlated EXISTS for the same reason.
+	keepClause := keepRecentExclusion(keepRecent) + wispTypeFilter("NOT IN", excludeTypes) + recentEventFilter("NOT IN", eventSince)
+	hasEvents := !slices.Contains(missingAux, "wisp_events")
+	digestQuery := "SELECT COALESCE(w.wisp_type, 'unknown') AS wtype, COUNT(*) AS cnt, 0 AS reclosed FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?" + keepClause + " GROUP BY wtype"
+	if hasEvents {
+		digestQuery = "SELECT COALESCE(w.wisp_type, 'unknown') AS wtype, COUNT(*) AS cnt, COUNT(r.issue_id) AS reclosed FROM wisps w" +
+			" LEFT JOIN (SELECT DISTINCT issue_id FROM wisp_events WHERE event_type = 'reopened') r ON r.issue_id = w.id" +
+			" WHERE w.status = 'closed' AND w.closed_at < ?" + keepClause + " GROUP BY wtype"
+	}
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, digestQuery), deleteCutoff)
 	if err != nil {
-		return 0, nil, fmt.Errorf("digest query: %w", err)
+		return nil, nil, 0, nil, fmt.Errorf("digest query: %w", err)
 	}
-	digestTotal := 0
+	digest := make(map[string]int)
+	digestTotal, reclosedTotal := 0, 0
 	for rows.Next() {
 		var wtype string
-		var cnt int
-		if err := rows.Scan(&wtype, &cnt); err != nil {
+		var cnt, reclosed int
+		if err := rows.Scan(&wtype, &cnt, &reclosed); err != nil {
 			rows.Close()
-			return 0, nil, fmt.Errorf("digest scan: %w", err)
+			return nil, nil, 0, nil, fmt.Errorf("digest scan: %w", err)
 		}
+		digest[wtype] += cnt
 		digestTotal += cnt
+		reclosedTotal += reclosed
 	}
 	rows.Close()
 
 	if digestTotal == 0 {
-		return 0, anomalies, nil
+		return digest, nil, 0, anomalies, nil
+	}
+	var buckets *ReopenBuckets
+	if hasEvents {
+		buckets = &ReopenBuckets{AgedOut: digestTotal - reclosedTotal, Reclosed: reclosedTotal}
 	}
 
 	if dryRun {
-		return digestTotal, anomalies, nil
+		return digest, buckets, digestTotal, anomalies, nil
 	}
 
-	if _, err := db.ExecContext(ctx, "SET @@autocommit = 0"); err != nil {
-		return 0, nil, fmt.Errorf("disable autocommit: %w", err)
-	}
-	defer func() {
-		_, _ = db.ExecContext(context.Background(), "SET @@autocommit = 1")
-	}()
-
 	// Batch delete — simple status+age filter, no parent check needed for purge.
+	// Each select fetches one batch per concurrent delete.
+	if concurrency < 1 {
+		concurrency = 1
+	}
 	idQuery := fmt.Sprintf(
-		"SELECT w.id FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ? LIMIT %d",
-		DefaultBatchSize)
-	auxTables := []string{"wisp_labels", "wisp_comments", "wisp_events", "wisp_dependencies"}
+		"SELECT w.id FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?%s LIMIT %d",
+		keepClause, DefaultBatchSize*concurrency)
+	if len(missingAux) > 0 {
+		anomalies = append(anomalies, Anomaly{
+			Type:    "aux_tables_missing",
+			Message: fmt.Sprintf("purging wisp aux tables %s; not present: %s", strings.Join(auxTables, ", "), strings.Join(missingAux, ", ")),
+			Count:   len(missingAux),
+		})
+	}
 
-	totalDeleted, err := batchDeleteRows(ctx, db, idQuery, deleteCutoff, "wisps", auxTables)
+	totalDeleted, err := batchDeleteRows(ctx, db, idQuery, deleteCutoff, "wisps", auxTables, tombstone, concurrency, timing)
 	if err != nil {
-		return totalDeleted, anomalies, err
+		return digest, buckets, totalDeleted, anomalies, err
 	}
 
 	if totalDeleted > 0 {
-		// Flush SQL transaction to working set before DOLT_COMMIT.
-		if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
-			anomalies = append(anomalies, Anomaly{
-				Type:    "sql_commit_failed",
-				Message: fmt.Sprintf("sql commit after purge failed: %v", err),
-			})
-			return totalDeleted, anomalies, nil
-		}
-		commitMsg := fmt.Sprintf("reaper: purge %d closed wisps from %s", totalDeleted, dbName)
+		// Each chunk committed its own SQL transaction, so the working set
+		// already holds every delete whichever pooled connection runs this.
+		commitMsg := fmt.Sprintf("reaper: %s %d closed wisps from %s", purgeVerb(tombstone), totalDeleted, dbName)
 		if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('--allow-empty', '-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
 			// Non-fatal — log but continue.
 			anomalies = append(anomalies, Anomaly{
@@ -651,20 +1170,129 @@ func purgeClosedWisps(db *sql.DB, dbName string, purgeAge time.Duration, dryRun
 		}
 	}
 
-	return totalDeleted, anomalies, nil
+	return digest, buckets, totalDeleted, anomalies, nil
+}
+
+// recentEventFilter returns the WHERE fragment "AND w.id <op> (...)" over
+// wisps with a wisp_events row at or after since, or "" when since is zero.
+// since is inlined (it is a formatted time, not user input) because the
+// purge batch queries bind only the closed_at cutoff.
+func recentEventFilter(op string, since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" AND w.id %s (SELECT e.issue_id FROM wisp_events e WHERE e.created_at >= '%s')",
+		op, since.UTC().Format("2006-01-02 15:04:05"))
+}
+
+// deferredRecentEventWisps counts the closed wisps closed before cutoff that
+// a recent-event window starting at since keeps from the purge, after the
+// keepRecent and excludeTypes exemptions (which are counted separately). It
+// returns the since to filter the purge by, or a zero time when the
+// database has no wisp_events table.
+func deferredRecentEventWisps(ctx context.Context, db *sql.DB, cutoff, since time.Time, keepRecent int, excludeTypes []string) (int, time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+
+	if ok, err := tableExists(ctx, db, "wisp_events"); err != nil || !ok {
+		return 0, time.Time{}, err
+	}
+	query := "SELECT COUNT(*) FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?" +
+		keepRecentExclusion(keepRecent) + wispTypeFilter("NOT IN", excludeTypes) + recentEventFilter("IN", since)
+	var count int
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, query), cutoff.UTC()).Scan(&count); err != nil {
+		return 0, time.Time{}, err
+	}
+	return count, since, nil
+}
+
+// rankedClosedWispsSQL ranks closed wisps within their wisp_type, most
+// recently closed first (rn = 1).
+const rankedClosedWispsSQL = "SELECT id, COALESCE(wisp_type, 'unknown') AS wtype, closed_at, " +
+	"ROW_NUMBER() OVER (PARTITION BY COALESCE(wisp_type, 'unknown') ORDER BY closed_at DESC, id DESC) AS rn " +
+	"FROM wisps WHERE status = 'closed'"
+
+// keepRecentExclusion returns the WHERE fragment that spares the keep most
+// recently closed wisps of each type from a purge, or "" when keep <= 0.
+func keepRecentExclusion(keep int) string {
+	if keep <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" AND w.id NOT IN (SELECT k.id FROM (%s) k WHERE k.rn <= %d)", rankedClosedWispsSQL, keep)
+}
+
+// retainedRecentWisps counts, by wisp_type, the closed wisps closed before
+// cutoff that keepRecentExclusion spares.
+func retainedRecentWisps(ctx context.Context, db *sql.DB, cutoff time.Time, keep int) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT k.wtype, COUNT(*) FROM (%s) k WHERE k.rn <= %d AND k.closed_at < ? GROUP BY k.wtype",
+		rankedClosedWispsSQL, keep)
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, query), cutoff.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	retained := make(map[string]int)
+	for rows.Next() {
+		var wtype string
+		var cnt int
+		if err := rows.Scan(&wtype, &cnt); err != nil {
+			return nil, err
+		}
+		retained[wtype] += cnt
+	}
+	return retained, rows.Err()
+}
+
+// wispTypeFilter returns the WHERE fragment restricting wisps to (op "IN")
+// or away from (op "NOT IN") the given types, or "" when there are none.
+// Types must have passed ValidateWispType.
+func wispTypeFilter(op string, types []string) string {
+	if len(types) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" AND COALESCE(w.wisp_type, 'unknown') %s (%s)", op, quotedList(types))
 }
 
-func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun bool) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+// retainedExcludedWisps counts, by wisp_type, the closed wisps closed before
+// cutoff that the purge keeps because their type is excluded.
+func retainedExcludedWisps(ctx context.Context, db *sql.DB, cutoff time.Time, types []string) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
 	defer cancel()
 
-	mailCutoff := time.Now().UTC().Add(-mailDeleteAge)
+	query := "SELECT COALESCE(w.wisp_type, 'unknown') AS wtype, COUNT(*) AS cnt FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?" +
+		wispTypeFilter("IN", types) + " GROUP BY wtype"
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, query), cutoff.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	retained := make(map[string]int)
+	for rows.Next() {
+		var wtype string
+		var cnt int
+		if err := rows.Scan(&wtype, &cnt); err != nil {
+			return nil, err
+		}
+		retained[wtype] += cnt
+	}
+	return retained, rows.Err()
+}
+
+func purgeOldMail(ctx context.Context, db *sql.DB, dbName string, mailCutoff time.Time, dryRun, tombstone bool) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	mailCutoff = mailCutoff.UTC()
 
 	countQuery := fmt.Sprintf(
 		"SELECT COUNT(*) FROM `%s`.issues WHERE status = 'closed' AND closed_at < ? AND id IN (SELECT issue_id FROM `%s`.labels WHERE label = 'gt:message')",
 		dbName, dbName)
 	var count int
-	if err := db.QueryRowContext(ctx, countQuery, mailCutoff).Scan(&count); err != nil {
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, countQuery), mailCutoff).Scan(&count); err != nil {
 		if isTableNotFound(err) {
 			return 0, nil // issues/labels not on this server
 		}
@@ -678,29 +1306,22 @@ func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun
 		return count, nil
 	}
 
-	if _, err := db.ExecContext(ctx, "SET @@autocommit = 0"); err != nil {
-		return 0, fmt.Errorf("disable autocommit: %w", err)
-	}
-	defer func() {
-		_, _ = db.ExecContext(context.Background(), "SET @@autocommit = 1")
-	}()
-
 	idQuery := fmt.Sprintf(
 		"SELECT i.id FROM `%s`.issues i INNER JOIN `%s`.labels l ON i.id = l.issue_id WHERE i.status = 'closed' AND i.closed_at < ? AND l.label = 'gt:message' LIMIT %d",
 		dbName, dbName, DefaultBatchSize)
-	auxTables := []string{"labels", "comments", "events", "dependencies"}
+	auxTables, _, err := partitionTables(ctx, db, []string{"labels", "comments", "events", "dependencies"})
+	if err != nil {
+		return 0, err
+	}
 
-	totalDeleted, err := batchDeleteRows(ctx, db, idQuery, mailCutoff, "issues", auxTables)
+	totalDeleted, err := batchDeleteRows(ctx, db, idQuery, mailCutoff, "issues", auxTables, tombstone, 1, nil)
 	if err != nil {
 		return totalDeleted, err
 	}
 
 	if totalDeleted > 0 {
-		// Flush SQL transaction to working set before DOLT_COMMIT.
-		if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
-			return totalDeleted, fmt.Errorf("sql commit: %w", err)
-		}
-		commitMsg := fmt.Sprintf("reaper: purge %d old mail from %s", totalDeleted, dbName)
+		// The chunk transactions already committed the deletes.
+		commitMsg := fmt.Sprintf("reaper: %s %d old mail from %s", purgeVerb(tombstone), totalDeleted, dbName)
 		if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('--allow-empty', '-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
 			// Non-fatal.
 		}
@@ -709,15 +1330,138 @@ func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun
 	return totalDeleted, nil
 }
 
+// AutoCloseWindow limits auto-close to issues whose updated_at falls in
+// [Since, Until). A zero bound is open-ended, and Until never extends past the
+// stale cutoff. Windows let a town with a large stale backlog be closed out
+// gradually, oldest first.
+type AutoCloseWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// ParseAutoCloseWindow parses window bounds, each a date (midnight UTC) or an
+// RFC 3339 timestamp. An empty bound leaves that side of the window open.
+func ParseAutoCloseWindow(since, until string) (AutoCloseWindow, error) {
+	var window AutoCloseWindow
+	for _, b := range []struct {
+		name  string
+		value string
+		dest  *time.Time
+	}{
+		{"since", since, &window.Since},
+		{"until", until, &window.Until},
+	} {
+		if b.value == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", b.value)
+		if err != nil {
+			t, err = time.Parse(time.RFC3339, b.value)
+			if err != nil {
+				return AutoCloseWindow{}, fmt.Errorf("invalid %s %q: want YYYY-MM-DD or RFC 3339", b.name, b.value)
+			}
+		}
+		*b.dest = t
+	}
+	if !window.Since.IsZero() && !window.Until.IsZero() && !window.Since.Before(window.Until) {
+		return AutoCloseWindow{}, fmt.Errorf("since %s is not before until %s", since, until)
+	}
+	return window, nil
+}
+
 // AutoClose closes issues that have been open with no updates past staleAge.
 // Excludes P0/P1 priority, epics, hooked/pinned issues, standing-order labels,
 // and issues with active dependencies.
-func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (*AutoCloseResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+func AutoClose(ctx context.Context, db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (*AutoCloseResult, error) {
+	return AutoCloseInWindow(ctx, db, dbName, staleAge, AutoCloseWindow{}, dryRun)
+}
+
+// AutoCloseInWindow is AutoClose restricted to stale issues last updated
+// inside window.
+func AutoCloseInWindow(ctx context.Context, db *sql.DB, dbName string, staleAge time.Duration, window AutoCloseWindow, dryRun bool) (*AutoCloseResult, error) {
+	return AutoCloseWithOptions(ctx, db, dbName, staleAge, dryRun, AutoCloseOptions{Window: window})
+}
+
+// AutoCloseExemptLabels mark issues auto-close never touches, however stale.
+var AutoCloseExemptLabels = []string{"gt:standing-orders", "gt:keep", "gt:role", "gt:rig"}
+
+// quotedList renders fixed identifiers as a SQL string list ('a', 'b').
+func quotedList(values []string) string {
+	return "'" + strings.Join(values, "', '") + "'"
+}
+
+// AutoCloseOptions tunes an auto-close beyond its stale age.
+type AutoCloseOptions struct {
+	// Window limits auto-close to issues last updated inside it.
+	Window AutoCloseWindow
+	// BusinessDaysOnly ages issues by weekday time only: Saturdays, Sundays
+	// and Holidays do not count toward the stale age, so an issue left on a
+	// Friday does not age over the weekend. Days are UTC calendar days.
+	BusinessDaysOnly bool
+	// Holidays are extra non-business days (any time on the day) when
+	// BusinessDaysOnly is set.
+	Holidays []time.Time
+	// Now, if set, replaces time.Now when computing the stale cutoff and
+	// business-day ages.
+	Now func() time.Time
+}
+
+// ParseHolidays parses YYYY-MM-DD dates for AutoCloseOptions.Holidays.
+func ParseHolidays(dates []string) ([]time.Time, error) {
+	holidays := make([]time.Time, 0, len(dates))
+	for _, d := range dates {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q: want YYYY-MM-DD", d)
+		}
+		holidays = append(holidays, t)
+	}
+	return holidays, nil
+}
+
+// businessAge returns how much of [from, to) falls on business days: UTC
+// weekdays that are not in holidays (keyed YYYY-MM-DD).
+func businessAge(from, to time.Time, holidays map[string]bool) time.Duration {
+	from, to = from.UTC(), to.UTC()
+	var age time.Duration
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday || holidays[day.Format("2006-01-02")] {
+			continue
+		}
+		start, end := day, day.AddDate(0, 0, 1)
+		if from.After(start) {
+			start = from
+		}
+		if to.Before(end) {
+			end = to
+		}
+		age += end.Sub(start)
+	}
+	return age
+}
+
+// AutoCloseWithOptions is AutoClose with explicit options.
+func AutoCloseWithOptions(ctx context.Context, db *sql.DB, dbName string, staleAge time.Duration, dryRun bool, opts AutoCloseOptions) (*AutoCloseResult, error) {
+	window := opts.Window
+	if !window.Since.IsZero() && !window.Until.IsZero() && !window.Since.Before(window.Until) {
+		return nil, fmt.Errorf("auto-close window: since %s is not before until %s",
+			window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
 	defer cancel()
 
-	staleCutoff := time.Now().UTC().Add(-staleAge)
+	// Business-day age never exceeds wall-clock age, so the wall-clock
+	// cutoff still selects a superset; candidates are narrowed below.
+	now := nowUTC(opts.Now)
+	staleCutoff := now.Add(-staleAge)
+	if !window.Until.IsZero() && window.Until.Before(staleCutoff) {
+		staleCutoff = window.Until
+	}
 	result := &AutoCloseResult{Database: dbName, DryRun: dryRun}
+	if !window.Since.IsZero() && !window.Since.Before(staleCutoff) {
+		return result, nil // window holds nothing stale yet
+	}
 
 	// Convoys are excluded from staleness auto-close (hq-jnap): their lifecycle
 	// is driven by tracked-bead status (`gt convoy check` / refinery post-merge),
@@ -725,31 +1469,63 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 	// below do NOT protect a convoy with open tracked issues. Stale-closing a
 	// convoy while its tracked beads are open orphans them from dispatch
 	// tracking and causes duplicate dispatches (hq-qouv/hq-shb1 incident).
-	whereClause := fmt.Sprintf(`
+	staleClause := `
 		i.status IN ('open', 'in_progress')
-		AND i.updated_at < ?
-		AND i.priority > 1
-		AND i.issue_type NOT IN ('epic', 'convoy')
-		AND i.id NOT IN (
+		AND i.updated_at < ?`
+	queryArgs := []interface{}{staleCutoff}
+	if !window.Since.IsZero() {
+		staleClause += "\n\t\tAND i.updated_at >= ?"
+		queryArgs = append(queryArgs, window.Since)
+	}
+	labelExempt := fmt.Sprintf(`
 			SELECT DISTINCT l.issue_id FROM `+"`%s`"+`.labels l
-			WHERE l.label IN ('gt:standing-orders', 'gt:keep', 'gt:role', 'gt:rig')
-		)
-		AND i.id NOT IN (
+			WHERE l.label IN (%s)`, dbName, quotedList(AutoCloseExemptLabels))
+	blockedExempt := fmt.Sprintf(`
 			SELECT DISTINCT d.issue_id FROM `+"`%s`"+`.dependencies d
 			INNER JOIN `+"`%s`"+`.issues dep ON d.depends_on_issue_id = dep.id
-			WHERE dep.status IN ('open', 'in_progress')
-		)
-		AND i.id NOT IN (
+			WHERE dep.status IN ('open', 'in_progress')`, dbName, dbName)
+	blockingExempt := fmt.Sprintf(`
 			SELECT DISTINCT d.depends_on_issue_id FROM `+"`%s`"+`.dependencies d
 			INNER JOIN `+"`%s`"+`.issues blocker ON d.issue_id = blocker.id
 			WHERE d.depends_on_issue_id IS NOT NULL
-			AND blocker.status IN ('open', 'in_progress')
-		)`, dbName, dbName, dbName, dbName, dbName)
+			AND blocker.status IN ('open', 'in_progress')`, dbName, dbName)
+	whereClause := staleClause + `
+		AND i.priority > 1
+		AND i.issue_type NOT IN ('epic', 'convoy')
+		AND i.id NOT IN (` + labelExempt + `
+		)
+		AND i.id NOT IN (` + blockedExempt + `
+		)
+		AND i.id NOT IN (` + blockingExempt + `
+		)`
+
+	// Tally what each exemption rule held back, so a growing backlog hidden
+	// by the exemptions is visible. Best effort: a failure is an anomaly.
+	exemptQuery := `SELECT
+		COALESCE(SUM(CASE WHEN i.priority <= 1 THEN 1 ELSE 0 END), 0) AS exempt_priority,
+		COALESCE(SUM(CASE WHEN i.issue_type IN ('epic', 'convoy') THEN 1 ELSE 0 END), 0) AS exempt_type,
+		COALESCE(SUM(CASE WHEN i.id IN (` + blockedExempt + `
+		) OR i.id IN (` + blockingExempt + `
+		) THEN 1 ELSE 0 END), 0) AS exempt_dependency,
+		COALESCE(SUM(CASE WHEN i.id IN (` + labelExempt + `
+		) THEN 1 ELSE 0 END), 0) AS exempt_keep_label
+		FROM issues i WHERE ` + staleClause
+	ex := &result.Exempted
+	if err := db.QueryRowContext(ctx, schemaSQL(ctx, exemptQuery), queryArgs...).Scan(&ex.Priority, &ex.Type, &ex.Dependency, &ex.KeepLabel); err != nil {
+		if isTableNotFound(err) {
+			return result, nil // issues/dependencies not on this server
+		}
+		result.Exempted = AutoCloseExemptCounts{}
+		result.Anomalies = append(result.Anomalies, Anomaly{
+			Type:    "exemption_count_failed",
+			Message: fmt.Sprintf("count auto-close exemptions: %v", err),
+		})
+	}
 
 	// Two-step SELECT-then-UPDATE to avoid self-referencing subquery in UPDATE,
 	// which is not valid MySQL (Error 1093) and fragile in Dolt (dolthub/dolt#10600).
-	selectQuery := fmt.Sprintf("SELECT i.id, i.title, i.updated_at FROM issues i WHERE %s", whereClause)
-	rows, err := db.QueryContext(ctx, selectQuery, staleCutoff)
+	selectQuery := fmt.Sprintf("SELECT i.id, i.title, COALESCE(i.assignee, ''), COALESCE(i.priority, 0), COALESCE(i.issue_type, ''), i.updated_at FROM issues i WHERE %s", whereClause)
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, selectQuery), queryArgs...)
 	if err != nil {
 		if isTableNotFound(err) {
 			return result, nil // issues/dependencies not on this server
@@ -759,12 +1535,15 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 	type candidate struct {
 		id        string
 		title     string
+		assignee  string
+		priority  int
+		issueType string
 		updatedAt time.Time
 	}
 	var candidates []candidate
 	for rows.Next() {
 		var c candidate
-		if err := rows.Scan(&c.id, &c.title, &c.updatedAt); err != nil {
+		if err := rows.Scan(&c.id, &c.title, &c.assignee, &c.priority, &c.issueType, &c.updatedAt); err != nil {
 			rows.Close()
 			return nil, fmt.Errorf("scan stale id: %w", err)
 		}
@@ -772,16 +1551,32 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 	}
 	rows.Close()
 
+	if opts.BusinessDaysOnly {
+		holidays := make(map[string]bool, len(opts.Holidays))
+		for _, h := range opts.Holidays {
+			holidays[h.UTC().Format("2006-01-02")] = true
+		}
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if businessAge(c.updatedAt, now, holidays) > staleAge {
+				kept = append(kept, c)
+			}
+		}
+		candidates = kept
+	}
+
 	// Build per-issue closure log entries.
-	now := time.Now().UTC()
 	ids := make([]string, len(candidates))
 	for i, c := range candidates {
 		ids[i] = c.id
 		result.ClosedEntries = append(result.ClosedEntries, ClosedEntry{
-			ID:       c.id,
-			Title:    c.title,
-			AgeDays:  int(now.Sub(c.updatedAt).Hours() / 24),
-			Database: dbName,
+			ID:        c.id,
+			Title:     c.title,
+			Assignee:  c.assignee,
+			Priority:  c.priority,
+			IssueType: c.issueType,
+			AgeDays:   int(now.Sub(c.updatedAt).Hours() / 24),
+			Database:  dbName,
 		})
 	}
 
@@ -810,7 +1605,7 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 	updateQuery := fmt.Sprintf(
 		"UPDATE `%s`.issues SET status = 'closed', closed_at = NOW(), close_reason = 'stale:auto-closed by reaper' WHERE id IN (%s)",
 		dbName, strings.Join(placeholders, ","))
-	if _, err := db.ExecContext(ctx, updateQuery, args...); err != nil {
+	if _, err := db.ExecContext(ctx, schemaSQL(ctx, updateQuery), args...); err != nil {
 		return nil, fmt.Errorf("auto-close: %w", err)
 	}
 
@@ -840,11 +1635,33 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 	return result, nil
 }
 
-// batchDeleteRows deletes rows from a primary table and its auxiliary tables in batches.
-func batchDeleteRows(ctx context.Context, db *sql.DB, idQuery string, cutoffArg time.Time, primaryTable string, auxTables []string) (int, error) {
+// purgeVerb names what a purge does to its rows, for Dolt commit messages.
+func purgeVerb(tombstone bool) string {
+	if tombstone {
+		return "tombstone"
+	}
+	return "purge"
+}
+
+// batchDeleteRows deletes rows from a primary table and its auxiliary tables
+// in batches. With tombstone, primary rows are stubbed out instead of deleted
+// (see PurgeOptions.Tombstone); idQuery must not select them again. The IDs
+// of each select are split into up to concurrency disjoint chunks deleted at
+// once; timing, if non-nil, records the chunks and how long they took.
+func batchDeleteRows(ctx context.Context, db *sql.DB, idQuery string, cutoffArg time.Time, primaryTable string, auxTables []string, tombstone bool, concurrency int, timing *DeleteTiming) (int, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if timing == nil {
+		timing = &DeleteTiming{}
+	}
+	timing.Concurrency = concurrency
+	start := time.Now()
+	defer func() { timing.Elapsed = time.Since(start) }()
+
 	totalDeleted := 0
 	for {
-		idRows, err := db.QueryContext(ctx, idQuery, cutoffArg)
+		idRows, err := db.QueryContext(ctx, schemaSQL(ctx, idQuery), cutoffArg)
 		if err != nil {
 			return totalDeleted, fmt.Errorf("select batch: %w", err)
 		}
@@ -864,51 +1681,176 @@ func batchDeleteRows(ctx context.Context, db *sql.DB, idQuery string, cutoffArg
 			break
 		}
 
-		placeholders := make([]string, len(ids))
-		args := make([]interface{}, len(ids))
-		for i, id := range ids {
-			placeholders[i] = "?"
-			args[i] = id
+		size := (len(ids) + concurrency - 1) / concurrency
+		if size > MaxInClauseSize {
+			size = MaxInClauseSize
+		}
+		deleted, err := deleteChunksConcurrently(ctx, db, chunkIDs(ids, size), primaryTable, auxTables, tombstone, concurrency, timing)
+		totalDeleted += deleted
+		if err != nil {
+			return totalDeleted, err
 		}
-		inClause := "(" + strings.Join(placeholders, ",") + ")"
+	}
+
+	return totalDeleted, nil
+}
 
-		for _, tbl := range auxTables {
-			delAux := fmt.Sprintf("DELETE FROM `%s` WHERE issue_id IN %s", tbl, inClause) //nolint:gosec // G201: tbl is internal
-			if _, err := db.ExecContext(ctx, delAux, args...); err != nil {
-				// Non-fatal: log and continue.
+// deleteChunksConcurrently runs deleteRowsChunk over chunks with at most
+// concurrency in flight, and returns once all of them have finished. After
+// the first error no further chunk is started; chunks already running still
+// commit or roll back on their own, and their deletions are counted.
+func deleteChunksConcurrently(ctx context.Context, db *sql.DB, chunks [][]string, primaryTable string, auxTables []string, tombstone bool, concurrency int, timing *DeleteTiming) (int, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		total    int
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, chunk := range chunks {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			start := time.Now()
+			deleted, err := deleteRowsChunk(ctx, db, chunk, primaryTable, auxTables, tombstone)
+			mu.Lock()
+			defer mu.Unlock()
+			total += deleted
+			timing.Batches++
+			timing.Busy += time.Since(start)
+			if err != nil && firstErr == nil {
+				firstErr = err
 			}
+		}(chunk)
+	}
+	wg.Wait()
+	return total, firstErr
+}
+
+// chunkIDs splits ids into consecutive slices of at most size elements.
+// A non-positive size returns ids as a single chunk.
+func chunkIDs(ids []string, size int) [][]string {
+	if size <= 0 || len(ids) <= size {
+		return [][]string{ids}
+	}
+	chunks := make([][]string, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
 		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
 
-		// Clean up typed reverse dependency references to prevent dangling parent refs.
-		var reverseDeletes []string
-		switch primaryTable {
-		case "wisps":
-			reverseDeletes = []string{
-				fmt.Sprintf("DELETE FROM wisp_dependencies WHERE depends_on_wisp_id IN %s", inClause),
-				fmt.Sprintf("DELETE FROM dependencies WHERE depends_on_wisp_id IN %s", inClause),
-			}
-		case "issues":
-			reverseDeletes = []string{
-				fmt.Sprintf("DELETE FROM wisp_dependencies WHERE depends_on_issue_id IN %s", inClause),
-				fmt.Sprintf("DELETE FROM dependencies WHERE depends_on_issue_id IN %s", inClause),
-			}
+// deleteChunkRetryDelay is the base backoff between delete attempts; attempt
+// n waits n times this long.
+var deleteChunkRetryDelay = 500 * time.Millisecond
+
+// deleteRowsChunk deletes one chunk of IDs from the auxiliary tables, the
+// typed reverse dependency references, and finally the primary table, all in
+// one transaction: a failure part way rolls the chunk back rather than
+// leaving aux rows without their wisp or a wisp without its aux rows. A
+// retriable failure reruns the whole chunk, up to DeleteChunkMaxAttempts.
+// Callers keep len(ids) <= MaxInClauseSize.
+func deleteRowsChunk(ctx context.Context, db *sql.DB, ids []string, primaryTable string, auxTables []string, tombstone bool) (int, error) {
+	var err error
+	for attempt := 1; attempt <= DeleteChunkMaxAttempts; attempt++ {
+		var deleted int
+		deleted, err = deleteRowsChunkTx(ctx, db, ids, primaryTable, auxTables, tombstone)
+		if err == nil {
+			return deleted, nil
 		}
-		for _, delReverse := range reverseDeletes {
-			if _, err := db.ExecContext(ctx, delReverse, args...); err != nil {
-				// Non-fatal.
-			}
+		if !isRetriableDeleteError(err) || attempt == DeleteChunkMaxAttempts {
+			break
 		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Duration(attempt) * deleteChunkRetryDelay):
+		}
+	}
+	return 0, err
+}
 
-		delPrimary := fmt.Sprintf("DELETE FROM `%s` WHERE id IN %s", primaryTable, inClause) //nolint:gosec // G201: primaryTable is internal
-		sqlResult, err := db.ExecContext(ctx, delPrimary, args...)
-		if err != nil {
-			return totalDeleted, fmt.Errorf("delete %s batch: %w", primaryTable, err)
+// deleteRowsChunkTx runs one attempt of deleteRowsChunk. Callers probe aux
+// tables up front (partitionTables); aux or reverse dependency tables that
+// still turn out not to exist are skipped, and any other error rolls the
+// transaction back. With tombstone, the primary rows are kept as stubs, so
+// dependencies pointing at them are left in place.
+func deleteRowsChunkTx(ctx context.Context, db *sql.DB, ids []string, primaryTable string, auxTables []string, tombstone bool) (int, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	inClause := "(" + strings.Join(placeholders, ",") + ")"
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin %s delete: %w", primaryTable, err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	for _, tbl := range auxTables {
+		delAux := fmt.Sprintf("DELETE FROM `%s` WHERE issue_id IN %s", schemaName(ctx, tbl), inClause) //nolint:gosec // G201: tbl is internal
+		if _, err := tx.ExecContext(ctx, delAux, args...); err != nil && !isTableNotFound(err) {
+			return 0, fmt.Errorf("delete %s rows: %w", tbl, err)
 		}
-		affected, _ := sqlResult.RowsAffected()
-		totalDeleted += int(affected)
 	}
 
-	return totalDeleted, nil
+	// Clean up typed reverse dependency references to prevent dangling parent refs.
+	var reverseDeletes []string
+	switch {
+	case tombstone:
+		// The stub row remains, so references to it do not dangle.
+	case primaryTable == "wisps":
+		reverseDeletes = []string{
+			fmt.Sprintf("DELETE FROM wisp_dependencies WHERE depends_on_wisp_id IN %s", inClause),
+			fmt.Sprintf("DELETE FROM dependencies WHERE depends_on_wisp_id IN %s", inClause),
+		}
+	case primaryTable == "issues":
+		reverseDeletes = []string{
+			fmt.Sprintf("DELETE FROM wisp_dependencies WHERE depends_on_issue_id IN %s", inClause),
+			fmt.Sprintf("DELETE FROM dependencies WHERE depends_on_issue_id IN %s", inClause),
+		}
+	}
+	for _, delReverse := range reverseDeletes {
+		if _, err := tx.ExecContext(ctx, schemaSQL(ctx, delReverse), args...); err != nil && !isTableNotFound(err) {
+			return 0, fmt.Errorf("delete reverse dependencies: %w", err)
+		}
+	}
+
+	delPrimary := fmt.Sprintf("DELETE FROM `%s` WHERE id IN %s", schemaName(ctx, primaryTable), inClause) //nolint:gosec // G201: primaryTable is internal
+	if tombstone {
+		delPrimary = tombstoneQuery(ctx, primaryTable, inClause)
+	}
+	sqlResult, err := tx.ExecContext(ctx, delPrimary, args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete %s batch: %w", primaryTable, err)
+	}
+	affected, _ := sqlResult.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit %s delete: %w", primaryTable, err)
+	}
+	return int(affected), nil
+}
+
+// tombstoneQuery builds the UPDATE that reduces the rows in inClause to stubs.
+func tombstoneQuery(ctx context.Context, primaryTable, inClause string) string {
+	return fmt.Sprintf("UPDATE `%s` SET %s = '%s', description = '' WHERE id IN %s", //nolint:gosec // G201: primaryTable is internal
+		schemaName(ctx, primaryTable), schemaName(ctx, "status"), TombstoneStatus, inClause)
 }
 
 // ClosePluginReceiptResult holds the results of closing plugin run receipts.
@@ -924,11 +1866,23 @@ type ClosePluginReceiptResult struct {
 // plugins; they should be closed shortly after creation since they exist only
 // for audit/cooldown-gate purposes. The standard AutoClose path requires 7 days
 // of staleness, which lets plugin receipts accumulate into the hundreds.
-func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+func ClosePluginReceipts(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
+	return ClosePluginReceiptsWithOptions(ctx, db, dbName, maxAge, dryRun, CloseOptions{})
+}
+
+// CloseOptions tunes ClosePluginReceipts, ClosePluginDispatches and
+// CloseStaleMail.
+type CloseOptions struct {
+	// Now, if set, replaces time.Now when computing the age cutoff.
+	Now func() time.Time
+}
+
+// ClosePluginReceiptsWithOptions is ClosePluginReceipts with explicit options.
+func ClosePluginReceiptsWithOptions(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool, opts CloseOptions) (*ClosePluginReceiptResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
 	defer cancel()
 
-	cutoff := time.Now().UTC().Add(-maxAge)
+	cutoff := nowUTC(opts.Now).Add(-maxAge)
 	result := &ClosePluginReceiptResult{Database: dbName, DryRun: dryRun}
 
 	// Find open issues with the "type:plugin-run" label older than maxAge.
@@ -939,7 +1893,7 @@ func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun
 		AND l.label = 'type:plugin-run'
 		AND i.created_at < ?`, dbName, dbName)
 
-	rows, err := db.QueryContext(ctx, selectQuery, cutoff)
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, selectQuery), cutoff)
 	if err != nil {
 		if isTableNotFound(err) {
 			return result, nil
@@ -978,7 +1932,7 @@ func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun
 	updateQuery := fmt.Sprintf(
 		"UPDATE `%s`.issues SET status = 'closed', closed_at = NOW() WHERE id IN (%s)",
 		dbName, strings.Join(placeholders, ","))
-	if _, err := db.ExecContext(ctx, updateQuery, args...); err != nil {
+	if _, err := db.ExecContext(ctx, schemaSQL(ctx, updateQuery), args...); err != nil {
 		return nil, fmt.Errorf("close plugin receipts: %w", err)
 	}
 
@@ -1008,11 +1962,17 @@ func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun
 // + "from:daemon" with a title prefix "Plugin:" and are never closed after the
 // dog completes. Without this, they accumulate at ~288/day (one per 5-minute
 // stuck-agent-dog run) and are only caught by AutoClose after 7 days.
-func ClosePluginDispatches(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+func ClosePluginDispatches(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
+	return ClosePluginDispatchesWithOptions(ctx, db, dbName, maxAge, dryRun, CloseOptions{})
+}
+
+// ClosePluginDispatchesWithOptions is ClosePluginDispatches with explicit
+// options.
+func ClosePluginDispatchesWithOptions(ctx context.Context, db *sql.DB, dbName string, maxAge time.Duration, dryRun bool, opts CloseOptions) (*ClosePluginReceiptResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
 	defer cancel()
 
-	cutoff := time.Now().UTC().Add(-maxAge)
+	cutoff := nowUTC(opts.Now).Add(-maxAge)
 	result := &ClosePluginReceiptResult{Database: dbName, DryRun: dryRun}
 
 	// Find open issues with both "gt:message" and "from:daemon" labels whose
@@ -1027,7 +1987,7 @@ func ClosePluginDispatches(db *sql.DB, dbName string, maxAge time.Duration, dryR
 		AND i.title LIKE 'Plugin:%%'
 		AND i.created_at < ?`, dbName, dbName, dbName)
 
-	rows, err := db.QueryContext(ctx, selectQuery, cutoff)
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, selectQuery), cutoff)
 	if err != nil {
 		if isTableNotFound(err) {
 			return result, nil
@@ -1066,7 +2026,7 @@ func ClosePluginDispatches(db *sql.DB, dbName string, maxAge time.Duration, dryR
 	updateQuery := fmt.Sprintf(
 		"UPDATE `%s`.issues SET status = 'closed', closed_at = NOW() WHERE id IN (%s)",
 		dbName, strings.Join(placeholders, ","))
-	if _, err := db.ExecContext(ctx, updateQuery, args...); err != nil {
+	if _, err := db.ExecContext(ctx, schemaSQL(ctx, updateQuery), args...); err != nil {
 		return nil, fmt.Errorf("close plugin dispatches: %w", err)
 	}
 
@@ -1091,6 +2051,238 @@ func ClosePluginDispatches(db *sql.DB, dbName string, maxAge time.Duration, dryR
 	return result, nil
 }
 
+// StaleMailCloseReason is the close_reason CloseStaleMail records.
+const StaleMailCloseReason = "mail:stale-unread"
+
+// CloseStaleMail closes open mail (issues labeled "gt:message") with no
+// updates for longer than staleAge, typically unread messages to rigs or
+// agents that no longer exist. purgeOldMail only deletes closed mail, so
+// without this such messages stay open forever; once closed here they age
+// into the normal mail purge. Hooked mail is left alone: it is being worked.
+func CloseStaleMail(ctx context.Context, db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
+	return CloseStaleMailWithOptions(ctx, db, dbName, staleAge, dryRun, CloseOptions{})
+}
+
+// CloseStaleMailWithOptions is CloseStaleMail with explicit options.
+func CloseStaleMailWithOptions(ctx context.Context, db *sql.DB, dbName string, staleAge time.Duration, dryRun bool, opts CloseOptions) (*ClosePluginReceiptResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultQueryTimeout)
+	defer cancel()
+
+	cutoff := nowUTC(opts.Now).Add(-staleAge)
+	result := &ClosePluginReceiptResult{Database: dbName, DryRun: dryRun}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT i.id FROM `+"`%s`"+`.issues i
+		INNER JOIN `+"`%s`"+`.labels l ON i.id = l.issue_id
+		WHERE i.status = 'open'
+		AND l.label = 'gt:message'
+		AND i.updated_at < ?`, dbName, dbName)
+
+	rows, err := db.QueryContext(ctx, schemaSQL(ctx, selectQuery), cutoff)
+	if err != nil {
+		if isTableNotFound(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("select stale mail: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan stale mail id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	result.Closed = len(ids)
+	if len(ids) == 0 || dryRun {
+		return result, nil
+	}
+
+	if _, err := db.ExecContext(ctx, "SET @@autocommit = 0"); err != nil {
+		return nil, fmt.Errorf("disable autocommit: %w", err)
+	}
+	defer func() {
+		_, _ = db.ExecContext(context.Background(), "SET @@autocommit = 1")
+	}()
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	updateQuery := fmt.Sprintf(
+		"UPDATE `%s`.issues SET status = 'closed', closed_at = NOW(), close_reason = '%s' WHERE id IN (%s)",
+		dbName, StaleMailCloseReason, strings.Join(placeholders, ","))
+	if _, err := db.ExecContext(ctx, schemaSQL(ctx, updateQuery), args...); err != nil {
+		return nil, fmt.Errorf("close stale mail: %w", err)
+	}
+
+	// Flush and commit.
+	if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
+		result.Anomalies = append(result.Anomalies, Anomaly{
+			Type:    "sql_commit_failed",
+			Message: fmt.Sprintf("sql commit after stale mail close failed: %v", err),
+		})
+		return result, nil
+	}
+	commitMsg := fmt.Sprintf("reaper: close %d stale mail in %s", len(ids), dbName)
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
+		if !isNothingToCommit(err) {
+			result.Anomalies = append(result.Anomalies, Anomaly{
+				Type:    "dolt_commit_failed",
+				Message: fmt.Sprintf("dolt commit after stale mail close failed: %v", err),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// orphanAuxTables maps each auxiliary table to the primary table its
+// issue_id references.
+var orphanAuxTables = []struct{ aux, primary string }{
+	{"wisp_labels", "wisps"},
+	{"wisp_comments", "wisps"},
+	{"wisp_events", "wisps"},
+	{"wisp_dependencies", "wisps"},
+	{"labels", "issues"},
+	{"comments", "issues"},
+	{"events", "issues"},
+	{"dependencies", "issues"},
+}
+
+// OrphanResult is the outcome of PurgeOrphans.
+type OrphanResult struct {
+	Database  string         `json:"database"`
+	Orphans   map[string]int `json:"orphans"` // orphaned rows by aux table
+	Deleted   int            `json:"deleted"`
+	DryRun    bool           `json:"dry_run,omitempty"`
+	Anomalies []Anomaly      `json:"anomalies,omitempty"`
+}
+
+// PurgeOrphans counts auxiliary rows (labels, comments, events and
+// dependencies, for both wisps and issues) whose issue_id matches no row in
+// the table they belong to, and unless dryRun deletes them in batches. Such
+// rows are left behind by deletes that removed a wisp or issue but not all of
+// its aux rows. Aux tables that do not exist are skipped.
+func PurgeOrphans(ctx context.Context, db *sql.DB, dbName string, dryRun bool) (*OrphanResult, error) {
+	if err := ValidateDBName(dbName); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	result := &OrphanResult{Database: dbName, Orphans: make(map[string]int), DryRun: dryRun}
+	var total int
+	for _, t := range orphanAuxTables {
+		if ok, err := tableExists(ctx, db, t.aux); err != nil {
+			return nil, fmt.Errorf("check %s: %w", t.aux, err)
+		} else if !ok {
+			continue
+		}
+		countQuery := fmt.Sprintf(
+			"SELECT COUNT(*) FROM %s a LEFT JOIN %s p ON p.id = a.issue_id WHERE p.id IS NULL",
+			t.aux, t.primary)
+		var count int
+		if err := db.QueryRowContext(ctx, schemaSQL(ctx, countQuery)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("count %s orphans: %w", t.aux, err)
+		}
+		if count > 0 {
+			result.Orphans[t.aux] = count
+			total += count
+		}
+	}
+	if total == 0 || dryRun {
+		return result, nil
+	}
+
+	if _, err := db.ExecContext(ctx, "SET @@autocommit = 0"); err != nil {
+		return nil, fmt.Errorf("disable autocommit: %w", err)
+	}
+	defer func() {
+		_, _ = db.ExecContext(context.Background(), "SET @@autocommit = 1")
+	}()
+
+	for _, t := range orphanAuxTables {
+		if result.Orphans[t.aux] == 0 {
+			continue
+		}
+		deleted, err := deleteOrphanRows(ctx, db, t.aux, t.primary)
+		result.Deleted += deleted
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if result.Deleted > 0 {
+		if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
+			result.Anomalies = append(result.Anomalies, Anomaly{
+				Type:    "sql_commit_failed",
+				Message: fmt.Sprintf("sql commit after orphan purge failed: %v", err),
+			})
+			return result, nil
+		}
+		commitMsg := fmt.Sprintf("reaper: delete %d orphaned aux rows from %s", result.Deleted, dbName)
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
+			if !isNothingToCommit(err) {
+				result.Anomalies = append(result.Anomalies, Anomaly{
+					Type:    "dolt_commit_failed",
+					Message: fmt.Sprintf("dolt commit after orphan purge failed: %v", err),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// deleteOrphanRows deletes aux rows with no matching primary row,
+// DefaultBatchSize issue IDs at a time. Each delete re-checks the primary
+// table so a row inserted since the select is kept.
+func deleteOrphanRows(ctx context.Context, db *sql.DB, auxTable, primaryTable string) (int, error) {
+	idQuery := fmt.Sprintf(
+		"SELECT DISTINCT a.issue_id FROM %s a LEFT JOIN %s p ON p.id = a.issue_id WHERE p.id IS NULL LIMIT %d",
+		auxTable, primaryTable, DefaultBatchSize)
+	totalDeleted := 0
+	for {
+		rows, err := db.QueryContext(ctx, schemaSQL(ctx, idQuery))
+		if err != nil {
+			return totalDeleted, fmt.Errorf("select %s orphans: %w", auxTable, err)
+		}
+		var ids []interface{}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return totalDeleted, fmt.Errorf("scan %s orphan: %w", auxTable, err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if len(ids) == 0 {
+			return totalDeleted, nil
+		}
+
+		placeholders := strings.TrimRight(strings.Repeat("?,", len(ids)), ",")
+		delQuery := fmt.Sprintf(
+			"DELETE FROM %s WHERE issue_id IN (%s) AND issue_id NOT IN (SELECT id FROM %s)",
+			auxTable, placeholders, primaryTable)
+		res, err := db.ExecContext(ctx, schemaSQL(ctx, delQuery), ids...)
+		if err != nil {
+			return totalDeleted, fmt.Errorf("delete %s orphans: %w", auxTable, err)
+		}
+		affected, _ := res.RowsAffected()
+		if affected == 0 {
+			// Nothing removable in this batch; stop rather than reselect it forever.
+			return totalDeleted, nil
+		}
+		totalDeleted += int(affected)
+	}
+}
+
 // FormatJSON marshals any value to indented JSON.
 func FormatJSON(v interface{}) string {
 	data, err := json.MarshalIndent(v, "", "  ")
@@ -4,16 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 func TestValidateDBName(t *testing.T) {
@@ -372,7 +376,7 @@ func TestClosedMoleculeStepReapBehavior(t *testing.T) {
 	t.Cleanup(func() { _ = db.Close() })
 
 	maxAge := 24 * time.Hour
-	scan, err := Scan(db, "testdb", maxAge, 7*24*time.Hour, 7*24*time.Hour, 30*24*time.Hour)
+	scan, err := Scan(context.Background(), db, "testdb", maxAge, 7*24*time.Hour, 7*24*time.Hour, 30*24*time.Hour)
 	if err != nil {
 		t.Fatalf("Scan: %v", err)
 	}
@@ -384,7 +388,7 @@ func TestClosedMoleculeStepReapBehavior(t *testing.T) {
 	}
 
 	beforeDryRun := state.statuses()
-	dryRun, err := Reap(db, "testdb", maxAge, true)
+	dryRun, err := Reap(context.Background(), db, "testdb", maxAge, true)
 	if err != nil {
 		t.Fatalf("dry-run Reap: %v", err)
 	}
@@ -402,7 +406,7 @@ func TestClosedMoleculeStepReapBehavior(t *testing.T) {
 	}
 
 	preRealOps := state.opCounts()
-	realRun, err := Reap(db, "testdb", maxAge, false)
+	realRun, err := Reap(context.Background(), db, "testdb", maxAge, false)
 	if err != nil {
 		t.Fatalf("real Reap: %v", err)
 	}
@@ -464,6 +468,14 @@ type fakeWisp struct {
 	status    string
 	issueType string
 	createdAt time.Time
+	closedAt  time.Time
+	reopened  bool
+	wispType  string
+	assignee  string
+	// lastEventAt is the newest wisp_events created_at for the wisp.
+	lastEventAt time.Time
+	// nullCreatedAt stands for a NULL created_at, which no cutoff matches.
+	nullCreatedAt bool
 }
 
 type fakeDep struct {
@@ -473,12 +485,45 @@ type fakeDep struct {
 	depType           string
 }
 
+// fakeIssue is a stale-eligible issue returned by the auto-close select, or
+// open mail for the stale-mail select when mail is set.
+type fakeIssue struct {
+	id        string
+	updatedAt time.Time
+	closedAt  time.Time
+	mail      bool
+	status    string
+	// exempt names the auto-close exemption rule the issue matches
+	// ("priority", "type", "dependency", "keep-label"), if any.
+	exempt string
+}
+
 type fakeReaperState struct {
 	mu       sync.Mutex
 	wisps    map[string]*fakeWisp
+	issues   []fakeIssue
 	deps     []fakeDep
 	nextConn int
 	ops      map[int][]string
+
+	// clockOffset is added to the local clock to answer SELECT NOW().
+	clockOffset time.Duration
+
+	// nonUTCArgs records queries that were passed a time.Time argument
+	// outside UTC.
+	nonUTCArgs []string
+
+	// orphans lists, per aux table, the issue_id of each row whose wisp or
+	// issue is gone.
+	orphans map[string][]string
+
+	// missingTables are reported absent by information_schema probes.
+	missingTables map[string]bool
+
+	// deleteErrs are returned, in order, by DELETEs on the primary table
+	// named by deleteErrTable; once exhausted, deletes succeed.
+	deleteErrTable string
+	deleteErrs     []error
 }
 
 func (s *fakeReaperState) status(id string) string {
@@ -520,6 +565,14 @@ func (s *fakeReaperState) opsSince(counts map[int]int) map[int][]string {
 	return opsSince
 }
 
+func (s *fakeReaperState) checkUTCArgsLocked(query string, args []driver.NamedValue) {
+	for _, arg := range args {
+		if t, ok := arg.Value.(time.Time); ok && t.Location() != time.UTC {
+			s.nonUTCArgs = append(s.nonUTCArgs, query)
+		}
+	}
+}
+
 func (s *fakeReaperState) record(connID int, op string) {
 	s.ops[connID] = append(s.ops[connID], normalizeSQL(op))
 }
@@ -561,7 +614,7 @@ func (s *fakeReaperState) isMoleculeStepCandidateLocked(id string) bool {
 func (s *fakeReaperState) staleCandidatesLocked(cutoff time.Time, excludeMoleculeSteps bool) []string {
 	var ids []string
 	for id, w := range s.wisps {
-		if !isOpenWispStatus(w.status) || w.issueType == "agent" || !w.createdAt.Before(cutoff) {
+		if !isOpenWispStatus(w.status) || w.issueType == "agent" || w.nullCreatedAt || !w.createdAt.Before(cutoff) {
 			continue
 		}
 		if s.hasOpenParentLocked(id) {
@@ -576,6 +629,109 @@ func (s *fakeReaperState) staleCandidatesLocked(cutoff time.Time, excludeMolecul
 	return ids
 }
 
+// filterLiveOwnedLocked applies a live-owner clause in query to ids: with
+// "NOT (...)" it drops hooked/in-progress wisps owned by the string args,
+// without it it keeps only those. Queries with no such clause pass ids through.
+func (s *fakeReaperState) filterLiveOwnedLocked(ids []string, query string, args []driver.NamedValue) []string {
+	if !strings.Contains(query, "COALESCE(w.assignee, '') IN") {
+		return ids
+	}
+	owners := map[string]bool{}
+	for _, arg := range args {
+		if owner, ok := arg.Value.(string); ok {
+			owners[owner] = true
+		}
+	}
+	exclude := strings.Contains(query, "NOT (w.status IN ('hooked', 'in_progress')")
+	var kept []string
+	for _, id := range ids {
+		w := s.wisps[id]
+		live := (w.status == "hooked" || w.status == "in_progress") && owners[w.assignee]
+		if live != exclude {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// keptRecentLocked returns the wisps a "k.rn <= N" keep-recent clause in
+// query spares: the N most recently closed wisps of each type. Nil when the
+// query has no such clause.
+func (s *fakeReaperState) keptRecentLocked(query string) map[string]bool {
+	i := strings.Index(query, "k.rn <= ")
+	if i < 0 {
+		return nil
+	}
+	keep, _ := strconv.Atoi(strings.TrimRight(strings.Fields(query[i+len("k.rn <= "):])[0], ")"))
+	byType := map[string][]*fakeWisp{}
+	for _, w := range s.wisps {
+		if w.status == "closed" {
+			byType[fakeWispType(w)] = append(byType[fakeWispType(w)], w)
+		}
+	}
+	kept := map[string]bool{}
+	for _, ws := range byType {
+		sort.Slice(ws, func(i, j int) bool {
+			if !ws[i].closedAt.Equal(ws[j].closedAt) {
+				return ws[i].closedAt.After(ws[j].closedAt)
+			}
+			return ws[i].id > ws[j].id
+		})
+		for n := 0; n < keep && n < len(ws); n++ {
+			kept[ws[n].id] = true
+		}
+	}
+	return kept
+}
+
+// wispTypeMatches applies a "COALESCE(w.wisp_type, 'unknown') [NOT] IN
+// (...)" filter in query to wtype. True when the query has no such filter.
+func wispTypeMatches(query, wtype string) bool {
+	for _, op := range []string{" NOT IN (", " IN ("} {
+		marker := "COALESCE(w.wisp_type, 'unknown')" + op
+		i := strings.Index(query, marker)
+		if i < 0 {
+			continue
+		}
+		list := query[i+len(marker):]
+		list = list[:strings.Index(list, ")")]
+		listed := false
+		for _, v := range strings.Split(list, ", ") {
+			if strings.Trim(v, "'") == wtype {
+				listed = true
+			}
+		}
+		return listed == (op == " IN (")
+	}
+	return true
+}
+
+// recentEventMatches applies a recentEventFilter fragment in query to w.
+func recentEventMatches(query string, w *fakeWisp) bool {
+	for _, op := range []string{" NOT IN (", " IN ("} {
+		marker := "w.id" + op + "SELECT e.issue_id FROM wisp_events e WHERE e.created_at >= '"
+		i := strings.Index(query, marker)
+		if i < 0 {
+			continue
+		}
+		literal := query[i+len(marker):]
+		since, err := time.Parse("2006-01-02 15:04:05", literal[:strings.Index(literal, "'")])
+		if err != nil {
+			return false
+		}
+		recent := !w.lastEventAt.IsZero() && !w.lastEventAt.Before(since)
+		return recent == (op == " IN (")
+	}
+	return true
+}
+
+func fakeWispType(w *fakeWisp) string {
+	if w.wispType == "" {
+		return "unknown"
+	}
+	return w.wispType
+}
+
 func (s *fakeReaperState) hasOpenParentLocked(id string) bool {
 	for _, dep := range s.deps {
 		if dep.issueID != id || dep.depType != "parent-child" {
@@ -626,7 +782,12 @@ func (c *fakeReaperConn) Prepare(string) (driver.Stmt, error) {
 
 func (c *fakeReaperConn) Close() error { return nil }
 
-func (c *fakeReaperConn) Begin() (driver.Tx, error) { return fakeReaperTx{}, nil }
+func (c *fakeReaperConn) Begin() (driver.Tx, error) {
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	c.state.record(c.id, "BEGIN")
+	return fakeReaperTx{conn: c}, nil
+}
 
 func (c *fakeReaperConn) CheckNamedValue(*driver.NamedValue) error { return nil }
 
@@ -635,36 +796,223 @@ func (c *fakeReaperConn) QueryContext(_ context.Context, query string, args []dr
 	c.state.mu.Lock()
 	defer c.state.mu.Unlock()
 	c.state.record(c.id, "QUERY "+normalized)
+	c.state.checkUTCArgsLocked(normalized, args)
 
 	switch {
+	case strings.Contains(normalized, "ON p.id = a.issue_id WHERE p.id IS NULL"):
+		table := strings.Fields(normalized[strings.Index(normalized, " FROM ")+len(" FROM "):])[0]
+		if strings.HasPrefix(normalized, "SELECT COUNT(*)") {
+			return fakeCountRows(len(c.state.orphans[table])), nil
+		}
+		var ids []string
+		seen := make(map[string]bool)
+		for _, id := range c.state.orphans[table] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return fakeIDRows(ids), nil
+	case strings.Contains(normalized, "GROUP BY w.status"):
+		if err := validateStaleWispQuery(normalized); err != nil {
+			return nil, err
+		}
+		counts := map[string]int64{}
+		for _, id := range c.state.staleCandidatesLocked(namedTime(args), true) {
+			counts[c.state.wisps[id].status]++
+		}
+		rows := &fakeReaperRows{cols: []string{"status", "cnt"}}
+		for status, cnt := range counts {
+			rows.rows = append(rows.rows, []driver.Value{status, cnt})
+		}
+		return rows, nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisps w") && strings.Contains(normalized, "created_at <"):
 		if err := validateStaleWispQuery(normalized); err != nil {
 			return nil, err
 		}
-		return fakeCountRows(len(c.state.staleCandidatesLocked(namedTime(args), strings.Contains(normalized, "closed_molecule_step.issue_id IS NULL")))), nil
+		ids := c.state.staleCandidatesLocked(namedTime(args), strings.Contains(normalized, "closed_molecule_step.issue_id IS NULL"))
+		return fakeCountRows(len(c.state.filterLiveOwnedLocked(ids, normalized, args))), nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisps w") && strings.Contains(normalized, "pm.issue_type = 'molecule'"):
 		if err := validateMoleculeStepQuery(normalized); err != nil {
 			return nil, err
 		}
 		return fakeCountRows(len(c.state.moleculeStepCandidatesLocked())), nil
+	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisps WHERE status IN") && strings.Contains(normalized, "created_at IS NULL"):
+		count := 0
+		for _, w := range c.state.wisps {
+			if isOpenWispStatus(w.status) && w.issueType != "agent" && w.nullCreatedAt {
+				count++
+			}
+		}
+		return fakeCountRows(count), nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisps WHERE status IN"):
 		return fakeCountRows(c.state.openCountLocked()), nil
+	case strings.HasPrefix(normalized, "SELECT COUNT(*) FROM wisps w WHERE w.status = 'closed'") && strings.Contains(normalized, "FROM wisp_events e WHERE e.created_at >="):
+		count := 0
+		kept := c.state.keptRecentLocked(normalized)
+		for id, w := range c.state.wisps {
+			if w.status == "closed" && w.closedAt.Before(namedTime(args)) && !kept[id] && wispTypeMatches(normalized, fakeWispType(w)) && recentEventMatches(normalized, w) {
+				count++
+			}
+		}
+		return fakeCountRows(count), nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisps w WHERE w.status = 'closed'"):
 		return fakeCountRows(0), nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM issues"):
 		return fakeCountRows(0), nil
 	case strings.Contains(normalized, "SELECT COUNT(*) FROM wisp_dependencies wd"):
 		return fakeCountRows(0), nil
+	case strings.Contains(normalized, "FROM information_schema.tables"):
+		if len(args) == 1 {
+			if table, _ := args[0].Value.(string); c.state.missingTables[table] {
+				return fakeCountRows(0), nil
+			}
+		}
+		return fakeCountRows(1), nil
+	case strings.HasPrefix(normalized, "SELECT status, COUNT(*) AS cnt FROM "):
+		counts := map[string]int64{}
+		if strings.Contains(normalized, "FROM wisps") {
+			for _, w := range c.state.wisps {
+				counts[w.status]++
+			}
+		} else {
+			for _, issue := range c.state.issues {
+				counts[issue.status]++
+			}
+		}
+		rows := &fakeReaperRows{cols: []string{"status", "cnt"}}
+		for status, cnt := range counts {
+			rows.rows = append(rows.rows, []driver.Value{status, cnt})
+		}
+		return rows, nil
+	case strings.Contains(normalized, "FROM wisps WHERE status IN") && strings.Contains(normalized, "GROUP BY wtype"):
+		counts := map[string]int64{}
+		for _, w := range c.state.wisps {
+			if isOpenWispStatus(w.status) {
+				wtype := w.wispType
+				if wtype == "" {
+					wtype = "unknown"
+				}
+				counts[wtype]++
+			}
+		}
+		rows := &fakeReaperRows{cols: []string{"wtype", "cnt"}}
+		for wtype, cnt := range counts {
+			rows.rows = append(rows.rows, []driver.Value{wtype, cnt})
+		}
+		return rows, nil
+	case strings.Contains(normalized, "GROUP BY k.wtype"):
+		counts := map[string]int64{}
+		for id := range c.state.keptRecentLocked(normalized) {
+			if w := c.state.wisps[id]; w.closedAt.Before(namedTime(args)) {
+				counts[fakeWispType(w)]++
+			}
+		}
+		rows := &fakeReaperRows{cols: []string{"wtype", "cnt"}}
+		for wtype, cnt := range counts {
+			rows.rows = append(rows.rows, []driver.Value{wtype, cnt})
+		}
+		return rows, nil
+	case strings.Contains(normalized, "GROUP BY wtype"):
+		counts, reclosed := map[string]int64{}, map[string]int64{}
+		joinsReopens := strings.Contains(normalized, "SELECT DISTINCT issue_id FROM wisp_events WHERE event_type = 'reopened'")
+		kept := c.state.keptRecentLocked(normalized)
+		for id, w := range c.state.wisps {
+			if w.status == "closed" && w.closedAt.Before(namedTime(args)) && !kept[id] && wispTypeMatches(normalized, fakeWispType(w)) && recentEventMatches(normalized, w) {
+				counts[fakeWispType(w)]++
+				if joinsReopens && w.reopened {
+					reclosed[fakeWispType(w)]++
+				}
+			}
+		}
+		if !strings.Contains(normalized, "AS reclosed") {
+			rows := &fakeReaperRows{cols: []string{"wtype", "cnt"}}
+			for wtype, cnt := range counts {
+				rows.rows = append(rows.rows, []driver.Value{wtype, cnt})
+			}
+			return rows, nil
+		}
+		rows := &fakeReaperRows{cols: []string{"wtype", "cnt", "reclosed"}}
+		for wtype, cnt := range counts {
+			rows.rows = append(rows.rows, []driver.Value{wtype, cnt, reclosed[wtype]})
+		}
+		return rows, nil
+	case normalized == "SELECT NOW()":
+		return &fakeReaperRows{cols: []string{"NOW()"}, rows: [][]driver.Value{{time.Now().Add(c.state.clockOffset)}}}, nil
+	case strings.Contains(normalized, "AS exempt_priority"):
+		if err := requireSQL(normalized, "i.priority <= 1", "i.issue_type IN ('epic', 'convoy')", "AS exempt_dependency", "AS exempt_keep_label"); err != nil {
+			return nil, err
+		}
+		var since time.Time
+		if len(args) > 1 && strings.Contains(normalized, "i.updated_at >= ?") {
+			since, _ = args[1].Value.(time.Time)
+		}
+		counts := map[string]int64{}
+		for _, issue := range c.state.issues {
+			if issue.updatedAt.Before(namedTime(args)) && !issue.updatedAt.Before(since) {
+				counts[issue.exempt]++
+			}
+		}
+		return &fakeReaperRows{
+			cols: []string{"exempt_priority", "exempt_type", "exempt_dependency", "exempt_keep_label"},
+			rows: [][]driver.Value{{counts["priority"], counts["type"], counts["dependency"], counts["keep-label"]}},
+		}, nil
+	case strings.Contains(normalized, "SELECT i.id, i.title"):
+		// A NULL priority or issue_type must not abort the scan.
+		if err := requireSQL(normalized, "COALESCE(i.priority, 0)", "COALESCE(i.issue_type, '')"); err != nil {
+			return nil, err
+		}
+		var since time.Time
+		if len(args) > 1 && strings.Contains(normalized, "i.updated_at >= ?") {
+			since, _ = args[1].Value.(time.Time)
+		}
+		rows := &fakeReaperRows{cols: []string{"id", "title", "assignee", "priority", "issue_type", "updated_at"}}
+		for _, issue := range c.state.issues {
+			if issue.exempt == "" && issue.updatedAt.Before(namedTime(args)) && !issue.updatedAt.Before(since) {
+				rows.rows = append(rows.rows, []driver.Value{issue.id, issue.id, "", int64(2), "task", issue.updatedAt})
+			}
+		}
+		return rows, nil
+	case strings.HasPrefix(normalized, "SELECT COUNT(*) FROM `") && strings.Contains(normalized, "label = 'gt:message'") && strings.Contains(normalized, "closed_at < ?"):
+		count := 0
+		for _, issue := range c.state.issues {
+			if issue.mail && issue.status == "closed" && issue.closedAt.Before(namedTime(args)) {
+				count++
+			}
+		}
+		return fakeCountRows(count), nil
+	case strings.Contains(normalized, "l.label = 'gt:message'") && strings.Contains(normalized, "i.updated_at < ?"):
+		if err := requireSQL(normalized, "i.status = 'open'"); err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, issue := range c.state.issues {
+			if issue.mail && issue.status == "open" && issue.updatedAt.Before(namedTime(args)) {
+				ids = append(ids, issue.id)
+			}
+		}
+		return fakeIDRows(ids), nil
 	case strings.Contains(normalized, "SELECT w.id FROM wisps w") && strings.Contains(normalized, "created_at <"):
 		if err := validateStaleWispQuery(normalized); err != nil {
 			return nil, err
 		}
-		return fakeIDRows(c.state.staleCandidatesLocked(namedTime(args), strings.Contains(normalized, "closed_molecule_step.issue_id IS NULL"))), nil
+		ids := c.state.staleCandidatesLocked(namedTime(args), strings.Contains(normalized, "closed_molecule_step.issue_id IS NULL"))
+		return fakeIDRows(applyFakeLimit(normalized, c.state.filterLiveOwnedLocked(ids, normalized, args))), nil
 	case strings.Contains(normalized, "SELECT w.id FROM wisps w") && strings.Contains(normalized, "pm.issue_type = 'molecule'"):
 		if err := validateMoleculeStepQuery(normalized); err != nil {
 			return nil, err
 		}
-		return fakeIDRows(c.state.moleculeStepCandidatesLocked()), nil
+		return fakeIDRows(applyFakeLimit(normalized, c.state.moleculeStepCandidatesLocked())), nil
+	case strings.HasPrefix(normalized, "SELECT w.id FROM wisps w WHERE w.status = 'closed' AND w.closed_at < ?"):
+		var ids []string
+		kept := c.state.keptRecentLocked(normalized)
+		for id, w := range c.state.wisps {
+			if w.status == "closed" && w.closedAt.Before(namedTime(args)) && !kept[id] && wispTypeMatches(normalized, fakeWispType(w)) && recentEventMatches(normalized, w) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		return fakeIDRows(applyFakeLimit(normalized, ids)), nil
 	default:
 		return nil, fmt.Errorf("unexpected query: %s", normalized)
 	}
@@ -675,6 +1023,7 @@ func (c *fakeReaperConn) ExecContext(_ context.Context, query string, args []dri
 	c.state.mu.Lock()
 	defer c.state.mu.Unlock()
 	c.state.record(c.id, "EXEC "+normalized)
+	c.state.checkUTCArgsLocked(normalized, args)
 
 	switch {
 	case strings.HasPrefix(normalized, "UPDATE wisps SET status='closed'"):
@@ -687,6 +1036,63 @@ func (c *fakeReaperConn) ExecContext(_ context.Context, query string, args []dri
 			}
 		}
 		return fakeReaperResult(affected), nil
+	case strings.Contains(normalized, ".issues SET status = 'closed'"):
+		if err := requireSQL(normalized, "close_reason = '"+StaleMailCloseReason+"'"); err != nil {
+			return nil, err
+		}
+		affected := int64(0)
+		for _, arg := range args {
+			id, _ := arg.Value.(string)
+			for i := range c.state.issues {
+				if c.state.issues[i].id == id && c.state.issues[i].status == "open" {
+					c.state.issues[i].status = "closed"
+					affected++
+				}
+			}
+		}
+		return fakeReaperResult(affected), nil
+	case strings.HasPrefix(normalized, "UPDATE `wisps` SET status = '"+TombstoneStatus+"'"):
+		if err := requireSQL(normalized, "description = ''"); err != nil {
+			return nil, err
+		}
+		affected := int64(0)
+		for _, arg := range args {
+			id, _ := arg.Value.(string)
+			if w := c.state.wisps[id]; w != nil {
+				w.status = TombstoneStatus
+				affected++
+			}
+		}
+		return fakeReaperResult(affected), nil
+	case strings.HasPrefix(normalized, "DELETE FROM") && strings.Contains(normalized, "AND issue_id NOT IN (SELECT id FROM"):
+		table := strings.Fields(normalized)[2]
+		del := make(map[string]bool)
+		for _, arg := range args {
+			id, _ := arg.Value.(string)
+			del[id] = true
+		}
+		var kept []string
+		for _, id := range c.state.orphans[table] {
+			if !del[id] {
+				kept = append(kept, id)
+			}
+		}
+		affected := len(c.state.orphans[table]) - len(kept)
+		c.state.orphans[table] = kept
+		return fakeReaperResult(affected), nil
+	case strings.HasPrefix(normalized, "DELETE FROM"):
+		if table := c.state.deleteErrTable; table != "" && strings.HasPrefix(normalized, "DELETE FROM `"+table+"` WHERE id IN") && len(c.state.deleteErrs) > 0 {
+			err := c.state.deleteErrs[0]
+			c.state.deleteErrs = c.state.deleteErrs[1:]
+			return nil, err
+		}
+		if strings.HasPrefix(normalized, "DELETE FROM `wisps` WHERE id IN") {
+			for _, arg := range args {
+				id, _ := arg.Value.(string)
+				delete(c.state.wisps, id)
+			}
+		}
+		return fakeReaperResult(len(args)), nil
 	case normalized == "SET @@autocommit = 0" || normalized == "SET @@autocommit = 1" || normalized == "ROLLBACK" || normalized == "COMMIT" || strings.HasPrefix(normalized, "CALL DOLT_COMMIT"):
 		return fakeReaperResult(0), nil
 	default:
@@ -694,10 +1100,17 @@ func (c *fakeReaperConn) ExecContext(_ context.Context, query string, args []dri
 	}
 }
 
-type fakeReaperTx struct{}
+type fakeReaperTx struct{ conn *fakeReaperConn }
+
+func (tx fakeReaperTx) Commit() error   { return tx.end("TX COMMIT") }
+func (tx fakeReaperTx) Rollback() error { return tx.end("TX ROLLBACK") }
 
-func (fakeReaperTx) Commit() error   { return nil }
-func (fakeReaperTx) Rollback() error { return nil }
+func (tx fakeReaperTx) end(op string) error {
+	tx.conn.state.mu.Lock()
+	defer tx.conn.state.mu.Unlock()
+	tx.conn.state.record(tx.conn.id, op)
+	return nil
+}
 
 type fakeReaperResult int64
 
@@ -714,6 +1127,20 @@ func fakeCountRows(count int) *fakeReaperRows {
 	return &fakeReaperRows{cols: []string{"count"}, rows: [][]driver.Value{{int64(count)}}}
 }
 
+// applyFakeLimit truncates ids to a query's trailing LIMIT, as the server
+// would, so batch loops see partial and final batches.
+func applyFakeLimit(normalized string, ids []string) []string {
+	idx := strings.LastIndex(normalized, " LIMIT ")
+	if idx < 0 {
+		return ids
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(normalized[idx+len(" LIMIT "):]))
+	if err != nil || limit >= len(ids) {
+		return ids
+	}
+	return ids[:limit]
+}
+
 func fakeIDRows(ids []string) *fakeReaperRows {
 	rows := make([][]driver.Value, len(ids))
 	for i, id := range ids {
@@ -806,3 +1233,1212 @@ func assertOpsContainInOrder(t *testing.T, ops []string, want ...string) {
 	}
 	t.Fatalf("ops missing ordered sequence %v in %v", want[next:], ops)
 }
+
+func TestChunkIDs(t *testing.T) {
+	ids := make([]string, 1201)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("w-%d", i)
+	}
+
+	chunks := chunkIDs(ids, MaxInClauseSize)
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	total := 0
+	for _, c := range chunks {
+		if len(c) > MaxInClauseSize {
+			t.Errorf("chunk of %d exceeds MaxInClauseSize %d", len(c), MaxInClauseSize)
+		}
+		total += len(c)
+	}
+	if total != len(ids) || chunks[2][len(chunks[2])-1] != "w-1200" {
+		t.Errorf("chunks lost or reordered ids: total=%d", total)
+	}
+
+	if got := chunkIDs(ids[:10], MaxInClauseSize); len(got) != 1 || len(got[0]) != 10 {
+		t.Errorf("small batch should be a single chunk, got %d", len(got))
+	}
+	if got := chunkIDs(ids, 0); len(got) != 1 {
+		t.Errorf("non-positive size should not chunk, got %d chunks", len(got))
+	}
+}
+
+func TestReapWithOptions_ReportsProgressForMultiBatchReaps(t *testing.T) {
+	now := time.Now().UTC()
+	newState := func() *fakeReaperState {
+		return &fakeReaperState{
+			wisps: map[string]*fakeWisp{
+				"stale-a": {id: "stale-a", status: "open", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+				"stale-b": {id: "stale-b", status: "open", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+				"stale-c": {id: "stale-c", status: "open", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+			},
+			ops: map[int][]string{},
+		}
+	}
+
+	// Batch smaller than the candidate set: progress is reported.
+	state := newState()
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+	var progress []string
+	result, err := ReapWithOptions(context.Background(), db, "testdb", 24*time.Hour, false, ReapOptions{
+		CloseBatchSize: 2,
+		Progress: func(description string, closed int) {
+			progress = append(progress, fmt.Sprintf("%s=%d", description, closed))
+		},
+	})
+	if err != nil {
+		t.Fatalf("ReapWithOptions: %v", err)
+	}
+	if result.Reaped != 3 {
+		t.Fatalf("Reaped = %d, want 3", result.Reaped)
+	}
+	// Batches of 2 then 1: progress after each once the first batch is full.
+	if want := []string{"stale wisps=2", "stale wisps=3"}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+
+	// Default batch size fits everything: no progress noise.
+	state = newState()
+	db2 := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db2.Close() })
+	progress = nil
+	if _, err := ReapWithOptions(context.Background(), db2, "testdb", 24*time.Hour, false, ReapOptions{
+		Progress: func(description string, closed int) {
+			progress = append(progress, description)
+		},
+	}); err != nil {
+		t.Fatalf("ReapWithOptions: %v", err)
+	}
+	if len(progress) != 0 {
+		t.Errorf("single-batch reap reported progress: %v", progress)
+	}
+}
+
+func TestReapAgeBoundary(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	maxAge := 24 * time.Hour
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"exactly":    {id: "exactly", status: "open", issueType: "task", createdAt: now.Add(-maxAge)},
+			"just-under": {id: "just-under", status: "open", issueType: "task", createdAt: now.Add(-maxAge + time.Second)},
+			"just-over":  {id: "just-over", status: "open", issueType: "task", createdAt: now.Add(-maxAge - time.Second)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := ReapWithOptions(context.Background(), db, "testdb", maxAge, false, ReapOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("ReapWithOptions: %v", err)
+	}
+	if result.Reaped != 1 {
+		t.Errorf("Reaped = %d, want 1", result.Reaped)
+	}
+	want := map[string]string{"exactly": "open", "just-under": "open", "just-over": "closed"}
+	if got := state.statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+}
+
+func TestReapSparesLiveOwnedWisps(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+	for _, dryRun := range []bool{true, false} {
+		state := &fakeReaperState{
+			wisps: map[string]*fakeWisp{
+				"live-hooked":   {id: "live-hooked", status: "hooked", issueType: "task", createdAt: old, assignee: "gastown/polecats/Toast"},
+				"live-working":  {id: "live-working", status: "in_progress", issueType: "task", createdAt: old, assignee: "gastown/polecats/Toast"},
+				"dead-hooked":   {id: "dead-hooked", status: "hooked", issueType: "task", createdAt: old, assignee: "gastown/polecats/Gone"},
+				"live-open":     {id: "live-open", status: "open", issueType: "task", createdAt: old, assignee: "gastown/polecats/Toast"},
+				"unowned-stale": {id: "unowned-stale", status: "in_progress", issueType: "task", createdAt: old},
+			},
+			ops: map[int][]string{},
+		}
+		db := openFakeReaperDB(t, state)
+		t.Cleanup(func() { _ = db.Close() })
+
+		result, err := ReapWithOptions(context.Background(), db, "testdb", 24*time.Hour, dryRun, ReapOptions{
+			LiveOwners: []string{"gastown/polecats/Toast"},
+		})
+		if err != nil {
+			t.Fatalf("ReapWithOptions(context.Background(), dryRun=%v): %v", dryRun, err)
+		}
+		if result.SparedLive != 2 {
+			t.Errorf("dryRun=%v: SparedLive = %d, want 2", dryRun, result.SparedLive)
+		}
+		if result.Reaped != 3 {
+			t.Errorf("dryRun=%v: Reaped = %d, want 3", dryRun, result.Reaped)
+		}
+		if dryRun {
+			continue
+		}
+		want := map[string]string{
+			"live-hooked":   "hooked",
+			"live-working":  "in_progress",
+			"dead-hooked":   "closed",
+			"live-open":     "closed",
+			"unowned-stale": "closed",
+		}
+		if got := state.statuses(); !reflect.DeepEqual(got, want) {
+			t.Errorf("statuses = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReapNullCreatedAt(t *testing.T) {
+	now := time.Now().UTC()
+	for _, dryRun := range []bool{true, false} {
+		state := &fakeReaperState{
+			wisps: map[string]*fakeWisp{
+				"stale":      {id: "stale", status: "open", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+				"no-created": {id: "no-created", status: "open", issueType: "task", nullCreatedAt: true},
+				"agent":      {id: "agent", status: "open", issueType: "agent", nullCreatedAt: true},
+			},
+			ops: map[int][]string{},
+		}
+		db := openFakeReaperDB(t, state)
+		t.Cleanup(func() { _ = db.Close() })
+
+		result, err := Reap(context.Background(), db, "testdb", 24*time.Hour, dryRun)
+		if err != nil {
+			t.Fatalf("Reap(context.Background(), dryRun=%v): %v", dryRun, err)
+		}
+		if result.Reaped != 1 {
+			t.Errorf("dryRun=%v: Reaped = %d, want 1", dryRun, result.Reaped)
+		}
+		if result.NullCreatedAt != 1 {
+			t.Errorf("dryRun=%v: NullCreatedAt = %d, want 1", dryRun, result.NullCreatedAt)
+		}
+		if len(result.Anomalies) != 1 || result.Anomalies[0].Type != "null_created_at" || result.Anomalies[0].Count != 1 {
+			t.Errorf("dryRun=%v: Anomalies = %+v, want one null_created_at anomaly", dryRun, result.Anomalies)
+		}
+		if got := state.status("no-created"); got != "open" {
+			t.Errorf("dryRun=%v: no-created status = %q, want open", dryRun, got)
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"stale-open":   {id: "stale-open", status: "open", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+			"stale-hooked": {id: "stale-hooked", status: "hooked", issueType: "task", createdAt: now.Add(-48 * time.Hour)},
+			"fresh":        {id: "fresh", status: "open", issueType: "task", createdAt: now.Add(-time.Hour)},
+			"old-patrol":   {id: "old-patrol", status: "closed", wispType: "patrol", closedAt: now.Add(-30 * 24 * time.Hour)},
+			"new-patrol":   {id: "new-patrol", status: "closed", wispType: "patrol", closedAt: now.Add(-time.Hour)},
+		},
+		issues: []fakeIssue{
+			{id: "gt-stale", updatedAt: now.Add(-30 * 24 * time.Hour)},
+			{id: "old-mail", mail: true, status: "closed", closedAt: now.Add(-30 * 24 * time.Hour)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := Preview(context.Background(), db, "testdb", PreviewOptions{
+		MaxAge:        24 * time.Hour,
+		PurgeAge:      7 * 24 * time.Hour,
+		MailDeleteAge: 7 * 24 * time.Hour,
+		StaleIssueAge: 7 * 24 * time.Hour,
+		Now:           func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if result.WispsToClose != 2 || result.CloseByStatus["open"] != 1 || result.CloseByStatus["hooked"] != 1 {
+		t.Errorf("close = %d %v, want 2 (open 1, hooked 1)", result.WispsToClose, result.CloseByStatus)
+	}
+	if len(result.IssuesToAutoClose) != 2 {
+		t.Errorf("IssuesToAutoClose = %+v, want the two old issues", result.IssuesToAutoClose)
+	}
+	if len(result.AutoCloseExemptions) == 0 {
+		t.Error("AutoCloseExemptions is empty")
+	}
+	if result.WispsToPurge != 1 || result.PurgeByType["patrol"] != 1 {
+		t.Errorf("purge = %d %v, want 1 patrol", result.WispsToPurge, result.PurgeByType)
+	}
+	if result.MailToPurge != 1 {
+		t.Errorf("MailToPurge = %d, want 1", result.MailToPurge)
+	}
+	for _, ops := range state.opsSince(nil) {
+		for _, op := range ops {
+			if strings.HasPrefix(op, "EXEC ") {
+				t.Errorf("Preview executed %q, want read-only", op)
+			}
+		}
+	}
+}
+
+func TestPurgeCutoffIsUTC(t *testing.T) {
+	// The reaper's clock reads local time five hours east of UTC, and the
+	// rows were written by a server seven hours west of it. The boundary must
+	// fall at the same instant either way, and every cutoff must reach the
+	// driver in UTC.
+	local := time.FixedZone("UTC+5", 5*3600)
+	server := time.FixedZone("UTC-7", -7*3600)
+	now := time.Date(2026, 3, 1, 17, 0, 0, 0, local) // 12:00 UTC
+	purgeAge := 24 * time.Hour
+	cutoff := now.Add(-purgeAge).In(server)
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"just-under": {id: "just-under", status: "closed", closedAt: cutoff.Add(time.Second)},
+			"just-over":  {id: "just-over", status: "closed", closedAt: cutoff.Add(-time.Second)},
+		},
+		issues: []fakeIssue{
+			{id: "mail-under", mail: true, status: "closed", closedAt: cutoff.Add(time.Second)},
+			{id: "mail-over", mail: true, status: "closed", closedAt: cutoff.Add(-time.Second)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := PurgeWithOptions(context.Background(), db, "testdb", purgeAge, purgeAge, true, PurgeOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.WispsPurged != 1 || result.MailPurged != 1 {
+		t.Errorf("purged wisps=%d mail=%d, want 1 and 1 (only rows closed before the cutoff)", result.WispsPurged, result.MailPurged)
+	}
+
+	if _, err := PurgeWispsBefore(context.Background(), db, "testdb", cutoff, true); err != nil {
+		t.Fatalf("PurgeWispsBefore: %v", err)
+	}
+	if len(state.nonUTCArgs) > 0 {
+		t.Errorf("cutoffs sent outside UTC for: %v", state.nonUTCArgs)
+	}
+}
+
+func TestOpenDBSessionIsUTC(t *testing.T) {
+	cfg, err := mysql.ParseDSN(openDBDSN(context.Background(), "127.0.0.1", 3307, "gastown", 10*time.Second, 20*time.Second))
+	if err != nil {
+		t.Fatalf("ParseDSN: %v", err)
+	}
+	if cfg.Loc != time.UTC || !cfg.ParseTime {
+		t.Errorf("Loc = %v ParseTime = %v, want UTC and true", cfg.Loc, cfg.ParseTime)
+	}
+	if got := cfg.Params["time_zone"]; got != "'+00:00'" {
+		t.Errorf("session time_zone = %q, want '+00:00'", got)
+	}
+	if cfg.DBName != "gastown" || cfg.ReadTimeout != 10*time.Second || cfg.WriteTimeout != 20*time.Second {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestWithConnConfig(t *testing.T) {
+	t.Setenv("GT_DOLT_USER", "")
+	dsn := func(ctx context.Context) *mysql.Config {
+		t.Helper()
+		cfg, err := mysql.ParseDSN(openDBDSN(ctx, "127.0.0.1", 3307, "gastown", 0, 0))
+		if err != nil {
+			t.Fatalf("ParseDSN: %v", err)
+		}
+		return cfg
+	}
+
+	ctx := WithConnConfig(context.Background(), DoltConnConfig{User: "reaper", TLS: "skip-verify"})
+	if cfg := dsn(ctx); cfg.User != "reaper" || cfg.TLSConfig != "skip-verify" {
+		t.Errorf("configured context: user=%q tls=%q, want reaper and skip-verify", cfg.User, cfg.TLSConfig)
+	}
+	// A context without a connection config gets the defaults, whatever an
+	// earlier caller used.
+	if cfg := dsn(context.Background()); cfg.User != "root" || cfg.TLSConfig != "" {
+		t.Errorf("default context: user=%q tls=%q, want root and no TLS", cfg.User, cfg.TLSConfig)
+	}
+}
+
+func TestDoltConnConfigDSN(t *testing.T) {
+	t.Setenv("GT_DOLT_USER", "")
+	t.Setenv("REAPER_TEST_PASSWORD", "s3cr@t")
+	conn := DoltConnConfig{
+		User:              "reaper",
+		PasswordEnv:       "REAPER_TEST_PASSWORD",
+		TLS:               "skip-verify",
+		Charset:           "utf8mb4",
+		Collation:         "utf8mb4_bin",
+		ConnectTimeoutStr: "2s",
+		ReadTimeoutStr:    "45s",
+	}
+	if err := conn.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	dsn := conn.DSN("db.internal", 3307, "gastown", 10*time.Second, 20*time.Second)
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("ParseDSN: %v", err)
+	}
+	if cfg.User != "reaper" || cfg.Passwd != "s3cr@t" || cfg.Addr != "db.internal:3307" || cfg.TLSConfig != "skip-verify" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.Collation != "utf8mb4_bin" || cfg.Timeout != 2*time.Second {
+		t.Errorf("Collation = %q Timeout = %v", cfg.Collation, cfg.Timeout)
+	}
+	// read_timeout overrides the caller's; write_timeout is left to the caller.
+	if cfg.ReadTimeout != 45*time.Second || cfg.WriteTimeout != 20*time.Second {
+		t.Errorf("ReadTimeout = %v WriteTimeout = %v", cfg.ReadTimeout, cfg.WriteTimeout)
+	}
+	if cfg.Loc != time.UTC || !cfg.ParseTime || cfg.Params["time_zone"] != "'+00:00'" {
+		t.Errorf("session is not UTC: %+v", cfg)
+	}
+
+	redacted := RedactDSN(dsn)
+	if strings.Contains(redacted, "s3cr@t") || !strings.Contains(redacted, "reaper:***@") {
+		t.Errorf("RedactDSN = %q", redacted)
+	}
+
+	if err := (DoltConnConfig{TLS: "always"}).Validate(); err == nil {
+		t.Error("Validate accepted tls \"always\"")
+	}
+}
+
+func TestDoltConnConfigDefaults(t *testing.T) {
+	t.Setenv("GT_DOLT_USER", "")
+	t.Setenv("GT_DOLT_PASSWORD", "")
+	cfg, err := mysql.ParseDSN(DoltConnConfig{}.DSN("127.0.0.1", 3307, "", 0, 0))
+	if err != nil {
+		t.Fatalf("ParseDSN: %v", err)
+	}
+	if cfg.User != "root" || cfg.Passwd != "" || cfg.TLSConfig != "" || cfg.Timeout != DefaultConnectTimeout {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestPurgeAgeBoundary(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	purgeAge := 7 * 24 * time.Hour
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"exactly":    {id: "exactly", status: "closed", closedAt: now.Add(-purgeAge)},
+			"just-under": {id: "just-under", status: "closed", closedAt: now.Add(-purgeAge + time.Second)},
+			"just-over":  {id: "just-over", status: "closed", closedAt: now.Add(-purgeAge - time.Second)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := PurgeWithOptions(context.Background(), db, "testdb", purgeAge, purgeAge, false, PurgeOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.WispsPurged != 1 {
+		t.Errorf("WispsPurged = %d, want 1", result.WispsPurged)
+	}
+	want := map[string]string{"exactly": "closed", "just-under": "closed"}
+	if got := state.statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %v, want %v", got, want)
+	}
+}
+
+func TestPurgeKeepRecentPerType(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			// patrol: the two most recent are both past the purge age.
+			"patrol-1": {id: "patrol-1", status: "closed", closedAt: now.Add(-10 * day), wispType: "patrol"},
+			"patrol-2": {id: "patrol-2", status: "closed", closedAt: now.Add(-20 * day), wispType: "patrol"},
+			"patrol-3": {id: "patrol-3", status: "closed", closedAt: now.Add(-30 * day), wispType: "patrol"},
+			// mail: the most recent is young, so only one old one is kept.
+			"mail-new": {id: "mail-new", status: "closed", closedAt: now.Add(-time.Hour), wispType: "mail"},
+			"mail-1":   {id: "mail-1", status: "closed", closedAt: now.Add(-10 * day), wispType: "mail"},
+			"mail-2":   {id: "mail-2", status: "closed", closedAt: now.Add(-20 * day), wispType: "mail"},
+			"open":     {id: "open", status: "open", wispType: "patrol"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	opts := PurgeOptions{KeepRecentPerType: 2, Now: func() time.Time { return now }}
+	result, err := PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, false, opts)
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.WispsPurged != 2 {
+		t.Errorf("WispsPurged = %d, want 2", result.WispsPurged)
+	}
+	if want := map[string]int{"patrol": 2, "mail": 1}; !reflect.DeepEqual(result.RetainedRecent, want) {
+		t.Errorf("RetainedRecent = %v, want %v", result.RetainedRecent, want)
+	}
+	want := map[string]string{"patrol-1": "closed", "patrol-2": "closed", "mail-new": "closed", "mail-1": "closed", "open": "open"}
+	if got := state.statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %v, want %v", got, want)
+	}
+}
+
+func TestPurgeExcludeTypes(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"patrol-1":  {id: "patrol-1", status: "closed", closedAt: now.Add(-10 * day), wispType: "patrol"},
+			"patrol-2":  {id: "patrol-2", status: "closed", closedAt: now.Add(-20 * day), wispType: "patrol"},
+			"audit-1":   {id: "audit-1", status: "closed", closedAt: now.Add(-10 * day), wispType: "audit"},
+			"audit-2":   {id: "audit-2", status: "closed", closedAt: now.Add(-20 * day), wispType: "audit"},
+			"audit-new": {id: "audit-new", status: "closed", closedAt: now.Add(-time.Hour), wispType: "audit"},
+			"untyped":   {id: "untyped", status: "closed", closedAt: now.Add(-10 * day)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	opts := PurgeOptions{ExcludeTypes: []string{"audit", "unknown"}, Now: func() time.Time { return now }}
+	result, err := PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, false, opts)
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.WispsPurged != 2 {
+		t.Errorf("WispsPurged = %d, want 2", result.WispsPurged)
+	}
+	if want := map[string]int{"audit": 2, "unknown": 1}; !reflect.DeepEqual(result.RetainedExcluded, want) {
+		t.Errorf("RetainedExcluded = %v, want %v", result.RetainedExcluded, want)
+	}
+	want := map[string]string{"audit-1": "closed", "audit-2": "closed", "audit-new": "closed", "untyped": "closed"}
+	if got := state.statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %v, want %v", got, want)
+	}
+
+	if _, err := PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, true, PurgeOptions{ExcludeTypes: []string{"x') OR ('1"}}); err == nil {
+		t.Error("PurgeWithOptions accepted an unsafe wisp type")
+	}
+}
+
+func TestPurgeRecentEventWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	newState := func() *fakeReaperState {
+		return &fakeReaperState{
+			wisps: map[string]*fakeWisp{
+				"quiet":     {id: "quiet", status: "closed", closedAt: now.Add(-10 * day), lastEventAt: now.Add(-10 * day)},
+				"reclosed":  {id: "reclosed", status: "closed", closedAt: now.Add(-10 * day), lastEventAt: now.Add(-time.Hour)},
+				"linked":    {id: "linked", status: "closed", closedAt: now.Add(-20 * day), lastEventAt: now.Add(-2 * day)},
+				"old-event": {id: "old-event", status: "closed", closedAt: now.Add(-20 * day), lastEventAt: now.Add(-5 * day)},
+				"audit":     {id: "audit", status: "closed", closedAt: now.Add(-10 * day), lastEventAt: now.Add(-time.Hour), wispType: "audit"},
+			},
+			ops: map[int][]string{},
+		}
+	}
+	opts := PurgeOptions{RecentEventWindow: 3 * day, ExcludeTypes: []string{"audit"}, Now: func() time.Time { return now }}
+
+	state := newState()
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+	result, err := PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, false, opts)
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.WispsPurged != 2 {
+		t.Errorf("WispsPurged = %d, want 2", result.WispsPurged)
+	}
+	// audit is counted under RetainedExcluded, not as deferred.
+	if result.DeferredRecentEvents != 2 {
+		t.Errorf("DeferredRecentEvents = %d, want 2", result.DeferredRecentEvents)
+	}
+	want := map[string]string{"reclosed": "closed", "linked": "closed", "audit": "closed"}
+	if got := state.statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %v, want %v", got, want)
+	}
+
+	// Without a wisp_events table there is nothing to defer by.
+	state = newState()
+	state.missingTables = map[string]bool{"wisp_events": true}
+	db = openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+	result, err = PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, true, opts)
+	if err != nil {
+		t.Fatalf("PurgeWithOptions without wisp_events: %v", err)
+	}
+	if result.DeferredRecentEvents != 0 || result.WispsPurged != 4 {
+		t.Errorf("without wisp_events: deferred=%d purged=%d, want 0 and 4", result.DeferredRecentEvents, result.WispsPurged)
+	}
+}
+
+func TestPurgeBatchConcurrency(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	newState := func() *fakeReaperState {
+		state := &fakeReaperState{wisps: map[string]*fakeWisp{}, ops: map[int][]string{}}
+		for i := 0; i < 7; i++ {
+			id := fmt.Sprintf("old-%d", i)
+			state.wisps[id] = &fakeWisp{id: id, status: "closed", closedAt: now.Add(-10 * day)}
+		}
+		state.wisps["young"] = &fakeWisp{id: "young", status: "closed", closedAt: now.Add(-time.Hour)}
+		return state
+	}
+
+	for _, tc := range []struct {
+		concurrency int
+		wantBatches int
+		wantLimit   int
+	}{
+		{concurrency: 0, wantBatches: 1, wantLimit: DefaultBatchSize},
+		{concurrency: 1, wantBatches: 1, wantLimit: DefaultBatchSize},
+		// One select of up to 3 batches returns all 7 IDs: chunks of 3, 3 and 1.
+		{concurrency: 3, wantBatches: 3, wantLimit: 3 * DefaultBatchSize},
+	} {
+		state := newState()
+		db := openFakeReaperDB(t, state)
+		t.Cleanup(func() { _ = db.Close() })
+		opts := PurgeOptions{BatchConcurrency: tc.concurrency, Now: func() time.Time { return now }}
+		result, err := PurgeWithOptions(context.Background(), db, "testdb", 7*day, 7*day, false, opts)
+		if err != nil {
+			t.Fatalf("concurrency %d: PurgeWithOptions: %v", tc.concurrency, err)
+		}
+		if result.WispsPurged != 7 {
+			t.Errorf("concurrency %d: WispsPurged = %d, want 7", tc.concurrency, result.WispsPurged)
+		}
+		if want := map[string]string{"young": "closed"}; !reflect.DeepEqual(state.statuses(), want) {
+			t.Errorf("concurrency %d: remaining = %v, want %v", tc.concurrency, state.statuses(), want)
+		}
+		timing := result.WispDelete
+		if timing == nil {
+			t.Fatalf("concurrency %d: WispDelete is nil", tc.concurrency)
+		}
+		if timing.Batches != tc.wantBatches || timing.Concurrency != max(tc.concurrency, 1) {
+			t.Errorf("concurrency %d: timing = %+v, want %d batches", tc.concurrency, timing, tc.wantBatches)
+		}
+		limit := fmt.Sprintf("LIMIT %d", tc.wantLimit)
+		found := false
+		for _, ops := range state.opsSince(nil) {
+			for _, op := range ops {
+				if strings.HasPrefix(op, "QUERY SELECT w.id FROM wisps w WHERE w.status = 'closed'") && strings.HasSuffix(op, limit) {
+					found = true
+				}
+				// Chunks commit their own transactions; a session-level
+				// autocommit toggle would land on an arbitrary pooled conn.
+				if strings.HasPrefix(op, "EXEC SET @@autocommit") || op == "EXEC COMMIT" {
+					t.Errorf("concurrency %d: unexpected %q outside the chunk transactions", tc.concurrency, op)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("concurrency %d: no batch select with %s", tc.concurrency, limit)
+		}
+	}
+}
+
+func TestPurgeReopenBuckets(t *testing.T) {
+	now := time.Now().UTC()
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"once":    {id: "once", status: "closed", closedAt: now.Add(-10 * 24 * time.Hour)},
+			"churn-a": {id: "churn-a", status: "closed", closedAt: now.Add(-10 * 24 * time.Hour), reopened: true},
+			"churn-b": {id: "churn-b", status: "closed", closedAt: now.Add(-9 * 24 * time.Hour), reopened: true, wispType: "patrol"},
+			"recent":  {id: "recent", status: "closed", closedAt: now.Add(-time.Hour), reopened: true},
+			"open":    {id: "open", status: "open"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := PurgeWithOptions(context.Background(), db, "hq", 7*24*time.Hour, 7*24*time.Hour, true, PurgeOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	want := &ReopenBuckets{AgedOut: 1, Reclosed: 2}
+	if !reflect.DeepEqual(result.ReopenBuckets, want) {
+		t.Errorf("buckets = %+v, want %+v", result.ReopenBuckets, want)
+	}
+
+	// Nothing past the cutoff: no buckets to report.
+	result, err = PurgeWithOptions(context.Background(), db, "hq", 30*24*time.Hour, 30*24*time.Hour, true, PurgeOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.ReopenBuckets != nil {
+		t.Errorf("buckets = %+v, want nil", result.ReopenBuckets)
+	}
+
+	// No wisp_events table: the digest runs without the reopen join.
+	state.missingTables = map[string]bool{"wisp_events": true}
+	result, err = PurgeWithOptions(context.Background(), db, "hq", 7*24*time.Hour, 7*24*time.Hour, true, PurgeOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("PurgeWithOptions: %v", err)
+	}
+	if result.ReopenBuckets != nil || result.WispsPurged != 3 {
+		t.Errorf("without wisp_events: buckets = %+v, purged = %d; want nil, 3", result.ReopenBuckets, result.WispsPurged)
+	}
+}
+
+func TestIsSystemDatabase(t *testing.T) {
+	for _, name := range []string{"information_schema", "mysql", "performance_schema", "sys", "dolt_cluster", "MySQL"} {
+		if !IsSystemDatabase(name) {
+			t.Errorf("IsSystemDatabase(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"hq", "gastown", "mysql_app"} {
+		if IsSystemDatabase(name) {
+			t.Errorf("IsSystemDatabase(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestPurgeWispsBeforeDryRun(t *testing.T) {
+	now := time.Now().UTC()
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"old-patrol-a": {id: "old-patrol-a", status: "closed", closedAt: now.Add(-40 * 24 * time.Hour), wispType: "patrol"},
+			"old-patrol-b": {id: "old-patrol-b", status: "closed", closedAt: now.Add(-35 * 24 * time.Hour), wispType: "patrol"},
+			"old-untyped":  {id: "old-untyped", status: "closed", closedAt: now.Add(-35 * 24 * time.Hour)},
+			"recent":       {id: "recent", status: "closed", closedAt: now.Add(-time.Hour), wispType: "patrol"},
+			"open":         {id: "open", status: "open", wispType: "patrol"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := PurgeWispsBefore(context.Background(), db, "hq", now.Add(-30*24*time.Hour), true)
+	if err != nil {
+		t.Fatalf("PurgeWispsBefore: %v", err)
+	}
+	if result.Deleted != 3 || !result.DryRun {
+		t.Errorf("Deleted = %d, DryRun = %v; want 3, true", result.Deleted, result.DryRun)
+	}
+	if want := map[string]int{"patrol": 2, "unknown": 1}; !reflect.DeepEqual(result.Digest, want) {
+		t.Errorf("Digest = %v, want %v", result.Digest, want)
+	}
+	if len(state.wisps) != 5 {
+		t.Errorf("dry run deleted wisps: %d remain, want 5", len(state.wisps))
+	}
+}
+
+func TestPurgeWispsBeforeSkipsMissingAuxTables(t *testing.T) {
+	now := time.Now().UTC()
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"old-a": {id: "old-a", status: "closed", closedAt: now.Add(-40 * 24 * time.Hour), wispType: "patrol"},
+			"old-b": {id: "old-b", status: "closed", closedAt: now.Add(-40 * 24 * time.Hour), wispType: "patrol"},
+		},
+		ops:           map[int][]string{},
+		missingTables: map[string]bool{"wisp_events": true},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := PurgeWispsBefore(context.Background(), db, "hq", now.Add(-30*24*time.Hour), false)
+	if err != nil {
+		t.Fatalf("PurgeWispsBefore: %v", err)
+	}
+	if result.Deleted != 2 || len(state.wisps) != 0 {
+		t.Fatalf("Deleted = %d with %d wisps left, want 2 and 0", result.Deleted, len(state.wisps))
+	}
+
+	var probes, eventDeletes int
+	for _, ops := range state.ops {
+		for _, op := range ops {
+			if strings.Contains(op, "information_schema.tables") {
+				probes++
+			}
+			if strings.HasPrefix(op, "EXEC DELETE FROM `wisp_events`") {
+				eventDeletes++
+			}
+		}
+	}
+	if probes != 4 {
+		t.Errorf("aux table probes = %d, want one per aux table (4)", probes)
+	}
+	if eventDeletes != 0 {
+		t.Errorf("%d DELETEs against missing wisp_events, want 0", eventDeletes)
+	}
+	if len(result.Anomalies) != 1 || result.Anomalies[0].Type != "aux_tables_missing" || !strings.Contains(result.Anomalies[0].Message, "not present: wisp_events") {
+		t.Errorf("Anomalies = %+v, want one aux_tables_missing naming wisp_events", result.Anomalies)
+	}
+}
+
+func TestPurgeWispsBeforeRejects(t *testing.T) {
+	if _, err := PurgeWispsBefore(context.Background(), nil, "hq; DROP TABLE wisps", time.Now().Add(-time.Hour), true); err == nil {
+		t.Error("expected an error for an invalid database name")
+	}
+	if _, err := PurgeWispsBefore(context.Background(), nil, "hq", time.Now().Add(time.Hour), true); err == nil || !strings.Contains(err.Error(), "future") {
+		t.Errorf("expected a future-cutoff error, got %v", err)
+	}
+}
+
+func TestBusinessAge(t *testing.T) {
+	fri := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC) // a Friday
+	tests := []struct {
+		name     string
+		from, to time.Time
+		holidays map[string]bool
+		want     time.Duration
+	}{
+		{name: "same day", from: fri, to: fri.Add(3 * time.Hour), want: 3 * time.Hour},
+		{name: "over the weekend", from: fri, to: fri.AddDate(0, 0, 3), want: 24 * time.Hour},
+		{name: "weekend only", from: fri.Add(24 * time.Hour), to: fri.Add(48 * time.Hour), want: 0},
+		{name: "holiday monday", from: fri, to: fri.AddDate(0, 0, 3), holidays: map[string]bool{"2026-03-09": true}, want: 12 * time.Hour},
+		{name: "two weeks", from: fri, to: fri.AddDate(0, 0, 14), want: 10 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := businessAge(tt.from, tt.to, tt.holidays); got != tt.want {
+			t.Errorf("%s: businessAge = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAutoCloseBusinessDaysOnly(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	// Any 11 days hold at least two weekend days, so 11 wall-clock days are
+	// at most 9 business days; any 20 days hold at most 6, leaving 14.
+	state := &fakeReaperState{
+		issues: []fakeIssue{
+			{id: "wall-stale", updatedAt: now.Add(-11 * day)},
+			{id: "business-stale", updatedAt: now.Add(-20 * day)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, tt := range []struct {
+		businessDays bool
+		want         []string
+	}{
+		{false, []string{"wall-stale", "business-stale"}},
+		{true, []string{"business-stale"}},
+	} {
+		result, err := AutoCloseWithOptions(context.Background(), db, "hq", 10*day, true, AutoCloseOptions{BusinessDaysOnly: tt.businessDays})
+		if err != nil {
+			t.Fatalf("AutoCloseWithOptions: %v", err)
+		}
+		var got []string
+		for _, e := range result.ClosedEntries {
+			got = append(got, e.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BusinessDaysOnly=%v: closed = %v, want %v", tt.businessDays, got, tt.want)
+		}
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	if _, err := ParseHolidays([]string{"2026-12-25", "2026-12-26"}); err != nil {
+		t.Errorf("ParseHolidays: %v", err)
+	}
+	if _, err := ParseHolidays([]string{"Dec 25"}); err == nil {
+		t.Error("expected an error for a non-date holiday")
+	}
+}
+
+func TestAutoCloseInWindow(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	state := &fakeReaperState{
+		issues: []fakeIssue{
+			{id: "ancient", updatedAt: now.Add(-400 * day)},
+			{id: "old", updatedAt: now.Add(-200 * day)},
+			{id: "stale", updatedAt: now.Add(-40 * day)},
+			{id: "fresh", updatedAt: now.Add(-5 * day)},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	closedIDs := func(r *AutoCloseResult) []string {
+		var ids []string
+		for _, e := range r.ClosedEntries {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		window AutoCloseWindow
+		want   []string
+	}{
+		{name: "open-ended", want: []string{"ancient", "old", "stale"}},
+		{name: "until", window: AutoCloseWindow{Until: now.Add(-300 * day)}, want: []string{"ancient"}},
+		{name: "since", window: AutoCloseWindow{Since: now.Add(-300 * day)}, want: []string{"old", "stale"}},
+		{name: "until clamped to cutoff", window: AutoCloseWindow{Since: now.Add(-100 * day), Until: now}, want: []string{"stale"}},
+		{name: "since after cutoff", window: AutoCloseWindow{Since: now.Add(-10 * day)}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := AutoCloseInWindow(context.Background(), db, "hq", 30*day, tt.window, true)
+			if err != nil {
+				t.Fatalf("AutoCloseInWindow: %v", err)
+			}
+			if got := closedIDs(result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := AutoCloseInWindow(context.Background(), db, "hq", 30*day, AutoCloseWindow{Since: now.Add(-100 * day), Until: now.Add(-200 * day)}, true); err == nil {
+		t.Error("expected an error when since is not before until")
+	}
+}
+
+func TestClosedEntryString(t *testing.T) {
+	entry := ClosedEntry{
+		ID: "gt-abc", Title: "Fix it", Assignee: "gastown/crew/max",
+		Priority: 3, IssueType: "task", AgeDays: 12, Database: "gastown",
+	}
+	want := "gt-abc Fix it (12d stale, task P3, assignee:gastown/crew/max, db:gastown)"
+	if got := entry.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	entry.Assignee = ""
+	want = "gt-abc Fix it (12d stale, task P3, assignee:unassigned, db:gastown)"
+	if got := entry.String(); got != want {
+		t.Errorf("String(unassigned) = %q, want %q", got, want)
+	}
+}
+
+func TestAutoCloseExemptCounts(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	state := &fakeReaperState{
+		issues: []fakeIssue{
+			{id: "stale", updatedAt: now.Add(-40 * day)},
+			{id: "p1", updatedAt: now.Add(-40 * day), exempt: "priority"},
+			{id: "p0", updatedAt: now.Add(-50 * day), exempt: "priority"},
+			{id: "epic", updatedAt: now.Add(-40 * day), exempt: "type"},
+			{id: "blocked", updatedAt: now.Add(-40 * day), exempt: "dependency"},
+			{id: "kept", updatedAt: now.Add(-40 * day), exempt: "keep-label"},
+			{id: "fresh-p0", updatedAt: now.Add(-5 * day), exempt: "priority"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := AutoClose(context.Background(), db, "hq", 30*day, true)
+	if err != nil {
+		t.Fatalf("AutoClose: %v", err)
+	}
+	if result.Closed != 1 {
+		t.Errorf("Closed = %d, want 1", result.Closed)
+	}
+	want := AutoCloseExemptCounts{Priority: 2, Type: 1, Dependency: 1, KeepLabel: 1}
+	if result.Exempted != want {
+		t.Errorf("Exempted = %+v, want %+v", result.Exempted, want)
+	}
+	if got := result.Exempted.String(); got != "priority=2 type=1 dependency=1 keep-label=1" {
+		t.Errorf("String() = %q", got)
+	}
+
+	var total AutoCloseExemptCounts
+	total.Add(result.Exempted)
+	total.Add(result.Exempted)
+	if total.Total() != 10 {
+		t.Errorf("Total() after two Adds = %d, want 10", total.Total())
+	}
+}
+
+func TestParseAutoCloseWindow(t *testing.T) {
+	window, err := ParseAutoCloseWindow("2025-01-01", "2025-04-01T12:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseAutoCloseWindow: %v", err)
+	}
+	if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !window.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v", window.Since, want)
+	}
+	if want := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC); !window.Until.Equal(want) {
+		t.Errorf("Until = %v, want %v", window.Until, want)
+	}
+
+	if window, err := ParseAutoCloseWindow("", ""); err != nil || !window.Since.IsZero() || !window.Until.IsZero() {
+		t.Errorf("empty bounds = %+v, %v; want open-ended window", window, err)
+	}
+	for _, bad := range [][2]string{{"last week", ""}, {"", "2025-13-01"}, {"2025-04-01", "2025-01-01"}} {
+		if _, err := ParseAutoCloseWindow(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseAutoCloseWindow(%q, %q): expected an error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	for _, offset := range []time.Duration{0, 10 * time.Minute, -3 * time.Hour} {
+		state := &fakeReaperState{clockOffset: offset, ops: map[int][]string{}}
+		db := openFakeReaperDB(t, state)
+		skew, err := ClockSkew(db)
+		_ = db.Close()
+		if err != nil {
+			t.Fatalf("ClockSkew: %v", err)
+		}
+		if diff := skew - offset; diff < -time.Second || diff > time.Second {
+			t.Errorf("ClockSkew with server offset %v = %v", offset, skew)
+		}
+		if got, want := ClockSkewExceeded(skew), offset != 0; got != want {
+			t.Errorf("ClockSkewExceeded(%v) = %v, want %v", skew, got, want)
+		}
+	}
+}
+
+func TestCloseStaleMail(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	state := &fakeReaperState{
+		issues: []fakeIssue{
+			{id: "unread-old", updatedAt: now.Add(-90 * day), mail: true, status: "open"},
+			{id: "unread-new", updatedAt: now.Add(-2 * day), mail: true, status: "open"},
+			{id: "hooked-old", updatedAt: now.Add(-90 * day), mail: true, status: "hooked"},
+			{id: "task-old", updatedAt: now.Add(-90 * day), status: "open"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	result, err := CloseStaleMail(context.Background(), db, "hq", 30*day, true)
+	if err != nil {
+		t.Fatalf("CloseStaleMail dry run: %v", err)
+	}
+	if result.Closed != 1 || state.issues[0].status != "open" {
+		t.Fatalf("dry run: closed=%d status=%q, want 1 and untouched", result.Closed, state.issues[0].status)
+	}
+
+	later := func() time.Time { return now.Add(60 * day) }
+	result, err = CloseStaleMailWithOptions(context.Background(), db, "hq", 30*day, true, CloseOptions{Now: later})
+	if err != nil {
+		t.Fatalf("CloseStaleMailWithOptions dry run: %v", err)
+	}
+	if result.Closed != 2 {
+		t.Fatalf("dry run with clock 60 days ahead: closed=%d, want 2", result.Closed)
+	}
+
+	result, err = CloseStaleMail(context.Background(), db, "hq", 30*day, false)
+	if err != nil {
+		t.Fatalf("CloseStaleMail: %v", err)
+	}
+	if result.Closed != 1 || len(result.Anomalies) != 0 {
+		t.Fatalf("closed=%d anomalies=%v, want 1 and none", result.Closed, result.Anomalies)
+	}
+	want := map[string]string{"unread-old": "closed", "unread-new": "open", "hooked-old": "hooked", "task-old": "open"}
+	for _, issue := range state.issues {
+		if issue.status != want[issue.id] {
+			t.Errorf("%s status = %q, want %q", issue.id, issue.status, want[issue.id])
+		}
+	}
+}
+
+func TestDeleteRowsChunkIsTransactional(t *testing.T) {
+	defer func(d time.Duration) { deleteChunkRetryDelay = d }(deleteChunkRetryDelay)
+	deleteChunkRetryDelay = time.Millisecond
+	auxTables := []string{"wisp_labels", "wisp_comments", "wisp_events", "wisp_dependencies"}
+
+	// txOps returns the transaction markers recorded since counts.
+	txOps := func(state *fakeReaperState, counts map[int]int) []string {
+		var ops []string
+		for _, connOps := range state.opsSince(counts) {
+			for _, op := range connOps {
+				if op == "BEGIN" || strings.HasPrefix(op, "TX ") {
+					ops = append(ops, op)
+				}
+			}
+		}
+		return ops
+	}
+
+	t.Run("retriable error reruns the whole chunk", func(t *testing.T) {
+		state := &fakeReaperState{
+			ops:            map[int][]string{},
+			deleteErrTable: "wisps",
+			deleteErrs:     []error{errors.New("Error 1213: serialization failure: this transaction conflicts with a committed transaction")},
+		}
+		db := openFakeReaperDB(t, state)
+		t.Cleanup(func() { _ = db.Close() })
+
+		counts := state.opCounts()
+		deleted, err := deleteRowsChunk(context.Background(), db, []string{"w-1", "w-2"}, "wisps", auxTables, false)
+		if err != nil || deleted != 2 {
+			t.Fatalf("deleteRowsChunk = %d, %v; want 2, nil", deleted, err)
+		}
+		want := []string{"BEGIN", "TX ROLLBACK", "BEGIN", "TX COMMIT"}
+		if got := txOps(state, counts); !reflect.DeepEqual(got, want) {
+			t.Errorf("transaction ops = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("other errors roll back without retry", func(t *testing.T) {
+		state := &fakeReaperState{
+			ops:            map[int][]string{},
+			deleteErrTable: "wisps",
+			deleteErrs:     []error{errors.New("Error 1105: out of disk")},
+		}
+		db := openFakeReaperDB(t, state)
+		t.Cleanup(func() { _ = db.Close() })
+
+		counts := state.opCounts()
+		if _, err := deleteRowsChunk(context.Background(), db, []string{"w-1"}, "wisps", auxTables, false); err == nil {
+			t.Fatal("expected the delete error to be returned")
+		}
+		want := []string{"BEGIN", "TX ROLLBACK"}
+		if got := txOps(state, counts); !reflect.DeepEqual(got, want) {
+			t.Errorf("transaction ops = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestDeleteRowsChunkTombstone(t *testing.T) {
+	state := &fakeReaperState{
+		ops: map[int][]string{},
+		wisps: map[string]*fakeWisp{
+			"w-1": {id: "w-1", status: "closed"},
+			"w-2": {id: "w-2", status: "closed"},
+		},
+	}
+	db := openFakeReaperDB(t, state)
+	t.Cleanup(func() { _ = db.Close() })
+
+	counts := state.opCounts()
+	n, err := deleteRowsChunk(context.Background(), db, []string{"w-1", "w-2"}, "wisps", []string{"wisp_comments"}, true)
+	if err != nil || n != 2 {
+		t.Fatalf("deleteRowsChunk = %d, %v; want 2, nil", n, err)
+	}
+	for _, id := range []string{"w-1", "w-2"} {
+		if got := state.status(id); got != TombstoneStatus {
+			t.Errorf("%s status = %q, want %q", id, got, TombstoneStatus)
+		}
+	}
+	var deletes []string
+	for _, connOps := range state.opsSince(counts) {
+		for _, op := range connOps {
+			if strings.HasPrefix(op, "EXEC DELETE") {
+				deletes = append(deletes, op)
+			}
+		}
+	}
+	// Aux rows go; the stub row and references to it stay.
+	if len(deletes) != 1 || !strings.HasPrefix(deletes[0], "EXEC DELETE FROM `wisp_comments`") {
+		t.Errorf("deletes = %v, want only the wisp_comments delete", deletes)
+	}
+}
+
+func TestIsRetriableDeleteError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{driver.ErrBadConn, true},
+		{fmt.Errorf("delete wisps batch: %w", driver.ErrBadConn), true},
+		{errors.New("Error 1205: Lock wait timeout exceeded; try restarting transaction"), true},
+		{errors.New("serialization failure"), true},
+		{errors.New("table not found: wisp_events"), false},
+	} {
+		if got := isRetriableDeleteError(tt.err); got != tt.want {
+			t.Errorf("isRetriableDeleteError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPurgeOrphans(t *testing.T) {
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{},
+		ops:   map[int][]string{},
+		orphans: map[string][]string{
+			"wisp_labels": {"w-gone", "w-gone", "w-other"},
+			"events":      {"i-gone"},
+		},
+	}
+	db := openFakeReaperDB(t, state)
+	defer db.Close()
+
+	dry, err := PurgeOrphans(context.Background(), db, "testdb", true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if want := map[string]int{"wisp_labels": 3, "events": 1}; !reflect.DeepEqual(dry.Orphans, want) {
+		t.Errorf("Orphans = %v, want %v", dry.Orphans, want)
+	}
+	if dry.Deleted != 0 || len(state.orphans["wisp_labels"]) != 3 {
+		t.Fatalf("dry run deleted rows: result %d, remaining %v", dry.Deleted, state.orphans)
+	}
+
+	result, err := PurgeOrphans(context.Background(), db, "testdb", false)
+	if err != nil {
+		t.Fatalf("PurgeOrphans: %v", err)
+	}
+	if result.Deleted != 4 {
+		t.Errorf("Deleted = %d, want 4", result.Deleted)
+	}
+	for table, ids := range state.orphans {
+		if len(ids) != 0 {
+			t.Errorf("%s still has orphans %v", table, ids)
+		}
+	}
+
+	again, err := PurgeOrphans(context.Background(), db, "testdb", false)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if len(again.Orphans) != 0 || again.Deleted != 0 {
+		t.Errorf("second run = %+v, want nothing", again)
+	}
+}
+
+func TestCountOpenWispsByType(t *testing.T) {
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"p1": {id: "p1", status: "open", wispType: "patrol"},
+			"p2": {id: "p2", status: "hooked", wispType: "patrol"},
+			"m1": {id: "m1", status: "in_progress", wispType: "mail"},
+			"u1": {id: "u1", status: "open"},
+			"c1": {id: "c1", status: "closed", wispType: "mail"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	defer db.Close()
+
+	got, err := CountOpenWispsByType(context.Background(), db)
+	if err != nil {
+		t.Fatalf("CountOpenWispsByType: %v", err)
+	}
+	if want := map[string]int{"patrol": 2, "mail": 1, "unknown": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountOpenWispsByType = %v, want %v", got, want)
+	}
+}
+
+func TestInspectDatabase(t *testing.T) {
+	state := &fakeReaperState{
+		wisps: map[string]*fakeWisp{
+			"w1": {id: "w1", status: "open"},
+			"w2": {id: "w2", status: "hooked"},
+			"w3": {id: "w3", status: "closed"},
+			"w4": {id: "w4", status: "deferred"},
+		},
+		issues: []fakeIssue{
+			{id: "i1", status: "open"},
+			{id: "i2", status: "closed"},
+			{id: "i3", status: "closed"},
+		},
+		ops: map[int][]string{},
+	}
+	db := openFakeReaperDB(t, state)
+	defer db.Close()
+
+	got, err := InspectDatabase(context.Background(), db)
+	if err != nil {
+		t.Fatalf("InspectDatabase: %v", err)
+	}
+	want := &DatabaseInventory{
+		Issues: TableInventory{Present: true, Open: 1, Closed: 2, Total: 3},
+		Wisps:  TableInventory{Present: true, Open: 2, Closed: 1, Total: 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InspectDatabase = %+v, want %+v", got, want)
+	}
+
+	state.missingTables = map[string]bool{"wisps": true}
+	got, err = InspectDatabase(context.Background(), db)
+	if err != nil {
+		t.Fatalf("InspectDatabase without wisps: %v", err)
+	}
+	if got.Wisps != (TableInventory{}) || !got.Issues.Present {
+		t.Errorf("InspectDatabase without wisps = %+v", got)
+	}
+}
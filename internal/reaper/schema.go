@@ -0,0 +1,170 @@
+package reaper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SchemaTables are the table names the reaper's queries are written against.
+var SchemaTables = []string{
+	"wisps", "issues", "labels", "comments", "events", "dependencies",
+	"wisp_labels", "wisp_comments", "wisp_events", "wisp_dependencies",
+}
+
+// SchemaColumns are the column names the reaper's queries are written
+// against that a schema mapping may rename.
+var SchemaColumns = []string{
+	"status", "closed_at", "priority", "issue_type", "updated_at",
+}
+
+var schemaIdentifierRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Schema maps the default beads table and column names onto the names used
+// by the database being reaped, for forks or alternate schemas. The zero
+// value is the default beads schema.
+type Schema struct {
+	names map[string]string // default name → actual name; only renamed entries
+}
+
+// NewSchema builds a schema mapping from overrides keyed by default table or
+// column name (e.g. {"issues": "bd_issues", "status": "state"}). It rejects
+// unknown keys, values that are not plain SQL identifiers, and mappings that
+// would make two tables (or two columns) share a name.
+func NewSchema(overrides map[string]string) (Schema, error) {
+	known := make(map[string]string, len(SchemaTables)+len(SchemaColumns))
+	for _, t := range SchemaTables {
+		known[t] = "table"
+	}
+	for _, c := range SchemaColumns {
+		known[c] = "column"
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	names := make(map[string]string)
+	for _, k := range keys {
+		v := overrides[k]
+		if _, ok := known[k]; !ok {
+			return Schema{}, fmt.Errorf("unknown schema name %q (tables: %s; columns: %s)",
+				k, strings.Join(SchemaTables, ", "), strings.Join(SchemaColumns, ", "))
+		}
+		if !schemaIdentifierRE.MatchString(v) {
+			return Schema{}, fmt.Errorf("schema name %s=%q is not a valid SQL identifier", k, v)
+		}
+		if v != k {
+			names[k] = v
+		}
+	}
+
+	s := Schema{names: names}
+	for _, group := range [][]string{SchemaTables, SchemaColumns} {
+		seen := make(map[string]string, len(group))
+		for _, name := range group {
+			actual := s.Name(name)
+			if prev, ok := seen[actual]; ok {
+				return Schema{}, fmt.Errorf("schema maps both %s and %s to %q", prev, name, actual)
+			}
+			seen[actual] = name
+		}
+	}
+	return s, nil
+}
+
+// IsDefault reports whether the schema renames nothing.
+func (s Schema) IsDefault() bool {
+	return len(s.names) == 0
+}
+
+// Name returns the actual name for a default table or column name.
+func (s Schema) Name(name string) string {
+	if actual, ok := s.names[name]; ok {
+		return actual
+	}
+	return name
+}
+
+// SQL rewrites a query written against the default schema to use the mapped
+// names. Only bare identifiers are rewritten: string literals and
+// backtick-quoted identifiers (database names) are left alone.
+func (s Schema) SQL(query string) string {
+	if s.IsDefault() {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 2
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(query) && isIdentPart(query[j]) {
+				j++
+			}
+			b.WriteString(s.Name(query[i:j]))
+			i = j
+		case isIdentPart(c) || c == '%' || c == '@':
+			// Digits, format verbs and variables: copy the whole token so its
+			// tail is not mistaken for an identifier.
+			j := i + 1
+			for j < len(query) && isIdentPart(query[j]) {
+				j++
+			}
+			b.WriteString(query[i:j])
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+type schemaKey struct{}
+
+// WithSchema returns a context whose reaper queries use schema s. Every
+// reaper entry point reads the mapping from its context, so callers with
+// different schemas never share state. Build s with NewSchema so it is
+// validated when the config is loaded.
+func WithSchema(ctx context.Context, s Schema) context.Context {
+	return context.WithValue(ctx, schemaKey{}, s)
+}
+
+// SchemaFrom returns the schema mapping carried by ctx, or the default
+// schema when there is none.
+func SchemaFrom(ctx context.Context) Schema {
+	s, _ := ctx.Value(schemaKey{}).(Schema)
+	return s
+}
+
+// schemaSQL rewrites query for the schema mapping in ctx.
+func schemaSQL(ctx context.Context, query string) string {
+	return SchemaFrom(ctx).SQL(query)
+}
+
+// schemaName maps a default table or column name for the schema in ctx.
+func schemaName(ctx context.Context, name string) string {
+	return SchemaFrom(ctx).Name(name)
+}
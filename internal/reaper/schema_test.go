@@ -0,0 +1,93 @@
+package reaper
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{name: "default", overrides: nil},
+		{name: "rename", overrides: map[string]string{"issues": "bd_issues", "status": "state"}},
+		{name: "identity", overrides: map[string]string{"wisps": "wisps"}},
+		{name: "unknown name", overrides: map[string]string{"issue": "bd_issues"}, wantErr: "unknown schema name"},
+		{name: "bad identifier", overrides: map[string]string{"issues": "bd-issues; DROP"}, wantErr: "not a valid SQL identifier"},
+		{name: "table collision", overrides: map[string]string{"issues": "wisps"}, wantErr: "maps both"},
+		{name: "column collision", overrides: map[string]string{"closed_at": "updated_at"}, wantErr: "maps both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSchema(tt.overrides)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewSchema: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewSchema error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaSQL(t *testing.T) {
+	if s, _ := NewSchema(map[string]string{"wisps": "wisps"}); !s.IsDefault() {
+		t.Error("identity mapping should be the default schema")
+	}
+
+	schema, err := NewSchema(map[string]string{
+		"issues":            "bd_issues",
+		"labels":            "bd_labels",
+		"wisp_dependencies": "wisp_deps",
+		"status":            "state",
+		"closed_at":         "done_at",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := schema.SQL("SELECT i.id FROM `%s`.issues i INNER JOIN `%s`.labels l ON i.id = l.issue_id WHERE i.status = 'closed' AND i.closed_at < ? AND l.label = 'issues status' LIMIT %d")
+	want := "SELECT i.id FROM `%s`.bd_issues i INNER JOIN `%s`.bd_labels l ON i.id = l.issue_id WHERE i.state = 'closed' AND i.done_at < ? AND l.label = 'issues status' LIMIT %d"
+	if got != want {
+		t.Errorf("SQL =\n  %s\nwant\n  %s", got, want)
+	}
+
+	got = schema.SQL("SELECT DISTINCT wd.issue_id FROM wisp_dependencies wd WHERE wd.status2 = 1 AND @@autocommit = 0")
+	want = "SELECT DISTINCT wd.issue_id FROM wisp_deps wd WHERE wd.status2 = 1 AND @@autocommit = 0"
+	if got != want {
+		t.Errorf("SQL =\n  %s\nwant\n  %s", got, want)
+	}
+
+	query := "UPDATE wisps SET status='closed' WHERE id IN (?)"
+	if got := (Schema{}).SQL(query); got != query {
+		t.Errorf("default schema rewrote query: %s", got)
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	schema, err := NewSchema(map[string]string{"issues": "bd_issues"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithSchema(context.Background(), schema)
+	if got := schemaName(ctx, "issues"); got != "bd_issues" {
+		t.Errorf("schemaName(issues) = %q, want bd_issues", got)
+	}
+	if got := schemaSQL(ctx, "SELECT COUNT(*) FROM issues"); got != "SELECT COUNT(*) FROM bd_issues" {
+		t.Errorf("schemaSQL = %q", got)
+	}
+
+	// The mapping belongs to the context: other callers keep the default.
+	if got := schemaSQL(context.Background(), "SELECT COUNT(*) FROM issues"); got != "SELECT COUNT(*) FROM issues" {
+		t.Errorf("schemaSQL without a schema = %q", got)
+	}
+	if !SchemaFrom(context.Background()).IsDefault() {
+		t.Error("SchemaFrom(Background) should be the default schema")
+	}
+}
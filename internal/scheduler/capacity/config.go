@@ -28,6 +28,29 @@ type SchedulerConfig struct {
 	// SpawnDelay is the delay between spawns to prevent Dolt lock contention.
 	// Default: "0s".
 	SpawnDelay string `json:"spawn_delay,omitempty"`
+
+	// PerRigSpawnDelay overrides SpawnDelay after dispatching to the named
+	// rig (rig → duration, e.g. {"gastown": "10s", "docs": "0s"}), so a
+	// heavy rig can back off without slowing every other rig down.
+	PerRigSpawnDelay map[string]string `json:"per_rig_spawn_delay,omitempty"`
+
+	// RigAffinity groups ready beads by target rig so a batch dispatches
+	// same-rig work back-to-back, reusing warm git/dolt state instead of
+	// alternating rigs. Trades strict FIFO fairness for locality.
+	// nil/absent = default (false).
+	RigAffinity *bool `json:"rig_affinity,omitempty"`
+
+	// ReserveForInteractive keeps this many of MaxPolecats free for direct
+	// `gt sling` by humans, so a busy queue cannot starve them: scheduler
+	// dispatch only uses capacity beyond the reserve. Interactive slings
+	// ignore it. nil/absent = default (0).
+	ReserveForInteractive *int `json:"reserve_for_interactive,omitempty"`
+
+	// DoltLatencyThreshold enables backpressure: before each spawn the
+	// dispatcher times a cheap Dolt query, and if it takes longer than this
+	// (e.g. "500ms") dispatch pauses until the next cycle so a large drain
+	// cannot degrade live agents. Empty or "0s" disables the check.
+	DoltLatencyThreshold string `json:"dolt_latency_threshold,omitempty"`
 }
 
 // DefaultSchedulerConfig returns a SchedulerConfig with sensible defaults.
@@ -66,6 +89,53 @@ func (c *SchedulerConfig) GetSpawnDelay() time.Duration {
 	return ParseDurationOrDefault(c.SpawnDelay, 0)
 }
 
+// GetSpawnDelayForRig returns the delay to wait after dispatching to rig:
+// its PerRigSpawnDelay override when set and valid, else GetSpawnDelay.
+func (c *SchedulerConfig) GetSpawnDelayForRig(rig string) time.Duration {
+	if c == nil {
+		return 0
+	}
+	if v, ok := c.PerRigSpawnDelay[rig]; ok && v != "" {
+		return ParseDurationOrDefault(v, c.GetSpawnDelay())
+	}
+	return c.GetSpawnDelay()
+}
+
+// GetRigAffinity returns RigAffinity or the default (false) if unset.
+func (c *SchedulerConfig) GetRigAffinity() bool {
+	if c == nil || c.RigAffinity == nil {
+		return false
+	}
+	return *c.RigAffinity
+}
+
+// GetReserveForInteractive returns ReserveForInteractive or the default (0)
+// if unset or negative.
+func (c *SchedulerConfig) GetReserveForInteractive() int {
+	if c == nil || c.ReserveForInteractive == nil || *c.ReserveForInteractive < 0 {
+		return 0
+	}
+	return *c.ReserveForInteractive
+}
+
+// GetDoltLatencyThreshold returns DoltLatencyThreshold as a duration, or 0
+// (backpressure disabled) if unset or invalid.
+func (c *SchedulerConfig) GetDoltLatencyThreshold() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return ParseDurationOrDefault(c.DoltLatencyThreshold, 0)
+}
+
+// DispatchableCapacity returns how many of free polecat slots scheduler
+// dispatch may use, after holding back the interactive reserve.
+func (c *SchedulerConfig) DispatchableCapacity(free int) int {
+	if n := free - c.GetReserveForInteractive(); n > 0 {
+		return n
+	}
+	return 0
+}
+
 // IsDeferred returns true when the scheduler is configured for deferred dispatch
 // (max_polecats > 0). Returns false for direct dispatch (-1) and disabled (0).
 func (c *SchedulerConfig) IsDeferred() bool {
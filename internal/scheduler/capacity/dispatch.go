@@ -77,6 +77,20 @@ type DispatchCycle struct {
 
 	// SpawnDelay between dispatches.
 	SpawnDelay time.Duration
+
+	// SpawnDelayFor, when set, returns the delay to wait after dispatching
+	// b and takes precedence over SpawnDelay (e.g. per-rig overrides).
+	SpawnDelayFor func(b PendingBead) time.Duration
+
+	// UnderLoad, when set, is checked before each dispatch. Returning true
+	// pauses dispatch for the rest of the cycle (Reason "load"), leaving the
+	// remaining planned items queued for a later cycle.
+	UnderLoad func() bool
+
+	// Deadline, when non-zero, is checked before each dispatch. Once it has
+	// passed, no further items are started (Reason "max-runtime"); an
+	// in-flight dispatch always runs to completion.
+	Deadline time.Time
 }
 
 // DispatchReport summarizes the result of one dispatch cycle.
@@ -84,7 +98,7 @@ type DispatchReport struct {
 	Dispatched int
 	Failed     int
 	Skipped    int
-	Reason     string // "capacity" | "batch" | "ready" | "none"
+	Reason     string // "capacity" | "batch" | "ready" | "none" | "load" | "max-runtime"
 }
 
 // Plan returns the dispatch plan without executing. Used for dry-run.
@@ -118,6 +132,17 @@ func (c *DispatchCycle) Run() (DispatchReport, error) {
 	}
 
 	for i, b := range plan.ToDispatch {
+		if c.UnderLoad != nil && c.UnderLoad() {
+			report.Skipped += len(plan.ToDispatch) - i
+			report.Reason = "load"
+			break
+		}
+		if !c.Deadline.IsZero() && time.Now().After(c.Deadline) {
+			report.Skipped += len(plan.ToDispatch) - i
+			report.Reason = "max-runtime"
+			break
+		}
+
 		if c.Validate != nil {
 			if err := c.Validate(b); err != nil {
 				report.Failed++
@@ -164,8 +189,12 @@ func (c *DispatchCycle) Run() (DispatchReport, error) {
 		report.Dispatched++
 
 		// Inter-spawn delay (skip after last item)
-		if c.SpawnDelay > 0 && i < len(plan.ToDispatch)-1 {
-			time.Sleep(c.SpawnDelay)
+		delay := c.SpawnDelay
+		if c.SpawnDelayFor != nil {
+			delay = c.SpawnDelayFor(b)
+		}
+		if delay > 0 && i < len(plan.ToDispatch)-1 {
+			time.Sleep(delay)
 		}
 	}
 
@@ -366,6 +366,82 @@ func TestDispatchCycle_Run_ValidateRefusesCrossRigPrefix(t *testing.T) {
 	}
 }
 
+func TestDispatchCycle_Run_SpawnDelayFor(t *testing.T) {
+	var delayedAfter []string
+	cycle := &DispatchCycle{
+		AvailableCapacity: func() (int, error) { return 100, nil },
+		QueryPending: func() ([]PendingBead, error) {
+			return []PendingBead{{ID: "a", TargetRig: "heavy"}, {ID: "b", TargetRig: "light"}, {ID: "c", TargetRig: "heavy"}}, nil
+		},
+		Execute:    func(b PendingBead) error { return nil },
+		OnSuccess:  func(b PendingBead) error { return nil },
+		BatchSize:  10,
+		SpawnDelay: time.Hour, // must be ignored in favor of SpawnDelayFor
+		SpawnDelayFor: func(b PendingBead) time.Duration {
+			delayedAfter = append(delayedAfter, b.ID)
+			if b.TargetRig == "heavy" {
+				return time.Millisecond
+			}
+			return 0
+		},
+	}
+
+	report, err := cycle.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if report.Dispatched != 3 {
+		t.Errorf("Dispatched = %d, want 3", report.Dispatched)
+	}
+	if len(delayedAfter) != 3 || delayedAfter[0] != "a" || delayedAfter[1] != "b" {
+		t.Errorf("SpawnDelayFor called for %v, want each dispatched bead in order", delayedAfter)
+	}
+}
+
+func TestGetSpawnDelayForRig(t *testing.T) {
+	cfg := &SchedulerConfig{
+		SpawnDelay:       "2s",
+		PerRigSpawnDelay: map[string]string{"heavy": "10s", "light": "0s", "broken": "soon"},
+	}
+	tests := map[string]time.Duration{
+		"heavy":  10 * time.Second,
+		"light":  0,
+		"broken": 2 * time.Second,
+		"other":  2 * time.Second,
+	}
+	for rig, want := range tests {
+		if got := cfg.GetSpawnDelayForRig(rig); got != want {
+			t.Errorf("GetSpawnDelayForRig(%q) = %v, want %v", rig, got, want)
+		}
+	}
+	var nilCfg *SchedulerConfig
+	if got := nilCfg.GetSpawnDelayForRig("heavy"); got != 0 {
+		t.Errorf("nil config GetSpawnDelayForRig = %v, want 0", got)
+	}
+}
+
+func TestDispatchableCapacity(t *testing.T) {
+	two, negative := 2, -3
+	tests := []struct {
+		name string
+		cfg  *SchedulerConfig
+		free int
+		want int
+	}{
+		{"nil config", nil, 3, 3},
+		{"unset", &SchedulerConfig{}, 3, 3},
+		{"reserve below free", &SchedulerConfig{ReserveForInteractive: &two}, 5, 3},
+		{"reserve equals free", &SchedulerConfig{ReserveForInteractive: &two}, 2, 0},
+		{"reserve above free", &SchedulerConfig{ReserveForInteractive: &two}, 1, 0},
+		{"negative reserve ignored", &SchedulerConfig{ReserveForInteractive: &negative}, 3, 3},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.DispatchableCapacity(tt.free); got != tt.want {
+			t.Errorf("%s: DispatchableCapacity(%d) = %d, want %d", tt.name, tt.free, got, tt.want)
+		}
+	}
+}
+
 func TestDispatchCycle_Run_SpawnDelay(t *testing.T) {
 	start := time.Now()
 	cycle := &DispatchCycle{
@@ -393,3 +469,86 @@ func TestDispatchCycle_Run_SpawnDelay(t *testing.T) {
 		t.Errorf("elapsed = %v, expected at least ~20ms for 2 delays", elapsed)
 	}
 }
+
+func TestDispatchCycle_Run_UnderLoad(t *testing.T) {
+	var executed []string
+	checks := 0
+	cycle := &DispatchCycle{
+		AvailableCapacity: func() (int, error) { return 10, nil },
+		QueryPending: func() ([]PendingBead, error) {
+			return []PendingBead{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil
+		},
+		Execute: func(b PendingBead) error {
+			executed = append(executed, b.ID)
+			return nil
+		},
+		BatchSize: 10,
+		// Dolt is healthy for the first spawn, then under load.
+		UnderLoad: func() bool {
+			checks++
+			return checks > 1
+		},
+	}
+
+	report, err := cycle.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if report.Dispatched != 1 || len(executed) != 1 || executed[0] != "a" {
+		t.Errorf("dispatched %d %v, want only a", report.Dispatched, executed)
+	}
+	if report.Skipped != 2 || report.Reason != "load" {
+		t.Errorf("Skipped = %d Reason = %q, want 2 load", report.Skipped, report.Reason)
+	}
+	if checks != 2 {
+		t.Errorf("UnderLoad checked %d times, want 2 (stops at first load)", checks)
+	}
+}
+
+func TestDispatchCycle_Run_Deadline(t *testing.T) {
+	var executed []string
+	cycle := &DispatchCycle{
+		AvailableCapacity: func() (int, error) { return 10, nil },
+		QueryPending: func() ([]PendingBead, error) {
+			return []PendingBead{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil
+		},
+		BatchSize: 10,
+	}
+	// The first spawn runs past the deadline; it completes, but nothing
+	// further starts.
+	cycle.Execute = func(b PendingBead) error {
+		executed = append(executed, b.ID)
+		cycle.Deadline = time.Now().Add(-time.Second)
+		return nil
+	}
+
+	report, err := cycle.Run()
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if report.Dispatched != 1 || len(executed) != 1 || executed[0] != "a" {
+		t.Errorf("dispatched %d %v, want only a", report.Dispatched, executed)
+	}
+	if report.Skipped != 2 || report.Reason != "max-runtime" {
+		t.Errorf("Skipped = %d Reason = %q, want 2 max-runtime", report.Skipped, report.Reason)
+	}
+}
+
+func TestGetDoltLatencyThreshold(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":      0,
+		"0s":    0,
+		"500ms": 500 * time.Millisecond,
+		"bogus": 0,
+	}
+	for in, want := range tests {
+		cfg := &SchedulerConfig{DoltLatencyThreshold: in}
+		if got := cfg.GetDoltLatencyThreshold(); got != want {
+			t.Errorf("GetDoltLatencyThreshold(%q) = %v, want %v", in, got, want)
+		}
+	}
+	var nilCfg *SchedulerConfig
+	if got := nilCfg.GetDoltLatencyThreshold(); got != 0 {
+		t.Errorf("nil config = %v, want 0", got)
+	}
+}
@@ -1,6 +1,9 @@
 package capacity
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // PendingBead represents a bead that is scheduled and ready for dispatch evaluation.
 type PendingBead struct {
@@ -13,8 +16,14 @@ type PendingBead struct {
 	Context         *SlingContextFields // Parsed sling params from context bead
 	ContextWorkDir  string              // Work dir for the DB where the context was discovered.
 	ContextBeadsDir string              // Resolved .beads dir where the context was discovered.
+	Priority        int                 // Effective dispatch priority (0=highest)
 }
 
+// SlingContextVersion is the schema version written to new sling contexts.
+// Fields are only ever added (with omitempty), so older parsers ignore what
+// they do not know; bump this only for an incompatible change.
+const SlingContextVersion = 1
+
 // SlingContextFields holds scheduling parameters stored on a sling context bead.
 // JSON-serialized as the context bead's description.
 type SlingContextFields struct {
@@ -38,11 +47,16 @@ type SlingContextFields struct {
 	Mode             string `json:"mode,omitempty"`
 	DispatchFailures int    `json:"dispatch_failures,omitempty"`
 	LastFailure      string `json:"last_failure,omitempty"`
+	Priority         *int   `json:"priority,omitempty"` // Dispatch priority override; nil = work bead's priority
 }
 
 // LabelSlingContext is the label used to identify sling context beads.
 const LabelSlingContext = "gt:sling-context"
 
+// LabelLegacyQueued marks work beads scheduled under the legacy format, where
+// scheduling metadata was embedded as a text block in the work bead itself.
+const LabelLegacyQueued = "gt:queued"
+
 // Labels that mark inter-agent messaging beads. These are never polecat work
 // and must not be dispatched to rig polecats.
 const (
@@ -81,6 +95,34 @@ func FilterMessagingBeads(beads []PendingBead) ([]PendingBead, int) {
 	return result, removed
 }
 
+// ClusterByRig reorders beads so that beads for the same target rig are
+// adjacent. Rigs appear in the order of their first (oldest) bead and beads
+// keep their relative order within a rig, so the head of the queue is
+// unchanged and FIFO holds per rig.
+func ClusterByRig(beads []PendingBead) []PendingBead {
+	var rigOrder []string
+	byRig := make(map[string][]PendingBead)
+	for _, b := range beads {
+		if _, ok := byRig[b.TargetRig]; !ok {
+			rigOrder = append(rigOrder, b.TargetRig)
+		}
+		byRig[b.TargetRig] = append(byRig[b.TargetRig], b)
+	}
+	result := make([]PendingBead, 0, len(beads))
+	for _, rig := range rigOrder {
+		result = append(result, byRig[rig]...)
+	}
+	return result
+}
+
+// SortByPriority orders beads by effective dispatch priority, lowest value
+// first. The sort is stable, so beads of equal priority keep their FIFO order.
+func SortByPriority(beads []PendingBead) {
+	sort.SliceStable(beads, func(i, j int) bool {
+		return beads[i].Priority < beads[j].Priority
+	})
+}
+
 // DispatchPlan is the output of PlanDispatch — what to dispatch and why.
 type DispatchPlan struct {
 	ToDispatch []PendingBead
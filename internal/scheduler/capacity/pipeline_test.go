@@ -122,6 +122,53 @@ func TestBlockerAware(t *testing.T) {
 	}
 }
 
+func TestClusterByRig(t *testing.T) {
+	beads := []PendingBead{
+		{ID: "1", TargetRig: "gastown"},
+		{ID: "2", TargetRig: "beads"},
+		{ID: "3", TargetRig: "gastown"},
+		{ID: "4", TargetRig: "wallet"},
+		{ID: "5", TargetRig: "beads"},
+	}
+
+	result := ClusterByRig(beads)
+
+	var got []string
+	for _, b := range result {
+		got = append(got, b.ID)
+	}
+	if want := "1,3,2,5,4"; strings.Join(got, ",") != want {
+		t.Errorf("ClusterByRig order: got %s, want %s", strings.Join(got, ","), want)
+	}
+	if beads[1].ID != "2" {
+		t.Error("ClusterByRig must not reorder its input")
+	}
+	if len(ClusterByRig(nil)) != 0 {
+		t.Error("ClusterByRig(nil) should be empty")
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	beads := []PendingBead{
+		{ID: "1", Priority: 2},
+		{ID: "2", Priority: 0},
+		{ID: "3", Priority: 2},
+		{ID: "4", Priority: 1},
+		{ID: "5", Priority: 0},
+	}
+
+	SortByPriority(beads)
+
+	var got []string
+	for _, b := range beads {
+		got = append(got, b.ID)
+	}
+	// Equal priorities keep their FIFO order.
+	if want := "2,5,4,1,3"; strings.Join(got, ",") != want {
+		t.Errorf("SortByPriority order: got %s, want %s", strings.Join(got, ","), want)
+	}
+}
+
 func TestBlockerAware_EmptySet(t *testing.T) {
 	beads := []PendingBead{{ID: "a", WorkBeadID: "wa"}, {ID: "b", WorkBeadID: "wb"}}
 	readyIDs := map[string]bool{}
@@ -2,6 +2,7 @@ package capacity
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -16,8 +17,21 @@ type SchedulerState struct {
 	PausedAt          string `json:"paused_at,omitempty"`
 	LastDispatchAt    string `json:"last_dispatch_at,omitempty"`
 	LastDispatchCount int    `json:"last_dispatch_count,omitempty"`
+	// StallReason is why the most recent dispatch cycles moved nothing
+	// (one of the Stall* constants); cleared by the next dispatch.
+	StallReason  string `json:"stall_reason,omitempty"`
+	StalledSince string `json:"stalled_since,omitempty"`
 }
 
+// Reasons a dispatch cycle with scheduled work dispatched nothing.
+const (
+	StallPaused         = "paused"
+	StallCapacity       = "capacity"
+	StallAllBlocked     = "all_blocked"
+	StallDispatchFailed = "dispatch_failed"
+	StallDoltLoad       = "dolt_load"
+)
+
 // stateFile returns the path to the scheduler state file.
 func stateFile(townRoot string) string {
 	return filepath.Join(townRoot, ".runtime", "scheduler-state.json")
@@ -58,6 +72,47 @@ func LoadState(townRoot string) (*SchedulerState, error) {
 	return &state, nil
 }
 
+// ReadStateFile returns the path of the state file LoadState reads (the
+// legacy queue-state.json when only it exists) and its raw contents, without
+// parsing them. data is nil when there is no state file at all.
+func ReadStateFile(townRoot string) (path string, data []byte, err error) {
+	for _, path := range []string{stateFile(townRoot), legacyStateFile(townRoot)} {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+		if err == nil {
+			return path, data, nil
+		}
+		if !os.IsNotExist(err) {
+			return path, nil, err
+		}
+	}
+	return stateFile(townRoot), nil, nil
+}
+
+// ResetState reinitializes the scheduler state to the zero value (not
+// paused, no dispatch or stall recorded), for recovering from a corrupt or
+// stale state file. The file LoadState would have read is first renamed to
+// <name>.bak-<timestamp>; its path is returned, or "" when there was none.
+func ResetState(townRoot string) (backup string, err error) {
+	path, data, err := ReadStateFile(townRoot)
+	if err != nil {
+		// Unreadable, but it may still be movable out of the way.
+		if _, statErr := os.Lstat(path); statErr != nil {
+			return "", err
+		}
+		data = []byte{}
+	}
+	if data != nil {
+		backup = fmt.Sprintf("%s.bak-%s", path, time.Now().UTC().Format("20060102T150405Z"))
+		if err := os.Rename(path, backup); err != nil {
+			return "", fmt.Errorf("backing up %s: %w", path, err)
+		}
+	}
+	if err := SaveState(townRoot, &SchedulerState{}); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
 // SaveState writes the scheduler runtime state to disk atomically.
 // Uses write-to-temp + rename to prevent corruption from concurrent writers
 // (e.g., dispatch RecordDispatch racing with gt scheduler pause).
@@ -111,8 +166,24 @@ func (s *SchedulerState) SetResumed() {
 	s.PausedAt = ""
 }
 
-// RecordDispatch records a dispatch event.
+// RecordDispatch records a dispatch event. Any stall is over.
 func (s *SchedulerState) RecordDispatch(count int) {
 	s.LastDispatchAt = time.Now().UTC().Format(time.RFC3339)
 	s.LastDispatchCount = count
+	s.StallReason = ""
+	s.StalledSince = ""
+}
+
+// RecordStall records that a dispatch cycle moved nothing for reason. It
+// returns true when the stall is new or its cause changed, so callers report
+// each stall once rather than on every heartbeat.
+func (s *SchedulerState) RecordStall(reason string) bool {
+	if s.StallReason == reason {
+		return false
+	}
+	if s.StallReason == "" {
+		s.StalledSince = time.Now().UTC().Format(time.RFC3339)
+	}
+	s.StallReason = reason
+	return true
 }
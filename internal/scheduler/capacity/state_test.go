@@ -183,3 +183,89 @@ func TestLoadState_LegacyFallback(t *testing.T) {
 		t.Errorf("PausedBy: got %q, want %q", state.PausedBy, "legacy-user")
 	}
 }
+
+func TestReadStateFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	path, data, err := ReadStateFile(tmpDir)
+	if err != nil || data != nil {
+		t.Fatalf("missing file: data=%q err=%v, want nil nil", data, err)
+	}
+	if path != filepath.Join(tmpDir, ".runtime", "scheduler-state.json") {
+		t.Errorf("missing file: path = %q, want scheduler-state.json", path)
+	}
+
+	runtimeDir := filepath.Join(tmpDir, ".runtime")
+	if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	legacy := filepath.Join(runtimeDir, "queue-state.json")
+	if err := os.WriteFile(legacy, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path, data, err = ReadStateFile(tmpDir)
+	if err != nil || path != legacy || string(data) != "{not json" {
+		t.Errorf("legacy file: path=%q data=%q err=%v", path, data, err)
+	}
+}
+
+func TestResetState(t *testing.T) {
+	tmpDir := t.TempDir()
+	runtimeDir := filepath.Join(tmpDir, ".runtime")
+	if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	sf := filepath.Join(runtimeDir, "scheduler-state.json")
+	if err := os.WriteFile(sf, []byte(`{"paused": true, "paused_by":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(tmpDir); err == nil {
+		t.Fatal("LoadState should fail on the corrupt file")
+	}
+
+	backup, err := ResetState(tmpDir)
+	if err != nil {
+		t.Fatalf("ResetState: %v", err)
+	}
+	saved, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("reading backup %q: %v", backup, err)
+	}
+	if string(saved) != `{"paused": true, "paused_by":` {
+		t.Errorf("backup = %q, want the corrupt contents", saved)
+	}
+	state, err := LoadState(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadState after reset: %v", err)
+	}
+	if *state != (SchedulerState{}) {
+		t.Errorf("state after reset = %+v, want zero value", *state)
+	}
+
+	// With no state file there is nothing to back up.
+	if backup, err := ResetState(t.TempDir()); err != nil || backup != "" {
+		t.Errorf("ResetState on empty town: backup=%q err=%v", backup, err)
+	}
+}
+
+func TestRecordStall(t *testing.T) {
+	state := &SchedulerState{}
+	if !state.RecordStall(StallCapacity) {
+		t.Fatal("first stall should be reported")
+	}
+	since := state.StalledSince
+	if since == "" {
+		t.Fatal("StalledSince not set")
+	}
+	if state.RecordStall(StallCapacity) {
+		t.Error("repeated stall with the same cause should not be reported")
+	}
+	if !state.RecordStall(StallPaused) || state.StalledSince != since {
+		t.Errorf("changed cause should be reported and keep StalledSince %q, got %q", since, state.StalledSince)
+	}
+
+	state.RecordDispatch(1)
+	if state.StallReason != "" || state.StalledSince != "" {
+		t.Errorf("dispatch should clear the stall, got %q since %q", state.StallReason, state.StalledSince)
+	}
+}
@@ -173,6 +173,21 @@ func ParseSessionNameWithRegistry(session string, registry *PrefixRegistry) (*Ag
 	return &AgentIdentity{Role: RolePolecat, Rig: rig, Name: rest, Prefix: prefix}, nil
 }
 
+// PolecatAddresses returns the addresses (e.g. "gastown/polecats/Toast") of
+// the polecats among the given tmux session names. Sessions that are not
+// polecats, or cannot be parsed, are skipped.
+func PolecatAddresses(sessions []string) []string {
+	var addresses []string
+	for _, name := range sessions {
+		identity, err := ParseSessionName(name)
+		if err != nil || identity.Role != RolePolecat {
+			continue
+		}
+		addresses = append(addresses, identity.Address())
+	}
+	return addresses
+}
+
 // SessionName returns the tmux session name for this identity.
 func (a *AgentIdentity) SessionName() string {
 	switch a.Role {
@@ -359,6 +359,23 @@ func TestAgentIdentity_Address(t *testing.T) {
 	}
 }
 
+func TestPolecatAddresses(t *testing.T) {
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	got := PolecatAddresses([]string{"hq-mayor", "gt-witness", "gt-Toast", "gt-crew-max", "bd-furiosa"})
+	want := []string{"gastown/polecats/Toast", "beads/polecats/furiosa"}
+	if len(got) != len(want) {
+		t.Fatalf("PolecatAddresses() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("PolecatAddresses()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
 func TestParseSessionName_RoundTrip(t *testing.T) {
 	reg := testRegistry()
 	old := DefaultRegistry()
@@ -19,6 +19,16 @@ func SessionCreatedAt(sessionName string) (time.Time, error) {
 	return ParseTmuxSessionCreated(info.Created)
 }
 
+// LivePolecatAddresses returns the addresses of polecats with a running tmux
+// session on the town's socket. Nil when tmux cannot be queried.
+func LivePolecatAddresses() []string {
+	sessions, err := tmux.NewTmux().ListSessions()
+	if err != nil {
+		return nil
+	}
+	return PolecatAddresses(sessions)
+}
+
 // ParseTmuxSessionCreated parses the tmux session created timestamp.
 func ParseTmuxSessionCreated(created string) (time.Time, error) {
 	created = strings.TrimSpace(created)
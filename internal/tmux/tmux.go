@@ -2467,6 +2467,42 @@ func (t *Tmux) CapturePane(session string, lines int) (string, error) {
 	return t.run("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
 }
 
+// PaneTarget returns the tmux target for pane index pane in the session's
+// current window, e.g. "gt-gastown-furiosa:.1".
+func PaneTarget(session string, pane int) string {
+	return fmt.Sprintf("%s:.%d", session, pane)
+}
+
+// CapturePaneAt captures the visible content of one pane, by index, in the
+// session's current window.
+func (t *Tmux) CapturePaneAt(session string, pane, lines int) (string, error) {
+	return t.CapturePane(PaneTarget(session, pane), lines)
+}
+
+// PaneSummary identifies a pane in a session's current window.
+type PaneSummary struct {
+	Index   int    // pane_index, as accepted by CapturePaneAt
+	Command string // pane_current_command
+}
+
+// ListPanes lists the panes of the session's current window in index order.
+func (t *Tmux) ListPanes(session string) ([]PaneSummary, error) {
+	out, err := t.run("list-panes", "-t", session, "-F", "#{pane_index}\t#{pane_current_command}")
+	if err != nil {
+		return nil, err
+	}
+	var panes []PaneSummary
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		idx, cmd, _ := strings.Cut(line, "\t")
+		n, err := strconv.Atoi(idx)
+		if err != nil {
+			continue
+		}
+		panes = append(panes, PaneSummary{Index: n, Command: cmd})
+	}
+	return panes, nil
+}
+
 // CapturePaneAll captures all scrollback history.
 func (t *Tmux) CapturePaneAll(session string) (string, error) {
 	return t.run("capture-pane", "-p", "-t", session, "-S", "-")
@@ -10,10 +10,12 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -217,6 +219,8 @@ func parseBeadContext(beadID string) (actor, rig, role string) {
 
 // GtEventsSource reads events from ~/gt/.events.jsonl (gt activity log)
 type GtEventsSource struct {
+	path   string
+	mu     sync.Mutex // guards file, which tail swaps when the log rotates
 	file   *os.File
 	events chan Event
 	cancel context.CancelFunc
@@ -243,6 +247,7 @@ func NewGtEventsSource(townRoot string) (*GtEventsSource, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	source := &GtEventsSource{
+		path:   eventsPath,
 		file:   file,
 		events: make(chan Event, 200),
 		cancel: cancel,
@@ -253,7 +258,9 @@ func NewGtEventsSource(townRoot string) (*GtEventsSource, error) {
 	return source, nil
 }
 
-// tail loads recent history then follows the file for new events.
+// tail loads recent history then follows the file for new events. When the
+// events file is rotated, the rest of the old file is read and then the new
+// one is followed from its start.
 func (s *GtEventsSource) tail(ctx context.Context) {
 	defer close(s.events)
 
@@ -283,6 +290,17 @@ func (s *GtEventsSource) tail(ctx context.Context) {
 					}
 				}
 			}
+			if events.Rotated(s.file, s.path) {
+				next, err := os.Open(s.path)
+				if err != nil {
+					continue
+				}
+				s.mu.Lock()
+				_ = s.file.Close()
+				s.file = next
+				s.mu.Unlock()
+				scanner = bufio.NewScanner(next)
+			}
 		}
 	}
 }
@@ -339,6 +357,8 @@ func (s *GtEventsSource) Events() <-chan Event {
 // Close stops the source
 func (s *GtEventsSource) Close() error {
 	s.cancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.file.Close()
 }
 
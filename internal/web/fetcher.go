@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -1810,23 +1811,31 @@ func (f *LiveConvoyFetcher) FetchIssues() ([]IssueRow, error) {
 
 // FetchActivity returns recent activity from the event log.
 func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
-	eventsPath := filepath.Join(f.townRoot, ".events.jsonl")
-
-	// Read events file
-	data, err := os.ReadFile(eventsPath)
-	if err != nil {
-		return nil, nil // No events file
+	// Take last 50 events for richer timeline. Just after a rotation the
+	// active file holds few events, so earlier ones come from its rolls.
+	const maxEvents = 50
+	var lines []string
+	for _, path := range events.LogFiles(f.townRoot) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // No events file
+		}
+		trimmed := strings.TrimSpace(string(data))
+		if trimmed == "" {
+			continue
+		}
+		lines = append(strings.Split(trimmed, "\n"), lines...)
+		if len(lines) >= maxEvents {
+			break
+		}
 	}
-
-	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
 	if len(lines) == 0 {
 		return nil, nil
 	}
 
-	// Take last 50 events for richer timeline
 	start := 0
-	if len(lines) > 50 {
-		start = len(lines) - 50
+	if len(lines) > maxEvents {
+		start = len(lines) - maxEvents
 	}
 
 	var rows []ActivityRow